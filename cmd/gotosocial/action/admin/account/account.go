@@ -340,6 +340,54 @@ var Enable action.GTSAction = func(ctx context.Context) error {
 	)
 }
 
+// DisableTwoFactor turns off two-factor authentication
+// for a user, for when they've lost access to both their
+// authenticator app and their backup codes.
+var DisableTwoFactor action.GTSAction = func(ctx context.Context) error {
+	state, err := initState(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// Ensure state gets stopped on return.
+		if err := stopState(state); err != nil {
+			log.Error(ctx, err)
+		}
+	}()
+
+	username := config.GetAdminAccountUsername()
+	if err := validate.Username(username); err != nil {
+		return err
+	}
+
+	account, err := state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	if err != nil {
+		return err
+	}
+
+	user, err := state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return fmt.Errorf("two-factor authentication is not enabled for account %s", username)
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorLastStep = 0
+	return state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+	)
+}
+
 // Password sets the password of target account.
 var Password action.GTSAction = func(ctx context.Context) error {
 	state, err := initState(ctx)
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
@@ -48,9 +49,26 @@ var Export action.GTSAction = func(ctx context.Context) error {
 		return errors.New("no path set")
 	}
 
-	if err := exporter.ExportMinimal(ctx, path); err != nil {
+	if config.GetAdminTransMinimal() {
+		err = exporter.ExportMinimal(ctx, path)
+	} else {
+		err = exporter.ExportFull(ctx, path)
+	}
+	if err != nil {
 		return err
 	}
 
+	if archivePath := config.GetAdminTransStorageArchive(); archivePath != "" {
+		//nolint:contextcheck
+		storage, err := gtsstorage.AutoConfig()
+		if err != nil {
+			return fmt.Errorf("error creating storage backend: %w", err)
+		}
+
+		if err := exporter.ExportStorage(ctx, archivePath, storage); err != nil {
+			return err
+		}
+	}
+
 	return dbConn.Close()
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
@@ -52,5 +53,17 @@ var Import action.GTSAction = func(ctx context.Context) error {
 		return err
 	}
 
+	if archivePath := config.GetAdminTransStorageArchive(); archivePath != "" {
+		//nolint:contextcheck
+		storage, err := gtsstorage.AutoConfig()
+		if err != nil {
+			return fmt.Errorf("error creating storage backend: %w", err)
+		}
+
+		if err := importer.ImportStorage(ctx, archivePath, storage); err != nil {
+			return err
+		}
+	}
+
 	return dbConn.Close()
 }
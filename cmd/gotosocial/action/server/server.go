@@ -62,6 +62,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Start creates and starts a gotosocial server
@@ -160,6 +161,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	if err := dbService.CreateInstanceApplication(ctx); err != nil {
 		return fmt.Errorf("error creating instance application: %s", err)
 	}
+	if err := dbService.CreateVAPIDKeyPair(ctx); err != nil {
+		return fmt.Errorf("error creating vapid key pair: %s", err)
+	}
 
 	// Get the instance account (we'll need this later).
 	instanceAccount, err := dbService.GetInstanceAccount(ctx, "")
@@ -264,6 +268,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		mediaManager,
 		state,
 		emailSender,
+		webpush.NewSender(client, state),
 	)
 
 	// Initialize the specialized workers pools.
@@ -273,6 +278,14 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	state.Workers.Client.Process = processor.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = processor.Workers().ProcessFromFediAPI
 
+	// Persist queued worker tasks as they're queued, so
+	// they can be replayed after an unclean exit, and
+	// replay any left over from the previous run.
+	state.Workers.SetTaskStore(dbService)
+	if err := processor.Admin().FillWorkerQueues(ctx); err != nil {
+		return fmt.Errorf("error filling worker queues: %w", err)
+	}
+
 	// Now start workers!
 	state.Workers.Start()
 
@@ -281,8 +294,26 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule publishing of all pending scheduled statuses.
+	if err := processor.ScheduledStatuses().ScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling statuses: %w", err)
+	}
+
+	// Schedule streaming of all pending announcement starts / ends.
+	if err := processor.Admin().AnnouncementsScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling announcements: %w", err)
+	}
+
+	// Schedule fetching + applying of domain permission subscriptions.
+	if err := processor.Admin().DomainPermissionSubscriptionsSchedule(); err != nil {
+		return fmt.Errorf("error scheduling domain permission subscriptions: %w", err)
+	}
+
+	// Schedule periodic verification of remote accounts' profile field links.
+	processor.Account().VerifyRemoteFieldsSchedule()
+
 	// Initialize metrics.
-	if err := metrics.Initialize(state.DB); err != nil {
+	if err := metrics.Initialize(state); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
 	}
 
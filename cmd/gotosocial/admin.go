@@ -21,7 +21,9 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
-	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
+	mediaprune "github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/prune"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/search"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
@@ -132,6 +134,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccount(adminAccountEnableCmd)
 	adminAccountCmd.AddCommand(adminAccountEnableCmd)
 
+	adminAccountDisableTwoFactorCmd := &cobra.Command{
+		Use:   "disable-2fa",
+		Short: "turn off two-factor authentication for a local account, for when they've lost access to their authenticator app and backup codes",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.DisableTwoFactor)
+		},
+	}
+	config.AddAdminAccount(adminAccountDisableTwoFactorCmd)
+	adminAccountCmd.AddCommand(adminAccountDisableTwoFactorCmd)
+
 	adminAccountPasswordCmd := &cobra.Command{
 		Use:   "password",
 		Short: "set a new password for the given local account",
@@ -163,6 +178,7 @@ func adminCommands() *cobra.Command {
 		},
 	}
 	config.AddAdminTrans(adminExportCmd)
+	config.AddAdminTransExport(adminExportCmd)
 	adminCmd.AddCommand(adminExportCmd)
 
 	adminImportCmd := &cobra.Command{
@@ -232,7 +248,7 @@ func adminCommands() *cobra.Command {
 			return preRun(preRunArgs{cmd: cmd})
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return run(cmd.Context(), prune.Orphaned)
+			return run(cmd.Context(), mediaprune.Orphaned)
 		},
 	}
 	config.AddAdminMediaPrune(adminMediaPruneOrphanedCmd)
@@ -245,7 +261,7 @@ func adminCommands() *cobra.Command {
 			return preRun(preRunArgs{cmd: cmd})
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return run(cmd.Context(), prune.Remote)
+			return run(cmd.Context(), mediaprune.Remote)
 		},
 	}
 	config.AddAdminMediaPrune(adminMediaPruneRemoteCmd)
@@ -258,7 +274,7 @@ func adminCommands() *cobra.Command {
 			return preRun(preRunArgs{cmd: cmd})
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return run(cmd.Context(), prune.All)
+			return run(cmd.Context(), mediaprune.All)
 		},
 	}
 	config.AddAdminMediaPrune(adminMediaPruneAllCmd)
@@ -268,5 +284,51 @@ func adminCommands() *cobra.Command {
 
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN PRUNE COMMANDS
+	*/
+	adminPruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "admin commands for pruning old remote content from the database",
+	}
+
+	adminPruneStatusesCmd := &cobra.Command{
+		Use:   "statuses",
+		Short: "prune remote statuses and accounts which no local account has interacted with, older than statuses-remote-cache-days",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), prune.Statuses)
+		},
+	}
+	config.AddAdminPrune(adminPruneStatusesCmd)
+	adminPruneCmd.AddCommand(adminPruneStatusesCmd)
+
+	adminCmd.AddCommand(adminPruneCmd)
+
+	/*
+		ADMIN SEARCH COMMANDS
+	*/
+
+	adminSearchCmd := &cobra.Command{
+		Use:   "search",
+		Short: "admin commands related to the full-text search index",
+	}
+
+	adminSearchRebuildIndexCmd := &cobra.Command{
+		Use:   "rebuild-index",
+		Short: "clear and rebuild the full-text search index of all statuses and accounts",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), search.RebuildIndex)
+		},
+	}
+	adminSearchCmd.AddCommand(adminSearchRebuildIndexCmd)
+
+	adminCmd.AddCommand(adminSearchCmd)
+
 	return adminCmd
 }
@@ -79,6 +79,14 @@ const (
 	TagHashtag = "Hashtag"
 )
 
+// PropertyFeaturedTags is the name of Mastodon's
+// `featuredTags` actor property, which is not part
+// of the go-fed vocab, pointing to a collection of
+// the actor's featured hashtags.
+//
+// See https://docs.joinmastodon.org/spec/activitypub/#featuredTags
+const PropertyFeaturedTags = "featuredTags"
+
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
 func isActivity(typeName string) bool {
 	switch typeName {
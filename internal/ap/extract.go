@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -748,7 +749,7 @@ func ExtractHashtags(i WithTag) ([]*gtsmodel.Tag, error) {
 			continue
 		}
 
-		tag, err := extractHashtag(hashtaggable)
+		tag, err := ExtractHashtag(hashtaggable)
 		if err != nil {
 			continue
 		}
@@ -778,9 +779,9 @@ func ExtractHashtags(i WithTag) ([]*gtsmodel.Tag, error) {
 	return tags, nil
 }
 
-// extractHashtag extracts a minimal gtsmodel.Tag from the given
+// ExtractHashtag extracts a minimal gtsmodel.Tag from the given
 // Hashtaggable, without yet doing any normalization on it.
-func extractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
+func ExtractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
 	// Extract name for the tag; trim leading hash
 	// character, so '#example' becomes 'example'.
 	name := ExtractName(i)
@@ -1079,6 +1080,140 @@ func ExtractSensitive(withSensitive WithSensitive) bool {
 	return false
 }
 
+// ExtractInteractionPolicy extracts the interaction policy
+// of the given item, resolving the IRIs in each policy rule
+// relative to the given status author, and the URIs of the
+// accounts mentioned in the status. IRIs that can't be resolved
+// to a gtsmodel.PolicyValue are ignored, and any sub-policy that
+// isn't set on the interaction policy is assumed to be public.
+//
+// If no interaction policy is set on the item, nil is returned.
+func ExtractInteractionPolicy(
+	withPolicy WithInteractionPolicy,
+	author *gtsmodel.Account,
+	mentionURIs []string,
+) *gtsmodel.InteractionPolicy {
+	policyProp := withPolicy.GetGoToSocialInteractionPolicy()
+	if policyProp == nil {
+		return nil
+	}
+
+	var policy vocab.GoToSocialInteractionPolicy
+	for iter := policyProp.Begin(); iter != policyProp.End(); iter = iter.Next() {
+		if iter.IsGoToSocialInteractionPolicy() {
+			policy = iter.Get()
+			break
+		}
+	}
+
+	if policy == nil {
+		return nil
+	}
+
+	var canLike, canReply, canAnnounce WithPolicyRules
+
+	if prop := policy.GetGoToSocialCanLike(); prop != nil {
+		for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+			if iter.IsGoToSocialCanLike() {
+				canLike = iter.Get()
+				break
+			}
+		}
+	}
+
+	if prop := policy.GetGoToSocialCanReply(); prop != nil {
+		for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+			if iter.IsGoToSocialCanReply() {
+				canReply = iter.Get()
+				break
+			}
+		}
+	}
+
+	if prop := policy.GetGoToSocialCanAnnounce(); prop != nil {
+		for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+			if iter.IsGoToSocialCanAnnounce() {
+				canAnnounce = iter.Get()
+				break
+			}
+		}
+	}
+
+	return &gtsmodel.InteractionPolicy{
+		CanLike:     extractPolicyRules(canLike, author, mentionURIs),
+		CanReply:    extractPolicyRules(canReply, author, mentionURIs),
+		CanAnnounce: extractPolicyRules(canAnnounce, author, mentionURIs),
+	}
+}
+
+// extractPolicyRules extracts gtsmodel.PolicyRules
+// from the given interaction policy rule type.
+func extractPolicyRules(
+	withRules WithPolicyRules,
+	author *gtsmodel.Account,
+	mentionURIs []string,
+) gtsmodel.PolicyRules {
+	if withRules == nil {
+		// No rules set,
+		// assume public.
+		return gtsmodel.PolicyRules{
+			Always: gtsmodel.PolicyValues{gtsmodel.PolicyValuePublic},
+		}
+	}
+
+	var rules gtsmodel.PolicyRules
+
+	if prop := withRules.GetGoToSocialAlways(); prop != nil {
+		for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+			rules.Always = appendPolicyValue(rules.Always, iter.GetIRI(), author, mentionURIs)
+		}
+	}
+
+	if prop := withRules.GetGoToSocialApprovalRequired(); prop != nil {
+		for iter := prop.Begin(); iter != prop.End(); iter = iter.Next() {
+			rules.WithApproval = appendPolicyValue(rules.WithApproval, iter.GetIRI(), author, mentionURIs)
+		}
+	}
+
+	return rules
+}
+
+// appendPolicyValue resolves the given IRI to a
+// gtsmodel.PolicyValue, and appends it to values
+// if it resolves and isn't already present.
+func appendPolicyValue(
+	values gtsmodel.PolicyValues,
+	iri *url.URL,
+	author *gtsmodel.Account,
+	mentionURIs []string,
+) gtsmodel.PolicyValues {
+	if iri == nil {
+		return values
+	}
+
+	var value gtsmodel.PolicyValue
+	switch iriStr := iri.String(); {
+	case pub.IsPublic(iriStr):
+		value = gtsmodel.PolicyValuePublic
+	case strings.EqualFold(iriStr, author.FollowersURI):
+		value = gtsmodel.PolicyValueFollowers
+	case strings.EqualFold(iriStr, author.URI):
+		value = gtsmodel.PolicyValueAuthor
+	case slices.ContainsFunc(mentionURIs, func(uri string) bool {
+		return strings.EqualFold(iriStr, uri)
+	}):
+		value = gtsmodel.PolicyValueMentioned
+	default:
+		return values
+	}
+
+	if values.Contains(value) {
+		return values
+	}
+
+	return append(values, value)
+}
+
 // ExtractSharedInbox extracts the sharedInbox URI property
 // from an Actor. Returns nil if this property is not set.
 func ExtractSharedInbox(withEndpoints WithEndpoints) *url.URL {
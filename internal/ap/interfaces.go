@@ -178,6 +178,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -188,6 +189,7 @@ type Statusable interface {
 	WithAttachment
 	WithTag
 	WithReplies
+	WithInteractionPolicy
 }
 
 // Pollable represents the minimum activitypub interface for representing a 'poll' (it's a subset of a status).
@@ -470,6 +472,13 @@ type WithFeatured interface {
 	SetTootFeatured(vocab.TootFeaturedProperty)
 }
 
+// WithUnknownProperties represents an Object with
+// properties not known to the go-fed vocab, such as
+// Mastodon's `featuredTags` actor property.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithMovedTo represents an Object with ActivityStreamsMovedToProperty.
 type WithMovedTo interface {
 	GetActivityStreamsMovedTo() vocab.ActivityStreamsMovedToProperty
@@ -524,6 +533,22 @@ type WithSensitive interface {
 	SetActivityStreamsSensitive(vocab.ActivityStreamsSensitiveProperty)
 }
 
+// WithInteractionPolicy represents an object with GoToSocialInteractionPolicyProperty.
+type WithInteractionPolicy interface {
+	GetGoToSocialInteractionPolicy() vocab.GoToSocialInteractionPolicyProperty
+	SetGoToSocialInteractionPolicy(vocab.GoToSocialInteractionPolicyProperty)
+}
+
+// WithPolicyRules represents an interaction policy rule
+// type (ie., CanLike, CanReply, CanAnnounce) with
+// GoToSocialAlwaysProperty and GoToSocialApprovalRequiredProperty.
+type WithPolicyRules interface {
+	GetGoToSocialAlways() vocab.GoToSocialAlwaysProperty
+	SetGoToSocialAlways(vocab.GoToSocialAlwaysProperty)
+	GetGoToSocialApprovalRequired() vocab.GoToSocialApprovalRequiredProperty
+	SetGoToSocialApprovalRequired(vocab.GoToSocialApprovalRequiredProperty)
+}
+
 // WithConversation ...
 type WithConversation interface { // TODO
 }
@@ -78,3 +78,38 @@ type SwaggerFeaturedCollection struct {
 	// example: 2
 	TotalItems int
 }
+
+// SwaggerFeaturedTagsCollection represents an ActivityPub Collection.
+// swagger:model swaggerFeaturedTagsCollection
+type SwaggerFeaturedTagsCollection struct {
+	// ActivityStreams JSON-LD context.
+	// A string or an array of strings, or more
+	// complex nested items.
+	// example: https://www.w3.org/ns/activitystreams
+	Context interface{} `json:"@context"`
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/collections/tags
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: Collection
+	Type string `json:"type"`
+	// List of Hashtag objects.
+	Items []SwaggerHashtag `json:"items"`
+	// Number of items in this collection.
+	// example: 2
+	TotalItems int
+}
+
+// SwaggerHashtag represents an ActivityPub Hashtag.
+// swagger:model swaggerHashtag
+type SwaggerHashtag struct {
+	// ActivityStreams type.
+	// example: Hashtag
+	Type string `json:"type"`
+	// URL of the hashtag.
+	// example: https://example.org/tags/gotosocial
+	Href string `json:"href"`
+	// Name of the hashtag, including the hash sign.
+	// example: #gotosocial
+	Name string `json:"name"`
+}
@@ -64,8 +64,11 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID            = "userid"
+	sessionClientID          = "client_id"
+	sessionRedirectURI       = "redirect_uri"
+	sessionScope             = "scope"
+	sessionTwoFactorVerified = "two_factor_verified"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -121,6 +121,10 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	if ensureTwoFactorOrRedirect(c, s, user) {
+		return
+	}
+
 	// Finally we should also get the redirect and scope of this particular request, as stored in the session.
 	redirect, ok := s.Get(sessionRedirectURI).(string)
 	if !ok || redirect == "" {
@@ -138,6 +142,16 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Make sure the app is only asking
+	// for scopes that it registered with.
+	scopes := oauth.ParseScopes(scope)
+	if !oauth.ParseScopes(app.Scopes).PermitsAll(scopes) {
+		m.clearSession(s)
+		err := fmt.Errorf("requested scope %s was not a subset of application scope %s", scope, app.Scopes)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
 	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -157,7 +171,7 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 			"appname":    app.Name,
 			"appwebsite": app.Website,
 			"redirect":   redirect,
-			"scope":      scope,
+			"scopes":     scopes,
 			"user":       acct.Username,
 		},
 	}
@@ -248,6 +262,10 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if ensureTwoFactorOrRedirect(c, s, user) {
+		return
+	}
+
 	if redirectURI != oauth.OOBURI {
 		// we're done with the session now, so just clear it out
 		m.clearSession(s)
@@ -298,7 +316,12 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 
 	// set default scope to read
 	if form.Scope == "" {
-		form.Scope = "read"
+		form.Scope = string(oauth.DefaultScope)
+	}
+
+	scopes := oauth.ParseScopes(form.Scope)
+	if err := scopes.Validate(); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
 	}
 
 	// save these values from the form so we can use them elsewhere in the session
@@ -306,7 +329,7 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	s.Set(sessionResponseType, form.ResponseType)
 	s.Set(sessionClientID, form.ClientID)
 	s.Set(sessionRedirectURI, form.RedirectURI)
-	s.Set(sessionScope, form.Scope)
+	s.Set(sessionScope, scopes.String())
 	s.Set(sessionInternalState, uuid.NewString())
 	s.Set(sessionClientState, form.State)
 
@@ -71,6 +71,20 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 			expectedStatusCode:     http.StatusSeeOther,
 			expectedLocationHeader: "/auth" + auth.AuthAccountDisabledPath,
 		},
+		{
+			description: "user has their email confirmed and is approved, but has two-factor enabled and hasn't entered a code yet",
+			mutateUserAccount: func(user *gtsmodel.User, account *gtsmodel.Account) []string {
+				user.ConfirmedAt = time.Now()
+				user.Email = user.UnconfirmedEmail
+				user.Approved = util.Ptr(true)
+				user.Disabled = util.Ptr(false)
+				user.TwoFactorSecret = "JBSWY3DPEHPK3PXP"
+				user.TwoFactorEnabledAt = time.Now()
+				return []string{"confirmed_at", "email", "approved", "disabled", "two_factor_secret", "two_factor_enabled_at"}
+			},
+			expectedStatusCode:     http.StatusSeeOther,
+			expectedLocationHeader: "/auth" + auth.AuthTwoFactorPath,
+		},
 	}
 
 	doTest := func(testCase authorizeHandlerTestCase) {
@@ -113,6 +127,36 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
+func (suite *AuthAuthorizeTestSuite) authorizeWithScope(scope string) (int, string) {
+	ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath, nil, "")
+
+	testSession := sessions.Default(ctx)
+	testSession.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+	testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+	testSession.Set(sessionRedirectURI, "http://localhost:8080")
+	testSession.Set(sessionScope, scope)
+	if err := testSession.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.AuthorizeGETHandler(ctx)
+	return recorder.Code, recorder.Body.String()
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeShowsScopes() {
+	code, body := suite.authorizeWithScope("read write:statuses")
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(body, "<li><code>read</code></li>")
+	suite.Contains(body, "<li><code>write:statuses</code></li>")
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeScopeNotRegistered() {
+	// application_1 registered with "read write follow push".
+	code, body := suite.authorizeWithScope("read admin")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Contains(body, "requested scope read admin was not a subset of application scope read write follow push")
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
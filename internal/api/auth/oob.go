@@ -97,6 +97,12 @@ func (m *Module) OobHandler(c *gin.Context) {
 		return
 	}
 
+	// Make sure the oob flow can't be
+	// used to skip two-factor auth.
+	if ensureTwoFactorOrRedirect(c, s, user) {
+		return
+	}
+
 	// we're done with the session now, so just clear it out
 	m.clearSession(s)
 
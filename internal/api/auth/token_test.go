@@ -96,6 +96,80 @@ func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
 	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, dbToken)
 	suite.NoError(err)
 	suite.NotNil(dbToken)
+
+	// no scope was requested, so it should be the default
+	suite.Equal("read", dbToken.Scope)
+}
+
+func (suite *TokenTestSuite) TestRetrieveClientCredentialsScope() {
+	testClient := suite.testClients["local_account_1"]
+
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"scope":         {"read:accounts  write:accounts"},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	t := &apimodel.Token{}
+	err = json.NewDecoder(result.Body).Decode(t)
+	suite.NoError(err)
+	suite.Equal("read:accounts write:accounts", t.Scope)
+
+	dbToken := &gtsmodel.Token{}
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, dbToken)
+	suite.NoError(err)
+	suite.Equal("read:accounts write:accounts", dbToken.Scope)
+}
+
+func (suite *TokenTestSuite) TestRetrieveClientCredentialsScopeNotRegistered() {
+	testClient := suite.testClients["local_account_1"]
+
+	// application_1 didn't register with admin scope
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		"", "",
+		map[string][]string{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {testClient.ID},
+			"client_secret": {testClient.Secret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"scope":         {"read admin:read"},
+		})
+	if err != nil {
+		panic(err)
+	}
+	bodyBytes := requestBody.Bytes()
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", bodyBytes, w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), "invalid_scope")
 }
 
 func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeOK() {
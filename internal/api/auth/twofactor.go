@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// twoFactorMaxAttempts is the number of wrong
+// two-factor codes that may be entered before
+// the session is cleared, and the user has to
+// sign in with their password again.
+const twoFactorMaxAttempts = 5
+
+// twoFactor just wraps a form-submitted two-factor code.
+type twoFactor struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/2fa.
+// The idea is to present a page to a signed-in user with two-factor authentication
+// enabled, where they can enter a code from their authenticator app, or a backup code.
+// The form will then POST to the two-factor page, which will be handled by TwoFactorPOSTHandler.
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// UserID will be set in the session by SignInPOSTHandler if
+	// the password was correct. If it's not set, the user needs
+	// to go back and sign in first.
+	s := sessions.Default(c)
+	userID, ok := s.Get(sessionUserID).(string)
+	if !ok || userID == "" {
+		c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "2fa.tmpl",
+		Instance: instance,
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/2fa.
+// It checks the submitted code against the signed-in user's two-factor secret
+// and backup codes, and marks the session as verified if it's correct, before
+// redirecting on to the authorize handler served at /oauth/authorize.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionUserID).(string)
+	if !ok || userID == "" {
+		m.clearSession(s)
+		err := fmt.Errorf("key %s was not found in session", sessionUserID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &twoFactor{}
+	if err := c.ShouldBind(form); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		safe := fmt.Sprintf("user with id %s could not be retrieved", userID)
+		var errWithCode gtserror.WithCode
+		if err == db.ErrNoEntries {
+			errWithCode = gtserror.NewErrorBadRequest(err, safe, oauth.HelpfulAdvice)
+		} else {
+			errWithCode = gtserror.NewErrorInternalError(err, safe, oauth.HelpfulAdvice)
+		}
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if !user.TwoFactorEnabled() {
+		// Nothing to check, carry on.
+		c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+		return
+	}
+
+	if errWithCode := m.processor.User().TwoFactorCheck(c.Request.Context(), user, form.Code); errWithCode != nil {
+		attempts, _ := s.Get(sessionTwoFactorAttempts).(int)
+		attempts++
+
+		if attempts >= twoFactorMaxAttempts {
+			// Too many wrong codes, make
+			// the user sign in all over again.
+			m.clearSession(s)
+		} else {
+			// Don't clear session here, so the user can
+			// just press back and try again if they made
+			// a typo or the code expired or something.
+			s.Set(sessionTwoFactorAttempts, attempts)
+			if err := s.Save(); err != nil {
+				err := fmt.Errorf("error saving two-factor attempts onto session: %s", err)
+				apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+				return
+			}
+		}
+
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	s.Delete(sessionTwoFactorAttempts)
+	s.Set(sessionTwoFactorVerified, user.ID)
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving two-factor verification onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
+
+// ensureTwoFactorOrRedirect redirects the user to the two-factor
+// page if they have two-factor authentication enabled, and haven't
+// yet entered a valid code during this session.
+func ensureTwoFactorOrRedirect(ctx *gin.Context, s sessions.Session, user *gtsmodel.User) (redirected bool) {
+	if !user.TwoFactorEnabled() {
+		return
+	}
+
+	if verified, _ := s.Get(sessionTwoFactorVerified).(string); verified == user.ID {
+		return
+	}
+
+	ctx.Redirect(http.StatusSeeOther, "/auth"+AuthTwoFactorPath)
+	redirected = true
+	return
+}
@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AuthTwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *AuthTwoFactorTestSuite) enableTwoFactor() *gtsmodel.User {
+	user := &gtsmodel.User{}
+	*user = *suite.testUsers["local_account_1"]
+	user.TwoFactorSecret = "JBSWY3DPEHPK3PXP"
+	user.TwoFactorEnabledAt = time.Now()
+
+	if err := suite.db.UpdateUser(
+		context.Background(), user,
+		"two_factor_secret",
+		"two_factor_enabled_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return user
+}
+
+func (suite *AuthTwoFactorTestSuite) postCode(user *gtsmodel.User, code string, attempts int) (sessions.Session, int, string) {
+	body := url.Values{"code": {code}}.Encode()
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthTwoFactorPath, []byte(body), "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(sessionUserID, user.ID)
+	if attempts != 0 {
+		s.Set("two_factor_attempts", attempts)
+	}
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+
+	// Redirects in response to a POST don't write
+	// a body, so check the status gin has recorded.
+	return s, ctx.Writer.Status(), recorder.Header().Get("Location")
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorPOSTOK() {
+	user := suite.enableTwoFactor()
+
+	code, err := totp.GenerateCode(user.TwoFactorSecret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	s, status, location := suite.postCode(user, code, 0)
+	suite.Equal(http.StatusFound, status)
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, location)
+	suite.Equal(user.ID, s.Get(sessionTwoFactorVerified))
+	suite.Equal(user.ID, s.Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorPOSTWrongCode() {
+	user := suite.enableTwoFactor()
+
+	s, status, _ := suite.postCode(user, "000000", 0)
+	suite.Equal(http.StatusForbidden, status)
+	suite.Nil(s.Get(sessionTwoFactorVerified))
+	suite.Equal(1, s.Get("two_factor_attempts"))
+
+	// Session should be kept so
+	// the user can try again.
+	suite.Equal(user.ID, s.Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorPOSTTooManyAttempts() {
+	user := suite.enableTwoFactor()
+
+	s, status, _ := suite.postCode(user, "000000", 4)
+	suite.Equal(http.StatusForbidden, status)
+	suite.Nil(s.Get(sessionTwoFactorVerified))
+
+	// Session should be cleared, so
+	// the user has to sign in again.
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func TestAuthTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &AuthTwoFactorTestSuite{})
+}
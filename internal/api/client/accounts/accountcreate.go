@@ -70,7 +70,8 @@ import (
 //			description: >-
 //				Unprocessable. Your account creation request cannot be processed
 //				because either too many accounts have been created on this instance
-//				in the last 24h, or the pending account backlog is full.
+//				in the last 24h, the pending account backlog is full, or the
+//				given invite code is invalid or has expired.
 //		'500':
 //			description: internal server error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
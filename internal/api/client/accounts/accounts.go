@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -77,60 +79,60 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	// create account
-	attachHandler(http.MethodPost, BasePath, m.AccountCreatePOSTHandler)
+	attachHandler(http.MethodPost, BasePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountCreatePOSTHandler)
 
 	// get account
-	attachHandler(http.MethodGet, BasePathWithID, m.AccountGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountGETHandler)
 
 	// delete account
-	attachHandler(http.MethodPost, DeletePath, m.AccountDeletePOSTHandler)
+	attachHandler(http.MethodPost, DeletePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountDeletePOSTHandler)
 
 	// verify account
-	attachHandler(http.MethodGet, VerifyPath, m.AccountVerifyGETHandler)
+	attachHandler(http.MethodGet, VerifyPath, middleware.RequireScope(oauth.ScopeReadAccounts, oauth.ScopeProfile), m.AccountVerifyGETHandler)
 
 	// modify account
-	attachHandler(http.MethodPatch, UpdatePath, m.AccountUpdateCredentialsPATCHHandler)
+	attachHandler(http.MethodPatch, UpdatePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountUpdateCredentialsPATCHHandler)
 
 	// modify account profile media
-	attachHandler(http.MethodDelete, AvatarPath, m.AccountAvatarDELETEHandler)
-	attachHandler(http.MethodDelete, HeaderPath, m.AccountHeaderDELETEHandler)
+	attachHandler(http.MethodDelete, AvatarPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountAvatarDELETEHandler)
+	attachHandler(http.MethodDelete, HeaderPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountHeaderDELETEHandler)
 
 	// get account's statuses
-	attachHandler(http.MethodGet, StatusesPath, m.AccountStatusesGETHandler)
+	attachHandler(http.MethodGet, StatusesPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountStatusesGETHandler)
 
 	// get following or followers
-	attachHandler(http.MethodGet, FollowersPath, m.AccountFollowersGETHandler)
-	attachHandler(http.MethodGet, FollowingPath, m.AccountFollowingGETHandler)
+	attachHandler(http.MethodGet, FollowersPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountFollowersGETHandler)
+	attachHandler(http.MethodGet, FollowingPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountFollowingGETHandler)
 
 	// get relationship with account
-	attachHandler(http.MethodGet, RelationshipsPath, m.AccountRelationshipsGETHandler)
+	attachHandler(http.MethodGet, RelationshipsPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountRelationshipsGETHandler)
 
 	// follow or unfollow account
-	attachHandler(http.MethodPost, FollowPath, m.AccountFollowPOSTHandler)
-	attachHandler(http.MethodPost, UnfollowPath, m.AccountUnfollowPOSTHandler)
+	attachHandler(http.MethodPost, FollowPath, middleware.RequireScope(oauth.ScopeWriteFollows), m.AccountFollowPOSTHandler)
+	attachHandler(http.MethodPost, UnfollowPath, middleware.RequireScope(oauth.ScopeWriteFollows), m.AccountUnfollowPOSTHandler)
 
 	// block or unblock account
-	attachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
-	attachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
+	attachHandler(http.MethodPost, BlockPath, middleware.RequireScope(oauth.ScopeWriteBlocks), m.AccountBlockPOSTHandler)
+	attachHandler(http.MethodPost, UnblockPath, middleware.RequireScope(oauth.ScopeWriteBlocks), m.AccountUnblockPOSTHandler)
 
 	// account lists
-	attachHandler(http.MethodGet, ListsPath, m.AccountListsGETHandler)
+	attachHandler(http.MethodGet, ListsPath, middleware.RequireScope(oauth.ScopeReadLists), m.AccountListsGETHandler)
 
 	// account note
-	attachHandler(http.MethodPost, NotePath, m.AccountNotePOSTHandler)
+	attachHandler(http.MethodPost, NotePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountNotePOSTHandler)
 
 	// mute or unmute account
-	attachHandler(http.MethodPost, MutePath, m.AccountMutePOSTHandler)
-	attachHandler(http.MethodPost, UnmutePath, m.AccountUnmutePOSTHandler)
+	attachHandler(http.MethodPost, MutePath, middleware.RequireScope(oauth.ScopeWriteMutes), m.AccountMutePOSTHandler)
+	attachHandler(http.MethodPost, UnmutePath, middleware.RequireScope(oauth.ScopeWriteMutes), m.AccountUnmutePOSTHandler)
 
 	// search for accounts
-	attachHandler(http.MethodGet, SearchPath, m.AccountSearchGETHandler)
-	attachHandler(http.MethodGet, LookupPath, m.AccountLookupGETHandler)
+	attachHandler(http.MethodGet, SearchPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountSearchGETHandler)
+	attachHandler(http.MethodGet, LookupPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountLookupGETHandler)
 
 	// migration handlers
-	attachHandler(http.MethodPost, AliasPath, m.AccountAliasPOSTHandler)
-	attachHandler(http.MethodPost, MovePath, m.AccountMovePOSTHandler)
+	attachHandler(http.MethodPost, AliasPath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountAliasPOSTHandler)
+	attachHandler(http.MethodPost, MovePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.AccountMovePOSTHandler)
 
 	// account themes
-	attachHandler(http.MethodGet, ThemesPath, m.AccountThemesGETHandler)
+	attachHandler(http.MethodGet, ThemesPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.AccountThemesGETHandler)
 }
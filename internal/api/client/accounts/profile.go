@@ -43,7 +43,7 @@ import (
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- admin
+//		- write:accounts
 //
 //	responses:
 //		'200':
@@ -78,7 +78,7 @@ func (m *Module) AccountAvatarDELETEHandler(c *gin.Context) {
 //
 //	security:
 //	- OAuth2 Bearer:
-//		- admin
+//		- write:accounts
 //
 //	responses:
 //		'200':
@@ -23,42 +23,56 @@ import (
 	"codeberg.org/gruf/go-debug"
 	"github.com/gin-gonic/gin"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + apiutil.IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + apiutil.IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                 = "/v1/admin"
+	EmojiPath                = BasePath + "/custom_emojis"
+	EmojiPathWithID          = EmojiPath + "/:" + apiutil.IDKey
+	EmojiCategoriesPath      = EmojiPath + "/categories"
+	DomainBlocksPath         = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID   = DomainBlocksPath + "/:" + apiutil.IDKey
+	DomainAllowsPath         = BasePath + "/domain_allows"
+	DomainAllowsPathWithID   = DomainAllowsPath + "/:" + apiutil.IDKey
+	DomainPermSubsPath       = BasePath + "/domain_permission_subscriptions"
+	DomainPermSubsPathWithID = DomainPermSubsPath + "/:" + apiutil.IDKey
+	DomainPermSubsTestPath   = DomainPermSubsPathWithID + "/test"
+	DomainKeysExpirePath     = BasePath + "/domain_keys_expire"
+	HeaderAllowsPath         = BasePath + "/header_allows"
+	HeaderAllowsPathWithID   = HeaderAllowsPath + "/:" + apiutil.IDKey
+	HeaderBlocksPath         = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID   = HeaderBlocksPath + "/:" + apiutil.IDKey
+	AccountsV1Path           = BasePath + "/accounts"
+	AccountsV2Path           = "/v2/admin/accounts"
+	AccountsPathWithID       = AccountsV1Path + "/:" + apiutil.IDKey
+	AccountsActionPath       = AccountsPathWithID + "/action"
+	AccountsApprovePath      = AccountsPathWithID + "/approve"
+	AccountsRejectPath       = AccountsPathWithID + "/reject"
+	MediaCleanupPath         = BasePath + "/media_cleanup"
+	MediaRefetchPath         = BasePath + "/media_refetch"
+	ReportsPath              = BasePath + "/reports"
+	ReportsPathWithID        = ReportsPath + "/:" + apiutil.IDKey
+	ReportsResolvePath       = ReportsPathWithID + "/resolve"
+	EmailPath                = BasePath + "/email"
+	EmailTestPath            = EmailPath + "/test"
+	InstanceRulesPath        = BasePath + "/instance/rules"
+	InstanceRulesPathWithID  = InstanceRulesPath + "/:" + apiutil.IDKey
+	AnnouncementsPath        = BasePath + "/announcements"
+	AnnouncementsPathWithID  = AnnouncementsPath + "/:" + apiutil.IDKey
+	ModPoliciesPath          = BasePath + "/moderation_policies"
+	ModPoliciesPathWithID    = ModPoliciesPath + "/:" + apiutil.IDKey
+	RelaysPath               = BasePath + "/relays"
+	RelaysPathWithID         = RelaysPath + "/:" + apiutil.IDKey
+	WorkersPath              = BasePath + "/workers"
+	WorkersFailedPath        = WorkersPath + "/failed_tasks"
+	WorkersFailedPathWithID  = WorkersFailedPath + "/:" + apiutil.IDKey
+	DebugPath                = BasePath + "/debug"
+	DebugAPUrlPath           = DebugPath + "/apurl"
+	DebugClearCachesPath     = DebugPath + "/caches/clear"
 
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
@@ -80,68 +94,100 @@ func New(state *state.State, processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	// emoji stuff
-	attachHandler(http.MethodPost, EmojiPath, m.EmojiCreatePOSTHandler)
-	attachHandler(http.MethodGet, EmojiPath, m.EmojisGETHandler)
-	attachHandler(http.MethodDelete, EmojiPathWithID, m.EmojiDELETEHandler)
-	attachHandler(http.MethodGet, EmojiPathWithID, m.EmojiGETHandler)
-	attachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiPATCHHandler)
-	attachHandler(http.MethodGet, EmojiCategoriesPath, m.EmojiCategoriesGETHandler)
+	attachHandler(http.MethodPost, EmojiPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmojiCreatePOSTHandler)
+	attachHandler(http.MethodGet, EmojiPath, middleware.RequireScope(oauth.ScopeAdminRead), m.EmojisGETHandler)
+	attachHandler(http.MethodDelete, EmojiPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmojiDELETEHandler)
+	attachHandler(http.MethodGet, EmojiPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.EmojiGETHandler)
+	attachHandler(http.MethodPatch, EmojiPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmojiPATCHHandler)
+	attachHandler(http.MethodGet, EmojiCategoriesPath, middleware.RequireScope(oauth.ScopeAdminRead), m.EmojiCategoriesGETHandler)
 
 	// domain block stuff
-	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
-	attachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
-	attachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
-	attachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
+	attachHandler(http.MethodPost, DomainBlocksPath, middleware.RequireScope(oauth.ScopeAdminWriteDomainBlocks), m.DomainBlocksPOSTHandler)
+	attachHandler(http.MethodGet, DomainBlocksPath, middleware.RequireScope(oauth.ScopeAdminReadDomainBlocks), m.DomainBlocksGETHandler)
+	attachHandler(http.MethodGet, DomainBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminReadDomainBlocks), m.DomainBlockGETHandler)
+	attachHandler(http.MethodDelete, DomainBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteDomainBlocks), m.DomainBlockDELETEHandler)
 
 	// domain allow stuff
-	attachHandler(http.MethodPost, DomainAllowsPath, m.DomainAllowsPOSTHandler)
-	attachHandler(http.MethodGet, DomainAllowsPath, m.DomainAllowsGETHandler)
-	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
-	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
+	attachHandler(http.MethodPost, DomainAllowsPath, middleware.RequireScope(oauth.ScopeAdminWriteDomainAllows), m.DomainAllowsPOSTHandler)
+	attachHandler(http.MethodGet, DomainAllowsPath, middleware.RequireScope(oauth.ScopeAdminReadDomainAllows), m.DomainAllowsGETHandler)
+	attachHandler(http.MethodGet, DomainAllowsPathWithID, middleware.RequireScope(oauth.ScopeAdminReadDomainAllows), m.DomainAllowGETHandler)
+	attachHandler(http.MethodDelete, DomainAllowsPathWithID, middleware.RequireScope(oauth.ScopeAdminWriteDomainAllows), m.DomainAllowDELETEHandler)
+
+	// domain permission subscriptions stuff
+	attachHandler(http.MethodPost, DomainPermSubsPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermSubsPath, middleware.RequireScope(oauth.ScopeAdminRead), m.DomainPermissionSubscriptionsGETHandler)
+	attachHandler(http.MethodGet, DomainPermSubsPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.DomainPermissionSubscriptionGETHandler)
+	attachHandler(http.MethodPatch, DomainPermSubsPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionPATCHHandler)
+	attachHandler(http.MethodDelete, DomainPermSubsPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionDELETEHandler)
+	attachHandler(http.MethodPost, DomainPermSubsTestPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.DomainPermissionSubscriptionTestPOSTHandler)
+
+	// moderation policy stuff
+	attachHandler(http.MethodPost, ModPoliciesPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.ModerationPolicyPOSTHandler)
+	attachHandler(http.MethodGet, ModPoliciesPath, middleware.RequireScope(oauth.ScopeAdminRead), m.ModerationPoliciesGETHandler)
+	attachHandler(http.MethodGet, ModPoliciesPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.ModerationPolicyGETHandler)
+	attachHandler(http.MethodPatch, ModPoliciesPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.ModerationPolicyPATCHHandler)
+	attachHandler(http.MethodDelete, ModPoliciesPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.ModerationPolicyDELETEHandler)
+
+	// relay stuff
+	attachHandler(http.MethodGet, RelaysPath, middleware.RequireScope(oauth.ScopeAdminRead), m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.RelayPOSTHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.RelayDELETEHandler)
 
 	// header filtering administration routes
-	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
-	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
-	attachHandler(http.MethodGet, HeaderAllowsPath, m.HeaderFilterAllowsGET)
-	attachHandler(http.MethodGet, HeaderBlocksPath, m.HeaderFilterBlocksGET)
-	attachHandler(http.MethodPost, HeaderAllowsPath, m.HeaderFilterAllowPOST)
-	attachHandler(http.MethodPost, HeaderBlocksPath, m.HeaderFilterBlockPOST)
-	attachHandler(http.MethodDelete, HeaderAllowsPathWithID, m.HeaderFilterAllowDELETE)
-	attachHandler(http.MethodDelete, HeaderBlocksPathWithID, m.HeaderFilterBlockDELETE)
+	attachHandler(http.MethodGet, HeaderAllowsPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.HeaderFilterAllowGET)
+	attachHandler(http.MethodGet, HeaderBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.HeaderFilterBlockGET)
+	attachHandler(http.MethodGet, HeaderAllowsPath, middleware.RequireScope(oauth.ScopeAdminRead), m.HeaderFilterAllowsGET)
+	attachHandler(http.MethodGet, HeaderBlocksPath, middleware.RequireScope(oauth.ScopeAdminRead), m.HeaderFilterBlocksGET)
+	attachHandler(http.MethodPost, HeaderAllowsPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.HeaderFilterAllowPOST)
+	attachHandler(http.MethodPost, HeaderBlocksPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.HeaderFilterBlockPOST)
+	attachHandler(http.MethodDelete, HeaderAllowsPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.HeaderFilterAllowDELETE)
+	attachHandler(http.MethodDelete, HeaderBlocksPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.HeaderFilterBlockDELETE)
 
 	// domain maintenance stuff
-	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
+	attachHandler(http.MethodPost, DomainKeysExpirePath, middleware.RequireScope(oauth.ScopeAdminWrite), m.DomainKeysExpirePOSTHandler)
 
 	// accounts stuff
-	attachHandler(http.MethodGet, AccountsV1Path, m.AccountsGETV1Handler)
-	attachHandler(http.MethodGet, AccountsV2Path, m.AccountsGETV2Handler)
-	attachHandler(http.MethodGet, AccountsPathWithID, m.AccountGETHandler)
-	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
-	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
-	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodGet, AccountsV1Path, middleware.RequireScope(oauth.ScopeAdminReadAccounts), m.AccountsGETV1Handler)
+	attachHandler(http.MethodGet, AccountsV2Path, middleware.RequireScope(oauth.ScopeAdminReadAccounts), m.AccountsGETV2Handler)
+	attachHandler(http.MethodGet, AccountsPathWithID, middleware.RequireScope(oauth.ScopeAdminReadAccounts), m.AccountGETHandler)
+	attachHandler(http.MethodPost, AccountsActionPath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsApprovePath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountApprovePOSTHandler)
+	attachHandler(http.MethodPost, AccountsRejectPath, middleware.RequireScope(oauth.ScopeAdminWriteAccounts), m.AccountRejectPOSTHandler)
 
 	// media stuff
-	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
-	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodPost, MediaCleanupPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.MediaCleanupPOSTHandler)
+	attachHandler(http.MethodPost, MediaRefetchPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.MediaRefetchPOSTHandler)
 
 	// reports stuff
-	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
-	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
-	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodGet, ReportsPath, middleware.RequireScope(oauth.ScopeAdminReadReports), m.ReportsGETHandler)
+	attachHandler(http.MethodGet, ReportsPathWithID, middleware.RequireScope(oauth.ScopeAdminReadReports), m.ReportGETHandler)
+	attachHandler(http.MethodPost, ReportsResolvePath, middleware.RequireScope(oauth.ScopeAdminWriteReports), m.ReportResolvePOSTHandler)
 
 	// email stuff
-	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
+	attachHandler(http.MethodPost, EmailTestPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.EmailTestPOSTHandler)
 
 	// instance rules stuff
-	attachHandler(http.MethodGet, InstanceRulesPath, m.RulesGETHandler)
-	attachHandler(http.MethodGet, InstanceRulesPathWithID, m.RuleGETHandler)
-	attachHandler(http.MethodPost, InstanceRulesPath, m.RulePOSTHandler)
-	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
-	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
+	attachHandler(http.MethodGet, InstanceRulesPath, middleware.RequireScope(oauth.ScopeAdminRead), m.RulesGETHandler)
+	attachHandler(http.MethodGet, InstanceRulesPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.RuleGETHandler)
+	attachHandler(http.MethodPost, InstanceRulesPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.RulePOSTHandler)
+	attachHandler(http.MethodPatch, InstanceRulesPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.RulePATCHHandler)
+	attachHandler(http.MethodDelete, InstanceRulesPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.RuleDELETEHandler)
+
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, middleware.RequireScope(oauth.ScopeAdminRead), m.AnnouncementsGETHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, middleware.RequireScope(oauth.ScopeAdminRead), m.AnnouncementGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.AnnouncementPOSTHandler)
+	attachHandler(http.MethodPut, AnnouncementsPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.AnnouncementPUTHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.AnnouncementDELETEHandler)
+
+	// workers stuff
+	attachHandler(http.MethodGet, WorkersPath, middleware.RequireScope(oauth.ScopeAdminRead), m.WorkersGETHandler)
+	attachHandler(http.MethodGet, WorkersFailedPath, middleware.RequireScope(oauth.ScopeAdminRead), m.WorkerTasksFailedGETHandler)
+	attachHandler(http.MethodDelete, WorkersFailedPathWithID, middleware.RequireScope(oauth.ScopeAdminWrite), m.WorkerTaskFailedDELETEHandler)
 
 	// debug stuff
 	if debug.DEBUG {
-		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
-		attachHandler(http.MethodPost, DebugClearCachesPath, m.DebugClearCachesHandler)
+		attachHandler(http.MethodGet, DebugAPUrlPath, middleware.RequireScope(oauth.ScopeAdminRead), m.DebugAPUrlHandler)
+		attachHandler(http.MethodPost, DebugClearCachesPath, middleware.RequireScope(oauth.ScopeAdminWrite), m.DebugClearCachesHandler)
 	}
 }
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+)
+
+type DomainPermissionSubscriptionGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionSubscriptionGetTestSuite) TestDomainPermissionSubscriptionGet() {
+	recorder := httptest.NewRecorder()
+
+	path := admin.DomainPermSubsPathWithID
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+	ctx.AddParam(apiutil.IDKey, "01JGE681TQSBPAV59GZXPKE62H")
+
+	suite.adminModule.DomainPermissionSubscriptionGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.NotNil(b)
+	dst := new(bytes.Buffer)
+	err = json.Indent(dst, b, "", "  ")
+	suite.NoError(err)
+	suite.Equal(`{
+  "id": "01JGE681TQSBPAV59GZXPKE62H",
+  "priority": 255,
+  "title": "baddies",
+  "permission_type": "block",
+  "adopt_orphans": false,
+  "remove_orphans": false,
+  "created_at": "2024-07-05T10:00:00.000Z",
+  "created_by": "01F8MH17FWEB39HZJ76B6VXSKF",
+  "uri": "https://lists.example.org/baddies.csv",
+  "content_type": "text/csv"
+}`, dst.String())
+}
+
+func (suite *DomainPermissionSubscriptionGetTestSuite) TestDomainPermissionSubscriptionGetNotFound() {
+	recorder := httptest.NewRecorder()
+
+	path := admin.DomainPermSubsPathWithID
+	ctx := suite.newContext(recorder, http.MethodGet, nil, path, "application/json")
+	ctx.AddParam(apiutil.IDKey, "01GF8VRXX1R00X7XH8973Z29R1")
+
+	suite.adminModule.DomainPermissionSubscriptionGETHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	suite.NoError(err)
+	suite.NotNil(b)
+	suite.Equal(`{"error":"Not Found: no domain permission subscription exists with id 01GF8VRXX1R00X7XH8973Z29R1"}`, string(b))
+}
+
+func TestDomainPermissionSubscriptionGetTestSuite(t *testing.T) {
+	suite.Run(t, &DomainPermissionSubscriptionGetTestSuite{})
+}
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ModerationPolicyPOSTHandler swagger:operation POST /api/v1/admin/moderation_policies moderationPolicyCreate
+//
+// Create a moderation policy for one domain or one account.
+//
+// Exactly one of domain or account_id must be provided.
+// Domain policies also apply to subdomains of the given domain.
+//
+// Policies are applied to remote statuses as they're received or
+// fetched, so existing statuses are not affected, except for the
+// strip_public_timelines setting which applies immediately.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: domain
+//		in: formData
+//		description: Domain to apply this policy to.
+//		type: string
+//	-
+//		name: account_id
+//		in: formData
+//		description: ID of the account to apply this policy to.
+//		type: string
+//	-
+//		name: force_sensitive
+//		in: formData
+//		description: Mark all statuses covered by this policy as sensitive.
+//		type: boolean
+//	-
+//		name: content_warning
+//		in: formData
+//		description: >-
+//			Content warning text to append to all statuses covered by this policy.
+//			Statuses with an appended content warning are also marked as sensitive.
+//		type: string
+//	-
+//		name: reject_media
+//		in: formData
+//		description: Don't fetch or store media attached to statuses covered by this policy.
+//		type: boolean
+//	-
+//		name: strip_public_timelines
+//		in: formData
+//		description: Don't show statuses covered by this policy on the public and local timelines.
+//		type: boolean
+//	-
+//		name: private_comment
+//		in: formData
+//		description: Private comment for other admins on why this policy was created.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The newly created moderation policy.
+//			schema:
+//				"$ref": "#/definitions/moderationPolicy"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict: a moderation policy already exists for this domain or account
+//		'500':
+//			description: internal server error
+func (m *Module) ModerationPolicyPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := new(apimodel.ModerationPolicyRequest)
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	policy, errWithCode := m.processor.Admin().ModerationPolicyCreate(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, policy)
+}
@@ -453,6 +453,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -528,7 +529,27 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "tags": [],
         "emojis": [],
         "card": null,
-        "poll": null
+        "poll": null,
+        "interaction_policy": {
+          "can_favourite": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          },
+          "can_reply": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          },
+          "can_reblog": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          }
+        }
       }
     ],
     "rules": [
@@ -675,6 +696,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -750,7 +772,27 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "tags": [],
         "emojis": [],
         "card": null,
-        "poll": null
+        "poll": null,
+        "interaction_policy": {
+          "can_favourite": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          },
+          "can_reply": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          },
+          "can_reblog": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          }
+        }
       }
     ],
     "rules": [
@@ -897,6 +939,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       {
         "id": "01FVW7JHQFSFK166WWKR8CBA6M",
         "created_at": "2021-09-20T10:40:37.000Z",
+        "edited_at": null,
         "in_reply_to_id": null,
         "in_reply_to_account_id": null,
         "sensitive": false,
@@ -972,7 +1015,27 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "tags": [],
         "emojis": [],
         "card": null,
-        "poll": null
+        "poll": null,
+        "interaction_policy": {
+          "can_favourite": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          },
+          "can_reply": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          },
+          "can_reblog": {
+            "always": [
+              "public"
+            ],
+            "with_approval": []
+          }
+        }
       }
     ],
     "rules": [
@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -28,6 +30,15 @@ const (
 	// BasePath is the base URI path for serving
 	// conversations, minus the api prefix.
 	BasePath = "/v1/conversations"
+
+	// IDKey is the key for the conversation ID path parameter.
+	IDKey = "id"
+
+	// BasePathWithID is the base path with the ID key in it, for operations on an existing conversation.
+	BasePathWithID = BasePath + "/:" + IDKey
+
+	// ReadPathWithID is the path for marking an existing conversation as read.
+	ReadPathWithID = BasePathWithID + "/read"
 )
 
 type Module struct {
@@ -41,5 +52,7 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.ConversationsGETHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.RequireScope(oauth.ScopeReadStatuses), m.ConversationsGETHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, middleware.RequireScope(oauth.ScopeWriteConversations), m.ConversationDELETEHandler)
+	attachHandler(http.MethodPost, ReadPathWithID, middleware.RequireScope(oauth.ScopeWriteConversations), m.ConversationReadPOSTHandler)
 }
@@ -0,0 +1,214 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ArchiveGETHandler swagger:operation GET /api/v1/exports/archive accountArchiveGet
+//
+// View the most recent archive of your account data.
+//
+//	---
+//	tags:
+//	- exports
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: The most recent account archive.
+//			schema:
+//				"$ref": "#/definitions/accountArchive"
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: no archive has been requested yet
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ArchiveGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archive, errWithCode := m.processor.Account().ArchiveGet(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, archive)
+}
+
+// ArchivePOSTHandler swagger:operation POST /api/v1/exports/archive accountArchiveCreate
+//
+// Request a new archive of your account data.
+//
+// The archive is generated in the background; poll
+// GET /api/v1/exports/archive to see when it's ready.
+// Archives can be requested at most once every 7 days.
+//
+//	---
+//	tags:
+//	- exports
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'202':
+//			description: The newly requested, pending account archive.
+//			schema:
+//				"$ref": "#/definitions/accountArchive"
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: an archive is already being generated
+//		'422':
+//			description: an archive was already generated in the last 7 days
+//		'500':
+//			description: internal server error
+func (m *Module) ArchivePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archive, errWithCode := m.processor.Account().ArchiveCreate(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, archive)
+}
+
+// ArchiveDownloadGETHandler swagger:operation GET /api/v1/exports/archive/download accountArchiveDownload
+//
+// Download the most recent completed archive of your account data as a zip file.
+//
+// The archive contains actor.json, outbox.json, likes.json and
+// bookmarks.json in ActivityStreams format, and a media_attachments
+// folder with the media referenced by your posts and profile.
+//
+//	---
+//	tags:
+//	- exports
+//
+//	produces:
+//	- application/zip
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			description: Zip archive of account data.
+//			schema:
+//				type: file
+//		'302':
+//			description: Redirect to the archive in object storage.
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: no completed archive available
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ArchiveDownloadGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.ZipAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	ctx := c.Request.Context()
+	content, errWithCode := m.processor.Account().ArchiveGetFile(ctx, authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if content.URL != nil {
+		// Archive is in object storage, redirect
+		// the requester there for the duration
+		// that the presigned link remains valid.
+		maxAge := int(time.Until(content.URL.Expiry).Seconds())
+		c.Header("Cache-Control", "private, max-age="+strconv.Itoa(maxAge))
+		c.Redirect(http.StatusFound, content.URL.String())
+		return
+	}
+
+	defer func() {
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(ctx, "error closing archive readcloser: %v", err)
+		}
+	}()
+
+	c.Header("Content-Disposition", `attachment; filename="archive.zip"`)
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, content.Content, nil)
+}
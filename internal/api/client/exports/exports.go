@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base URI path for serving exports, minus the api prefix.
+	BasePath = "/v1/exports"
+	// FollowingPath is for exporting followed accounts as CSV.
+	FollowingPath = BasePath + "/following.csv"
+	// BlocksPath is for exporting blocked accounts as CSV.
+	BlocksPath = BasePath + "/blocks.csv"
+	// MutesPath is for exporting muted accounts as CSV.
+	MutesPath = BasePath + "/mutes.csv"
+	// BookmarksPath is for exporting bookmarked statuses as CSV.
+	BookmarksPath = BasePath + "/bookmarks.csv"
+	// ListsPath is for exporting lists as CSV.
+	ListsPath = BasePath + "/lists.csv"
+	// ArchivePath is for requesting and viewing an account archive.
+	ArchivePath = BasePath + "/archive"
+	// ArchiveDownloadPath is for downloading an account archive.
+	ArchiveDownloadPath = ArchivePath + "/download"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, FollowingPath, middleware.RequireScope(oauth.ScopeReadFollows), m.FollowingCSVGETHandler)
+	attachHandler(http.MethodGet, BlocksPath, middleware.RequireScope(oauth.ScopeReadBlocks), m.BlocksCSVGETHandler)
+	attachHandler(http.MethodGet, MutesPath, middleware.RequireScope(oauth.ScopeReadMutes), m.MutesCSVGETHandler)
+	attachHandler(http.MethodGet, BookmarksPath, middleware.RequireScope(oauth.ScopeReadBookmarks), m.BookmarksCSVGETHandler)
+	attachHandler(http.MethodGet, ListsPath, middleware.RequireScope(oauth.ScopeReadLists), m.ListsCSVGETHandler)
+	attachHandler(http.MethodGet, ArchivePath, middleware.RequireScope(oauth.ScopeReadAccounts), m.ArchiveGETHandler)
+	attachHandler(http.MethodPost, ArchivePath, middleware.RequireScope(oauth.ScopeWriteAccounts), m.ArchivePOSTHandler)
+	attachHandler(http.MethodGet, ArchiveDownloadPath, middleware.RequireScope(oauth.ScopeReadAccounts), m.ArchiveDownloadGETHandler)
+}
+
+// exportCSV serves the CSV records returned
+// by export for the authed account, as a file
+// download with the given name.
+func (m *Module) exportCSV(
+	c *gin.Context,
+	filename string,
+	export func(context.Context, *gtsmodel.Account) ([][]string, gtserror.WithCode),
+) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.CSVAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	records, errWithCode := export(c.Request.Context(), authed.Account)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		err := gtserror.Newf("error writing csv: %w", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	apiutil.Data(c, http.StatusOK, apiutil.TextCSV, buf.Bytes())
+}
@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// FollowingCSVGETHandler swagger:operation GET /api/v1/exports/following.csv exportFollowing
+//
+// Export accounts followed by you as CSV.
+//
+// The file is in the CSV format used by Mastodon for following_accounts.csv,
+// so it can be imported into GoToSocial or Mastodon.
+//
+//	---
+//	tags:
+//	- exports
+//
+//	produces:
+//	- text/csv
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:follows
+//
+//	responses:
+//		'200':
+//			description: CSV file of followed accounts, with a header line: Account address, Show boosts, Notify on new posts, Languages.
+//			schema:
+//				type: file
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) FollowingCSVGETHandler(c *gin.Context) {
+	m.exportCSV(c, "following_accounts.csv", m.processor.Account().ExportFollowing)
+}
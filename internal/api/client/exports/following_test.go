@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports_test
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FollowingTestSuite struct {
+	ExportsStandardTestSuite
+}
+
+func (suite *FollowingTestSuite) TestExportFollowing() {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + exports.FollowingPath
+	request := httptest.NewRequest(http.MethodGet, requestPath, nil)
+	request.Header.Set("accept", "text/csv")
+	ctx.Request = request
+
+	// trigger the handler
+	suite.exportsModule.FollowingCSVGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/csv", result.Header.Get("Content-Type"))
+	suite.Equal(`attachment; filename="following_accounts.csv"`, result.Header.Get("Content-Disposition"))
+
+	records, err := csv.NewReader(result.Body).ReadAll()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Header line plus two follows.
+	suite.Len(records, 3)
+	suite.Equal("Account address", records[0][0])
+}
+
+func TestFollowingTestSuite(t *testing.T) {
+	suite.Run(t, &FollowingTestSuite{})
+}
@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package featuredtags_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FeaturedTagCreateTestSuite struct {
+	FeaturedTagsStandardTestSuite
+}
+
+func (suite *FeaturedTagCreateTestSuite) postFeaturedTag(
+	expectedHTTPStatus int,
+	name string,
+) ([]byte, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + featuredtags.BasePath
+
+	// Prepare test body.
+	buf, w, err := testrig.CreateMultipartFormData("", "", map[string][]string{
+		"name": {name},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare test context request.
+	request := httptest.NewRequest(http.MethodPost, requestPath, bytes.NewReader(buf.Bytes()))
+	request.Header.Set("accept", "application/json")
+	request.Header.Set("content-type", w.FormDataContentType())
+	ctx.Request = request
+
+	// trigger the handler
+	suite.featuredTagsModule.FeaturedTagCreatePOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		err = fmt.Errorf("expected %d got %d", expectedHTTPStatus, status)
+	}
+
+	return b, err
+}
+
+func (suite *FeaturedTagCreateTestSuite) TestCreateFeaturedTagExisting() {
+	b, err := suite.postFeaturedTag(http.StatusOK, "Welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	featuredTag := &apimodel.FeaturedTag{}
+	if err := json.Unmarshal(b, featuredTag); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(featuredTag.ID)
+	suite.Equal("welcome", featuredTag.Name)
+	suite.Equal("http://localhost:8080/tags/welcome", featuredTag.URL)
+	suite.Equal(1, featuredTag.StatusesCount)
+	suite.NotNil(featuredTag.LastStatusAt)
+
+	// Featuring the same tag again should fail.
+	b, err = suite.postFeaturedTag(http.StatusUnprocessableEntity, "welcome")
+	suite.NoError(err)
+	suite.Equal(`{"error":"Unprocessable Entity: you are already featuring this hashtag"}`, string(b))
+}
+
+func (suite *FeaturedTagCreateTestSuite) TestCreateFeaturedTagNew() {
+	b, err := suite.postFeaturedTag(http.StatusOK, "somethingnew")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	featuredTag := &apimodel.FeaturedTag{}
+	if err := json.Unmarshal(b, featuredTag); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("somethingnew", featuredTag.Name)
+	suite.Zero(featuredTag.StatusesCount)
+	suite.Nil(featuredTag.LastStatusAt)
+}
+
+func (suite *FeaturedTagCreateTestSuite) TestCreateFeaturedTagInvalid() {
+	b, err := suite.postFeaturedTag(http.StatusBadRequest, "not a hashtag!")
+	suite.NoError(err)
+	suite.Equal(`{"error":"Bad Request: invalid hashtag name"}`, string(b))
+}
+
+func TestFeaturedTagCreateTestSuite(t *testing.T) {
+	suite.Run(t, &FeaturedTagCreateTestSuite{})
+}
@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package importdata
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ImportPOSTHandler swagger:operation POST /api/v1/import importData
+//
+// Import a Mastodon-format CSV file of follows, blocks, mutes, bookmarks or lists.
+//
+// The file is processed in the background, so entries may take
+// some time to show up, especially when they refer to remote accounts.
+//
+//	---
+//	tags:
+//	- import
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data
+//		in: formData
+//		description: The CSV file to import.
+//		type: file
+//		required: true
+//	-
+//		name: type
+//		in: formData
+//		description: |-
+//			Type of data contained in the file.
+//
+//			`following` - accounts to follow (following_accounts.csv).
+//			`blocks` - accounts to block (blocked_accounts.csv).
+//			`mutes` - accounts to mute (muted_accounts.csv).
+//			`bookmarks` - statuses to bookmark (bookmarks.csv).
+//			`lists` - lists and their members (lists.csv).
+//		type: string
+//		enum:
+//			- following
+//			- blocks
+//			- mutes
+//			- bookmarks
+//			- lists
+//		required: true
+//	-
+//		name: mode
+//		in: formData
+//		description: |-
+//			How to apply the imported data.
+//
+//			`merge` - add entries from the file to existing ones.
+//			`overwrite` - also remove existing entries that are not in the file.
+//		type: string
+//		enum:
+//			- merge
+//			- overwrite
+//		default: merge
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'202':
+//			description: Import accepted and queued for processing.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: an import is already in progress for this account
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) ImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.ImportRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	var overwrite bool
+	switch form.Mode {
+	case "", "merge":
+		// Default.
+	case "overwrite":
+		overwrite = true
+	default:
+		const text = "mode must be one of: merge, overwrite"
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	errWithCode := m.processor.Account().ImportData(
+		c.Request.Context(),
+		authed.Account,
+		form.Data,
+		form.Type,
+		overwrite,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusAccepted, apiutil.AppJSON, apiutil.StatusAcceptedJSON)
+}
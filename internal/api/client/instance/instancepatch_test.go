@@ -107,7 +107,12 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/m4a",
+        "audio/x-wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -228,7 +233,12 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/m4a",
+        "audio/x-wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -349,7 +359,12 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/m4a",
+        "audio/x-wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -521,7 +536,12 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/m4a",
+        "audio/x-wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -664,7 +684,12 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/m4a",
+        "audio/x-wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
@@ -822,7 +847,12 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
         "image/gif",
         "image/png",
         "image/webp",
-        "video/mp4"
+        "video/mp4",
+        "audio/mpeg",
+        "audio/ogg",
+        "audio/x-flac",
+        "audio/m4a",
+        "audio/x-wav"
       ],
       "image_size_limit": 10485760,
       "image_matrix_limit": 16777216,
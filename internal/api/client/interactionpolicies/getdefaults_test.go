@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interactionpolicies_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionpolicies"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type GetDefaultsTestSuite struct {
+	InteractionPoliciesStandardTestSuite
+}
+
+func (suite *GetDefaultsTestSuite) getDefaults(
+	expectedHTTPStatus int,
+	accountKey string,
+) (*apimodel.DefaultPolicies, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + interactionpolicies.DefaultsPath
+
+	// Prepare test context request.
+	request := httptest.NewRequest(http.MethodGet, requestPath, nil)
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+
+	// trigger the handler
+	suite.policiesModule.PoliciesDefaultsGETHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		return nil, fmt.Errorf("expected %d got %d: %s", expectedHTTPStatus, status, string(b))
+	}
+
+	policies := &apimodel.DefaultPolicies{}
+	if err := json.Unmarshal(b, policies); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (suite *GetDefaultsTestSuite) TestGetInstanceDefaults() {
+	policies, err := suite.getDefaults(http.StatusOK, "local_account_1")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Account hasn't set any policies,
+	// so instance defaults should apply.
+	suite.Equal([]apimodel.PolicyValue{apimodel.PolicyValuePublic}, policies.Public.CanReply.Always)
+	suite.Empty(policies.Public.CanReply.WithApproval)
+	suite.Equal([]apimodel.PolicyValue{apimodel.PolicyValueAuthor}, policies.Private.CanReblog.Always)
+	suite.Equal(
+		[]apimodel.PolicyValue{apimodel.PolicyValueAuthor, apimodel.PolicyValueMentioned},
+		policies.Direct.CanReply.Always,
+	)
+}
+
+func TestGetDefaultsTestSuite(t *testing.T) {
+	suite.Run(t, &GetDefaultsTestSuite{})
+}
@@ -0,0 +1,153 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteCreateTestSuite struct {
+	InvitesStandardTestSuite
+}
+
+func (suite *InviteCreateTestSuite) postInvite(
+	expectedHTTPStatus int,
+	accountKey string,
+	form map[string][]string,
+) ([]byte, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + invites.BasePath
+
+	// Prepare test body.
+	buf, w, err := testrig.CreateMultipartFormData("", "", form)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare test context request.
+	request := httptest.NewRequest(http.MethodPost, requestPath, bytes.NewReader(buf.Bytes()))
+	request.Header.Set("accept", "application/json")
+	request.Header.Set("content-type", w.FormDataContentType())
+	ctx.Request = request
+
+	// trigger the handler
+	suite.invitesModule.InviteCreatePOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		err = fmt.Errorf("expected %d got %d", expectedHTTPStatus, status)
+	}
+
+	return b, err
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInvite() {
+	b, err := suite.postInvite(http.StatusOK, "local_account_1", map[string][]string{
+		"max_uses":   {"5"},
+		"expires_in": {"3600"},
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	invite := &apimodel.Invite{}
+	if err := json.Unmarshal(b, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(invite.ID)
+	suite.Len(invite.Code, 8)
+	suite.Equal("http://localhost:8080/signup?invite="+invite.Code, invite.URL)
+	if suite.NotNil(invite.MaxUses) {
+		suite.Equal(5, *invite.MaxUses)
+	}
+	suite.Zero(invite.Uses)
+	suite.NotNil(invite.ExpiresAt)
+	suite.False(invite.Expired)
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteUnlimited() {
+	b, err := suite.postInvite(http.StatusOK, "local_account_1", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	invite := &apimodel.Invite{}
+	if err := json.Unmarshal(b, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Nil(invite.MaxUses)
+	suite.Nil(invite.ExpiresAt)
+	suite.False(invite.Expired)
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteInvalidMaxUses() {
+	b, err := suite.postInvite(http.StatusBadRequest, "local_account_1", map[string][]string{
+		"max_uses": {"1000"},
+	})
+	suite.NoError(err)
+	suite.Equal(`{"error":"Bad Request: max_uses must be between 0 and 100"}`, string(b))
+}
+
+func (suite *InviteCreateTestSuite) TestCreateInviteModeratorsOnly() {
+	config.SetAccountsInvitePolicy(config.AccountsInvitePolicyModerators)
+
+	b, err := suite.postInvite(http.StatusForbidden, "local_account_1", nil)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Forbidden: you are not permitted to create invites on this instance"}`, string(b))
+
+	_, err = suite.postInvite(http.StatusOK, "admin_account", nil)
+	suite.NoError(err)
+}
+
+func TestInviteCreateTestSuite(t *testing.T) {
+	suite.Run(t, &InviteCreateTestSuite{})
+}
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/invites"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteDeleteTestSuite struct {
+	InvitesStandardTestSuite
+}
+
+func (suite *InviteDeleteTestSuite) deleteInvite(
+	expectedHTTPStatus int,
+	accountKey string,
+	inviteID string,
+) ([]byte, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + invites.BasePath + "/" + inviteID
+
+	// Prepare test context request.
+	request := httptest.NewRequest(http.MethodDelete, requestPath, nil)
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+	ctx.AddParam(invites.IDKey, inviteID)
+
+	// trigger the handler
+	suite.invitesModule.InviteDELETEHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		err = fmt.Errorf("expected %d got %d", expectedHTTPStatus, status)
+	}
+
+	return b, err
+}
+
+func (suite *InviteDeleteTestSuite) TestRevokeInvite() {
+	testInvite := suite.testInvites["admin_account_invite"]
+
+	b, err := suite.deleteInvite(http.StatusOK, "admin_account", testInvite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	invite := &apimodel.Invite{}
+	if err := json.Unmarshal(b, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(testInvite.ID, invite.ID)
+	suite.NotNil(invite.ExpiresAt)
+	suite.True(invite.Expired)
+}
+
+func (suite *InviteDeleteTestSuite) TestRevokeInviteNotOwned() {
+	testInvite := suite.testInvites["admin_account_invite"]
+
+	b, err := suite.deleteInvite(http.StatusNotFound, "local_account_1", testInvite.ID)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+}
+
+func TestInviteDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &InviteDeleteTestSuite{})
+}
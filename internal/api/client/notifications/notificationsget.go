@@ -93,6 +93,8 @@ import (
 //				- poll
 //				- status
 //				- admin.sign_up
+//				- pending.reply
+//				- pending.reblog
 //		description: Types of notifications to include. If not provided, all notification types will be included.
 //		in: query
 //		required: false
@@ -110,6 +112,8 @@ import (
 //				- poll
 //				- status
 //				- admin.sign_up
+//				- pending.reply
+//				- pending.reblog
 //		description: Types of notifications to exclude.
 //		in: query
 //		required: false
@@ -22,6 +22,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -43,6 +45,6 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, PollWithID, m.PollGETHandler)
-	attachHandler(http.MethodPost, PollVotesWithID, m.PollVotePOSTHandler)
+	attachHandler(http.MethodGet, PollWithID, middleware.RequireScope(oauth.ScopeReadStatuses), m.PollGETHandler)
+	attachHandler(http.MethodPost, PollVotesWithID, middleware.RequireScope(oauth.ScopeWriteStatuses), m.PollVotePOSTHandler)
 }
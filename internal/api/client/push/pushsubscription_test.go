@@ -0,0 +1,237 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const (
+	testEndpoint = "https://push.example.org/some/subscription"
+	testP256dh   = "BIf9jZr0QsNo6GSwhQBn0ZmBqg1ZkvnUlB5LZgI2AhzhdKKrmPKDoYkj8GlhLm5Z8ssuqg5rU0ZvVsWAo5bYyPg"
+	testAuth     = "DlSmzvwRm1PeB2WSCA0Gag"
+)
+
+type PushSubscriptionTestSuite struct {
+	PushTestSuite
+}
+
+// pushSubscription calls the given handler as local_account_1,
+// with the given form data, or JSON body if jsonBody is set.
+func (suite *PushSubscriptionTestSuite) pushSubscription(
+	handler func(*gin.Context),
+	method string,
+	expectedHTTPStatus int,
+	form map[string][]string,
+	jsonBody string,
+) (*apimodel.PushSubscription, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + push.SubscriptionPath
+
+	// Prepare test context request.
+	var request *http.Request
+	switch {
+	case jsonBody != "":
+		request = httptest.NewRequest(method, requestPath, bytes.NewReader([]byte(jsonBody)))
+		request.Header.Set("content-type", "application/json")
+	case form != nil:
+		buf, w, err := testrig.CreateMultipartFormData("", "", form)
+		if err != nil {
+			return nil, err
+		}
+		request = httptest.NewRequest(method, requestPath, bytes.NewReader(buf.Bytes()))
+		request.Header.Set("content-type", w.FormDataContentType())
+	default:
+		request = httptest.NewRequest(method, requestPath, nil)
+	}
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+
+	// trigger the handler
+	handler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		return nil, fmt.Errorf("expected %d got %d: %s", expectedHTTPStatus, status, string(b))
+	}
+
+	if status := recorder.Code; status != http.StatusOK || method == http.MethodDelete {
+		return nil, nil
+	}
+
+	apiSubscription := &apimodel.PushSubscription{}
+	if err := json.Unmarshal(b, apiSubscription); err != nil {
+		return nil, err
+	}
+
+	return apiSubscription, nil
+}
+
+func (suite *PushSubscriptionTestSuite) TestPushSubscriptionLifecycle() {
+	// No subscription yet.
+	_, err := suite.pushSubscription(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, http.StatusNotFound, nil, "")
+	suite.NoError(err)
+
+	// Create one using form data.
+	created, err := suite.pushSubscription(suite.pushModule.PushSubscriptionPOSTHandler, http.MethodPost, http.StatusOK, map[string][]string{
+		"subscription[endpoint]":      {testEndpoint},
+		"subscription[keys][p256dh]":  {testP256dh},
+		"subscription[keys][auth]":    {testAuth},
+		"data[alerts][mention]":       {"true"},
+		"data[alerts][follow]":        {"true"},
+		"data[alerts][admin.sign_up]": {"true"},
+		"data[policy]":                {"followed"},
+	}, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(created.ID)
+	suite.Equal(testEndpoint, created.Endpoint)
+	suite.Equal(testrig.NewTestVAPIDKeyPair().Public, created.ServerKey)
+	suite.Equal("followed", created.Policy)
+	suite.Equal(&apimodel.PushSubscriptionAlerts{
+		Follow:      true,
+		Mention:     true,
+		AdminSignup: true,
+	}, created.Alerts)
+
+	// Update it using JSON, leaving
+	// omitted alerts and policy as-is.
+	updated, err := suite.pushSubscription(suite.pushModule.PushSubscriptionPUTHandler, http.MethodPut, http.StatusOK, nil, `{
+  "data": {
+    "alerts": {
+      "follow": false,
+      "favourite": true
+    }
+  }
+}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal("followed", updated.Policy)
+	suite.Equal(&apimodel.PushSubscriptionAlerts{
+		Favourite:   true,
+		Mention:     true,
+		AdminSignup: true,
+	}, updated.Alerts)
+
+	// Get it.
+	got, err := suite.pushSubscription(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, http.StatusOK, nil, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(updated, got)
+
+	// Creating again replaces the existing subscription.
+	replaced, err := suite.pushSubscription(suite.pushModule.PushSubscriptionPOSTHandler, http.MethodPost, http.StatusOK, nil, `{
+  "subscription": {
+    "endpoint": "`+testEndpoint+`/2",
+    "keys": {
+      "p256dh": "`+testP256dh+`",
+      "auth": "`+testAuth+`"
+    }
+  }
+}`)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEqual(created.ID, replaced.ID)
+	suite.Equal(testEndpoint+"/2", replaced.Endpoint)
+	suite.Equal("all", replaced.Policy)
+	suite.Equal(&apimodel.PushSubscriptionAlerts{}, replaced.Alerts)
+
+	// Delete it, twice.
+	for i := 0; i < 2; i++ {
+		_, err = suite.pushSubscription(suite.pushModule.PushSubscriptionDELETEHandler, http.MethodDelete, http.StatusOK, nil, "")
+		suite.NoError(err)
+	}
+
+	_, err = suite.pushSubscription(suite.pushModule.PushSubscriptionGETHandler, http.MethodGet, http.StatusNotFound, nil, "")
+	suite.NoError(err)
+}
+
+func (suite *PushSubscriptionTestSuite) TestPushSubscriptionCreateInvalid() {
+	for _, form := range []map[string][]string{
+		// Missing keys.
+		{
+			"subscription[endpoint]": {testEndpoint},
+		},
+		// Not https.
+		{
+			"subscription[endpoint]":     {"http://push.example.org/some/subscription"},
+			"subscription[keys][p256dh]": {testP256dh},
+			"subscription[keys][auth]":   {testAuth},
+		},
+		// Auth secret too short.
+		{
+			"subscription[endpoint]":     {testEndpoint},
+			"subscription[keys][p256dh]": {testP256dh},
+			"subscription[keys][auth]":   {"DlSmzvwRm1Pe"},
+		},
+		// Unknown policy.
+		{
+			"subscription[endpoint]":     {testEndpoint},
+			"subscription[keys][p256dh]": {testP256dh},
+			"subscription[keys][auth]":   {testAuth},
+			"data[policy]":               {"everyone"},
+		},
+	} {
+		_, err := suite.pushSubscription(suite.pushModule.PushSubscriptionPOSTHandler, http.MethodPost, http.StatusUnprocessableEntity, form, "")
+		suite.NoError(err)
+	}
+}
+
+func TestPushSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, &PushSubscriptionTestSuite{})
+}
@@ -0,0 +1,295 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPOSTHandler swagger:operation POST /api/v1/push/subscription pushSubscriptionPost
+//
+// Create a web push subscription for the current access token, replacing any existing one.
+//
+// Only one web push subscription can exist per access token.
+// Push alerts are encrypted with the given keys (RFC 8291),
+// and authorized with the instance's VAPID key (RFC 8292).
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: subscription[endpoint]
+//		in: formData
+//		type: string
+//		required: true
+//		description: HTTPS URL of the push service to which push alerts will be sent.
+//	-
+//		name: subscription[keys][p256dh]
+//		in: formData
+//		type: string
+//		required: true
+//		description: Base64-encoded P-256 ECDH public key of the user agent.
+//	-
+//		name: subscription[keys][auth]
+//		in: formData
+//		type: string
+//		required: true
+//		description: Base64-encoded auth secret of the user agent.
+//	-
+//		name: data[alerts][follow]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when someone has followed you?
+//	-
+//		name: data[alerts][follow_request]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when someone has requested to follow you?
+//	-
+//		name: data[alerts][favourite]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when a status you created has been favourited by someone else?
+//	-
+//		name: data[alerts][mention]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when someone else has mentioned you in a status?
+//	-
+//		name: data[alerts][reblog]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when a status you created has been boosted by someone else?
+//	-
+//		name: data[alerts][poll]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when a poll you voted in or created has ended?
+//	-
+//		name: data[alerts][status]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when a subscribed account posts a status?
+//	-
+//		name: data[alerts][admin.sign_up]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push alert when a new user has signed up? Only has an effect for admins and moderators.
+//	-
+//		name: data[policy]
+//		in: formData
+//		type: string
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		default: all
+//		description: Which accounts to receive push alerts from.
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			name: subscription
+//			description: The new web push subscription.
+//			schema:
+//				"$ref": "#/definitions/webPushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden to moved accounts
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable content
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if err := validateNormalizeCreate(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	apiSubscription, errWithCode := m.processor.Push().Create(
+		c.Request.Context(),
+		authed.Account,
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiSubscription)
+}
+
+func validateNormalizeCreate(form *apimodel.PushSubscriptionCreateRequest) error {
+	// Parse form variant of subscription.
+	if form.Subscription == nil {
+		form.Subscription = &apimodel.PushSubscriptionRequestSubscription{}
+	}
+	if form.SubscriptionEndpoint != nil {
+		form.Subscription.Endpoint = form.SubscriptionEndpoint
+	}
+	if form.Subscription.Keys == nil {
+		form.Subscription.Keys = &apimodel.PushSubscriptionRequestKeys{}
+	}
+	if form.SubscriptionKeysP256dh != nil {
+		form.Subscription.Keys.P256dh = form.SubscriptionKeysP256dh
+	}
+	if form.SubscriptionKeysAuth != nil {
+		form.Subscription.Keys.Auth = form.SubscriptionKeysAuth
+	}
+
+	if form.Subscription.Endpoint == nil {
+		return errors.New("subscription[endpoint] must be set")
+	}
+	endpoint, err := url.Parse(*form.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("subscription[endpoint] %s must be an https URL", *form.Subscription.Endpoint)
+	}
+
+	// Keys are usually base64url, but be lenient about
+	// padding and alphabet, as clients vary. The sender
+	// decodes them equally leniently.
+	if err := validateKey("subscription[keys][p256dh]", form.Subscription.Keys.P256dh, 65); err != nil {
+		return err
+	}
+	if err := validateKey("subscription[keys][auth]", form.Subscription.Keys.Auth, 16); err != nil {
+		return err
+	}
+
+	return validateNormalizeUpdate(&form.PushSubscriptionUpdateRequest)
+}
+
+// validateKey checks that the given key is
+// set, and decodes to the expected length.
+func validateKey(name string, key *string, length int) error {
+	if key == nil {
+		return fmt.Errorf("%s must be set", name)
+	}
+
+	k := strings.TrimRight(*key, "=")
+	k = strings.NewReplacer("+", "-", "/", "_").Replace(k)
+	b, err := base64.RawURLEncoding.DecodeString(k)
+	if err != nil || len(b) != length {
+		return fmt.Errorf("%s must be a base64-encoded %d-byte key", name, length)
+	}
+
+	return nil
+}
+
+func validateNormalizeUpdate(form *apimodel.PushSubscriptionUpdateRequest) error {
+	// Parse form variant of data.
+	if form.Data == nil {
+		form.Data = &apimodel.PushSubscriptionRequestData{}
+	}
+	if form.Data.Alerts == nil {
+		form.Data.Alerts = &apimodel.PushSubscriptionRequestAlerts{}
+	}
+	for _, alert := range []struct {
+		dst **bool
+		src *bool
+	}{
+		{&form.Data.Alerts.Follow, form.DataAlertsFollow},
+		{&form.Data.Alerts.FollowRequest, form.DataAlertsFollowRequest},
+		{&form.Data.Alerts.Favourite, form.DataAlertsFavourite},
+		{&form.Data.Alerts.Mention, form.DataAlertsMention},
+		{&form.Data.Alerts.Reblog, form.DataAlertsReblog},
+		{&form.Data.Alerts.Poll, form.DataAlertsPoll},
+		{&form.Data.Alerts.Status, form.DataAlertsStatus},
+		{&form.Data.Alerts.AdminSignup, form.DataAlertsAdminSignup},
+	} {
+		if alert.src != nil {
+			*alert.dst = alert.src
+		}
+	}
+	if form.DataPolicy != nil {
+		form.Data.Policy = form.DataPolicy
+	}
+
+	if policy := form.Data.Policy; policy != nil {
+		switch gtsmodel.WebPushNotificationPolicy(*policy) {
+		case gtsmodel.WebPushNotificationPolicyAll,
+			gtsmodel.WebPushNotificationPolicyFollowed,
+			gtsmodel.WebPushNotificationPolicyFollower,
+			gtsmodel.WebPushNotificationPolicyNone:
+		default:
+			return fmt.Errorf("data[policy] %s not recognized", *policy)
+		}
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -143,8 +144,6 @@ import (
 //			ISO 8601 Datetime at which to schedule a status.
 //			Providing this parameter will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//
-//			This feature isn't implemented yet.
 //		type: string
 //		in: formData
 //	-
@@ -196,7 +195,9 @@ import (
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: >-
+//				The newly created status, or the
+//				newly scheduled status if scheduled_at was set.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -209,6 +210,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable entity
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -248,6 +251,31 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status should be published later.
+		scheduledAt, err := time.Parse(time.RFC3339, form.ScheduledAt)
+		if err != nil {
+			text := "scheduled_at could not be parsed as ISO 8601 datetime"
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, text), m.processor.InstanceGetV1)
+			return
+		}
+
+		apiScheduled, errWithCode := m.processor.ScheduledStatuses().Create(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+			scheduledAt,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, apiScheduled)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
@@ -291,7 +319,7 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 	}
 
 	if form.Poll != nil {
-		if err := validateNormalizeCreatePoll(form); err != nil {
+		if err := validateNormalizeCreatePoll(form.Poll); err != nil {
 			return err
 		}
 	}
@@ -307,17 +335,17 @@ func validateNormalizeCreateStatus(form *apimodel.AdvancedStatusCreateForm) erro
 	return nil
 }
 
-func validateNormalizeCreatePoll(form *apimodel.AdvancedStatusCreateForm) error {
+func validateNormalizeCreatePoll(poll *apimodel.PollRequest) error {
 	maxPollOptions := config.GetStatusesPollMaxOptions()
 	maxPollChars := config.GetStatusesPollOptionMaxChars()
 
 	// Normalize poll expiry if necessary.
 	// If we parsed this as JSON, expires_in
 	// may be either a float64 or a string.
-	if ei := form.Poll.ExpiresInI; ei != nil {
+	if ei := poll.ExpiresInI; ei != nil {
 		switch e := ei.(type) {
 		case float64:
-			form.Poll.ExpiresIn = int(e)
+			poll.ExpiresIn = int(e)
 
 		case string:
 			expiresIn, err := strconv.Atoi(e)
@@ -325,22 +353,22 @@ func validateNormalizeCreatePoll(form *apimodel.AdvancedStatusCreateForm) error
 				return fmt.Errorf("could not parse expires_in value %s as integer: %w", e, err)
 			}
 
-			form.Poll.ExpiresIn = expiresIn
+			poll.ExpiresIn = expiresIn
 
 		default:
 			return fmt.Errorf("could not parse expires_in type %T as integer", ei)
 		}
 	}
 
-	if len(form.Poll.Options) == 0 {
+	if len(poll.Options) == 0 {
 		return errors.New("poll with no options")
 	}
 
-	if len(form.Poll.Options) > maxPollOptions {
-		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
+	if len(poll.Options) > maxPollOptions {
+		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(poll.Options), maxPollOptions)
 	}
 
-	for _, p := range form.Poll.Options {
+	for _, p := range poll.Options {
 		if length := len([]rune(p)); length > maxPollChars {
 			return fmt.Errorf("poll option too long, %d characters provided but limit is %d", length, maxPollChars)
 		}
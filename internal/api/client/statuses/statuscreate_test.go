@@ -28,6 +28,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
@@ -35,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -102,6 +104,51 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
 	suite.NoError(err)
 }
 
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this is a status from the future!"},
+		"spoiler_text": {"hello hello"},
+		"visibility":   {string(apimodel.VisibilityUnlisted)},
+		"scheduled_at": {scheduledAt.Format(time.RFC3339)},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	scheduledReply := &apimodel.ScheduledStatus{}
+	err = json.Unmarshal(b, scheduledReply)
+	suite.NoError(err)
+
+	suite.Equal(util.FormatISO8601(scheduledAt), scheduledReply.ScheduledAt)
+	suite.Equal("this is a status from the future!", scheduledReply.Params.Text)
+	suite.Equal("hello hello", scheduledReply.Params.SpoilerText)
+	suite.Equal("unlisted", scheduledReply.Params.Visibility)
+
+	// status should be stored for later, not posted yet
+	scheduled, err := suite.db.GetScheduledStatusByID(context.Background(), scheduledReply.ID)
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, scheduled.AccountID)
+}
+
 func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
 	// Copy zork.
 	testAccount := &gtsmodel.Account{}
@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) editStatus(
+	accountKey string,
+	targetStatusID string,
+	form url.Values,
+) (*apimodel.Status, int) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Request = httptest.NewRequest(http.MethodPut, "http://localhost:8080"+strings.ReplaceAll(statuses.BasePathWithID, ":id", targetStatusID), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = form
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	suite.statusModule.StatusEditPUTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code
+	}
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus := &apimodel.Status{}
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return apiStatus, recorder.Code
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status":       {"hello everyone! #edited"},
+		"spoiler_text": {"edited introduction post"},
+		"sensitive":    {"false"},
+	})
+	suite.Equal(http.StatusOK, code)
+
+	suite.Equal(targetStatus.ID, apiStatus.ID)
+	suite.Equal("edited introduction post", apiStatus.SpoilerText)
+	suite.Equal("<p>hello everyone! <a href=\"http://localhost:8080/tags/edited\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>edited</span></a></p>", apiStatus.Content)
+	suite.False(apiStatus.Sensitive)
+	suite.NotNil(apiStatus.EditedAt)
+	suite.Len(apiStatus.Tags, 1)
+
+	// Status should now have one edit
+	// in the database with previous content.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbStatus.Edits, 1)
+	suite.Equal(targetStatus.Content, dbStatus.Edits[0].Content)
+	suite.Equal(targetStatus.ContentWarning, dbStatus.Edits[0].ContentWarning)
+	suite.True(targetStatus.CreatedAt.Equal(dbStatus.Edits[0].CreatedAt))
+	suite.False(dbStatus.EditedAt.IsZero())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusMentions() {
+	targetStatus := suite.testStatuses["local_account_2_status_5"]
+	existingMention := testrig.NewTestMentions()["local_user_2_mention_zork"]
+
+	apiStatus, code := suite.editStatus("local_account_2", targetStatus.ID, url.Values{
+		"status": {"🐢 @the_mighty_zork @admin hi both! 🐢"},
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Len(apiStatus.Mentions, 2)
+
+	// Existing mention should be kept as-is,
+	// with only the new mention being added.
+	dbStatus, err := suite.db.GetStatusByID(context.Background(), targetStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbStatus.MentionIDs, 2)
+	suite.Equal(existingMention.ID, dbStatus.MentionIDs[0])
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbStatus.Mentions[1].TargetAccountID)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	_, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status": {"this isn't my status"},
+	})
+	suite.Equal(http.StatusForbidden, code)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusEmpty() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	_, code := suite.editStatus("local_account_1", targetStatus.ID, url.Values{})
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
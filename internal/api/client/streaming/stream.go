@@ -184,6 +184,9 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 
 		// Set the auth'ed account.
 		account = authed.Account
+
+		// And the token it used.
+		token = authed.Token.GetAccess()
 	}
 
 	if account.IsMoving() {
@@ -209,9 +212,13 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// Open a stream with the processor; this lets processor
 	// functions pass messages into a channel, which we can
 	// then read from and put into a websockets connection.
-	stream, errWithCode := m.processor.Stream().Open(
+	//
+	// The stream is tied to the token used to open it, so
+	// it can be closed if that token is revoked later on.
+	stream, errWithCode := m.processor.Stream().OpenWithToken(
 		c.Request.Context(), // this ctx is only used for logging
 		account,
+		token,
 		streamType,
 	)
 	if errWithCode != nil {
@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TagFollowTestSuite struct {
+	TagsStandardTestSuite
+}
+
+func (suite *TagFollowTestSuite) tagRequest(
+	expectedHTTPStatus int,
+	method string,
+	path string,
+	name string,
+	handler gin.HandlerFunc,
+) (*apimodel.Tag, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// Prepare test context request.
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + path
+	request := httptest.NewRequest(method, requestPath, nil)
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+	ctx.AddParam(apiutil.TagNameKey, name)
+
+	// trigger the handler
+	handler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		return nil, fmt.Errorf("expected %d got %d: %s", expectedHTTPStatus, status, string(b))
+	}
+
+	if status := recorder.Code; status != http.StatusOK {
+		// No tag to parse.
+		return nil, nil
+	}
+
+	tag := &apimodel.Tag{}
+	if err := json.Unmarshal(b, tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (suite *TagFollowTestSuite) getTag(expectedHTTPStatus int, name string) (*apimodel.Tag, error) {
+	return suite.tagRequest(expectedHTTPStatus, http.MethodGet, "/v1/tags/"+name, name, suite.tagsModule.TagGETHandler)
+}
+
+func (suite *TagFollowTestSuite) followTag(expectedHTTPStatus int, name string) (*apimodel.Tag, error) {
+	return suite.tagRequest(expectedHTTPStatus, http.MethodPost, "/v1/tags/"+name+"/follow", name, suite.tagsModule.TagFollowPOSTHandler)
+}
+
+func (suite *TagFollowTestSuite) unfollowTag(expectedHTTPStatus int, name string) (*apimodel.Tag, error) {
+	return suite.tagRequest(expectedHTTPStatus, http.MethodPost, "/v1/tags/"+name+"/unfollow", name, suite.tagsModule.TagUnfollowPOSTHandler)
+}
+
+func (suite *TagFollowTestSuite) TestFollowUnfollowTag() {
+	// Not followed to begin with.
+	tag, err := suite.getTag(http.StatusOK, "Welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("welcome", tag.Name)
+	suite.Equal("http://localhost:8080/tags/welcome", tag.URL)
+	suite.False(*tag.Following)
+
+	// Follow it.
+	tag, err = suite.followTag(http.StatusOK, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*tag.Following)
+
+	// Following again is a no-op.
+	tag, err = suite.followTag(http.StatusOK, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*tag.Following)
+
+	// Get shows it as followed.
+	tag, err = suite.getTag(http.StatusOK, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*tag.Following)
+
+	// Unfollow it.
+	tag, err = suite.unfollowTag(http.StatusOK, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*tag.Following)
+
+	// Get shows it as not followed.
+	tag, err = suite.getTag(http.StatusOK, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*tag.Following)
+}
+
+func (suite *TagFollowTestSuite) TestFollowNewTag() {
+	// Tag doesn't exist yet.
+	if _, err := suite.getTag(http.StatusNotFound, "somethingnew"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Following creates it.
+	tag, err := suite.followTag(http.StatusOK, "somethingnew")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("somethingnew", tag.Name)
+	suite.True(*tag.Following)
+
+	tag, err = suite.getTag(http.StatusOK, "somethingnew")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*tag.Following)
+}
+
+func (suite *TagFollowTestSuite) TestFollowTagInvalid() {
+	if _, err := suite.followTag(http.StatusBadRequest, "not-a-hashtag!"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func TestTagFollowTestSuite(t *testing.T) {
+	suite.Run(t, &TagFollowTestSuite{})
+}
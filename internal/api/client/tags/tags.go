@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base API path for this module, excluding the 'api' prefix.
+	BasePath = "/v1/tags"
+	// TagPath is for operations on the hashtag with the given name.
+	TagPath = BasePath + "/:" + apiutil.TagNameKey
+	// FollowPath is for following the hashtag with the given name.
+	FollowPath = TagPath + "/follow"
+	// UnfollowPath is for unfollowing the hashtag with the given name.
+	UnfollowPath = TagPath + "/unfollow"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, TagPath, middleware.RequireScope(oauth.ScopeReadFollows), m.TagGETHandler)
+	attachHandler(http.MethodPost, FollowPath, middleware.RequireScope(oauth.ScopeWriteFollows), m.TagFollowPOSTHandler)
+	attachHandler(http.MethodPost, UnfollowPath, middleware.RequireScope(oauth.ScopeWriteFollows), m.TagUnfollowPOSTHandler)
+}
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokensGetTestSuite struct {
+	TokensStandardTestSuite
+}
+
+func (suite *TokensGetTestSuite) getTokens(
+	expectedHTTPStatus int,
+	accountKey string,
+) ([]byte, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + tokens.BasePath
+
+	// Prepare test context request.
+	request := httptest.NewRequest(http.MethodGet, requestPath, nil)
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+
+	// trigger the handler
+	suite.tokensModule.TokensInfoGETHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		err = fmt.Errorf("expected %d got %d", expectedHTTPStatus, status)
+	}
+
+	return b, err
+}
+
+func (suite *TokensGetTestSuite) TestGetTokens() {
+	b, err := suite.getTokens(http.StatusOK, "local_account_1")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tokenInfos := []*apimodel.TokenInfo{}
+	if err := json.Unmarshal(b, &tokenInfos); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(tokenInfos, 1) {
+		suite.FailNow("")
+	}
+
+	tokenInfo := tokenInfos[0]
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokenInfo.ID)
+	suite.Equal("read write follow push", tokenInfo.Scope)
+	suite.Equal(suite.testApplications["application_1"].ID, tokenInfo.Application.ID)
+	suite.Equal(suite.testApplications["application_1"].Name, tokenInfo.Application.Name)
+
+	// Secrets should never be included.
+	suite.NotContains(string(b), suite.testTokens["local_account_1"].Access)
+}
+
+func TestTokensGetTestSuite(t *testing.T) {
+	suite.Run(t, &TokensGetTestSuite{})
+}
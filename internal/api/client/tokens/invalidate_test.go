@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tokens_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokenInvalidateTestSuite struct {
+	TokensStandardTestSuite
+}
+
+func (suite *TokenInvalidateTestSuite) invalidateToken(
+	expectedHTTPStatus int,
+	accountKey string,
+	tokenID string,
+) ([]byte, error) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+
+	requestPath := config.GetProtocol() + "://" + config.GetHost() + "/api" + tokens.BasePath + "/" + tokenID + "/invalidate"
+
+	// Prepare test context request.
+	request := httptest.NewRequest(http.MethodPost, requestPath, nil)
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+	ctx.AddParam(tokens.IDKey, tokenID)
+
+	// trigger the handler
+	suite.tokensModule.TokenInvalidatePOSTHandler(ctx)
+
+	// read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code.
+	if status := recorder.Code; expectedHTTPStatus != status {
+		err = fmt.Errorf("expected %d got %d", expectedHTTPStatus, status)
+	}
+
+	return b, err
+}
+
+func (suite *TokenInvalidateTestSuite) TestInvalidateToken() {
+	testToken := suite.testTokens["local_account_1"]
+
+	b, err := suite.invalidateToken(http.StatusOK, "local_account_1", testToken.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tokenInfo := &apimodel.TokenInfo{}
+	if err := json.Unmarshal(b, tokenInfo); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testToken.ID, tokenInfo.ID)
+
+	// Token should now be gone.
+	_, err = suite.db.GetTokenByID(context.Background(), testToken.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TokenInvalidateTestSuite) TestInvalidateTokenNotOwned() {
+	testToken := suite.testTokens["admin_account"]
+
+	b, err := suite.invalidateToken(http.StatusNotFound, "local_account_1", testToken.ID)
+	suite.NoError(err)
+	suite.Equal(`{"error":"Not Found"}`, string(b))
+
+	// Token should still be there.
+	_, err = suite.db.GetTokenByID(context.Background(), testToken.ID)
+	suite.NoError(err)
+}
+
+func TestTokenInvalidateTestSuite(t *testing.T) {
+	suite.Run(t, &TokenInvalidateTestSuite{})
+}
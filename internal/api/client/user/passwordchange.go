@@ -38,6 +38,8 @@ const OIDCPasswordHelp = "password change request cannot be processed by GoToSoc
 // The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
 // The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
 //
+// If `revoke_other_tokens` is true, all access tokens other than the one used to make the request will be revoked.
+//
 //	---
 //	tags:
 //	- user
@@ -105,7 +107,14 @@ func (m *Module) PasswordChangePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	if errWithCode := m.processor.User().PasswordChange(c.Request.Context(), authed.User, form.OldPassword, form.NewPassword); errWithCode != nil {
+	if errWithCode := m.processor.User().PasswordChange(
+		c.Request.Context(),
+		authed.User,
+		form.OldPassword,
+		form.NewPassword,
+		form.RevokeOtherTokens,
+		authed.Token.GetAccess(),
+	); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnablePOST() {
+	// Pretend the user has
+	// already got a QR code.
+	testUser := suite.testUsers["local_account_1"]
+	testUser.TwoFactorSecret = "JBSWY3DPEHPK3PXP"
+
+	code, err := totp.GenerateCode(testUser.TwoFactorSecret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	response, status := suite.POST(user.TwoFactorEnablePath, map[string][]string{
+		"code": {code},
+	}, suite.userModule.TwoFactorEnablePOSTHandler)
+	defer response.Body.Close()
+
+	// Check response
+	suite.EqualValues(http.StatusOK, status)
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	backups := []string{}
+	if err := json.Unmarshal(b, &backups); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(backups, 10)
+
+	dbUser := &gtsmodel.User{}
+	if err := suite.db.GetByID(context.Background(), testUser.ID, dbUser); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbUser.TwoFactorEnabled())
+	suite.Len(dbUser.TwoFactorBackups, 10)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnablePOSTWrongCode() {
+	testUser := suite.testUsers["local_account_1"]
+	testUser.TwoFactorSecret = "JBSWY3DPEHPK3PXP"
+
+	response, status := suite.POST(user.TwoFactorEnablePath, map[string][]string{
+		"code": {"not a code"},
+	}, suite.userModule.TwoFactorEnablePOSTHandler)
+	defer response.Body.Close()
+
+	// Check response
+	suite.EqualValues(http.StatusForbidden, status)
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"error":"Forbidden: invalid two-factor code"}`, string(b))
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnablePOSTNoSecret() {
+	response, status := suite.POST(user.TwoFactorEnablePath, map[string][]string{
+		"code": {"123456"},
+	}, suite.userModule.TwoFactorEnablePOSTHandler)
+	defer response.Body.Close()
+
+	// Check response
+	suite.EqualValues(http.StatusUnprocessableEntity, status)
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(`{"error":"Unprocessable Entity: no two-factor secret generated yet; get a qr code first"}`, string(b))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorTestSuite{})
+}
@@ -21,6 +21,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -31,6 +33,16 @@ const (
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// TwoFactorPath is the base path for two-factor authentication settings.
+	TwoFactorPath = BasePath + "/2fa"
+	// TwoFactorQRCodeURIPath is the path for GETting the otpauth:// URI of a new two-factor secret.
+	TwoFactorQRCodeURIPath = TwoFactorPath + "/qruri"
+	// TwoFactorQRCodePNGPath is the path for GETting a QR code image of a new two-factor secret.
+	TwoFactorQRCodePNGPath = TwoFactorPath + "/qr.png"
+	// TwoFactorEnablePath is the path for POSTing a two-factor enable request.
+	TwoFactorEnablePath = TwoFactorPath + "/enable"
+	// TwoFactorDisablePath is the path for POSTing a two-factor disable request.
+	TwoFactorDisablePath = TwoFactorPath + "/disable"
 )
 
 type Module struct {
@@ -44,7 +56,11 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
-	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
-	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, BasePath, middleware.RequireScope(oauth.ScopeReadUser), m.UserGETHandler)
+	attachHandler(http.MethodPost, PasswordChangePath, middleware.RequireScope(oauth.ScopeWriteUser), m.PasswordChangePOSTHandler)
+	attachHandler(http.MethodPost, EmailChangePath, middleware.RequireScope(oauth.ScopeWriteUser), m.EmailChangePOSTHandler)
+	attachHandler(http.MethodGet, TwoFactorQRCodeURIPath, middleware.RequireScope(oauth.ScopeReadUser), m.TwoFactorQRCodeURIGETHandler)
+	attachHandler(http.MethodGet, TwoFactorQRCodePNGPath, middleware.RequireScope(oauth.ScopeReadUser), m.TwoFactorQRCodePNGGETHandler)
+	attachHandler(http.MethodPost, TwoFactorEnablePath, middleware.RequireScope(oauth.ScopeWriteUser), m.TwoFactorEnablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorDisablePath, middleware.RequireScope(oauth.ScopeWriteUser), m.TwoFactorDisablePOSTHandler)
 }
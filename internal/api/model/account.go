@@ -165,6 +165,12 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Invite code to sign up with. If provided and valid, the account
+	// can be created even if registration is closed, and will not
+	// need to be approved by a moderator.
+	// swagger:parameters
+	// example: k7w3NqZp
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
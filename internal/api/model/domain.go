@@ -61,6 +61,8 @@ type DomainPermission struct {
 	// Time at which the permission entry was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at,omitempty"`
+	// Moderation policy set on this domain, if any.
+	ModerationPolicy *ModerationPolicy `json:"moderation_policy,omitempty"`
 }
 
 // DomainPermissionRequest is the form submitted as a POST to create a new domain permission entry (allow/block).
@@ -94,3 +96,74 @@ type DomainKeysExpireRequest struct {
 	// hostname/domain to expire keys for.
 	Domain string `form:"domain" json:"domain" xml:"domain"`
 }
+
+// DomainPermissionSubscription represents an auto-refreshing subscription to a list of domain permissions (allows, blocks).
+//
+// swagger:model domainPermissionSubscription
+type DomainPermissionSubscription struct {
+	// The ID of the domain permission subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	// example: 100
+	Priority uint8 `json:"priority"`
+	// Title of this subscription, as set by admin who created or updated it.
+	// example: really cool list of neato pals
+	Title string `json:"title"`
+	// The type of domain permission subscription (allow, block).
+	// example: block
+	PermissionType string `json:"permission_type"`
+	// If true, domain permissions on this instance which match domains on the list, but
+	// which have no subscription ID, will be taken over by this subscription.
+	// example: false
+	AdoptOrphans bool `json:"adopt_orphans"`
+	// If true, domain permissions created by this subscription which no longer appear on the
+	// list (or which are left behind when this subscription is removed) will be removed, rather
+	// than retained on the instance without a subscription ID.
+	// example: false
+	RemoveOrphans bool `json:"remove_orphans"`
+	// Time at which the subscription was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this subscription.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// URI to call in order to fetch the permissions list.
+	// example: https://www.example.org/blocklists/list1.csv
+	URI string `json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	// example: text/csv
+	ContentType string `json:"content_type"`
+	// Time of the most recent fetch attempt (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time of the most recent successful fetch (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	SuccessfullyFetchedAt string `json:"successfully_fetched_at,omitempty"`
+	// If most recent fetch attempt failed, this field will contain an error message related to the fetch attempt.
+	// example: fetch failed: 404 Not Found
+	Error string `json:"error,omitempty"`
+}
+
+// DomainPermissionSubscriptionRequest is the form submitted as a POST or PATCH
+// to create or update a domain permission subscription entry.
+//
+// swagger:ignore
+type DomainPermissionSubscriptionRequest struct {
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Priority *int `form:"priority" json:"priority" xml:"priority"`
+	// Title of this subscription, as set by admin who created or updated it.
+	Title *string `form:"title" json:"title" xml:"title"`
+	// URI to call in order to fetch the permissions list.
+	URI *string `form:"uri" json:"uri" xml:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	ContentType *string `form:"content_type" json:"content_type" xml:"content_type"`
+	// Take over matching domain permissions that have no subscription ID.
+	AdoptOrphans *bool `form:"adopt_orphans" json:"adopt_orphans" xml:"adopt_orphans"`
+	// Remove (rather than orphan) domain permissions which are no longer on the list.
+	RemoveOrphans *bool `form:"remove_orphans" json:"remove_orphans" xml:"remove_orphans"`
+	// Type of domain permission to create from the list (allow, block).
+	// Only used when creating a new subscription.
+	PermissionType *string `form:"permission_type" json:"permission_type" xml:"permission_type"`
+}
@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// AccountArchive represents an archive of
+// an account's data, requested by its owner.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Time at which the archive was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// State of the archive (pending, complete, failed).
+	// example: complete
+	State string `json:"state"`
+	// Size of the archive zip file in bytes. Only set once complete.
+	// example: 1048576
+	Size int64 `json:"size,omitempty"`
+	// Earliest time at which a new archive may be requested (ISO 8601 Datetime).
+	// example: 2021-08-06T09:20:25+00:00
+	NextRequestAt string `json:"next_request_at"`
+}
+
+// ImportRequest is the form submitted as a POST to
+// /api/v1/import, to import a Mastodon-format CSV file.
+//
+// swagger:ignore
+type ImportRequest struct {
+	// The CSV file to import.
+	Data *multipart.FileHeader `form:"data" binding:"required"`
+	// Type of the CSV file: following, blocks, mutes, bookmarks or lists.
+	Type string `form:"type" binding:"required"`
+	// Import mode: merge (default) or overwrite.
+	Mode string `form:"mode"`
+}
+
+// ImportArchiveRequest is the form submitted as a POST
+// to /api/v1/import/archive, to import an account archive.
+//
+// swagger:ignore
+type ImportArchiveRequest struct {
+	// The zip file containing the archive.
+	Data *multipart.FileHeader `form:"data" binding:"required"`
+}
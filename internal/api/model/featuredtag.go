@@ -18,6 +18,8 @@
 package model
 
 // FeaturedTag represents a hashtag that is featured on a profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
 	// The internal ID of the featured tag in the database.
 	ID string `json:"id"`
@@ -28,5 +30,17 @@ type FeaturedTag struct {
 	// The number of authored statuses containing this hashtag.
 	StatusesCount int `json:"statuses_count"`
 	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// Will be null if there are no such statuses.
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag on the requester's profile.
+//
+// swagger:parameters featuredTagCreate
+type FeaturedTagCreateRequest struct {
+	// The hashtag to be featured, without the hash sign.
+	// Sample: gotosocial
+	// in: formData
+	// required: true
+	Name string `form:"name" json:"name" xml:"name"`
 }
@@ -68,7 +68,7 @@ type InstanceV1 struct {
 	Registrations bool `json:"registrations"`
 	// New account registrations require admin approval.
 	ApprovalRequired bool `json:"approval_required"`
-	// Invites are enabled on this instance.
+	// Ordinary users of this instance may create invites.
 	InvitesEnabled bool `json:"invites_enabled"`
 	// Configuration object containing values about status limits etc.
 	// This key/value will be omitted for remote instances.
@@ -145,6 +145,15 @@ type InstanceV2ConfigurationTranslation struct {
 	Enabled bool `json:"enabled"`
 }
 
+// Web Push configuration.
+//
+// swagger:model instanceV2ConfigurationVAPID
+type InstanceV2ConfigurationVAPID struct {
+	// The instance's VAPID public key, used to create push subscriptions.
+	// example: BP7pFKyn5MsXib9R6zZUzDy-9ivK7W7Lbdki3ZknFj_BxkssAmK1CV6zE5FV5B0Jx_Tt-grJFSsWpZhfRbBOuXQ
+	PublicKey string `json:"public_key"`
+}
+
 // Configured values and limits for this instance.
 //
 // swagger:model instanceV2Configuration
@@ -161,6 +170,8 @@ type InstanceV2Configuration struct {
 	Polls InstanceConfigurationPolls `json:"polls"`
 	// Hints related to translation.
 	Translation InstanceV2ConfigurationTranslation `json:"translation"`
+	// Web Push configuration.
+	VAPID InstanceV2ConfigurationVAPID `json:"vapid"`
 	// Instance configuration pertaining to emojis.
 	Emojis InstanceConfigurationEmojis `json:"emojis"`
 	// True if instance is running with OIDC as auth/identity backend, else omitted.
@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// PolicyValue describes a group of accounts to which
+// an interaction policy rule applies.
+//
+//	public    = Anyone at all.
+//	followers = Accounts following the status author.
+//	mentioned = Accounts mentioned in the status.
+//	author    = The status author only.
+//
+// swagger:enum interactionPolicyValue
+// swagger:type string
+type PolicyValue string
+
+const (
+	PolicyValuePublic    PolicyValue = "public"
+	PolicyValueFollowers PolicyValue = "followers"
+	PolicyValueMentioned PolicyValue = "mentioned"
+	PolicyValueAuthor    PolicyValue = "author"
+)
+
+// PolicyRules describes which groups of accounts may perform
+// an interaction with a status outright, and which may do so
+// pending approval of the status author.
+//
+// swagger:model interactionPolicyRules
+type PolicyRules struct {
+	// Groups of accounts permitted to perform this interaction without approval.
+	Always []PolicyValue `form:"always" json:"always"`
+	// Groups of accounts permitted to perform this interaction pending approval of the status author.
+	WithApproval []PolicyValue `form:"with_approval" json:"with_approval"`
+}
+
+// InteractionPolicy models who may favourite, reply to, or reblog a status.
+//
+// swagger:model interactionPolicy
+type InteractionPolicy struct {
+	// Rules for who may favourite the status.
+	// Approval is not supported for favourites.
+	CanFavourite PolicyRules `form:"can_favourite" json:"can_favourite"`
+	// Rules for who may reply to the status.
+	CanReply PolicyRules `form:"can_reply" json:"can_reply"`
+	// Rules for who may reblog the status.
+	CanReblog PolicyRules `form:"can_reblog" json:"can_reblog"`
+}
+
+// DefaultPolicies models the default interaction
+// policies applied by an account to its new statuses,
+// keyed by status visibility.
+//
+// swagger:model defaultPolicies
+type DefaultPolicies struct {
+	// Default policy for new public statuses.
+	Public InteractionPolicy `json:"public"`
+	// Default policy for new unlisted statuses.
+	Unlisted InteractionPolicy `json:"unlisted"`
+	// Default policy for new private (followers-only and mutuals-only) statuses.
+	Private InteractionPolicy `json:"private"`
+	// Default policy for new direct statuses.
+	Direct InteractionPolicy `json:"direct"`
+}
+
+// UpdateInteractionPoliciesRequest models a request
+// to update an account's default interaction policies.
+// Policies that are omitted or null are left unchanged.
+//
+// swagger:ignore
+type UpdateInteractionPoliciesRequest struct {
+	Public   *InteractionPolicy `json:"public"`
+	Unlisted *InteractionPolicy `json:"unlisted"`
+	Private  *InteractionPolicy `json:"private"`
+	Direct   *InteractionPolicy `json:"direct"`
+}
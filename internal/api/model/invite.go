@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite represents an invite code which can
+// be used to sign up to this instance.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01J2RQ7Y3ZB1M5Q6C9F0N4X8KD
+	ID string `json:"id"`
+	// When the invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The invite code.
+	// example: k7w3NqZp
+	Code string `json:"code"`
+	// Link to the sign-up page with the invite code filled in.
+	// example: https://example.org/signup?invite=k7w3NqZp
+	URL string `json:"url"`
+	// Maximum number of sign-ups allowed with this invite.
+	// Null if the invite can be used an unlimited number of times.
+	MaxUses *int `json:"max_uses"`
+	// Number of sign-ups made with this invite so far.
+	Uses int `json:"uses"`
+	// When the invite expires (ISO 8601 Datetime).
+	// Null if the invite never expires.
+	ExpiresAt *string `json:"expires_at"`
+	// Whether this invite has expired or been used up,
+	// and so can no longer be used to sign up.
+	Expired bool `json:"expired"`
+}
+
+// InviteCreateRequest models a request to create a new invite.
+//
+// swagger:parameters inviteCreate
+type InviteCreateRequest struct {
+	// Maximum number of sign-ups allowed with the invite.
+	// 0 or not set means unlimited.
+	// in: formData
+	MaxUses int `form:"max_uses" json:"max_uses" xml:"max_uses"`
+	// Number of seconds from now after which the invite expires.
+	// 0 or not set means the invite never expires.
+	// in: formData
+	ExpiresIn int `form:"expires_in" json:"expires_in" xml:"expires_in"`
+}
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// ModerationPolicy represents a set of moderation measures applied
+// to all content from one domain (and its subdomains), or one account.
+//
+// swagger:model moderationPolicy
+type ModerationPolicy struct {
+	// The ID of the moderation policy.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Domain this policy applies to. Only set for domain policies.
+	// example: example.org
+	Domain string `json:"domain,omitempty"`
+	// ID of the account this policy applies to. Only set for account policies.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	AccountID string `json:"account_id,omitempty"`
+	// Mark all statuses covered by this policy as sensitive.
+	// example: true
+	ForceSensitive bool `json:"force_sensitive"`
+	// Content warning text appended to all statuses covered by this policy.
+	// example: ai-generated images
+	ContentWarning string `json:"content_warning"`
+	// Don't fetch or store media attached to statuses covered by this policy.
+	// example: false
+	RejectMedia bool `json:"reject_media"`
+	// Don't show statuses covered by this policy on public timelines.
+	// example: false
+	StripPublicTimelines bool `json:"strip_public_timelines"`
+	// Private comment for this policy, visible to this instance's admins only.
+	// example: lots of unmarked gore
+	PrivateComment string `json:"private_comment,omitempty"`
+	// Time at which the policy was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that created this policy.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// ModerationPolicyRequest is the form submitted as a POST or PATCH
+// to create or update a moderation policy.
+//
+// swagger:ignore
+type ModerationPolicyRequest struct {
+	// Domain this policy applies to.
+	// Only used when creating a new policy.
+	Domain *string `form:"domain" json:"domain" xml:"domain"`
+	// ID of the account this policy applies to.
+	// Only used when creating a new policy.
+	AccountID *string `form:"account_id" json:"account_id" xml:"account_id"`
+	// Mark all statuses covered by this policy as sensitive.
+	ForceSensitive *bool `form:"force_sensitive" json:"force_sensitive" xml:"force_sensitive"`
+	// Content warning text to append to all statuses covered by this policy.
+	ContentWarning *string `form:"content_warning" json:"content_warning" xml:"content_warning"`
+	// Don't fetch or store media attached to statuses covered by this policy.
+	RejectMedia *bool `form:"reject_media" json:"reject_media" xml:"reject_media"`
+	// Don't show statuses covered by this policy on public timelines.
+	StripPublicTimelines *bool `form:"strip_public_timelines" json:"strip_public_timelines" xml:"strip_public_timelines"`
+	// Private comment for other admins on why this policy was created.
+	PrivateComment *string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
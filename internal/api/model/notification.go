@@ -34,6 +34,8 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	pending.reply = Someone replied to one of your statuses, pending your approval. `status` will be set. `account` will be set.
+	// 	pending.reblog = Someone boosted one of your statuses, pending your approval. `status` will be set. `account` will be set.
 	Type string `json:"type"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
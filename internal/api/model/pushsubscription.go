@@ -18,6 +18,8 @@
 package model
 
 // PushSubscription represents a subscription to the push streaming server.
+//
+// swagger:model webPushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
 	ID string `json:"id"`
@@ -27,12 +29,23 @@ type PushSubscription struct {
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
 	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Which accounts to receive push notifications from.
+	// Enum:
+	//	- all
+	//	- followed
+	//	- follower
+	//	- none
+	Policy string `json:"policy"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model webPushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -41,4 +54,123 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+	// Receive a push notification when a new user has signed up?
+	// Only has an effect for admins and moderators.
+	AdminSignup bool `json:"admin.sign_up"`
+}
+
+// PushSubscriptionCreateRequest captures params for creating or replacing a push subscription.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	// The subscription to the push service.
+	Subscription *PushSubscriptionRequestSubscription `form:"-" json:"subscription" xml:"subscription"`
+	// Form data version of Subscription.Endpoint.
+	SubscriptionEndpoint *string `form:"subscription[endpoint]" json:"-" xml:"-"`
+	// Form data version of Subscription.Keys.P256dh.
+	SubscriptionKeysP256dh *string `form:"subscription[keys][p256dh]" json:"-" xml:"-"`
+	// Form data version of Subscription.Keys.Auth.
+	SubscriptionKeysAuth *string `form:"subscription[keys][auth]" json:"-" xml:"-"`
+
+	PushSubscriptionUpdateRequest
+}
+
+// PushSubscriptionRequestSubscription captures the details
+// of a subscription to a push service made by a user agent.
+//
+// swagger:ignore
+type PushSubscriptionRequestSubscription struct {
+	// Where push alerts will be sent to.
+	Endpoint *string `json:"endpoint"`
+	// Keys used to encrypt push alerts.
+	Keys *PushSubscriptionRequestKeys `json:"keys"`
+}
+
+// PushSubscriptionRequestKeys captures the keys
+// with which push alerts for a user agent are encrypted.
+//
+// swagger:ignore
+type PushSubscriptionRequestKeys struct {
+	// Base64-encoded P-256 ECDH public key of the user agent.
+	P256dh *string `json:"p256dh"`
+	// Base64-encoded auth secret of the user agent.
+	Auth *string `json:"auth"`
+}
+
+// PushSubscriptionUpdateRequest captures params for updating a push subscription.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	// Which alerts should be delivered, and from whom.
+	Data *PushSubscriptionRequestData `form:"-" json:"data" xml:"data"`
+	// Form data version of Data.Alerts.Follow.
+	DataAlertsFollow *bool `form:"data[alerts][follow]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.FollowRequest.
+	DataAlertsFollowRequest *bool `form:"data[alerts][follow_request]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.Favourite.
+	DataAlertsFavourite *bool `form:"data[alerts][favourite]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.Mention.
+	DataAlertsMention *bool `form:"data[alerts][mention]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.Reblog.
+	DataAlertsReblog *bool `form:"data[alerts][reblog]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.Poll.
+	DataAlertsPoll *bool `form:"data[alerts][poll]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.Status.
+	DataAlertsStatus *bool `form:"data[alerts][status]" json:"-" xml:"-"`
+	// Form data version of Data.Alerts.AdminSignup.
+	DataAlertsAdminSignup *bool `form:"data[alerts][admin.sign_up]" json:"-" xml:"-"`
+	// Form data version of Data.Policy.
+	DataPolicy *string `form:"data[policy]" json:"-" xml:"-"`
+}
+
+// PushSubscriptionRequestData captures which alerts
+// a push subscription should receive, and from whom.
+//
+// swagger:ignore
+type PushSubscriptionRequestData struct {
+	// Which alerts should be delivered to the endpoint.
+	Alerts *PushSubscriptionRequestAlerts `json:"alerts"`
+	// Which accounts to receive push notifications from.
+	Policy *string `json:"policy"`
+}
+
+// PushSubscriptionRequestAlerts captures which alerts
+// a push subscription should receive. Omitted alerts
+// are left unchanged (or disabled, on creation).
+//
+// swagger:ignore
+type PushSubscriptionRequestAlerts struct {
+	Follow        *bool `json:"follow"`
+	FollowRequest *bool `json:"follow_request"`
+	Favourite     *bool `json:"favourite"`
+	Mention       *bool `json:"mention"`
+	Reblog        *bool `json:"reblog"`
+	Poll          *bool `json:"poll"`
+	Status        *bool `json:"status"`
+	AdminSignup   *bool `json:"admin.sign_up"`
+}
+
+// WebPushNotification is the decrypted payload
+// of a push alert sent to a push subscription.
+//
+// swagger:ignore
+type WebPushNotification struct {
+	// Access token of the subscription,
+	// so clients can tell which account
+	// the notification is for.
+	AccessToken string `json:"access_token"`
+	// ID of the notification.
+	NotificationID string `json:"notification_id"`
+	// Type of the notification.
+	NotificationType string `json:"notification_type"`
+	// Title of the alert.
+	Title string `json:"title"`
+	// Plaintext preview of the notification.
+	Body string `json:"body"`
+	// Avatar URL of the notification's origin account.
+	Icon string `json:"icon"`
+	// Locale of the user receiving the notification.
+	PreferredLocale string `json:"preferred_locale"`
 }
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Relay represents a subscription of this instance to a relay.
+//
+// swagger:model relay
+type Relay struct {
+	// The ID of the relay subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Inbox URL of the relay, to which our Follow and public posts are delivered.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// URI of the relay actor. Only set once the relay has responded to our Follow.
+	// example: https://relay.example.org/actor
+	ActorURI string `json:"actor_uri,omitempty"`
+	// State of the relay subscription (pending, accepted, rejected).
+	// example: accepted
+	State string `json:"state"`
+	// Time at which the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the account that added this relay.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// RelayCreateRequest is the form submitted
+// as a POST to subscribe to a new relay.
+//
+// swagger:ignore
+type RelayCreateRequest struct {
+	// Inbox URL of the relay.
+	InboxURL string `form:"inbox_url" json:"inbox_url" xml:"inbox_url"`
+}
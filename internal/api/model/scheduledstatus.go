@@ -18,21 +18,69 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// ID of the scheduled status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// ISO 8601 Datetime at which the status will be published.
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt string `json:"scheduled_at"`
+	// Parameters that will be used to publish the status.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached to the status.
+	MediaAttachments []Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model statusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	// Text content of the status.
+	Text string `json:"text"`
+	// ID of the status being replied to, if any.
+	InReplyToID string `json:"in_reply_to_id,omitempty"`
+	// IDs of media attachments to attach to the status.
+	MediaIDs []string `json:"media_ids,omitempty"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	// Visibility of the status, empty means the account default.
+	Visibility string `json:"visibility"`
+	// ISO 8601 Datetime at which the status will be published.
+	ScheduledAt string `json:"scheduled_at,omitempty"`
+	// ID of the application used to schedule the status.
+	ApplicationID string `json:"application_id"`
+	// Poll to attach to the status, if any.
+	Poll *StatusParamsPoll `json:"poll,omitempty"`
+	// ISO 639 language code of the status, empty means the account default.
+	Language string `json:"language,omitempty"`
+	// Content type to use when parsing the status, empty means the account default.
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// StatusParamsPoll represents the parameters of
+// a poll to be attached to a scheduled status.
+//
+// swagger:model statusParamsPoll
+type StatusParamsPoll struct {
+	// Possible answers of the poll.
+	Options []string `json:"options"`
+	// Duration the poll should be open, in seconds.
+	ExpiresIn int `json:"expires_in"`
+	// Allow multiple choices on this poll.
+	Multiple bool `json:"multiple"`
+	// Hide vote counts until the poll ends.
+	HideTotals bool `json:"hide_totals"`
+}
+
+// ScheduledStatusUpdateRequest models a request to reschedule a scheduled status.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which to publish the status.
+	// Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
 }
@@ -31,4 +31,8 @@ type Tag struct {
 	// Currently just a stub, if provided will always be an empty array.
 	// example: []
 	History *[]any `json:"history,omitempty"`
+	// Whether the requesting account follows this hashtag.
+	// Only set when fetching a hashtag directly, or listing followed hashtags.
+	// example: true
+	Following *bool `json:"following,omitempty"`
 }
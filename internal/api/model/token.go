@@ -33,3 +33,41 @@ type Token struct {
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
 }
+
+// TokenInfo represents an OAuth access token that has been issued
+// to the requesting user, without revealing the token itself.
+//
+// swagger:model tokenInfo
+type TokenInfo struct {
+	// Database ID of this token.
+	// example: 01JMW7QBAZYZ8T8H73PCEX12F3
+	ID string `json:"id"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Approximate time (accurate to within five minutes) when the
+	// token was last used to authorize a request (ISO 8601 Datetime).
+	// Omitted if token has never been used, or it is not known
+	// when it was last used (eg., it was last used before tracking
+	// "last_used" became a thing).
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsed string `json:"last_used,omitempty"`
+	// OAuth scopes granted by the token, space-separated.
+	// example: read write admin
+	Scope string `json:"scope"`
+	// Application used to create this token.
+	Application *Application `json:"application"`
+}
+
+// TokenInvalidateRequest models a request
+// to invalidate all tokens of one application.
+//
+// swagger:parameters tokensInvalidatePost
+type TokenInvalidateRequest struct {
+	// ID of the application whose
+	// tokens should be invalidated.
+	//
+	// in: formData
+	// required: true
+	ApplicationID string `form:"application_id" json:"application_id" xml:"application_id"`
+}
@@ -60,6 +60,9 @@ type User struct {
 	// Time when the last "please reset your password" email was sent, if at all. (ISO 8601 Datetime)
 	// example: 2021-07-30T09:20:25+00:00
 	ResetPasswordSentAt string `json:"reset_password_sent_at,omitempty"`
+	// Time at which the user enabled two-factor authentication, if at all. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	TwoFactorEnabledAt string `json:"two_factor_enabled_at,omitempty"`
 }
 
 // PasswordChangeRequest models user password change parameters.
@@ -78,6 +81,11 @@ type PasswordChangeRequest struct {
 	// in: formData
 	// required: true
 	NewPassword string `form:"new_password" json:"new_password" xml:"new_password" validation:"required"`
+	// Revoke all access tokens other than the one used to
+	// make this request, signing out all other sessions.
+	//
+	// in: formData
+	RevokeOtherTokens bool `form:"revoke_other_tokens" json:"revoke_other_tokens" xml:"revoke_other_tokens"`
 }
 
 // EmailChangeRequest models user email change parameters.
@@ -95,3 +103,37 @@ type EmailChangeRequest struct {
 	// required: true
 	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
 }
+
+// TwoFactorQRCodeURI models the otpauth:// URI
+// of a user's two-factor authentication secret.
+//
+// swagger:model twoFactorQRCodeURI
+type TwoFactorQRCodeURI struct {
+	// URI to be entered into, or
+	// scanned by, an authenticator app.
+	// example: otpauth://totp/example.org:some_user@example.org?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=ONSWG4TFOQZGKIC2MFZGQIDBNZSCAYLTONSXE5CPNZSWK
+	URI string `json:"uri"`
+}
+
+// TwoFactorEnableRequest models user two-factor enable parameters.
+//
+// swagger:parameters userTwoFactorEnable
+type TwoFactorEnableRequest struct {
+	// Current code from the user's authenticator app,
+	// to confirm that two-factor is set up correctly.
+	//
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" validation:"required"`
+}
+
+// TwoFactorDisableRequest models user two-factor disable parameters.
+//
+// swagger:parameters userTwoFactorDisable
+type TwoFactorDisableRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
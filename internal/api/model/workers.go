@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "encoding/json"
+
+// WorkerQueue represents the current
+// state of one of the worker queues.
+//
+// swagger:model workerQueue
+type WorkerQueue struct {
+	// Name of the worker pool processing this queue.
+	// example: delivery
+	Worker string `json:"worker"`
+	// Number of tasks currently queued, awaiting processing.
+	// example: 12
+	Queued int `json:"queued"`
+	// Number of tasks that failed processing, kept for inspection.
+	// example: 3
+	Failed int `json:"failed"`
+}
+
+// WorkerTask represents a
+// worker task that failed.
+//
+// swagger:model workerTask
+type WorkerTask struct {
+	// The ID of the task.
+	// example: 1234
+	ID string `json:"id"`
+	// Name of the worker pool the task was queued in.
+	// example: delivery
+	Worker string `json:"worker"`
+	// Time when the task was queued (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time when the task failed processing (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FailedAt string `json:"failed_at"`
+	// The error the task failed processing with.
+	// example: http response: 503 Service Unavailable
+	Error string `json:"error"`
+	// The serialized task data, as JSON.
+	// swagger:type object
+	Data json.RawMessage `json:"data"`
+}
@@ -30,6 +30,16 @@ var JSONAcceptHeaders = []string{
 	AppJSON,
 }
 
+// CSVAcceptHeaders is a slice of offers that just contains text/csv types.
+var CSVAcceptHeaders = []string{
+	TextCSV,
+}
+
+// ZipAcceptHeaders is a slice of offers that just contains application/zip types.
+var ZipAcceptHeaders = []string{
+	AppZip,
+}
+
 // WebfingerJSONAcceptHeaders is a slice of offers that prefers the
 // jrd+json content type, but will be chill and fall back to app/json.
 // This is to be used specifically for webfinger responses.
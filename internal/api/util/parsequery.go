@@ -59,6 +59,10 @@ const (
 	SearchResolveKey           = "resolve"
 	SearchTypeKey              = "type"
 
+	/* Announcement keys */
+
+	WithDismissedKey = "with_dismissed"
+
 	/* Tag keys */
 
 	TagNameKey = "tag_name"
@@ -69,8 +73,9 @@ const (
 
 	/* Domain permission keys */
 
-	DomainPermissionExportKey = "export"
-	DomainPermissionImportKey = "import"
+	DomainPermissionExportKey   = "export"
+	DomainPermissionImportKey   = "import"
+	DomainPermissionPermTypeKey = "permission_type"
 
 	/* Admin query keys */
 
@@ -166,6 +171,10 @@ func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.Wit
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
 
+func ParseWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, WithDismissedKey)
+}
+
 func ParseAdminRemote(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, AdminRemoteKey)
 }
@@ -51,6 +51,9 @@ var (
 	ErrorRateLimited = mustJSON(map[string]string{
 		"error": "rate limit reached",
 	})
+	ErrorInsufficientScope = mustJSON(map[string]string{
+		"error": "this action is outside the authorized scopes",
+	})
 	EmptyJSONObject = json.RawMessage(`{}`)
 	EmptyJSONArray  = json.RawMessage(`[]`)
 
@@ -60,10 +60,14 @@ func (c *Caches) Init() {
 	c.initBlockIDs()
 	c.initBoostOfIDs()
 	c.initClient()
+	c.initConversation()
+	c.initConversationLastStatusIDs()
 	c.initDomainAllow()
 	c.initDomainBlock()
 	c.initEmoji()
 	c.initEmojiCategory()
+	c.initFeaturedTag()
+	c.initFeaturedTagIDs()
 	c.initFilter()
 	c.initFilterKeyword()
 	c.initFilterStatus()
@@ -71,6 +75,8 @@ func (c *Caches) Init() {
 	c.initFollowIDs()
 	c.initFollowRequest()
 	c.initFollowRequestIDs()
+	c.initFollowedTag()
+	c.initFollowedTagIDs()
 	c.initInReplyToIDs()
 	c.initInstance()
 	c.initList()
@@ -83,10 +89,13 @@ func (c *Caches) Init() {
 	c.initPoll()
 	c.initPollVote()
 	c.initPollVoteIDs()
+	c.initPreviewCard()
 	c.initReport()
+	c.initScheduledStatus()
 	c.initStatus()
 	c.initStatusBookmark()
 	c.initStatusBookmarkIDs()
+	c.initStatusEdit()
 	c.initStatusFave()
 	c.initStatusFaveIDs()
 	c.initTag()
@@ -96,6 +105,7 @@ func (c *Caches) Init() {
 	c.initUser()
 	c.initUserMute()
 	c.initUserMuteIDs()
+	c.initWebPushSubscription()
 	c.initWebfinger()
 	c.initVisibility()
 }
@@ -134,8 +144,12 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.BlockIDs.Trim(threshold)
 	c.GTS.BoostOfIDs.Trim(threshold)
 	c.GTS.Client.Trim(threshold)
+	c.GTS.Conversation.Trim(threshold)
+	c.GTS.ConversationLastStatusIDs.Trim(threshold)
 	c.GTS.Emoji.Trim(threshold)
 	c.GTS.EmojiCategory.Trim(threshold)
+	c.GTS.FeaturedTag.Trim(threshold)
+	c.GTS.FeaturedTagIDs.Trim(threshold)
 	c.GTS.Filter.Trim(threshold)
 	c.GTS.FilterKeyword.Trim(threshold)
 	c.GTS.FilterStatus.Trim(threshold)
@@ -143,6 +157,8 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.FollowIDs.Trim(threshold)
 	c.GTS.FollowRequest.Trim(threshold)
 	c.GTS.FollowRequestIDs.Trim(threshold)
+	c.GTS.FollowedTag.Trim(threshold)
+	c.GTS.FollowedTagIDs.Trim(threshold)
 	c.GTS.InReplyToIDs.Trim(threshold)
 	c.GTS.Instance.Trim(threshold)
 	c.GTS.List.Trim(threshold)
@@ -155,10 +171,13 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.Poll.Trim(threshold)
 	c.GTS.PollVote.Trim(threshold)
 	c.GTS.PollVoteIDs.Trim(threshold)
+	c.GTS.PreviewCard.Trim(threshold)
 	c.GTS.Report.Trim(threshold)
+	c.GTS.ScheduledStatus.Trim(threshold)
 	c.GTS.Status.Trim(threshold)
 	c.GTS.StatusBookmark.Trim(threshold)
 	c.GTS.StatusBookmarkIDs.Trim(threshold)
+	c.GTS.StatusEdit.Trim(threshold)
 	c.GTS.StatusFave.Trim(threshold)
 	c.GTS.StatusFaveIDs.Trim(threshold)
 	c.GTS.Tag.Trim(threshold)
@@ -168,5 +187,67 @@ func (c *Caches) Sweep(threshold float64) {
 	c.GTS.User.Trim(threshold)
 	c.GTS.UserMute.Trim(threshold)
 	c.GTS.UserMuteIDs.Trim(threshold)
+	c.GTS.WebPushSubscription.Trim(threshold)
 	c.Visibility.Trim(threshold)
 }
+
+// Stats returns hit / miss counts and current
+// size of each of the available caches, by name.
+func (c *Caches) Stats() map[string]Stats {
+	return map[string]Stats{
+		"Account":                   c.GTS.Account.Stats(),
+		"AccountNote":               c.GTS.AccountNote.Stats(),
+		"AccountSettings":           c.GTS.AccountSettings.Stats(),
+		"AccountStats":              c.GTS.AccountStats.Stats(),
+		"Application":               c.GTS.Application.Stats(),
+		"Block":                     c.GTS.Block.Stats(),
+		"BlockIDs":                  c.GTS.BlockIDs.Stats(),
+		"BoostOfIDs":                c.GTS.BoostOfIDs.Stats(),
+		"Client":                    c.GTS.Client.Stats(),
+		"Conversation":              c.GTS.Conversation.Stats(),
+		"ConversationLastStatusIDs": c.GTS.ConversationLastStatusIDs.Stats(),
+		"Emoji":                     c.GTS.Emoji.Stats(),
+		"EmojiCategory":             c.GTS.EmojiCategory.Stats(),
+		"FeaturedTag":               c.GTS.FeaturedTag.Stats(),
+		"FeaturedTagIDs":            c.GTS.FeaturedTagIDs.Stats(),
+		"Filter":                    c.GTS.Filter.Stats(),
+		"FilterKeyword":             c.GTS.FilterKeyword.Stats(),
+		"FilterStatus":              c.GTS.FilterStatus.Stats(),
+		"Follow":                    c.GTS.Follow.Stats(),
+		"FollowIDs":                 c.GTS.FollowIDs.Stats(),
+		"FollowRequest":             c.GTS.FollowRequest.Stats(),
+		"FollowRequestIDs":          c.GTS.FollowRequestIDs.Stats(),
+		"FollowedTag":               c.GTS.FollowedTag.Stats(),
+		"FollowedTagIDs":            c.GTS.FollowedTagIDs.Stats(),
+		"InReplyToIDs":              c.GTS.InReplyToIDs.Stats(),
+		"Instance":                  c.GTS.Instance.Stats(),
+		"List":                      c.GTS.List.Stats(),
+		"ListEntry":                 c.GTS.ListEntry.Stats(),
+		"Marker":                    c.GTS.Marker.Stats(),
+		"Media":                     c.GTS.Media.Stats(),
+		"Mention":                   c.GTS.Mention.Stats(),
+		"Move":                      c.GTS.Move.Stats(),
+		"Notification":              c.GTS.Notification.Stats(),
+		"Poll":                      c.GTS.Poll.Stats(),
+		"PollVote":                  c.GTS.PollVote.Stats(),
+		"PollVoteIDs":               c.GTS.PollVoteIDs.Stats(),
+		"PreviewCard":               c.GTS.PreviewCard.Stats(),
+		"Report":                    c.GTS.Report.Stats(),
+		"ScheduledStatus":           c.GTS.ScheduledStatus.Stats(),
+		"Status":                    c.GTS.Status.Stats(),
+		"StatusBookmark":            c.GTS.StatusBookmark.Stats(),
+		"StatusBookmarkIDs":         c.GTS.StatusBookmarkIDs.Stats(),
+		"StatusEdit":                c.GTS.StatusEdit.Stats(),
+		"StatusFave":                c.GTS.StatusFave.Stats(),
+		"StatusFaveIDs":             c.GTS.StatusFaveIDs.Stats(),
+		"Tag":                       c.GTS.Tag.Stats(),
+		"ThreadMute":                c.GTS.ThreadMute.Stats(),
+		"Token":                     c.GTS.Token.Stats(),
+		"Tombstone":                 c.GTS.Tombstone.Stats(),
+		"User":                      c.GTS.User.Stats(),
+		"UserMute":                  c.GTS.UserMute.Stats(),
+		"UserMuteIDs":               c.GTS.UserMuteIDs.Stats(),
+		"WebPushSubscription":       c.GTS.WebPushSubscription.Stats(),
+		"Visibility":                c.Visibility.Stats(),
+	}
+}
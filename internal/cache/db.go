@@ -56,6 +56,12 @@ type GTSCaches struct {
 	// Client provides access to the gtsmodel Client database cache.
 	Client StructCache[*gtsmodel.Client]
 
+	// Conversation provides access to the gtsmodel Conversation database cache.
+	Conversation StructCache[*gtsmodel.Conversation]
+
+	// ConversationLastStatusIDs provides access to the conversation last status IDs database cache.
+	ConversationLastStatusIDs SliceCache[string]
+
 	// DomainAllow provides access to the domain allow database cache.
 	DomainAllow *domain.Cache
 
@@ -68,6 +74,12 @@ type GTSCaches struct {
 	// EmojiCategory provides access to the gtsmodel EmojiCategory database cache.
 	EmojiCategory StructCache[*gtsmodel.EmojiCategory]
 
+	// FeaturedTag provides access to the gtsmodel FeaturedTag database cache.
+	FeaturedTag StructCache[*gtsmodel.FeaturedTag]
+
+	// FeaturedTagIDs provides access to the featured tag IDs database cache.
+	FeaturedTagIDs SliceCache[string]
+
 	// Filter provides access to the gtsmodel Filter database cache.
 	Filter StructCache[*gtsmodel.Filter]
 
@@ -97,6 +109,15 @@ type GTSCaches struct {
 	// - '<'  for follower IDs
 	FollowRequestIDs SliceCache[string]
 
+	// FollowedTag provides access to the gtsmodel FollowedTag database cache.
+	FollowedTag StructCache[*gtsmodel.FollowedTag]
+
+	// FollowedTagIDs provides access to the followed tag / tag follower IDs database cache.
+	// THIS CACHE IS KEYED AS THE FOLLOWING {prefix}{ID} WHERE PREFIX IS:
+	// - '>'  for tag IDs followed by account ID
+	// - '<'  for account IDs following tag ID
+	FollowedTagIDs SliceCache[string]
+
 	// Instance provides access to the gtsmodel Instance database cache.
 	Instance StructCache[*gtsmodel.Instance]
 
@@ -133,9 +154,15 @@ type GTSCaches struct {
 	// PollVoteIDs provides access to the poll vote IDs list database cache.
 	PollVoteIDs SliceCache[string]
 
+	// PreviewCard provides access to the gtsmodel PreviewCard database cache.
+	PreviewCard StructCache[*gtsmodel.PreviewCard]
+
 	// Report provides access to the gtsmodel Report database cache.
 	Report StructCache[*gtsmodel.Report]
 
+	// ScheduledStatus provides access to the gtsmodel ScheduledStatus database cache.
+	ScheduledStatus StructCache[*gtsmodel.ScheduledStatus]
+
 	// Status provides access to the gtsmodel Status database cache.
 	Status StructCache[*gtsmodel.Status]
 
@@ -145,6 +172,9 @@ type GTSCaches struct {
 	// StatusBookmarkIDs ...
 	StatusBookmarkIDs SliceCache[string]
 
+	// StatusEdit provides access to the gtsmodel StatusEdit database cache.
+	StatusEdit StructCache[*gtsmodel.StatusEdit]
+
 	// StatusFave provides access to the gtsmodel StatusFave database cache.
 	StatusFave StructCache[*gtsmodel.StatusFave]
 
@@ -172,6 +202,9 @@ type GTSCaches struct {
 	// UserMuteIDs provides access to the user mute IDs database cache.
 	UserMuteIDs SliceCache[string]
 
+	// WebPushSubscription provides access to the gtsmodel WebPushSubscription database cache.
+	WebPushSubscription StructCache[*gtsmodel.WebPushSubscription]
+
 	// Webfinger provides access to the webfinger URL cache.
 	// TODO: move out of GTS caches since unrelated to DB.
 	Webfinger *ttl.Cache[string, string] // TTL=24hr, sweep=5min
@@ -423,6 +456,53 @@ func (c *Caches) initClient() {
 	})
 }
 
+func (c *Caches) initConversation() {
+	cap := calculateResultCacheMax(
+		sizeofConversation(), // model in-mem size.
+		config.GetCacheConversationMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(c1 *gtsmodel.Conversation) *gtsmodel.Conversation {
+		c2 := new(gtsmodel.Conversation)
+		*c2 = *c1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/conversation.go.
+		c2.Account = nil
+		c2.OtherAccounts = nil
+		c2.LastStatus = nil
+
+		return c2
+	}
+
+	c.GTS.Conversation.Init(structr.CacheConfig[*gtsmodel.Conversation]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "ThreadID,AccountID,OtherAccountsKey"},
+			{Fields: "AccountID,LastStatusID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateConversation,
+	})
+}
+
+func (c *Caches) initConversationLastStatusIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheConversationLastStatusIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.ConversationLastStatusIDs.Init(0, cap)
+}
+
 func (c *Caches) initDomainAllow() {
 	c.GTS.DomainAllow = new(domain.Cache)
 }
@@ -493,6 +573,52 @@ func (c *Caches) initEmojiCategory() {
 	})
 }
 
+func (c *Caches) initFeaturedTag() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFeaturedTag(), // model in-mem size.
+		config.GetCacheFeaturedTagMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(f1 *gtsmodel.FeaturedTag) *gtsmodel.FeaturedTag {
+		f2 := new(gtsmodel.FeaturedTag)
+		*f2 = *f1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/featuredtag.go.
+		f2.Account = nil
+		f2.Tag = nil
+
+		return f2
+	}
+
+	c.GTS.FeaturedTag.Init(structr.CacheConfig[*gtsmodel.FeaturedTag]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID,TagID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateFeaturedTag,
+	})
+}
+
+func (c *Caches) initFeaturedTagIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheFeaturedTagIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.FeaturedTagIDs.Init(0, cap)
+}
+
 func (c *Caches) initFilter() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -693,6 +819,52 @@ func (c *Caches) initFollowRequestIDs() {
 	c.GTS.FollowRequestIDs.Init(0, cap)
 }
 
+func (c *Caches) initFollowedTag() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofFollowedTag(), // model in-mem size.
+		config.GetCacheFollowedTagMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(f1 *gtsmodel.FollowedTag) *gtsmodel.FollowedTag {
+		f2 := new(gtsmodel.FollowedTag)
+		*f2 = *f1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/followedtag.go.
+		f2.Account = nil
+		f2.Tag = nil
+
+		return f2
+	}
+
+	c.GTS.FollowedTag.Init(structr.CacheConfig[*gtsmodel.FollowedTag]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID,TagID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateFollowedTag,
+	})
+}
+
+func (c *Caches) initFollowedTagIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheFollowedTagIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.GTS.FollowedTagIDs.Init(0, cap)
+}
+
 func (c *Caches) initInReplyToIDs() {
 	// Calculate maximum cache size.
 	cap := calculateSliceCacheMax(
@@ -1032,6 +1204,39 @@ func (c *Caches) initPollVoteIDs() {
 	c.GTS.PollVoteIDs.Init(0, cap)
 }
 
+func (c *Caches) initPreviewCard() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofPreviewCard(), // model in-mem size.
+		config.GetCachePreviewCardMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(p1 *gtsmodel.PreviewCard) *gtsmodel.PreviewCard {
+		p2 := new(gtsmodel.PreviewCard)
+		*p2 = *p1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/previewcard.go.
+		p2.Image = nil
+
+		return p2
+	}
+
+	c.GTS.PreviewCard.Init(structr.CacheConfig[*gtsmodel.PreviewCard]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URL"},
+			{Fields: "ImageID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initReport() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1067,6 +1272,40 @@ func (c *Caches) initReport() {
 	})
 }
 
+func (c *Caches) initScheduledStatus() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofScheduledStatus(), // model in-mem size.
+		config.GetCacheScheduledStatusMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.ScheduledStatus) *gtsmodel.ScheduledStatus {
+		s2 := new(gtsmodel.ScheduledStatus)
+		*s2 = *s1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/scheduledstatus.go.
+		s2.Account = nil
+		s2.MediaAttachments = nil
+		s2.Application = nil
+
+		return s2
+	}
+
+	c.GTS.ScheduledStatus.Init(structr.CacheConfig[*gtsmodel.ScheduledStatus]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initStatus() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1089,6 +1328,8 @@ func (c *Caches) initStatus() {
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
 		s2.Poll = nil
+		s2.Edits = nil
+		s2.PreviewCard = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
@@ -1105,6 +1346,7 @@ func (c *Caches) initStatus() {
 			{Fields: "URL"},
 			{Fields: "PollID"},
 			{Fields: "BoostOfID,AccountID"},
+			{Fields: "AccountID,ImportedURI"},
 			{Fields: "ThreadID", Multiple: true},
 		},
 		MaxSize:    cap,
@@ -1162,6 +1404,38 @@ func (c *Caches) initStatusBookmarkIDs() {
 	c.GTS.StatusBookmarkIDs.Init(0, cap)
 }
 
+func (c *Caches) initStatusEdit() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusEdit(), // model in-mem size.
+		config.GetCacheStatusEditMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(e1 *gtsmodel.StatusEdit) *gtsmodel.StatusEdit {
+		e2 := new(gtsmodel.StatusEdit)
+		*e2 = *e1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/statusedit.go.
+		e2.Attachments = nil
+
+		return e2
+	}
+
+	c.GTS.StatusEdit.Init(structr.CacheConfig[*gtsmodel.StatusEdit]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initStatusFave() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1398,6 +1672,33 @@ func (c *Caches) initUserMuteIDs() {
 	c.GTS.UserMuteIDs.Init(0, cap)
 }
 
+func (c *Caches) initWebPushSubscription() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofWebPushSubscription(), // model in-mem size.
+		config.GetCacheWebPushSubscriptionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.WebPushSubscription) *gtsmodel.WebPushSubscription {
+		s2 := new(gtsmodel.WebPushSubscription)
+		*s2 = *s1
+		return s2
+	}
+
+	c.GTS.WebPushSubscription.Init(structr.CacheConfig[*gtsmodel.WebPushSubscription]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "TokenID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initWebfinger() {
 	// Calculate maximum cache size.
 	cap := calculateCacheMax(
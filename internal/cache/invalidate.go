@@ -83,11 +83,21 @@ func (c *Caches) OnInvalidateClient(client *gtsmodel.Client) {
 	c.GTS.Token.Invalidate("ClientID", client.ID)
 }
 
+func (c *Caches) OnInvalidateConversation(conversation *gtsmodel.Conversation) {
+	// Invalidate owning account's conversation list.
+	c.GTS.ConversationLastStatusIDs.Invalidate(conversation.AccountID)
+}
+
 func (c *Caches) OnInvalidateEmojiCategory(category *gtsmodel.EmojiCategory) {
 	// Invalidate any emoji in this category.
 	c.GTS.Emoji.Invalidate("CategoryID", category.ID)
 }
 
+func (c *Caches) OnInvalidateFeaturedTag(featuredTag *gtsmodel.FeaturedTag) {
+	// Invalidate owning account's featured tags list.
+	c.GTS.FeaturedTagIDs.Invalidate(featuredTag.AccountID)
+}
+
 func (c *Caches) OnInvalidateFollow(follow *gtsmodel.Follow) {
 	// Invalidate follow request with this same ID.
 	c.GTS.FollowRequest.Invalidate("ID", follow.ID)
@@ -133,6 +143,19 @@ func (c *Caches) OnInvalidateFollowRequest(followReq *gtsmodel.FollowRequest) {
 	)
 }
 
+func (c *Caches) OnInvalidateFollowedTag(followedTag *gtsmodel.FollowedTag) {
+	// Invalidate following account's cached home timeline
+	// visibility, which depends on the tags it follows.
+	c.Visibility.Invalidate("RequesterID", followedTag.AccountID)
+
+	// Invalidate account's followed tags
+	// list, and tag's followers list.
+	c.GTS.FollowedTagIDs.Invalidate(
+		">"+followedTag.AccountID,
+		"<"+followedTag.TagID,
+	)
+}
+
 func (c *Caches) OnInvalidateList(list *gtsmodel.List) {
 	// Invalidate all cached entries of this list.
 	c.GTS.ListEntry.Invalidate("ListID", list.ID)
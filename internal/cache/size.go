@@ -19,6 +19,7 @@ package cache
 
 import (
 	"crypto/rsa"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -179,8 +180,12 @@ func totalOfRatios() float64 {
 		config.GetCacheBlockIDsMemRatio() +
 		config.GetCacheBoostOfIDsMemRatio() +
 		config.GetCacheClientMemRatio() +
+		config.GetCacheConversationMemRatio() +
+		config.GetCacheConversationLastStatusIDsMemRatio() +
 		config.GetCacheEmojiMemRatio() +
 		config.GetCacheEmojiCategoryMemRatio() +
+		config.GetCacheFeaturedTagMemRatio() +
+		config.GetCacheFeaturedTagIDsMemRatio() +
 		config.GetCacheFilterMemRatio() +
 		config.GetCacheFilterKeywordMemRatio() +
 		config.GetCacheFilterStatusMemRatio() +
@@ -188,6 +193,8 @@ func totalOfRatios() float64 {
 		config.GetCacheFollowIDsMemRatio() +
 		config.GetCacheFollowRequestMemRatio() +
 		config.GetCacheFollowRequestIDsMemRatio() +
+		config.GetCacheFollowedTagMemRatio() +
+		config.GetCacheFollowedTagIDsMemRatio() +
 		config.GetCacheInstanceMemRatio() +
 		config.GetCacheInReplyToIDsMemRatio() +
 		config.GetCacheListMemRatio() +
@@ -199,10 +206,13 @@ func totalOfRatios() float64 {
 		config.GetCacheNotificationMemRatio() +
 		config.GetCachePollMemRatio() +
 		config.GetCachePollVoteMemRatio() +
+		config.GetCachePreviewCardMemRatio() +
 		config.GetCacheReportMemRatio() +
+		config.GetCacheScheduledStatusMemRatio() +
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusBookmarkMemRatio() +
 		config.GetCacheStatusBookmarkIDsMemRatio() +
+		config.GetCacheStatusEditMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
@@ -210,6 +220,7 @@ func totalOfRatios() float64 {
 		config.GetCacheTokenMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
 		config.GetCacheUserMemRatio() +
+		config.GetCacheWebPushSubscriptionMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
 		config.GetCacheVisibilityMemRatio()
 }
@@ -319,6 +330,20 @@ func sizeofClient() uintptr {
 	}))
 }
 
+func sizeofConversation() uintptr {
+	return uintptr(size.Of(&gtsmodel.Conversation{
+		ID:               exampleID,
+		CreatedAt:        exampleTime,
+		UpdatedAt:        exampleTime,
+		AccountID:        exampleID,
+		OtherAccountIDs:  []string{exampleID, exampleID, exampleID},
+		OtherAccountsKey: strings.Join([]string{exampleID, exampleID, exampleID}, ","),
+		ThreadID:         exampleID,
+		LastStatusID:     exampleID,
+		Read:             util.Ptr(true),
+	}))
+}
+
 func sizeofEmoji() uintptr {
 	return uintptr(size.Of(&gtsmodel.Emoji{
 		ID:                     exampleID,
@@ -351,6 +376,16 @@ func sizeofEmojiCategory() uintptr {
 	}))
 }
 
+func sizeofFeaturedTag() uintptr {
+	return uintptr(size.Of(&gtsmodel.FeaturedTag{
+		ID:        exampleID,
+		CreatedAt: exampleTime,
+		UpdatedAt: exampleTime,
+		AccountID: exampleID,
+		TagID:     exampleID,
+	}))
+}
+
 func sizeofFilter() uintptr {
 	return uintptr(size.Of(&gtsmodel.Filter{
 		ID:        exampleID,
@@ -409,6 +444,15 @@ func sizeofFollowRequest() uintptr {
 	}))
 }
 
+func sizeofFollowedTag() uintptr {
+	return uintptr(size.Of(&gtsmodel.FollowedTag{
+		ID:        exampleID,
+		CreatedAt: exampleTime,
+		AccountID: exampleID,
+		TagID:     exampleID,
+	}))
+}
+
 func sizeofInstance() uintptr {
 	return uintptr(size.Of(&gtsmodel.Instance{
 		ID:                     exampleID,
@@ -546,6 +590,23 @@ func sizeofPollVote() uintptr {
 	}))
 }
 
+func sizeofPreviewCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.PreviewCard{
+		ID:           exampleID,
+		CreatedAt:    exampleTime,
+		UpdatedAt:    exampleTime,
+		URL:          exampleURI,
+		Title:        exampleTextSmall,
+		Description:  exampleText,
+		Type:         gtsmodel.PreviewCardTypeLink,
+		ProviderName: exampleUsername,
+		ProviderURL:  exampleURI,
+		Width:        640,
+		Height:       480,
+		ImageID:      exampleID,
+	}))
+}
+
 func sizeofReport() uintptr {
 	return uintptr(size.Of(&gtsmodel.Report{
 		ID:                     exampleID,
@@ -563,6 +624,25 @@ func sizeofReport() uintptr {
 	}))
 }
 
+func sizeofScheduledStatus() uintptr {
+	return uintptr(size.Of(&gtsmodel.ScheduledStatus{
+		ID:            exampleID,
+		CreatedAt:     exampleTime,
+		UpdatedAt:     exampleTime,
+		AccountID:     exampleID,
+		ScheduledAt:   exampleTime,
+		Text:          exampleText,
+		SpoilerText:   exampleTextSmall,
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		InReplyToID:   exampleID,
+		Language:      "en",
+		ContentType:   "text/plain",
+		MediaIDs:      []string{exampleID, exampleID, exampleID},
+		ApplicationID: exampleID,
+	}))
+}
+
 func sizeofStatus() uintptr {
 	return uintptr(size.Of(&gtsmodel.Status{
 		ID:                       exampleID,
@@ -574,9 +654,11 @@ func sizeofStatus() uintptr {
 		TagIDs:                   []string{exampleID, exampleID, exampleID},
 		MentionIDs:               []string{},
 		EmojiIDs:                 []string{exampleID, exampleID, exampleID},
+		EditIDs:                  []string{exampleID, exampleID, exampleID},
 		CreatedAt:                exampleTime,
 		UpdatedAt:                exampleTime,
 		FetchedAt:                exampleTime,
+		EditedAt:                 exampleTime,
 		Local:                    func() *bool { ok := false; return &ok }(),
 		AccountURI:               exampleURI,
 		AccountID:                exampleID,
@@ -594,6 +676,8 @@ func sizeofStatus() uintptr {
 		Boostable:                func() *bool { ok := true; return &ok }(),
 		Replyable:                func() *bool { ok := true; return &ok }(),
 		Likeable:                 func() *bool { ok := true; return &ok }(),
+		InteractionPolicy:        gtsmodel.DefaultInteractionPolicyFor(gtsmodel.VisibilityPublic),
+		PendingApproval:          func() *bool { ok := false; return &ok }(),
 		ActivityStreamsType:      ap.ObjectNote,
 	}))
 }
@@ -612,6 +696,22 @@ func sizeofStatusBookmark() uintptr {
 	}))
 }
 
+func sizeofStatusEdit() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusEdit{
+		ID:             exampleID,
+		Content:        exampleText,
+		ContentWarning: exampleTextSmall,
+		Text:           exampleText,
+		Language:       "en",
+		Sensitive:      func() *bool { ok := false; return &ok }(),
+		AttachmentIDs:  []string{exampleID, exampleID, exampleID},
+		PollOptions:    []string{exampleTextSmall, exampleTextSmall, exampleTextSmall, exampleTextSmall},
+		PollVotes:      []int{69, 420, 1337, 1969},
+		StatusID:       exampleID,
+		CreatedAt:      exampleTime,
+	}))
+}
+
 func sizeofStatusFave() uintptr {
 	return uintptr(size.Of(&gtsmodel.StatusFave{
 		ID:              exampleID,
@@ -664,6 +764,7 @@ func sizeofToken() uintptr {
 		Refresh:             "", // TODO: clients don't really support this very well yet
 		RefreshCreateAt:     exampleTime,
 		RefreshExpiresAt:    exampleTime,
+		LastUsed:            exampleTime,
 	}))
 }
 
@@ -710,6 +811,9 @@ func sizeofUser() uintptr {
 		ResetPasswordToken:     exampleTextSmall,
 		ResetPasswordSentAt:    exampleTime,
 		ExternalID:             exampleID,
+		TwoFactorSecret:        exampleID,
+		TwoFactorEnabledAt:     exampleTime,
+		TwoFactorLastStep:      1,
 	}))
 }
 
@@ -724,3 +828,25 @@ func sizeofUserMute() uintptr {
 		Notifications:   util.Ptr(false),
 	}))
 }
+
+func sizeofWebPushSubscription() uintptr {
+	return uintptr(size.Of(&gtsmodel.WebPushSubscription{
+		ID:                 exampleID,
+		CreatedAt:          exampleTime,
+		UpdatedAt:          exampleTime,
+		AccountID:          exampleID,
+		TokenID:            exampleID,
+		Endpoint:           exampleURI,
+		Auth:               "DlSmzvwRm1PeB2WSCA0Gag",
+		P256dh:             "BIf9jZr0QsNo6GSwhQBn0ZmBqg1ZkvnUlB5LZgI2AhzhdKKrmPKDoYkj8GlhLm5Z8ssuqg5rU0ZvVsWAo5bYyPg",
+		AlertFollow:        util.Ptr(true),
+		AlertFollowRequest: util.Ptr(true),
+		AlertFavourite:     util.Ptr(true),
+		AlertMention:       util.Ptr(true),
+		AlertReblog:        util.Ptr(true),
+		AlertPoll:          util.Ptr(true),
+		AlertStatus:        util.Ptr(true),
+		AlertSignup:        util.Ptr(true),
+		Policy:             gtsmodel.WebPushNotificationPolicyAll,
+	}))
+}
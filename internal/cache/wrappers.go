@@ -19,15 +19,50 @@ package cache
 
 import (
 	"slices"
+	"sync/atomic"
 
 	"codeberg.org/gruf/go-cache/v3/simple"
 	"codeberg.org/gruf/go-structr"
 )
 
+// Stats contains usage figures for a cache.
+type Stats struct {
+	Hits   uint64 // no. lookups found in cache
+	Misses uint64 // no. lookups not found in cache
+	Len    int    // current no. cached entries
+	Cap    int    // maximum no. cached entries
+}
+
+// counts tracks cache hits and misses.
+type counts struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// stats returns current counts with given length + capacity as Stats{}.
+func (c *counts) stats(len, cap int) Stats {
+	return Stats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Len:    len,
+		Cap:    cap,
+	}
+}
+
+// add increments hits if hit, else misses.
+func (c *counts) add(hit bool) {
+	if hit {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
 // SliceCache wraps a simple.Cache to provide simple loader-callback
 // functions for fetching + caching slices of objects (e.g. IDs).
 type SliceCache[T any] struct {
 	cache simple.Cache[string, []T]
+	count counts
 }
 
 // Init initializes the cache with given length + capacity.
@@ -43,6 +78,7 @@ func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error)
 
 	if !ok {
 		var err error
+		c.count.misses.Add(1)
 
 		// Not cached, load!
 		data, err = load()
@@ -52,6 +88,8 @@ func (c *SliceCache[T]) Load(key string, load func() ([]T, error)) ([]T, error)
 
 		// Store the data.
 		c.cache.Set(key, data)
+	} else {
+		c.count.hits.Add(1)
 	}
 
 	// Return data clone for safety.
@@ -83,6 +121,11 @@ func (c *SliceCache[T]) Cap() int {
 	return c.cache.Cap()
 }
 
+// Stats returns hit / miss counts and current size of the cache.
+func (c *SliceCache[T]) Stats() Stats {
+	return c.count.stats(c.cache.Len(), c.cache.Cap())
+}
+
 // StructCache wraps a structr.Cache{} to simple index caching
 // by name (also to ease update to library version that introduced
 // this). (in the future it may be worth embedding these indexes by
@@ -91,6 +134,7 @@ func (c *SliceCache[T]) Cap() int {
 type StructCache[StructType any] struct {
 	cache structr.Cache[StructType]
 	index map[string]*structr.Index
+	count counts
 }
 
 // Init initializes the cache with given structr.CacheConfig{}.
@@ -107,14 +151,19 @@ func (c *StructCache[T]) Init(config structr.CacheConfig[T]) {
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) GetOne(index string, key ...any) (T, bool) {
 	i := c.index[index]
-	return c.cache.GetOne(i, i.Key(key...))
+	value, ok := c.cache.GetOne(i, i.Key(key...))
+	c.count.add(ok)
+	return value, ok
 }
 
 // Get calls structr.Cache{}.Get(), using a cached structr.Index{} by 'index' name.
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) Get(index string, keys ...[]any) []T {
 	i := c.index[index]
-	return c.cache.Get(i, i.Keys(keys...)...)
+	values := c.cache.Get(i, i.Keys(keys...)...)
+	c.count.hits.Add(uint64(len(values)))
+	c.count.misses.Add(uint64(len(keys) - len(values)))
+	return values
 }
 
 // Put: see structr.Cache{}.Put().
@@ -126,7 +175,13 @@ func (c *StructCache[T]) Put(values ...T) {
 // Note: this also handles conversion of the untyped (any) keys to structr.Key{} via structr.Index{}.
 func (c *StructCache[T]) LoadOne(index string, load func() (T, error), key ...any) (T, error) {
 	i := c.index[index]
-	return c.cache.LoadOne(i, i.Key(key...), load)
+	loaded := false
+	value, err := c.cache.LoadOne(i, i.Key(key...), func() (T, error) {
+		loaded = true
+		return load()
+	})
+	c.count.add(!loaded)
+	return value, err
 }
 
 // LoadIDs calls structr.Cache{}.Load(), using a cached structr.Index{} by 'index' name. Note: this also handles
@@ -148,14 +203,22 @@ func (c *StructCache[T]) LoadIDs(index string, ids []string, load func([]string)
 		keys[x] = i.Key(id)
 	}
 
+	// No. keys passed to loader.
+	var misses int
+
 	// Pass loader callback with wrapper onto main cache load function.
-	return c.cache.Load(i, keys, func(uncached []structr.Key) ([]T, error) {
+	values, err := c.cache.Load(i, keys, func(uncached []structr.Key) ([]T, error) {
+		misses = len(uncached)
 		uncachedIDs := make([]string, len(uncached))
 		for i := range uncached {
 			uncachedIDs[i] = uncached[i].Values()[0].(string)
 		}
 		return load(uncachedIDs)
 	})
+
+	c.count.hits.Add(uint64(len(keys) - misses))
+	c.count.misses.Add(uint64(misses))
+	return values, err
 }
 
 // Store: see structr.Cache{}.Store().
@@ -212,3 +275,8 @@ func (c *StructCache[T]) Len() int {
 func (c *StructCache[T]) Cap() int {
 	return c.cache.Cap()
 }
+
+// Stats returns hit / miss counts and current size of the cache.
+func (c *StructCache[T]) Stats() Stats {
+	return c.count.stats(c.cache.Len(), c.cache.Cap())
+}
@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache_test
+
+import (
+	"testing"
+
+	"codeberg.org/gruf/go-structr"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+)
+
+type testItem struct {
+	ID string
+}
+
+func TestStructCacheStats(t *testing.T) {
+	var c cache.StructCache[*testItem]
+	c.Init(structr.CacheConfig[*testItem]{
+		Indices: []structr.IndexConfig{{Fields: "ID"}},
+		MaxSize: 100,
+		Copy: func(i *testItem) *testItem {
+			i2 := *i
+			return &i2
+		},
+	})
+
+	load := func(id string) func() (*testItem, error) {
+		return func() (*testItem, error) {
+			return &testItem{ID: id}, nil
+		}
+	}
+
+	// First load misses, second hits.
+	_, _ = c.LoadOne("ID", load("a"), "a")
+	_, _ = c.LoadOne("ID", load("a"), "a")
+	expectStats(t, c.Stats(), 1, 1, 1)
+
+	// Get of cached
+	// item hits, other
+	// item misses.
+	_, _ = c.GetOne("ID", "a")
+	_, _ = c.GetOne("ID", "b")
+	expectStats(t, c.Stats(), 2, 2, 1)
+
+	// Load of many counts each
+	// ID, only loading uncached.
+	_, _ = c.LoadIDs("ID", []string{"a", "b", "c"}, func(ids []string) ([]*testItem, error) {
+		items := make([]*testItem, 0, len(ids))
+		for _, id := range ids {
+			items = append(items, &testItem{ID: id})
+		}
+		return items, nil
+	})
+	expectStats(t, c.Stats(), 3, 4, 3)
+
+	// Get of many counts each key.
+	_ = c.Get("ID", []any{"a"}, []any{"c"}, []any{"d"})
+	expectStats(t, c.Stats(), 5, 5, 3)
+}
+
+func TestSliceCacheStats(t *testing.T) {
+	var c cache.SliceCache[string]
+	c.Init(0, 100)
+
+	load := func() ([]string, error) {
+		return []string{"a", "b"}, nil
+	}
+
+	// First load misses, then hits.
+	_, _ = c.Load("key", load)
+	_, _ = c.Load("key", load)
+	_, _ = c.Load("key", load)
+	expectStats(t, c.Stats(), 2, 1, 1)
+
+	// Invalidated key misses again.
+	c.Invalidate("key")
+	_, _ = c.Load("key", load)
+	expectStats(t, c.Stats(), 2, 2, 1)
+}
+
+func expectStats(t *testing.T, stats cache.Stats, hits, misses uint64, len int) {
+	t.Helper()
+	if stats.Hits != hits || stats.Misses != misses || stats.Len != len {
+		t.Errorf("expected %d hits, %d misses, %d len; got %d hits, %d misses, %d len",
+			hits, misses, len, stats.Hits, stats.Misses, stats.Len)
+	}
+	if stats.Cap != 100 {
+		t.Errorf("expected cap 100, got %d", stats.Cap)
+	}
+}
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Accounts encompasses a set of
+// account cleanup / admin utils.
+type Accounts struct{ *Cleaner }
+
+// All will execute all cleaner.Accounts utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (a *Accounts) All(ctx context.Context, maxRemoteDays int) {
+	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
+	a.LogPruneRemote(ctx, t)
+}
+
+// LogPruneRemote performs Accounts.PruneRemote(...), logging the start and outcome.
+func (a *Accounts) LogPruneRemote(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := a.PruneRemote(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// PruneRemote will delete all remote accounts older than given input time, which have no
+// remaining statuses or relationships with local accounts, along with their avatar, header
+// and featured tags.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+//
+// Note that this should be run after Statuses.PruneRemote(), so pruned statuses don't count.
+func (a *Accounts) PruneRemote(ctx context.Context, olderThan time.Time) (int, error) {
+	var total int
+
+	// Start paging from the highest
+	// ID possible at olderThan time.
+	maxID, err := id.NewULIDFromTime(olderThan)
+	if err != nil {
+		return total, gtserror.Newf("error generating max id: %w", err)
+	}
+
+	for {
+		// Fetch the next batch of prunable accounts with ID below last-set max.
+		accounts, err := a.state.DB.GetPrunableAccounts(
+			gtscontext.SetBarebones(ctx),
+			olderThan,
+			maxID,
+			selectLimit,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting remote accounts: %w", err)
+		}
+
+		// If no accounts are returned, we reached the end.
+		if len(accounts) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = accounts[len(accounts)-1].ID
+
+		for _, account := range accounts {
+			// Delete each prunable account.
+			if err := a.delete(ctx, account); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+func (a *Accounts) delete(ctx context.Context, account *gtsmodel.Account) error {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return nil
+	}
+
+	var errs gtserror.MultiError
+
+	// Delete the avatar and header of
+	// this account, and their stored files.
+	for _, mediaID := range []string{
+		account.AvatarMediaAttachmentID,
+		account.HeaderMediaAttachmentID,
+	} {
+		if mediaID == "" {
+			continue
+		}
+
+		media, err := a.state.DB.GetAttachmentByID(ctx, mediaID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error fetching media: %w", err)
+			}
+			continue
+		}
+
+		if err := a.media.delete(ctx, media); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	// Delete any tags featured by this account.
+	if err := a.state.DB.DeleteFeaturedTagsByAccountID(ctx, account.ID); err != nil {
+		errs.Appendf("error deleting featured tags: %w", err)
+	}
+
+	// Delete the account's stats, and the account itself.
+	if err := a.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
+		errs.Appendf("error deleting account stats: %w", err)
+	}
+
+	log.Debugf(ctx, "deleting account: %s", account.URI)
+	if err := a.state.DB.DeleteAccount(ctx, account.ID); err != nil {
+		errs.Appendf("error deleting account: %w", err)
+	}
+
+	if err := errs.Combine(); err != nil {
+		return gtserror.Newf("error(s) deleting account %s: %w", account.ID, err)
+	}
+
+	return nil
+}
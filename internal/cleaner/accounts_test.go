@@ -0,0 +1,178 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *CleanerTestSuite) TestAccountsPruneRemote() {
+	suite.testAccountsPruneRemote(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestAccountsPruneRemoteDryRun() {
+	suite.testAccountsPruneRemote(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testAccountsPruneRemote(ctx context.Context) {
+	var (
+		testAccounts = testrig.NewTestAccounts()
+		old          = time.Now().Add(-30 * 24 * time.Hour)
+	)
+
+	// Remote account with no statuses or
+	// relationships, which should be pruned.
+	prunable := suite.putRemoteAccount(testAccounts["remote_account_2"], "prunable", old)
+
+	// Remote account with a status
+	// that hasn't been pruned.
+	withStatus := suite.putRemoteAccount(testAccounts["remote_account_2"], "with_status", old)
+	suite.putRemoteStatus(withStatus, time.Now())
+
+	// Remote account followed by
+	// a local account.
+	followed := suite.putRemoteAccount(testAccounts["remote_account_2"], "followed", old)
+	if err := suite.state.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/follow/" + followed.ID,
+		AccountID:       testAccounts["local_account_1"].ID,
+		TargetAccountID: followed.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Prunable account featuring a tag,
+	// which should be pruned along with it.
+	featuring := suite.putRemoteAccount(testAccounts["remote_account_2"], "featuring", old)
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: featuring.ID,
+		TagID:     testrig.NewTestTags()["welcome"].ID,
+	}
+	if err := suite.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remote account in a conversation
+	// with a local account.
+	conversing := suite.putRemoteAccount(testAccounts["remote_account_2"], "conversing", old)
+	if err := suite.state.DB.UpsertConversation(ctx, &gtsmodel.Conversation{
+		ID:               id.NewULID(),
+		AccountID:        testAccounts["local_account_1"].ID,
+		OtherAccountIDs:  []string{conversing.ID},
+		OtherAccountsKey: gtsmodel.ConversationOtherAccountsKey([]string{conversing.ID}),
+		ThreadID:         id.NewULID(),
+		LastStatusID:     id.NewULID(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remote account acting
+	// as a subscribed relay.
+	relaying := suite.putRemoteAccount(testAccounts["remote_account_2"], "relaying", old)
+	if err := suite.state.DB.PutRelay(ctx, &gtsmodel.Relay{
+		ID:                 id.NewULID(),
+		InboxURI:           relaying.InboxURI,
+		ActorURI:           relaying.URI,
+		FollowURI:          "http://localhost:8080/follow/" + relaying.ID,
+		State:              gtsmodel.RelayStateAccepted,
+		CreatedByAccountID: testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Prune remote accounts from before a week ago.
+	t := time.Now().Add(-7 * 24 * time.Hour)
+	total, err := suite.cleaner.Accounts().PruneRemote(ctx, t)
+	suite.NoError(err)
+	suite.GreaterOrEqual(total, 1)
+
+	// Only the prunable account should be
+	// gone, and only if not a dry run.
+	suite.Equal(gtscontext.DryRun(ctx), suite.haveAccount(prunable.ID))
+	suite.True(suite.haveAccount(withStatus.ID))
+	suite.True(suite.haveAccount(followed.ID))
+	suite.True(suite.haveAccount(conversing.ID))
+	suite.True(suite.haveAccount(relaying.ID))
+
+	// Featured tags of a pruned
+	// account are pruned with it.
+	suite.Equal(gtscontext.DryRun(ctx), suite.haveAccount(featuring.ID))
+	_, err = suite.state.DB.GetFeaturedTagByID(ctx, featuredTag.ID)
+	if gtscontext.DryRun(ctx) {
+		suite.NoError(err)
+	} else {
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+
+	// Local and instance accounts are always kept.
+	suite.True(suite.haveAccount(testAccounts["local_account_1"].ID))
+	suite.True(suite.haveAccount(testAccounts["instance_account"].ID))
+}
+
+// putRemoteAccount stores a new copy of remote account with given username, created at given time.
+func (suite *CleanerTestSuite) putRemoteAccount(template *gtsmodel.Account, username string, createdAt time.Time) *gtsmodel.Account {
+	accountID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	uri := "http://" + template.Domain + "/users/" + username
+
+	account := new(gtsmodel.Account)
+	*account = *template
+	account.ID = accountID
+	account.Username = username
+	account.URI = uri
+	account.URL = uri
+	account.InboxURI = uri + "/inbox"
+	account.OutboxURI = uri + "/outbox"
+	account.FollowersURI = uri + "/followers"
+	account.FollowingURI = uri + "/following"
+	account.FeaturedCollectionURI = uri + "/collections/featured"
+	account.PublicKeyURI = uri + "#main-key"
+	account.AvatarMediaAttachmentID = ""
+	account.HeaderMediaAttachmentID = ""
+	account.EmojiIDs = nil
+	account.Emojis = nil
+	account.CreatedAt = createdAt
+	account.UpdatedAt = createdAt
+
+	if err := suite.state.DB.PutAccount(context.Background(), account); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return account
+}
+
+// haveAccount returns whether account with ID is still stored in the database.
+func (suite *CleanerTestSuite) haveAccount(accountID string) bool {
+	_, err := suite.state.DB.GetAccountByID(gtscontext.SetBarebones(context.Background()), accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	return err == nil
+}
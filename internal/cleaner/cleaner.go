@@ -34,9 +34,12 @@ const (
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state    *state.State
+	emoji    Emoji
+	media    Media
+	statuses Statuses
+	accounts Accounts
+	tasks    WorkerTasks
 }
 
 func New(state *state.State) *Cleaner {
@@ -44,6 +47,9 @@ func New(state *state.State) *Cleaner {
 	c.state = state
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	c.statuses.Cleaner = c
+	c.accounts.Cleaner = c
+	c.tasks.Cleaner = c
 	return c
 }
 
@@ -57,6 +63,21 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// Statuses returns the status set of cleaner utilities.
+func (c *Cleaner) Statuses() *Statuses {
+	return &c.statuses
+}
+
+// Accounts returns the account set of cleaner utilities.
+func (c *Cleaner) Accounts() *Accounts {
+	return &c.accounts
+}
+
+// WorkerTasks returns the worker task set of cleaner utilities.
+func (c *Cleaner) WorkerTasks() *WorkerTasks {
+	return &c.tasks
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, file := range files {
@@ -148,6 +169,23 @@ func (c *Cleaner) ScheduleJobs() error {
 	}
 
 	fn := func(ctx context.Context, start time.Time) {
+		if days := config.GetStatusesRemoteCacheDays(); days > 0 {
+			// Prune statuses before accounts, so
+			// that their authors may be pruned too,
+			// and before media, so that their media
+			// are already gone before media clean.
+			log.Info(ctx, "starting status and account prune")
+			c.Statuses().All(ctx, days)
+			c.Accounts().All(ctx, days)
+			log.Infof(ctx, "finished status and account prune after %s", time.Since(start))
+		}
+
+		if days := config.GetAdvancedFailedWorkerTaskDays(); days > 0 {
+			log.Info(ctx, "starting failed worker task prune")
+			c.WorkerTasks().All(ctx, days)
+			log.Infof(ctx, "finished failed worker task prune after %s", time.Since(start))
+		}
+
 		log.Info(ctx, "starting media clean")
 		c.Media().All(ctx, config.GetMediaRemoteCacheDays())
 		c.Emoji().All(ctx, config.GetMediaRemoteCacheDays())
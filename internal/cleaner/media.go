@@ -326,6 +326,21 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			l.Debug("missing db entry for emoji")
 			return true, nil
 		}
+
+	case media.TypeArchive:
+		// Look for account archive in database stored by ID.
+		archive, err := m.state.DB.GetAccountArchiveByID(
+			gtscontext.SetBarebones(ctx),
+			mediaID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching account archive by id %s: %w", mediaID, err)
+		}
+
+		if archive == nil {
+			l.Debug("missing db entry for account archive")
+			return true, nil
+		}
 	}
 
 	return false, nil
@@ -374,6 +389,24 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether media awaits a scheduled status.
+	inUse, err := m.isScheduledStatusMedia(ctx, media)
+	if err != nil {
+		return false, err
+	} else if inUse {
+		l.Debug("skipping as attached to scheduled status")
+		return false, nil
+	}
+
+	// Check whether media is a preview card image.
+	inUse, err = m.isPreviewCardImage(ctx, media)
+	if err != nil {
+		return false, err
+	} else if inUse {
+		l.Debug("skipping as preview card media in use")
+		return false, nil
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -542,6 +575,49 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) isScheduledStatusMedia(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if media.ScheduledStatusID == "" {
+		// not scheduled.
+		return false, nil
+	}
+
+	// Look for the scheduled status this media awaits.
+	_, err := m.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.ScheduledStatusID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return false, nil
+		}
+		return false, gtserror.Newf("error fetching scheduled status %s: %w", media.ScheduledStatusID, err)
+	}
+
+	return true, nil
+}
+
+func (m *Media) isPreviewCardImage(ctx context.Context, media *gtsmodel.MediaAttachment) (bool, error) {
+	if media.StatusID != "" ||
+		*media.Avatar || *media.Header {
+		// can't be a card image.
+		return false, nil
+	}
+
+	// Look for a preview card using this media.
+	_, err := m.state.DB.GetPreviewCardByImageID(
+		gtscontext.SetBarebones(ctx),
+		media.ID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return false, nil
+		}
+		return false, gtserror.Newf("error fetching preview card by image id %s: %w", media.ID, err)
+	}
+
+	return true, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
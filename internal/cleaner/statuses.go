@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Statuses encompasses a set of
+// status cleanup / admin utils.
+type Statuses struct{ *Cleaner }
+
+// All will execute all cleaner.Statuses utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (s *Statuses) All(ctx context.Context, maxRemoteDays int) {
+	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
+	s.LogPruneRemote(ctx, t)
+}
+
+// LogPruneRemote performs Statuses.PruneRemote(...), logging the start and outcome.
+func (s *Statuses) LogPruneRemote(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := s.PruneRemote(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// PruneRemote will delete all remote statuses older than given input time, which no local
+// account has interacted with, along with their attachments, mentions, polls and boosts.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (s *Statuses) PruneRemote(ctx context.Context, olderThan time.Time) (int, error) {
+	var total int
+
+	// Start paging from the highest
+	// ID possible at olderThan time.
+	maxID, err := id.NewULIDFromTime(olderThan)
+	if err != nil {
+		return total, gtserror.Newf("error generating max id: %w", err)
+	}
+
+	for {
+		// Fetch the next batch of prunable statuses with ID below last-set max.
+		statuses, err := s.state.DB.GetPrunableStatuses(
+			gtscontext.SetBarebones(ctx),
+			olderThan,
+			maxID,
+			selectLimit,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting remote statuses: %w", err)
+		}
+
+		// If no statuses are returned, we reached the end.
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			// Delete each prunable status.
+			if err := s.delete(ctx, status); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+func (s *Statuses) delete(ctx context.Context, status *gtsmodel.Status) error {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return nil
+	}
+
+	var errs gtserror.MultiError
+
+	// Delete all attachments of this
+	// status, and their stored files.
+	attachments, err := s.state.DB.GetAttachmentsByIDs(ctx, status.AttachmentIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		errs.Appendf("error fetching attachments: %w", err)
+	}
+
+	for _, media := range attachments {
+		if err := s.media.delete(ctx, media); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	// Delete all historical edits of this status.
+	if err := s.state.DB.DeleteStatusEdits(ctx, status.EditIDs); err != nil {
+		errs.Appendf("error deleting status edits: %w", err)
+	}
+
+	// Delete all mention entries generated by this status.
+	for _, id := range status.MentionIDs {
+		if err := s.state.DB.DeleteMentionByID(ctx, id); err != nil {
+			errs.Appendf("error deleting status mention: %w", err)
+		}
+	}
+
+	// Delete any (remote) notifications and faves of this status.
+	if err := s.state.DB.DeleteNotificationsForStatus(ctx, status.ID); err != nil {
+		errs.Appendf("error deleting status notifications: %w", err)
+	}
+
+	if err := s.state.DB.DeleteStatusFavesForStatus(ctx, status.ID); err != nil {
+		errs.Appendf("error deleting status faves: %w", err)
+	}
+
+	if pollID := status.PollID; pollID != "" {
+		// Delete this poll and any votes in it.
+		if err := s.state.DB.DeletePollByID(ctx, pollID); err != nil {
+			errs.Appendf("error deleting status poll: %w", err)
+		}
+
+		if err := s.state.DB.DeletePollVotes(ctx, pollID); err != nil {
+			errs.Appendf("error deleting status poll votes: %w", err)
+		}
+
+		// Cancel any scheduled expiry task for poll.
+		_ = s.state.Workers.Scheduler.Cancel(pollID)
+	}
+
+	// Delete all (remote) boosts of this status.
+	boosts, err := s.state.DB.GetStatusBoosts(
+		gtscontext.SetBarebones(ctx),
+		status.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		errs.Appendf("error fetching status boosts: %w", err)
+	}
+
+	for _, boost := range boosts {
+		s.wipeFromTimelines(ctx, boost.ID)
+		if err := s.state.DB.DeleteStatusByID(ctx, boost.ID); err != nil {
+			errs.Appendf("error deleting boost: %w", err)
+		}
+	}
+
+	// Finally, delete the status itself.
+	log.Debugf(ctx, "deleting status: %s", status.URI)
+	s.wipeFromTimelines(ctx, status.ID)
+	if err := s.state.DB.DeleteStatusByID(ctx, status.ID); err != nil {
+		errs.Appendf("error deleting status: %w", err)
+	}
+
+	if err := errs.Combine(); err != nil {
+		return gtserror.Newf("error(s) deleting status %s: %w", status.ID, err)
+	}
+
+	return nil
+}
+
+// wipeFromTimelines removes the status with given ID from
+// any in-memory timelines, when running within the server.
+func (s *Statuses) wipeFromTimelines(ctx context.Context, statusID string) {
+	if s.state.Timelines.Home != nil {
+		if err := s.state.Timelines.Home.WipeItemFromAllTimelines(ctx, statusID); err != nil {
+			log.Errorf(ctx, "error wiping status from home timelines: %v", err)
+		}
+	}
+
+	if s.state.Timelines.List != nil {
+		if err := s.state.Timelines.List.WipeItemFromAllTimelines(ctx, statusID); err != nil {
+			log.Errorf(ctx, "error wiping status from list timelines: %v", err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+func (suite *CleanerTestSuite) TestStatusesPruneRemote() {
+	suite.testStatusesPruneRemote(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestStatusesPruneRemoteDryRun() {
+	suite.testStatusesPruneRemote(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testStatusesPruneRemote(ctx context.Context) {
+	var (
+		testAccounts  = testrig.NewTestAccounts()
+		localAccount  = testAccounts["local_account_1"]
+		remoteAccount = testAccounts["remote_account_2"]
+		old           = time.Now().Add(-30 * 24 * time.Hour)
+	)
+
+	// Untouched remote status, which should be pruned.
+	prunable := suite.putRemoteStatus(remoteAccount, old)
+
+	// Remote status faved by a local account.
+	faved := suite.putRemoteStatus(remoteAccount, old)
+	if err := suite.state.DB.PutStatusFave(ctx, &gtsmodel.StatusFave{
+		ID:              id.NewULID(),
+		AccountID:       localAccount.ID,
+		TargetAccountID: remoteAccount.ID,
+		StatusID:        faved.ID,
+		URI:             "http://localhost:8080/fave/" + faved.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remote status replied to by a local account.
+	repliedTo := suite.putRemoteStatus(remoteAccount, old)
+	if err := suite.state.DB.PutStatus(ctx, &gtsmodel.Status{
+		ID:                  id.NewULID(),
+		URI:                 localAccount.URI + "/statuses/reply",
+		Content:             "nice one",
+		Local:               util.Ptr(true),
+		AccountURI:          localAccount.URI,
+		AccountID:           localAccount.ID,
+		InReplyToID:         repliedTo.ID,
+		InReplyToURI:        repliedTo.URI,
+		InReplyToAccountID:  remoteAccount.ID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+		Boostable:           util.Ptr(true),
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remote status in a thread involving a local account.
+	threaded := suite.putRemoteStatus(remoteAccount, old, func(status *gtsmodel.Status) {
+		status.ThreadID = id.NewULID()
+	})
+
+	// Remote status pinned by an account a local account follows.
+	pinned := suite.putRemoteStatus(remoteAccount, old, func(status *gtsmodel.Status) {
+		status.PinnedAt = old
+	})
+	if err := suite.state.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/follow/" + pinned.ID,
+		AccountID:       localAccount.ID,
+		TargetAccountID: remoteAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Remote status that isn't old enough.
+	recent := suite.putRemoteStatus(remoteAccount, time.Now())
+
+	// Prune remote statuses from before a week ago.
+	t := time.Now().Add(-7 * 24 * time.Hour)
+	total, err := suite.cleaner.Statuses().PruneRemote(ctx, t)
+	suite.NoError(err)
+	suite.GreaterOrEqual(total, 1)
+
+	// Only the untouched status should be
+	// gone, and only if not a dry run.
+	suite.Equal(gtscontext.DryRun(ctx), suite.haveStatus(prunable.ID))
+	for _, kept := range []*gtsmodel.Status{
+		faved,
+		repliedTo,
+		threaded,
+		pinned,
+		recent,
+	} {
+		suite.True(suite.haveStatus(kept.ID), kept.URI)
+	}
+}
+
+// putRemoteStatus stores a new remote status by account, created at given time, modified by given functions.
+func (suite *CleanerTestSuite) putRemoteStatus(account *gtsmodel.Account, createdAt time.Time, mods ...func(*gtsmodel.Status)) *gtsmodel.Status {
+	statusID, err := id.NewULIDFromTime(createdAt)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		Content:             "hello world",
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		Local:               util.Ptr(false),
+		AccountURI:          account.URI,
+		AccountID:           account.ID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+		Federated:           util.Ptr(true),
+		Boostable:           util.Ptr(true),
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+	}
+
+	for _, mod := range mods {
+		mod(status)
+	}
+
+	if err := suite.state.DB.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// haveStatus returns whether status with ID is still stored in the database.
+func (suite *CleanerTestSuite) haveStatus(statusID string) bool {
+	_, err := suite.state.DB.GetStatusByID(gtscontext.SetBarebones(context.Background()), statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	return err == nil
+}
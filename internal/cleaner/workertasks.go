@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// WorkerTasks encompasses a set of
+// worker task cleanup / admin utils.
+type WorkerTasks struct{ *Cleaner }
+
+// All will execute all cleaner.WorkerTasks utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (w *WorkerTasks) All(ctx context.Context, maxFailedDays int) {
+	t := time.Now().Add(-24 * time.Hour * time.Duration(maxFailedDays))
+	w.LogPruneFailed(ctx, t)
+}
+
+// LogPruneFailed performs WorkerTasks.PruneFailed(...), logging the start and outcome.
+func (w *WorkerTasks) LogPruneFailed(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := w.PruneFailed(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// PruneFailed will delete all worker tasks that failed before given input time.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (w *WorkerTasks) PruneFailed(ctx context.Context, olderThan time.Time) (int, error) {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, only count.
+		n, err := w.state.DB.CountFailedWorkerTasksOlderThan(ctx, olderThan)
+		if err != nil {
+			return 0, gtserror.Newf("error counting failed worker tasks: %w", err)
+		}
+		return n, nil
+	}
+
+	n, err := w.state.DB.DeleteFailedWorkerTasksOlderThan(ctx, olderThan)
+	if err != nil {
+		return 0, gtserror.Newf("error deleting failed worker tasks: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (suite *CleanerTestSuite) TestWorkerTasksPruneFailed() {
+	suite.testWorkerTasksPruneFailed(context.Background())
+}
+
+func (suite *CleanerTestSuite) TestWorkerTasksPruneFailedDryRun() {
+	suite.testWorkerTasksPruneFailed(gtscontext.SetDryRun(context.Background()))
+}
+
+func (suite *CleanerTestSuite) testWorkerTasksPruneFailed(ctx context.Context) {
+	// Pending task, which should always be kept.
+	pending := suite.putWorkerTask(time.Time{})
+
+	// Task that failed long ago, which should be pruned.
+	oldFailed := suite.putWorkerTask(time.Now().Add(-30 * 24 * time.Hour))
+
+	// Task that failed recently, which should be kept.
+	newFailed := suite.putWorkerTask(time.Now())
+
+	// Prune tasks that failed before a week ago.
+	t := time.Now().Add(-7 * 24 * time.Hour)
+	total, err := suite.cleaner.WorkerTasks().PruneFailed(ctx, t)
+	suite.NoError(err)
+	suite.Equal(1, total)
+
+	// Only the old failed task should
+	// be gone, and only if not a dry run.
+	suite.Equal(gtscontext.DryRun(ctx), suite.haveWorkerTask(oldFailed.ID))
+	suite.True(suite.haveWorkerTask(pending.ID))
+	suite.True(suite.haveWorkerTask(newFailed.ID))
+}
+
+// putWorkerTask inserts a new delivery worker
+// task into the database, failed at given time
+// (if not zero), returning the inserted task.
+func (suite *CleanerTestSuite) putWorkerTask(failedAt time.Time) *gtsmodel.WorkerTask {
+	task := &gtsmodel.WorkerTask{
+		WorkerType: gtsmodel.DeliveryWorker,
+		TaskData:   []byte("{}"),
+		CreatedAt:  time.Now(),
+		FailedAt:   failedAt,
+	}
+
+	if !failedAt.IsZero() {
+		task.Error = "http response: 410 Gone"
+	}
+
+	if err := suite.state.DB.PutWorkerTask(context.Background(), task); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return task
+}
+
+// haveWorkerTask returns whether
+// worker task with ID is in the database.
+func (suite *CleanerTestSuite) haveWorkerTask(id uint) bool {
+	_, err := suite.state.DB.GetWorkerTaskByID(context.Background(), id)
+	if errors.Is(err, db.ErrNoEntries) {
+		return false
+	} else if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return true
+}
@@ -77,23 +77,26 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
-
-	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	InstanceFederationMode            string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter      bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceExposePeers               bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended           bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb        bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline      bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes    bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion     bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                 language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceSubscriptionsProcessFrom  string             `name:"instance-subscriptions-process-from" usage:"Time of day from which to start running instance subscriptions processing jobs. Should be in the format 'hh:mm', eg., '23:00'."`
+	InstanceSubscriptionsProcessEvery time.Duration      `name:"instance-subscriptions-process-every" usage:"Period to elapse between instance subscriptions processing jobs, starting from instance-subscriptions-process-from."`
+
+	AccountsRegistrationOpen bool   `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsReasonRequired   bool   `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsInvitePolicy     string `name:"accounts-invite-policy" usage:"Which local users may create invite codes that let others sign up to the instance, even if registration is closed. Options: disabled, moderators, users."`
+	AccountsAllowCustomCSS   bool   `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength  int    `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
-	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
+	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos and audio in bytes"`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
 	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
@@ -115,6 +118,7 @@ type Configuration struct {
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
 	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
 	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	StatusesRemoteCacheDays    int `name:"statuses-remote-cache-days" usage:"Number of days to keep remote statuses and accounts that no local account has interacted with. If set to 0, they will be kept indefinitely."`
 
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
@@ -162,6 +166,7 @@ type Configuration struct {
 	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
 	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
 	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedFailedWorkerTaskDays int           `name:"advanced-failed-worker-task-days" usage:"Number of days to keep failed worker tasks (e.g. deliveries) for inspection and retry by admins. If set to 0, they will be kept indefinitely."`
 	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
 	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
 
@@ -176,6 +181,8 @@ type Configuration struct {
 	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
 	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
 	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
+	AdminTransMinimal        bool   `name:"minimal" usage:"only export local accounts, their relationships, users, domain blocks and instances, instead of all database entries"`
+	AdminTransStorageArchive string `name:"storage-archive" usage:"the path of a tar archive to also export stored media files to, or import them from"`
 	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
 	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
 	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
@@ -191,52 +198,62 @@ type HTTPClientConfiguration struct {
 }
 
 type CacheConfiguration struct {
-	MemoryTarget              bytesize.Size `name:"memory-target"`
-	AccountMemRatio           float64       `name:"account-mem-ratio"`
-	AccountNoteMemRatio       float64       `name:"account-note-mem-ratio"`
-	AccountSettingsMemRatio   float64       `name:"account-settings-mem-ratio"`
-	AccountStatsMemRatio      float64       `name:"account-stats-mem-ratio"`
-	ApplicationMemRatio       float64       `name:"application-mem-ratio"`
-	BlockMemRatio             float64       `name:"block-mem-ratio"`
-	BlockIDsMemRatio          float64       `name:"block-ids-mem-ratio"`
-	BoostOfIDsMemRatio        float64       `name:"boost-of-ids-mem-ratio"`
-	ClientMemRatio            float64       `name:"client-mem-ratio"`
-	EmojiMemRatio             float64       `name:"emoji-mem-ratio"`
-	EmojiCategoryMemRatio     float64       `name:"emoji-category-mem-ratio"`
-	FilterMemRatio            float64       `name:"filter-mem-ratio"`
-	FilterKeywordMemRatio     float64       `name:"filter-keyword-mem-ratio"`
-	FilterStatusMemRatio      float64       `name:"filter-status-mem-ratio"`
-	FollowMemRatio            float64       `name:"follow-mem-ratio"`
-	FollowIDsMemRatio         float64       `name:"follow-ids-mem-ratio"`
-	FollowRequestMemRatio     float64       `name:"follow-request-mem-ratio"`
-	FollowRequestIDsMemRatio  float64       `name:"follow-request-ids-mem-ratio"`
-	InReplyToIDsMemRatio      float64       `name:"in-reply-to-ids-mem-ratio"`
-	InstanceMemRatio          float64       `name:"instance-mem-ratio"`
-	ListMemRatio              float64       `name:"list-mem-ratio"`
-	ListEntryMemRatio         float64       `name:"list-entry-mem-ratio"`
-	MarkerMemRatio            float64       `name:"marker-mem-ratio"`
-	MediaMemRatio             float64       `name:"media-mem-ratio"`
-	MentionMemRatio           float64       `name:"mention-mem-ratio"`
-	MoveMemRatio              float64       `name:"move-mem-ratio"`
-	NotificationMemRatio      float64       `name:"notification-mem-ratio"`
-	PollMemRatio              float64       `name:"poll-mem-ratio"`
-	PollVoteMemRatio          float64       `name:"poll-vote-mem-ratio"`
-	PollVoteIDsMemRatio       float64       `name:"poll-vote-ids-mem-ratio"`
-	ReportMemRatio            float64       `name:"report-mem-ratio"`
-	StatusMemRatio            float64       `name:"status-mem-ratio"`
-	StatusBookmarkMemRatio    float64       `name:"status-bookmark-mem-ratio"`
-	StatusBookmarkIDsMemRatio float64       `name:"status-bookmark-ids-mem-ratio"`
-	StatusFaveMemRatio        float64       `name:"status-fave-mem-ratio"`
-	StatusFaveIDsMemRatio     float64       `name:"status-fave-ids-mem-ratio"`
-	TagMemRatio               float64       `name:"tag-mem-ratio"`
-	ThreadMuteMemRatio        float64       `name:"thread-mute-mem-ratio"`
-	TokenMemRatio             float64       `name:"token-mem-ratio"`
-	TombstoneMemRatio         float64       `name:"tombstone-mem-ratio"`
-	UserMemRatio              float64       `name:"user-mem-ratio"`
-	UserMuteMemRatio          float64       `name:"user-mute-mem-ratio"`
-	UserMuteIDsMemRatio       float64       `name:"user-mute-ids-mem-ratio"`
-	WebfingerMemRatio         float64       `name:"webfinger-mem-ratio"`
-	VisibilityMemRatio        float64       `name:"visibility-mem-ratio"`
+	MemoryTarget                      bytesize.Size `name:"memory-target"`
+	AccountMemRatio                   float64       `name:"account-mem-ratio"`
+	AccountNoteMemRatio               float64       `name:"account-note-mem-ratio"`
+	AccountSettingsMemRatio           float64       `name:"account-settings-mem-ratio"`
+	AccountStatsMemRatio              float64       `name:"account-stats-mem-ratio"`
+	ApplicationMemRatio               float64       `name:"application-mem-ratio"`
+	BlockMemRatio                     float64       `name:"block-mem-ratio"`
+	BlockIDsMemRatio                  float64       `name:"block-ids-mem-ratio"`
+	BoostOfIDsMemRatio                float64       `name:"boost-of-ids-mem-ratio"`
+	ClientMemRatio                    float64       `name:"client-mem-ratio"`
+	ConversationMemRatio              float64       `name:"conversation-mem-ratio"`
+	ConversationLastStatusIDsMemRatio float64       `name:"conversation-last-status-ids-mem-ratio"`
+	EmojiMemRatio                     float64       `name:"emoji-mem-ratio"`
+	EmojiCategoryMemRatio             float64       `name:"emoji-category-mem-ratio"`
+	FeaturedTagMemRatio               float64       `name:"featured-tag-mem-ratio"`
+	FeaturedTagIDsMemRatio            float64       `name:"featured-tag-ids-mem-ratio"`
+	FilterMemRatio                    float64       `name:"filter-mem-ratio"`
+	FilterKeywordMemRatio             float64       `name:"filter-keyword-mem-ratio"`
+	FilterStatusMemRatio              float64       `name:"filter-status-mem-ratio"`
+	FollowMemRatio                    float64       `name:"follow-mem-ratio"`
+	FollowIDsMemRatio                 float64       `name:"follow-ids-mem-ratio"`
+	FollowRequestMemRatio             float64       `name:"follow-request-mem-ratio"`
+	FollowRequestIDsMemRatio          float64       `name:"follow-request-ids-mem-ratio"`
+	FollowedTagMemRatio               float64       `name:"followed-tag-mem-ratio"`
+	FollowedTagIDsMemRatio            float64       `name:"followed-tag-ids-mem-ratio"`
+	InReplyToIDsMemRatio              float64       `name:"in-reply-to-ids-mem-ratio"`
+	InstanceMemRatio                  float64       `name:"instance-mem-ratio"`
+	ListMemRatio                      float64       `name:"list-mem-ratio"`
+	ListEntryMemRatio                 float64       `name:"list-entry-mem-ratio"`
+	MarkerMemRatio                    float64       `name:"marker-mem-ratio"`
+	MediaMemRatio                     float64       `name:"media-mem-ratio"`
+	MentionMemRatio                   float64       `name:"mention-mem-ratio"`
+	MoveMemRatio                      float64       `name:"move-mem-ratio"`
+	NotificationMemRatio              float64       `name:"notification-mem-ratio"`
+	PollMemRatio                      float64       `name:"poll-mem-ratio"`
+	PollVoteMemRatio                  float64       `name:"poll-vote-mem-ratio"`
+	PollVoteIDsMemRatio               float64       `name:"poll-vote-ids-mem-ratio"`
+	PreviewCardMemRatio               float64       `name:"preview-card-mem-ratio"`
+	ReportMemRatio                    float64       `name:"report-mem-ratio"`
+	ScheduledStatusMemRatio           float64       `name:"scheduled-status-mem-ratio"`
+	StatusMemRatio                    float64       `name:"status-mem-ratio"`
+	StatusBookmarkMemRatio            float64       `name:"status-bookmark-mem-ratio"`
+	StatusBookmarkIDsMemRatio         float64       `name:"status-bookmark-ids-mem-ratio"`
+	StatusEditMemRatio                float64       `name:"status-edit-mem-ratio"`
+	StatusFaveMemRatio                float64       `name:"status-fave-mem-ratio"`
+	StatusFaveIDsMemRatio             float64       `name:"status-fave-ids-mem-ratio"`
+	TagMemRatio                       float64       `name:"tag-mem-ratio"`
+	ThreadMuteMemRatio                float64       `name:"thread-mute-mem-ratio"`
+	TokenMemRatio                     float64       `name:"token-mem-ratio"`
+	TombstoneMemRatio                 float64       `name:"tombstone-mem-ratio"`
+	UserMemRatio                      float64       `name:"user-mem-ratio"`
+	UserMuteMemRatio                  float64       `name:"user-mute-mem-ratio"`
+	UserMuteIDsMemRatio               float64       `name:"user-mute-ids-mem-ratio"`
+	WebPushSubscriptionMemRatio       float64       `name:"web-push-subscription-mem-ratio"`
+	WebfingerMemRatio                 float64       `name:"webfinger-mem-ratio"`
+	VisibilityMemRatio                float64       `name:"visibility-mem-ratio"`
 }
 
 // MarshalMap will marshal current Configuration into a map structure (useful for JSON/TOML/YAML).
@@ -24,6 +24,13 @@ const (
 	InstanceFederationModeAllowlist = "allowlist"
 	InstanceFederationModeDefault   = InstanceFederationModeBlocklist
 
+	// Accounts invite policy determines which
+	// local users may create sign-up invites.
+	AccountsInvitePolicyDisabled   = "disabled"
+	AccountsInvitePolicyModerators = "moderators"
+	AccountsInvitePolicyUsers      = "users"
+	AccountsInvitePolicyDefault    = AccountsInvitePolicyModerators
+
 	// Request header filter mode determines how
 	// this instance will perform request filtering.
 	RequestHeaderFilterModeAllow    = "allow"
@@ -58,16 +58,19 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:            InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:      false,
+	InstanceExposePeers:               false,
+	InstanceExposeSuspended:           false,
+	InstanceExposeSuspendedWeb:        false,
+	InstanceDeliverToSharedInboxes:    true,
+	InstanceLanguages:                 make(language.Languages, 0),
+	InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm.
+	InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
+	AccountsInvitePolicy:     AccountsInvitePolicyDefault,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
@@ -90,6 +93,7 @@ var Defaults = Configuration{
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	StatusesRemoteCacheDays:    0,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -133,6 +137,7 @@ var Defaults = Configuration{
 	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
 	AdvancedThrottlingRetryAfter: time.Second * 30,
 	AdvancedSenderMultiplier:     2, // 2 senders per CPU
+	AdvancedFailedWorkerTaskDays: 30,
 	AdvancedCSPExtraURIs:         []string{},
 	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
 
@@ -156,51 +161,61 @@ var Defaults = Configuration{
 		// when TODO items in the size.go source
 		// file have been addressed, these should
 		// be able to make some more sense :D
-		AccountMemRatio:           5,
-		AccountNoteMemRatio:       1,
-		AccountSettingsMemRatio:   0.1,
-		AccountStatsMemRatio:      2,
-		ApplicationMemRatio:       0.1,
-		BlockMemRatio:             2,
-		BlockIDsMemRatio:          3,
-		BoostOfIDsMemRatio:        3,
-		ClientMemRatio:            0.1,
-		EmojiMemRatio:             3,
-		EmojiCategoryMemRatio:     0.1,
-		FilterMemRatio:            0.5,
-		FilterKeywordMemRatio:     0.5,
-		FilterStatusMemRatio:      0.5,
-		FollowMemRatio:            2,
-		FollowIDsMemRatio:         4,
-		FollowRequestMemRatio:     2,
-		FollowRequestIDsMemRatio:  2,
-		InReplyToIDsMemRatio:      3,
-		InstanceMemRatio:          1,
-		ListMemRatio:              1,
-		ListEntryMemRatio:         2,
-		MarkerMemRatio:            0.5,
-		MediaMemRatio:             4,
-		MentionMemRatio:           2,
-		MoveMemRatio:              0.1,
-		NotificationMemRatio:      2,
-		PollMemRatio:              1,
-		PollVoteMemRatio:          2,
-		PollVoteIDsMemRatio:       2,
-		ReportMemRatio:            1,
-		StatusMemRatio:            5,
-		StatusBookmarkMemRatio:    0.5,
-		StatusBookmarkIDsMemRatio: 2,
-		StatusFaveMemRatio:        2,
-		StatusFaveIDsMemRatio:     3,
-		TagMemRatio:               2,
-		ThreadMuteMemRatio:        0.2,
-		TokenMemRatio:             0.75,
-		TombstoneMemRatio:         0.5,
-		UserMemRatio:              0.25,
-		UserMuteMemRatio:          2,
-		UserMuteIDsMemRatio:       3,
-		WebfingerMemRatio:         0.1,
-		VisibilityMemRatio:        2,
+		AccountMemRatio:                   5,
+		AccountNoteMemRatio:               1,
+		AccountSettingsMemRatio:           0.1,
+		AccountStatsMemRatio:              2,
+		ApplicationMemRatio:               0.1,
+		BlockMemRatio:                     2,
+		BlockIDsMemRatio:                  3,
+		BoostOfIDsMemRatio:                3,
+		ClientMemRatio:                    0.1,
+		ConversationMemRatio:              1,
+		ConversationLastStatusIDsMemRatio: 2,
+		EmojiMemRatio:                     3,
+		EmojiCategoryMemRatio:             0.1,
+		FeaturedTagMemRatio:               0.5,
+		FeaturedTagIDsMemRatio:            0.5,
+		FilterMemRatio:                    0.5,
+		FilterKeywordMemRatio:             0.5,
+		FilterStatusMemRatio:              0.5,
+		FollowMemRatio:                    2,
+		FollowIDsMemRatio:                 4,
+		FollowRequestMemRatio:             2,
+		FollowRequestIDsMemRatio:          2,
+		FollowedTagMemRatio:               0.5,
+		FollowedTagIDsMemRatio:            1,
+		InReplyToIDsMemRatio:              3,
+		InstanceMemRatio:                  1,
+		ListMemRatio:                      1,
+		ListEntryMemRatio:                 2,
+		MarkerMemRatio:                    0.5,
+		MediaMemRatio:                     4,
+		MentionMemRatio:                   2,
+		MoveMemRatio:                      0.1,
+		NotificationMemRatio:              2,
+		PollMemRatio:                      1,
+		PollVoteMemRatio:                  2,
+		PollVoteIDsMemRatio:               2,
+		PreviewCardMemRatio:               1,
+		ReportMemRatio:                    1,
+		ScheduledStatusMemRatio:           0.5,
+		StatusMemRatio:                    5,
+		StatusBookmarkMemRatio:            0.5,
+		StatusBookmarkIDsMemRatio:         2,
+		StatusEditMemRatio:                2,
+		StatusFaveMemRatio:                2,
+		StatusFaveIDsMemRatio:             3,
+		TagMemRatio:                       2,
+		ThreadMuteMemRatio:                0.2,
+		TokenMemRatio:                     0.75,
+		TombstoneMemRatio:                 0.5,
+		UserMemRatio:                      0.25,
+		UserMuteMemRatio:                  2,
+		UserMuteIDsMemRatio:               3,
+		WebPushSubscriptionMemRatio:       1,
+		WebfingerMemRatio:                 0.1,
+		VisibilityMemRatio:                2,
 	},
 
 	HTTPClient: HTTPClientConfiguration{
@@ -975,6 +975,62 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceSubscriptionsProcessFrom safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessFrom = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessFromFlag returns the flag name for the 'InstanceSubscriptionsProcessFrom' field
+func InstanceSubscriptionsProcessFromFlag() string { return "instance-subscriptions-process-from" }
+
+// GetInstanceSubscriptionsProcessFrom safely fetches the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func GetInstanceSubscriptionsProcessFrom() string {
+	return global.GetInstanceSubscriptionsProcessFrom()
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func SetInstanceSubscriptionsProcessFrom(v string) { global.SetInstanceSubscriptionsProcessFrom(v) }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessEvery = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessEveryFlag returns the flag name for the 'InstanceSubscriptionsProcessEvery' field
+func InstanceSubscriptionsProcessEveryFlag() string { return "instance-subscriptions-process-every" }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func GetInstanceSubscriptionsProcessEvery() time.Duration {
+	return global.GetInstanceSubscriptionsProcessEvery()
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	global.SetInstanceSubscriptionsProcessEvery(v)
+}
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1025,6 +1081,31 @@ func GetAccountsReasonRequired() bool { return global.GetAccountsReasonRequired(
 // SetAccountsReasonRequired safely sets the value for global configuration 'AccountsReasonRequired' field
 func SetAccountsReasonRequired(v bool) { global.SetAccountsReasonRequired(v) }
 
+// GetAccountsInvitePolicy safely fetches the Configuration value for state's 'AccountsInvitePolicy' field
+func (st *ConfigState) GetAccountsInvitePolicy() (v string) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitePolicy
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitePolicy safely sets the Configuration value for state's 'AccountsInvitePolicy' field
+func (st *ConfigState) SetAccountsInvitePolicy(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitePolicy = v
+	st.reloadToViper()
+}
+
+// AccountsInvitePolicyFlag returns the flag name for the 'AccountsInvitePolicy' field
+func AccountsInvitePolicyFlag() string { return "accounts-invite-policy" }
+
+// GetAccountsInvitePolicy safely fetches the value for global configuration 'AccountsInvitePolicy' field
+func GetAccountsInvitePolicy() string { return global.GetAccountsInvitePolicy() }
+
+// SetAccountsInvitePolicy safely sets the value for global configuration 'AccountsInvitePolicy' field
+func SetAccountsInvitePolicy(v string) { global.SetAccountsInvitePolicy(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1600,6 +1681,31 @@ func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
 // SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
 func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
 
+// GetStatusesRemoteCacheDays safely fetches the Configuration value for state's 'StatusesRemoteCacheDays' field
+func (st *ConfigState) GetStatusesRemoteCacheDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.StatusesRemoteCacheDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetStatusesRemoteCacheDays safely sets the Configuration value for state's 'StatusesRemoteCacheDays' field
+func (st *ConfigState) SetStatusesRemoteCacheDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StatusesRemoteCacheDays = v
+	st.reloadToViper()
+}
+
+// StatusesRemoteCacheDaysFlag returns the flag name for the 'StatusesRemoteCacheDays' field
+func StatusesRemoteCacheDaysFlag() string { return "statuses-remote-cache-days" }
+
+// GetStatusesRemoteCacheDays safely fetches the value for global configuration 'StatusesRemoteCacheDays' field
+func GetStatusesRemoteCacheDays() int { return global.GetStatusesRemoteCacheDays() }
+
+// SetStatusesRemoteCacheDays safely sets the value for global configuration 'StatusesRemoteCacheDays' field
+func SetStatusesRemoteCacheDays(v int) { global.SetStatusesRemoteCacheDays(v) }
+
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2575,6 +2681,31 @@ func GetAdvancedSenderMultiplier() int { return global.GetAdvancedSenderMultipli
 // SetAdvancedSenderMultiplier safely sets the value for global configuration 'AdvancedSenderMultiplier' field
 func SetAdvancedSenderMultiplier(v int) { global.SetAdvancedSenderMultiplier(v) }
 
+// GetAdvancedFailedWorkerTaskDays safely fetches the Configuration value for state's 'AdvancedFailedWorkerTaskDays' field
+func (st *ConfigState) GetAdvancedFailedWorkerTaskDays() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedFailedWorkerTaskDays
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedFailedWorkerTaskDays safely sets the Configuration value for state's 'AdvancedFailedWorkerTaskDays' field
+func (st *ConfigState) SetAdvancedFailedWorkerTaskDays(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedFailedWorkerTaskDays = v
+	st.reloadToViper()
+}
+
+// AdvancedFailedWorkerTaskDaysFlag returns the flag name for the 'AdvancedFailedWorkerTaskDays' field
+func AdvancedFailedWorkerTaskDaysFlag() string { return "advanced-failed-worker-task-days" }
+
+// GetAdvancedFailedWorkerTaskDays safely fetches the value for global configuration 'AdvancedFailedWorkerTaskDays' field
+func GetAdvancedFailedWorkerTaskDays() int { return global.GetAdvancedFailedWorkerTaskDays() }
+
+// SetAdvancedFailedWorkerTaskDays safely sets the value for global configuration 'AdvancedFailedWorkerTaskDays' field
+func SetAdvancedFailedWorkerTaskDays(v int) { global.SetAdvancedFailedWorkerTaskDays(v) }
+
 // GetAdvancedCSPExtraURIs safely fetches the Configuration value for state's 'AdvancedCSPExtraURIs' field
 func (st *ConfigState) GetAdvancedCSPExtraURIs() (v []string) {
 	st.mutex.RLock()
@@ -2975,6 +3106,62 @@ func GetCacheClientMemRatio() float64 { return global.GetCacheClientMemRatio() }
 // SetCacheClientMemRatio safely sets the value for global configuration 'Cache.ClientMemRatio' field
 func SetCacheClientMemRatio(v float64) { global.SetCacheClientMemRatio(v) }
 
+// GetCacheConversationMemRatio safely fetches the Configuration value for state's 'Cache.ConversationMemRatio' field
+func (st *ConfigState) GetCacheConversationMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ConversationMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheConversationMemRatio safely sets the Configuration value for state's 'Cache.ConversationMemRatio' field
+func (st *ConfigState) SetCacheConversationMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ConversationMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheConversationMemRatioFlag returns the flag name for the 'Cache.ConversationMemRatio' field
+func CacheConversationMemRatioFlag() string { return "cache-conversation-mem-ratio" }
+
+// GetCacheConversationMemRatio safely fetches the value for global configuration 'Cache.ConversationMemRatio' field
+func GetCacheConversationMemRatio() float64 { return global.GetCacheConversationMemRatio() }
+
+// SetCacheConversationMemRatio safely sets the value for global configuration 'Cache.ConversationMemRatio' field
+func SetCacheConversationMemRatio(v float64) { global.SetCacheConversationMemRatio(v) }
+
+// GetCacheConversationLastStatusIDsMemRatio safely fetches the Configuration value for state's 'Cache.ConversationLastStatusIDsMemRatio' field
+func (st *ConfigState) GetCacheConversationLastStatusIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ConversationLastStatusIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheConversationLastStatusIDsMemRatio safely sets the Configuration value for state's 'Cache.ConversationLastStatusIDsMemRatio' field
+func (st *ConfigState) SetCacheConversationLastStatusIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ConversationLastStatusIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheConversationLastStatusIDsMemRatioFlag returns the flag name for the 'Cache.ConversationLastStatusIDsMemRatio' field
+func CacheConversationLastStatusIDsMemRatioFlag() string {
+	return "cache-conversation-last-status-ids-mem-ratio"
+}
+
+// GetCacheConversationLastStatusIDsMemRatio safely fetches the value for global configuration 'Cache.ConversationLastStatusIDsMemRatio' field
+func GetCacheConversationLastStatusIDsMemRatio() float64 {
+	return global.GetCacheConversationLastStatusIDsMemRatio()
+}
+
+// SetCacheConversationLastStatusIDsMemRatio safely sets the value for global configuration 'Cache.ConversationLastStatusIDsMemRatio' field
+func SetCacheConversationLastStatusIDsMemRatio(v float64) {
+	global.SetCacheConversationLastStatusIDsMemRatio(v)
+}
+
 // GetCacheEmojiMemRatio safely fetches the Configuration value for state's 'Cache.EmojiMemRatio' field
 func (st *ConfigState) GetCacheEmojiMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3025,6 +3212,56 @@ func GetCacheEmojiCategoryMemRatio() float64 { return global.GetCacheEmojiCatego
 // SetCacheEmojiCategoryMemRatio safely sets the value for global configuration 'Cache.EmojiCategoryMemRatio' field
 func SetCacheEmojiCategoryMemRatio(v float64) { global.SetCacheEmojiCategoryMemRatio(v) }
 
+// GetCacheFeaturedTagMemRatio safely fetches the Configuration value for state's 'Cache.FeaturedTagMemRatio' field
+func (st *ConfigState) GetCacheFeaturedTagMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FeaturedTagMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFeaturedTagMemRatio safely sets the Configuration value for state's 'Cache.FeaturedTagMemRatio' field
+func (st *ConfigState) SetCacheFeaturedTagMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FeaturedTagMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFeaturedTagMemRatioFlag returns the flag name for the 'Cache.FeaturedTagMemRatio' field
+func CacheFeaturedTagMemRatioFlag() string { return "cache-featured-tag-mem-ratio" }
+
+// GetCacheFeaturedTagMemRatio safely fetches the value for global configuration 'Cache.FeaturedTagMemRatio' field
+func GetCacheFeaturedTagMemRatio() float64 { return global.GetCacheFeaturedTagMemRatio() }
+
+// SetCacheFeaturedTagMemRatio safely sets the value for global configuration 'Cache.FeaturedTagMemRatio' field
+func SetCacheFeaturedTagMemRatio(v float64) { global.SetCacheFeaturedTagMemRatio(v) }
+
+// GetCacheFeaturedTagIDsMemRatio safely fetches the Configuration value for state's 'Cache.FeaturedTagIDsMemRatio' field
+func (st *ConfigState) GetCacheFeaturedTagIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FeaturedTagIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFeaturedTagIDsMemRatio safely sets the Configuration value for state's 'Cache.FeaturedTagIDsMemRatio' field
+func (st *ConfigState) SetCacheFeaturedTagIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FeaturedTagIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFeaturedTagIDsMemRatioFlag returns the flag name for the 'Cache.FeaturedTagIDsMemRatio' field
+func CacheFeaturedTagIDsMemRatioFlag() string { return "cache-featured-tag-ids-mem-ratio" }
+
+// GetCacheFeaturedTagIDsMemRatio safely fetches the value for global configuration 'Cache.FeaturedTagIDsMemRatio' field
+func GetCacheFeaturedTagIDsMemRatio() float64 { return global.GetCacheFeaturedTagIDsMemRatio() }
+
+// SetCacheFeaturedTagIDsMemRatio safely sets the value for global configuration 'Cache.FeaturedTagIDsMemRatio' field
+func SetCacheFeaturedTagIDsMemRatio(v float64) { global.SetCacheFeaturedTagIDsMemRatio(v) }
+
 // GetCacheFilterMemRatio safely fetches the Configuration value for state's 'Cache.FilterMemRatio' field
 func (st *ConfigState) GetCacheFilterMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3200,6 +3437,56 @@ func GetCacheFollowRequestIDsMemRatio() float64 { return global.GetCacheFollowRe
 // SetCacheFollowRequestIDsMemRatio safely sets the value for global configuration 'Cache.FollowRequestIDsMemRatio' field
 func SetCacheFollowRequestIDsMemRatio(v float64) { global.SetCacheFollowRequestIDsMemRatio(v) }
 
+// GetCacheFollowedTagMemRatio safely fetches the Configuration value for state's 'Cache.FollowedTagMemRatio' field
+func (st *ConfigState) GetCacheFollowedTagMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FollowedTagMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFollowedTagMemRatio safely sets the Configuration value for state's 'Cache.FollowedTagMemRatio' field
+func (st *ConfigState) SetCacheFollowedTagMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FollowedTagMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFollowedTagMemRatioFlag returns the flag name for the 'Cache.FollowedTagMemRatio' field
+func CacheFollowedTagMemRatioFlag() string { return "cache-followed-tag-mem-ratio" }
+
+// GetCacheFollowedTagMemRatio safely fetches the value for global configuration 'Cache.FollowedTagMemRatio' field
+func GetCacheFollowedTagMemRatio() float64 { return global.GetCacheFollowedTagMemRatio() }
+
+// SetCacheFollowedTagMemRatio safely sets the value for global configuration 'Cache.FollowedTagMemRatio' field
+func SetCacheFollowedTagMemRatio(v float64) { global.SetCacheFollowedTagMemRatio(v) }
+
+// GetCacheFollowedTagIDsMemRatio safely fetches the Configuration value for state's 'Cache.FollowedTagIDsMemRatio' field
+func (st *ConfigState) GetCacheFollowedTagIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.FollowedTagIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheFollowedTagIDsMemRatio safely sets the Configuration value for state's 'Cache.FollowedTagIDsMemRatio' field
+func (st *ConfigState) SetCacheFollowedTagIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.FollowedTagIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheFollowedTagIDsMemRatioFlag returns the flag name for the 'Cache.FollowedTagIDsMemRatio' field
+func CacheFollowedTagIDsMemRatioFlag() string { return "cache-followed-tag-ids-mem-ratio" }
+
+// GetCacheFollowedTagIDsMemRatio safely fetches the value for global configuration 'Cache.FollowedTagIDsMemRatio' field
+func GetCacheFollowedTagIDsMemRatio() float64 { return global.GetCacheFollowedTagIDsMemRatio() }
+
+// SetCacheFollowedTagIDsMemRatio safely sets the value for global configuration 'Cache.FollowedTagIDsMemRatio' field
+func SetCacheFollowedTagIDsMemRatio(v float64) { global.SetCacheFollowedTagIDsMemRatio(v) }
+
 // GetCacheInReplyToIDsMemRatio safely fetches the Configuration value for state's 'Cache.InReplyToIDsMemRatio' field
 func (st *ConfigState) GetCacheInReplyToIDsMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3500,6 +3787,31 @@ func GetCachePollVoteIDsMemRatio() float64 { return global.GetCachePollVoteIDsMe
 // SetCachePollVoteIDsMemRatio safely sets the value for global configuration 'Cache.PollVoteIDsMemRatio' field
 func SetCachePollVoteIDsMemRatio(v float64) { global.SetCachePollVoteIDsMemRatio(v) }
 
+// GetCachePreviewCardMemRatio safely fetches the Configuration value for state's 'Cache.PreviewCardMemRatio' field
+func (st *ConfigState) GetCachePreviewCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.PreviewCardMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCachePreviewCardMemRatio safely sets the Configuration value for state's 'Cache.PreviewCardMemRatio' field
+func (st *ConfigState) SetCachePreviewCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.PreviewCardMemRatio = v
+	st.reloadToViper()
+}
+
+// CachePreviewCardMemRatioFlag returns the flag name for the 'Cache.PreviewCardMemRatio' field
+func CachePreviewCardMemRatioFlag() string { return "cache-preview-card-mem-ratio" }
+
+// GetCachePreviewCardMemRatio safely fetches the value for global configuration 'Cache.PreviewCardMemRatio' field
+func GetCachePreviewCardMemRatio() float64 { return global.GetCachePreviewCardMemRatio() }
+
+// SetCachePreviewCardMemRatio safely sets the value for global configuration 'Cache.PreviewCardMemRatio' field
+func SetCachePreviewCardMemRatio(v float64) { global.SetCachePreviewCardMemRatio(v) }
+
 // GetCacheReportMemRatio safely fetches the Configuration value for state's 'Cache.ReportMemRatio' field
 func (st *ConfigState) GetCacheReportMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3525,6 +3837,31 @@ func GetCacheReportMemRatio() float64 { return global.GetCacheReportMemRatio() }
 // SetCacheReportMemRatio safely sets the value for global configuration 'Cache.ReportMemRatio' field
 func SetCacheReportMemRatio(v float64) { global.SetCacheReportMemRatio(v) }
 
+// GetCacheScheduledStatusMemRatio safely fetches the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) GetCacheScheduledStatusMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ScheduledStatusMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheScheduledStatusMemRatio safely sets the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) SetCacheScheduledStatusMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ScheduledStatusMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheScheduledStatusMemRatioFlag returns the flag name for the 'Cache.ScheduledStatusMemRatio' field
+func CacheScheduledStatusMemRatioFlag() string { return "cache-scheduled-status-mem-ratio" }
+
+// GetCacheScheduledStatusMemRatio safely fetches the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func GetCacheScheduledStatusMemRatio() float64 { return global.GetCacheScheduledStatusMemRatio() }
+
+// SetCacheScheduledStatusMemRatio safely sets the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func SetCacheScheduledStatusMemRatio(v float64) { global.SetCacheScheduledStatusMemRatio(v) }
+
 // GetCacheStatusMemRatio safely fetches the Configuration value for state's 'Cache.StatusMemRatio' field
 func (st *ConfigState) GetCacheStatusMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3600,6 +3937,31 @@ func GetCacheStatusBookmarkIDsMemRatio() float64 { return global.GetCacheStatusB
 // SetCacheStatusBookmarkIDsMemRatio safely sets the value for global configuration 'Cache.StatusBookmarkIDsMemRatio' field
 func SetCacheStatusBookmarkIDsMemRatio(v float64) { global.SetCacheStatusBookmarkIDsMemRatio(v) }
 
+// GetCacheStatusEditMemRatio safely fetches the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) GetCacheStatusEditMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusEditMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusEditMemRatio safely sets the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) SetCacheStatusEditMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusEditMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusEditMemRatioFlag returns the flag name for the 'Cache.StatusEditMemRatio' field
+func CacheStatusEditMemRatioFlag() string { return "cache-status-edit-mem-ratio" }
+
+// GetCacheStatusEditMemRatio safely fetches the value for global configuration 'Cache.StatusEditMemRatio' field
+func GetCacheStatusEditMemRatio() float64 { return global.GetCacheStatusEditMemRatio() }
+
+// SetCacheStatusEditMemRatio safely sets the value for global configuration 'Cache.StatusEditMemRatio' field
+func SetCacheStatusEditMemRatio(v float64) { global.SetCacheStatusEditMemRatio(v) }
+
 // GetCacheStatusFaveMemRatio safely fetches the Configuration value for state's 'Cache.StatusFaveMemRatio' field
 func (st *ConfigState) GetCacheStatusFaveMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3825,6 +4187,33 @@ func GetCacheUserMuteIDsMemRatio() float64 { return global.GetCacheUserMuteIDsMe
 // SetCacheUserMuteIDsMemRatio safely sets the value for global configuration 'Cache.UserMuteIDsMemRatio' field
 func SetCacheUserMuteIDsMemRatio(v float64) { global.SetCacheUserMuteIDsMemRatio(v) }
 
+// GetCacheWebPushSubscriptionMemRatio safely fetches the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) GetCacheWebPushSubscriptionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebPushSubscriptionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) SetCacheWebPushSubscriptionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebPushSubscriptionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheWebPushSubscriptionMemRatioFlag returns the flag name for the 'Cache.WebPushSubscriptionMemRatio' field
+func CacheWebPushSubscriptionMemRatioFlag() string { return "cache-web-push-subscription-mem-ratio" }
+
+// GetCacheWebPushSubscriptionMemRatio safely fetches the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func GetCacheWebPushSubscriptionMemRatio() float64 {
+	return global.GetCacheWebPushSubscriptionMemRatio()
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func SetCacheWebPushSubscriptionMemRatio(v float64) { global.SetCacheWebPushSubscriptionMemRatio(v) }
+
 // GetCacheWebfingerMemRatio safely fetches the Configuration value for state's 'Cache.WebfingerMemRatio' field
 func (st *ConfigState) GetCacheWebfingerMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3975,6 +4364,56 @@ func GetAdminTransPath() string { return global.GetAdminTransPath() }
 // SetAdminTransPath safely sets the value for global configuration 'AdminTransPath' field
 func SetAdminTransPath(v string) { global.SetAdminTransPath(v) }
 
+// GetAdminTransMinimal safely fetches the Configuration value for state's 'AdminTransMinimal' field
+func (st *ConfigState) GetAdminTransMinimal() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminTransMinimal
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransMinimal safely sets the Configuration value for state's 'AdminTransMinimal' field
+func (st *ConfigState) SetAdminTransMinimal(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransMinimal = v
+	st.reloadToViper()
+}
+
+// AdminTransMinimalFlag returns the flag name for the 'AdminTransMinimal' field
+func AdminTransMinimalFlag() string { return "minimal" }
+
+// GetAdminTransMinimal safely fetches the value for global configuration 'AdminTransMinimal' field
+func GetAdminTransMinimal() bool { return global.GetAdminTransMinimal() }
+
+// SetAdminTransMinimal safely sets the value for global configuration 'AdminTransMinimal' field
+func SetAdminTransMinimal(v bool) { global.SetAdminTransMinimal(v) }
+
+// GetAdminTransStorageArchive safely fetches the Configuration value for state's 'AdminTransStorageArchive' field
+func (st *ConfigState) GetAdminTransStorageArchive() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminTransStorageArchive
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransStorageArchive safely sets the Configuration value for state's 'AdminTransStorageArchive' field
+func (st *ConfigState) SetAdminTransStorageArchive(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransStorageArchive = v
+	st.reloadToViper()
+}
+
+// AdminTransStorageArchiveFlag returns the flag name for the 'AdminTransStorageArchive' field
+func AdminTransStorageArchiveFlag() string { return "storage-archive" }
+
+// GetAdminTransStorageArchive safely fetches the value for global configuration 'AdminTransStorageArchive' field
+func GetAdminTransStorageArchive() string { return global.GetAdminTransStorageArchive() }
+
+// SetAdminTransStorageArchive safely sets the value for global configuration 'AdminTransStorageArchive' field
+func SetAdminTransStorageArchive(v string) { global.SetAdminTransStorageArchive(v) }
+
 // GetAdminMediaPruneDryRun safely fetches the Configuration value for state's 'AdminMediaPruneDryRun' field
 func (st *ConfigState) GetAdminMediaPruneDryRun() (v bool) {
 	st.mutex.RLock()
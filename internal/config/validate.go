@@ -98,6 +98,24 @@ func Validate() error {
 		)
 	}
 
+	// `accounts-invite-policy` should be
+	// "disabled", "moderators" or "users".
+	switch invitePolicy := GetAccountsInvitePolicy(); invitePolicy {
+	case AccountsInvitePolicyDisabled,
+		AccountsInvitePolicyModerators,
+		AccountsInvitePolicyUsers:
+		// No problem.
+
+	case "":
+		errf("%s must be set", AccountsInvitePolicyFlag())
+
+	default:
+		errf(
+			"%s must be set to either disabled, moderators or users, provided value was %s",
+			AccountsInvitePolicyFlag(), invitePolicy,
+		)
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
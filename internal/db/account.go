@@ -20,6 +20,7 @@ package db
 import (
 	"context"
 	"net/netip"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
@@ -127,6 +128,12 @@ type Account interface {
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
 	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) error
 
+	// GetPrunableAccounts fetches up to limit remote accounts with ID less than maxID, which
+	// haven't been fetched since olderThan, and which have no remaining statuses, mentions,
+	// faves, poll votes, notifications, reports, moderation policies, conversations, relays
+	// or relationships (follows, follow requests, blocks, mutes and notes) stored in the database.
+	GetPrunableAccounts(ctx context.Context, olderThan time.Time, maxID string, limit int) ([]*gtsmodel.Account, error)
+
 	// GetInstanceAccount returns the instance account for the given domain.
 	// If domain is empty, this instance account will be returned.
 	GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, error)
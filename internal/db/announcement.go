@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Announcement handles getting/creation/deletion/updating of
+// instance announcements, and of dismissals and reactions to them.
+type Announcement interface {
+	// GetAnnouncementByID gets one announcement by its db id.
+	GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error)
+
+	// GetAnnouncements gets all announcements, including unpublished and inactive ones, newest first.
+	GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error)
+
+	// GetActiveAnnouncements gets all published announcements which are within their start and end times at given time, newest first.
+	GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error)
+
+	// GetPendingAnnouncements gets all published announcements which have yet to start or end at given time.
+	GetPendingAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error)
+
+	// PopulateAnnouncement ensures that all sub-models of an announcement are populated (e.g. emojis).
+	PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// PutAnnouncement puts the given announcement in the database.
+	PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// UpdateAnnouncement updates one announcement by its db id, only on selected columns if provided (else, all).
+	UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error
+
+	// DeleteAnnouncementByID deletes one announcement by its db id, along with all dismissals of and reactions to it.
+	DeleteAnnouncementByID(ctx context.Context, id string) error
+
+	// IsAnnouncementDismissed checks whether the given announcement has been dismissed by the given account.
+	IsAnnouncementDismissed(ctx context.Context, announcementID string, accountID string) (bool, error)
+
+	// PutAnnouncementDismissal puts the given announcement dismissal in the database.
+	PutAnnouncementDismissal(ctx context.Context, dismissal *gtsmodel.AnnouncementDismissal) error
+
+	// DeleteAnnouncementDismissalsByAccountID deletes all announcement dismissals by the given account.
+	DeleteAnnouncementDismissalsByAccountID(ctx context.Context, accountID string) error
+
+	// GetAnnouncementReactions gets all reactions to the given announcement, oldest first.
+	GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error)
+
+	// PutAnnouncementReaction puts the given announcement reaction in the database.
+	PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error
+
+	// DeleteAnnouncementReaction deletes the reaction with given name by the given account to the given announcement.
+	DeleteAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) error
+
+	// DeleteAnnouncementReactionsByAccountID deletes all announcement reactions by the given account.
+	DeleteAnnouncementReactionsByAccountID(ctx context.Context, accountID string) error
+}
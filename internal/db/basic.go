@@ -53,6 +53,12 @@ type Basic interface {
 	// In case of no entries, a 'no entries' error will be returned
 	GetAll(ctx context.Context, i interface{}) error
 
+	// GetAllStream will scan every entry of the type of i into i, one at a time, calling fn after each.
+	// Unlike GetAll, entries are streamed from the database instead of all being loaded into memory.
+	// The given interface i should be a pointer to a struct, which will be reset before each scan.
+	// If fn returns an error, iteration will stop and the error will be returned.
+	GetAllStream(ctx context.Context, i interface{}, fn func() error) error
+
 	// Put simply stores i. It is up to the implementation to figure out how to store it, and using what key.
 	// The given interface i will be set to the result of the query, whatever it is. Use a pointer or a slice.
 	Put(ctx context.Context, i interface{}) error
@@ -238,6 +238,76 @@ func (a *accountDB) GetAccountByFollowingURI(ctx context.Context, uri string) (*
 	)
 }
 
+func (a *accountDB) GetPrunableAccounts(ctx context.Context, olderThan time.Time, maxID string, limit int) ([]*gtsmodel.Account, error) {
+	accountIDs := make([]string, 0, limit)
+
+	// referencedBy returns a subquery selecting entries in
+	// table which refer to the account in any given column.
+	referencedBy := func(table string, alias string, columns ...string) *bun.SelectQuery {
+		return a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident(table), bun.Ident(alias)).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				for _, column := range columns {
+					q = q.WhereOr("? = ?", bun.Ident(alias+"."+column), bun.Ident("account.id"))
+				}
+				return q
+			})
+	}
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		// Instance accounts are needed for
+		// validating signed requests, so keep.
+		Where("? != ?", bun.Ident("account.username"), bun.Ident("account.domain")).
+		Where("? < ?", bun.Ident("account.id"), maxID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("account.fetched_at")).
+				WhereOr("? < ?", bun.Ident("account.fetched_at"), olderThan)
+		}).
+		Where("NOT EXISTS (?)", referencedBy("statuses", "status", "account_id", "in_reply_to_account_id", "boost_of_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("mentions", "mention", "target_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("status_faves", "status_fave", "account_id")).
+		Where("NOT EXISTS (?)", referencedBy("poll_votes", "poll_vote", "account_id")).
+		Where("NOT EXISTS (?)", referencedBy("notifications", "notification", "origin_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("reports", "report", "account_id", "target_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("moderation_policies", "moderation_policy", "account_id")).
+		Where("NOT EXISTS (?)", referencedBy("follows", "follow", "account_id", "target_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("follow_requests", "follow_request", "account_id", "target_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("blocks", "block", "account_id", "target_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("user_mutes", "user_mute", "target_account_id")).
+		Where("NOT EXISTS (?)", referencedBy("account_notes", "account_note", "target_account_id")).
+		// Conversations store their other participants as
+		// a comma-separated key of (fixed length) account IDs.
+		Where("NOT EXISTS (?)", a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("conversations"), bun.Ident("conversation")).
+			Where("? LIKE '%' || ? || '%'", bun.Ident("conversation.other_accounts_key"), bun.Ident("account.id")),
+		).
+		// Relay actors are referenced by URI
+		// once the relay accepts our Follow.
+		Where("NOT EXISTS (?)", a.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("relays"), bun.Ident("relay")).
+			Where("? = ?", bun.Ident("relay.actor_uri"), bun.Ident("account.uri")),
+		).
+		Order("account.id DESC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	return a.GetAccountsByIDs(ctx, accountIDs)
+}
+
 func (a *accountDB) GetInstanceAccount(ctx context.Context, domain string) (*gtsmodel.Account, error) {
 	var username string
 
@@ -463,7 +533,31 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts which signed
+		// up with an invite by invitedBy.
+		invites, err := a.state.DB.GetInvitesByAccountID(
+			gtscontext.SetBarebones(ctx),
+			invitedBy,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting invites: %w", err)
+		}
+		inviteIDs := make(map[string]struct{}, len(invites))
+		for _, invite := range invites {
+			inviteIDs[invite.ID] = struct{}{}
+		}
+
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if _, ok := inviteIDs[user.InviteID]; ok {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -739,8 +833,12 @@ func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) e
 			}
 
 			// insert the account
-			_, err := tx.NewInsert().Model(account).Exec(ctx)
-			return err
+			if _, err := tx.NewInsert().Model(account).Exec(ctx); err != nil {
+				return err
+			}
+
+			// index the account for search
+			return indexAccount(ctx, tx, account)
 		})
 	})
 }
@@ -780,12 +878,24 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 			}
 
 			// update the account
-			_, err := tx.NewUpdate().
+			if _, err := tx.NewUpdate().
 				Model(account).
 				Where("? = ?", bun.Ident("account.id"), account.ID).
 				Column(columns...).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// if searchable text was updated,
+			// reindex the account for search
+			if len(columns) == 0 ||
+				slices.Contains(columns, "username") ||
+				slices.Contains(columns, "display_name") ||
+				slices.Contains(columns, "note") {
+				return indexAccount(ctx, tx, account)
+			}
+
+			return nil
 		})
 	})
 }
@@ -814,6 +924,15 @@ func (a *accountDB) DeleteAccount(ctx context.Context, id string) error {
 			return err
 		}
 
+		// clear out the account search index entry
+		if _, err := tx.
+			NewDelete().
+			Table("account_search").
+			Where("? = ?", bun.Ident("account_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the account
 		_, err := tx.
 			NewDelete().
@@ -0,0 +1,287 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type announcementDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *announcementDB) GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error) {
+	var announcement gtsmodel.Announcement
+
+	if err := a.db.
+		NewSelect().
+		Model(&announcement).
+		Where("? = ?", bun.Ident("announcement.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &announcement, nil
+	}
+
+	if err := a.PopulateAnnouncement(ctx, &announcement); err != nil {
+		return nil, err
+	}
+
+	return &announcement, nil
+}
+
+func (a *announcementDB) GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error) {
+	return a.getAnnouncements(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q
+	})
+}
+
+func (a *announcementDB) GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error) {
+	return a.getAnnouncements(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("announcement.published"), true).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NULL", bun.Ident("announcement.starts_at")).
+					WhereOr("? <= ?", bun.Ident("announcement.starts_at"), now)
+			}).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NULL", bun.Ident("announcement.ends_at")).
+					WhereOr("? > ?", bun.Ident("announcement.ends_at"), now)
+			})
+	})
+}
+
+func (a *announcementDB) GetPendingAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error) {
+	return a.getAnnouncements(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("announcement.published"), true).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? > ?", bun.Ident("announcement.starts_at"), now).
+					WhereOr("? > ?", bun.Ident("announcement.ends_at"), now)
+			})
+	})
+}
+
+func (a *announcementDB) getAnnouncements(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) ([]*gtsmodel.Announcement, error) {
+	var announcementIDs []string
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("announcements"), bun.Ident("announcement")).
+		// Select only IDs from table.
+		Column("announcement.id").
+		// Newest announcements first.
+		OrderExpr("? DESC", bun.Ident("announcement.id"))
+
+	if err := where(q).Scan(ctx, &announcementIDs); err != nil {
+		return nil, err
+	}
+
+	// Allocate return slice (will be at most len announcementIDs).
+	announcements := make([]*gtsmodel.Announcement, 0, len(announcementIDs))
+	for _, id := range announcementIDs {
+		announcement, err := a.GetAnnouncementByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Deleted since
+				// selecting IDs.
+				continue
+			}
+			return nil, err
+		}
+
+		announcements = append(announcements, announcement)
+	}
+
+	return announcements, nil
+}
+
+func (a *announcementDB) PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	var err error
+
+	if !announcement.EmojisPopulated() {
+		// Announcement emojis are out-of-date with IDs, repopulate.
+		announcement.Emojis, err = a.state.DB.GetEmojisByIDs(
+			gtscontext.SetBarebones(ctx),
+			announcement.EmojiIDs,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating announcement emojis: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *announcementDB) PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	_, err := a.db.
+		NewInsert().
+		Model(announcement).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error {
+	announcement.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(announcement).
+		Column(columns...).
+		Where("? = ?", bun.Ident("announcement.id"), announcement.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementByID(ctx context.Context, id string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete all dismissals of this announcement.
+		if _, err := tx.
+			NewDelete().
+			Table("announcement_dismissals").
+			Where("? = ?", bun.Ident("announcement_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete all reactions to this announcement.
+		if _, err := tx.
+			NewDelete().
+			Table("announcement_reactions").
+			Where("? = ?", bun.Ident("announcement_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Delete the announcement itself.
+		_, err := tx.
+			NewDelete().
+			Table("announcements").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (a *announcementDB) IsAnnouncementDismissed(ctx context.Context, announcementID string, accountID string) (bool, error) {
+	return exists(ctx, a.db.
+		NewSelect().
+		Table("announcement_dismissals").
+		Column("id").
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("account_id"), accountID),
+	)
+}
+
+func (a *announcementDB) PutAnnouncementDismissal(ctx context.Context, dismissal *gtsmodel.AnnouncementDismissal) error {
+	_, err := a.db.
+		NewInsert().
+		Model(dismissal).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementDismissalsByAccountID(ctx context.Context, accountID string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("announcement_dismissals").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error) {
+	var reactions []*gtsmodel.AnnouncementReaction
+
+	if err := a.db.
+		NewSelect().
+		Model(&reactions).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		OrderExpr("? ASC", bun.Ident("announcement_reaction.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		if reaction.EmojiID == "" {
+			// Unicode emoji.
+			continue
+		}
+
+		// Populate the custom emoji of this reaction.
+		emoji, err := a.state.DB.GetEmojiByID(
+			gtscontext.SetBarebones(ctx),
+			reaction.EmojiID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error populating reaction emoji: %w", err)
+		}
+		reaction.Emoji = emoji
+	}
+
+	return reactions, nil
+}
+
+func (a *announcementDB) PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	_, err := a.db.
+		NewInsert().
+		Model(reaction).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("announcement_reactions").
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("name"), name).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReactionsByAccountID(ctx context.Context, accountID string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("announcement_reactions").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AnnouncementTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AnnouncementTestSuite) putAnnouncement(published bool, startsAt time.Time, endsAt time.Time) *gtsmodel.Announcement {
+	announcement := &gtsmodel.Announcement{
+		ID:        id.NewULID(),
+		Text:      "hello",
+		Content:   "<p>hello</p>",
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		AllDay:    util.Ptr(false),
+		Published: util.Ptr(published),
+	}
+
+	if err := suite.db.PutAnnouncement(context.Background(), announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return announcement
+}
+
+func (suite *AnnouncementTestSuite) TestGetActivePendingAnnouncements() {
+	var (
+		ctx = context.Background()
+		now = time.Now()
+	)
+
+	active := suite.putAnnouncement(true, time.Time{}, time.Time{})
+	activeEnding := suite.putAnnouncement(true, now.Add(-time.Hour), now.Add(time.Hour))
+	pending := suite.putAnnouncement(true, now.Add(time.Hour), time.Time{})
+	_ = suite.putAnnouncement(true, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	_ = suite.putAnnouncement(false, time.Time{}, time.Time{})
+
+	announcements, err := suite.db.GetAnnouncements(ctx)
+	suite.NoError(err)
+	suite.Len(announcements, 5)
+
+	announcements, err = suite.db.GetActiveAnnouncements(ctx, now)
+	suite.NoError(err)
+	if suite.Len(announcements, 2) {
+		// Newest first.
+		suite.Equal(activeEnding.ID, announcements[0].ID)
+		suite.Equal(active.ID, announcements[1].ID)
+	}
+
+	announcements, err = suite.db.GetPendingAnnouncements(ctx, now)
+	suite.NoError(err)
+	if suite.Len(announcements, 2) {
+		suite.Equal(pending.ID, announcements[0].ID)
+		suite.Equal(activeEnding.ID, announcements[1].ID)
+	}
+}
+
+func (suite *AnnouncementTestSuite) TestDismissReactDelete() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+	emoji := suite.testEmojis["rainbow"]
+	announcement := suite.putAnnouncement(true, time.Time{}, time.Time{})
+
+	dismissed, err := suite.db.IsAnnouncementDismissed(ctx, announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.False(dismissed)
+
+	dismissal := &gtsmodel.AnnouncementDismissal{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      account.ID,
+	}
+	suite.NoError(suite.db.PutAnnouncementDismissal(ctx, dismissal))
+
+	dismissed, err = suite.db.IsAnnouncementDismissed(ctx, announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.True(dismissed)
+
+	// Dismissing twice should fail.
+	dismissal.ID = id.NewULID()
+	err = suite.db.PutAnnouncementDismissal(ctx, dismissal)
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      account.ID,
+		Name:           emoji.Shortcode,
+		EmojiID:        emoji.ID,
+	}
+	suite.NoError(suite.db.PutAnnouncementReaction(ctx, reaction))
+
+	reactions, err := suite.db.GetAnnouncementReactions(ctx, announcement.ID)
+	suite.NoError(err)
+	if suite.Len(reactions, 1) {
+		suite.NotNil(reactions[0].Emoji)
+		suite.Equal(emoji.ID, reactions[0].Emoji.ID)
+	}
+
+	// Deleting the announcement should
+	// delete its dismissals and reactions.
+	suite.NoError(suite.db.DeleteAnnouncementByID(ctx, announcement.ID))
+
+	_, err = suite.db.GetAnnouncementByID(ctx, announcement.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dismissed, err = suite.db.IsAnnouncementDismissed(ctx, announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.False(dismissed)
+
+	reactions, err = suite.db.GetAnnouncementReactions(ctx, announcement.ID)
+	suite.NoError(err)
+	suite.Empty(reactions)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementTestSuite))
+}
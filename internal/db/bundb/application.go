@@ -19,8 +19,12 @@ package bundb
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -143,9 +147,80 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 		return nil, err
 	}
 
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) GetAccessTokens(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		tokenIDs = make([]string, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("tokens"), bun.Ident("token")).
+		// Select only IDs from table.
+		Column("token.id").
+		Where("? = ?", bun.Ident("token.user_id"), userID).
+		// Only select tokens that have actually been
+		// exchanged for an access token, not pending
+		// authorization codes.
+		Where("? != ?", bun.Ident("token.access"), "")
+
+	// Return only tokens with
+	// id lower than provided maxID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("token.id"), maxID)
+	}
+
+	// Return only tokens with
+	// id greater than provided minID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("token.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// tokens returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("token.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("token.id"))
+	}
+
+	if err := q.Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no tokens early
+	if len(tokenIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want tokens
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(tokenIDs)
+	}
+
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) getTokensByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Token, error) {
 	// Load all input token IDs via cache loader callback.
 	tokens, err := a.state.Caches.GTS.Token.LoadIDs("ID",
-		tokenIDs,
+		ids,
 		func(uncached []string) ([]*gtsmodel.Token, error) {
 			// Preallocate expected length of uncached tokens.
 			tokens := make([]*gtsmodel.Token, 0, len(uncached))
@@ -166,14 +241,24 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 		return nil, err
 	}
 
-	// Reoroder the tokens by their
+	// Reorder the tokens by their
 	// IDs to ensure in correct order.
 	getID := func(t *gtsmodel.Token) string { return t.ID }
-	util.OrderBy(tokens, tokenIDs, getID)
+	util.OrderBy(tokens, ids, getID)
 
 	return tokens, nil
 }
 
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"ID",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("id"), id).Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *applicationDB) GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error) {
 	return a.getTokenBy(
 		"Code",
@@ -223,6 +308,26 @@ func (a *applicationDB) PutToken(ctx context.Context, token *gtsmodel.Token) err
 	})
 }
 
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	// Update the token's last-updated
+	token.UpdatedAt = time.Now()
+
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included
+		columns = append(columns, "updated_at")
+	}
+
+	return a.state.Caches.GTS.Token.Store(token, func() error {
+		_, err := a.db.
+			NewUpdate().
+			Model(token).
+			Where("? = ?", bun.Ident("token.id"), token.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
 	_, err := a.db.NewDelete().
 		Table("tokens").
@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"errors"
+	"reflect"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -67,6 +68,35 @@ func (b *basicDB) GetAll(ctx context.Context, i interface{}) error {
 	return err
 }
 
+func (b *basicDB) GetAllStream(ctx context.Context, i interface{}, fn func() error) error {
+	rows, err := b.db.
+		NewSelect().
+		Model(i).
+		Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Value pointed to by i,
+	// zeroed before each scan.
+	v := reflect.ValueOf(i).Elem()
+
+	for rows.Next() {
+		v.SetZero()
+
+		if err := b.db.ScanRow(ctx, rows, i); err != nil {
+			return err
+		}
+
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func (b *basicDB) DeleteByID(ctx context.Context, id string, i interface{}) error {
 	q := b.db.
 		NewDelete().
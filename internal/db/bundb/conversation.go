@@ -0,0 +1,471 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type conversationDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *conversationDB) GetConversationByID(ctx context.Context, id string) (*gtsmodel.Conversation, error) {
+	return c.getConversation(
+		ctx,
+		"ID",
+		func(conversation *gtsmodel.Conversation) error {
+			return c.db.
+				NewSelect().
+				Model(conversation).
+				Where("? = ?", bun.Ident("id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (c *conversationDB) GetConversationByThreadAndAccountIDs(
+	ctx context.Context,
+	threadID string,
+	accountID string,
+	otherAccountIDs []string,
+) (*gtsmodel.Conversation, error) {
+	otherAccountsKey := gtsmodel.ConversationOtherAccountsKey(otherAccountIDs)
+	return c.getConversation(
+		ctx,
+		"ThreadID,AccountID,OtherAccountsKey",
+		func(conversation *gtsmodel.Conversation) error {
+			return c.db.
+				NewSelect().
+				Model(conversation).
+				Where("? = ?", bun.Ident("thread_id"), threadID).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Where("? = ?", bun.Ident("other_accounts_key"), otherAccountsKey).
+				Scan(ctx)
+		},
+		threadID,
+		accountID,
+		otherAccountsKey,
+	)
+}
+
+func (c *conversationDB) getConversationByLastStatusID(
+	ctx context.Context,
+	accountID string,
+	lastStatusID string,
+) (*gtsmodel.Conversation, error) {
+	return c.getConversation(
+		ctx,
+		"AccountID,LastStatusID",
+		func(conversation *gtsmodel.Conversation) error {
+			return c.db.
+				NewSelect().
+				Model(conversation).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Where("? = ?", bun.Ident("last_status_id"), lastStatusID).
+				Scan(ctx)
+		},
+		accountID,
+		lastStatusID,
+	)
+}
+
+func (c *conversationDB) getConversation(
+	ctx context.Context,
+	lookup string,
+	dbQuery func(conversation *gtsmodel.Conversation) error,
+	keyParts ...any,
+) (*gtsmodel.Conversation, error) {
+	// Fetch conversation from cache with loader callback
+	conversation, err := c.state.Caches.GTS.Conversation.LoadOne(lookup, func() (*gtsmodel.Conversation, error) {
+		var conversation gtsmodel.Conversation
+
+		// Not cached! Perform database query
+		if err := dbQuery(&conversation); err != nil {
+			return nil, err
+		}
+
+		return &conversation, nil
+	}, keyParts...)
+	if err != nil {
+		// already processed
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return conversation, nil
+	}
+
+	if err := c.PopulateConversation(ctx, conversation); err != nil {
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+func (c *conversationDB) getConversationsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Conversation, error) {
+	// Load all conversation IDs via cache loader callbacks.
+	conversations, err := c.state.Caches.GTS.Conversation.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.Conversation, error) {
+			// Preallocate expected length of uncached conversations.
+			conversations := make([]*gtsmodel.Conversation, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := c.db.NewSelect().
+				Model(&conversations).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return conversations, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the conversations by their
+	// IDs to ensure in correct order.
+	getID := func(c *gtsmodel.Conversation) string { return c.ID }
+	util.OrderBy(conversations, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return conversations, nil
+	}
+
+	// Populate all loaded conversations, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	conversations = slices.DeleteFunc(conversations, func(conversation *gtsmodel.Conversation) bool {
+		if err := c.PopulateConversation(ctx, conversation); err != nil {
+			log.Errorf(ctx, "error populating conversation %s: %v", conversation.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return conversations, nil
+}
+
+func (c *conversationDB) GetConversationsByOwnerAccountID(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.Conversation, error) {
+	// Conversations are paged by their last status ID, as
+	// this is what the client API uses for paging, and it's
+	// the only ordering that stays stable as new statuses arrive.
+	lastStatusIDs, err := c.getAccountConversationLastStatusIDs(ctx, accountID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations := make([]*gtsmodel.Conversation, 0, len(lastStatusIDs))
+	for _, lastStatusID := range lastStatusIDs {
+		conversation, err := c.getConversationByLastStatusID(ctx, accountID, lastStatusID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Cache was out of date
+				// with the db, skip it.
+				continue
+			}
+			return nil, gtserror.Newf("error getting conversation with last status %s: %w", lastStatusID, err)
+		}
+		conversations = append(conversations, conversation)
+	}
+
+	return conversations, nil
+}
+
+func (c *conversationDB) getAccountConversationLastStatusIDs(ctx context.Context, accountID string, page *paging.Page) ([]string, error) {
+	return loadPagedIDs(&c.state.Caches.GTS.ConversationLastStatusIDs, accountID, page, func() ([]string, error) {
+		var lastStatusIDs []string
+
+		// Conversation last status IDs not in cache. Perform DB query.
+		if _, err := c.db.
+			NewSelect().
+			TableExpr("?", bun.Ident("conversations")).
+			ColumnExpr("?", bun.Ident("last_status_id")).
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			OrderExpr("? DESC", bun.Ident("last_status_id")).
+			Exec(ctx, &lastStatusIDs); // nocollapse
+		err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		return lastStatusIDs, nil
+	})
+}
+
+func (c *conversationDB) PopulateConversation(ctx context.Context, conversation *gtsmodel.Conversation) error {
+	var (
+		errs gtserror.MultiError
+		err  error
+	)
+
+	if conversation.Account == nil {
+		// Conversation owner account is not set, fetch from database.
+		conversation.Account, err = c.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			conversation.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation owner account: %w", err)
+		}
+	}
+
+	if len(conversation.OtherAccounts) != len(conversation.OtherAccountIDs) {
+		// Conversation other accounts are not set, fetch from database.
+		conversation.OtherAccounts, err = c.state.DB.GetAccountsByIDs(
+			gtscontext.SetBarebones(ctx),
+			conversation.OtherAccountIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating other conversation accounts: %w", err)
+		}
+	}
+
+	if conversation.LastStatus == nil && conversation.LastStatusID != "" {
+		// Conversation last status is not set, fetch from database.
+		conversation.LastStatus, err = c.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			conversation.LastStatusID,
+		)
+		if err != nil {
+			errs.Appendf("error populating conversation last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *conversationDB) UpsertConversation(ctx context.Context, conversation *gtsmodel.Conversation, columns ...string) error {
+	// If we're updating by column, ensure "updated_at" is included.
+	if len(columns) > 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	return c.state.Caches.GTS.Conversation.Store(conversation, func() error {
+		_, err := NewUpsert(c.db).
+			Model(conversation).
+			Constraint("id").
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (c *conversationDB) LinkConversationToStatus(ctx context.Context, conversationID string, statusID string) error {
+	conversationToStatus := &gtsmodel.ConversationToStatus{
+		ConversationID: conversationID,
+		StatusID:       statusID,
+	}
+
+	if _, err := c.db.NewInsert().
+		Model(conversationToStatus).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("conversation_id"), bun.Ident("status_id")).
+		Exec(ctx); // nocollapse
+	err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *conversationDB) DeleteConversationByID(ctx context.Context, id string) error {
+	// Load conversation into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	_, err := c.GetConversationByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Drop this now-cached conversation on return after delete.
+	defer c.state.Caches.GTS.Conversation.Invalidate("ID", id)
+
+	// Finally delete conversation and its status links from DB.
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Table("conversation_to_statuses").
+			Where("? = ?", bun.Ident("conversation_id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting conversation-status links: %w", err)
+		}
+
+		if _, err := tx.NewDelete().
+			Table("conversations").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting conversation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (c *conversationDB) DeleteConversationsByOwnerAccountID(ctx context.Context, accountID string) error {
+	defer func() {
+		// Invalidate any cached conversations
+		// and conversation IDs owned by account.
+		c.state.Caches.GTS.Conversation.Invalidate("AccountID", accountID)
+		c.state.Caches.GTS.ConversationLastStatusIDs.Invalidate(accountID)
+	}()
+
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete conversations matching the account ID.
+		deletedConversationIDs := []string{}
+		if err := tx.NewDelete().
+			Table("conversations").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Returning("?", bun.Ident("id")).
+			Scan(ctx, &deletedConversationIDs); // nocollapse
+		err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("error deleting conversations for account %s: %w", accountID, err)
+		}
+
+		if len(deletedConversationIDs) == 0 {
+			// Nothing
+			// else to do.
+			return nil
+		}
+
+		// Delete any conversation-to-status links
+		// matching the deleted conversation IDs.
+		if _, err := tx.NewDelete().
+			Table("conversation_to_statuses").
+			Where("? IN (?)", bun.Ident("conversation_id"), bun.In(deletedConversationIDs)).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting conversation-status links for account %s: %w", accountID, err)
+		}
+
+		return nil
+	})
+}
+
+func (c *conversationDB) DeleteStatusFromConversations(ctx context.Context, statusID string) error {
+	// Gather the IDs of all conversations
+	// that the given status is part of.
+	var conversationIDs []string
+	if err := c.db.NewSelect().
+		Table("conversation_to_statuses").
+		Column("conversation_id").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Scan(ctx, &conversationIDs); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error finding conversations containing status %s: %w", statusID, err)
+	}
+
+	if len(conversationIDs) == 0 {
+		// Nothing
+		// else to do.
+		return nil
+	}
+
+	// Load conversations into cache before attempting
+	// an update, as we need them cached in order to
+	// trigger the invalidate callbacks on return.
+	conversations, err := c.getConversationsByIDs(
+		gtscontext.SetBarebones(ctx),
+		conversationIDs,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting conversations containing status %s: %w", statusID, err)
+	}
+
+	// Drop the now-cached conversations on return.
+	defer c.state.Caches.GTS.Conversation.InvalidateIDs("ID", conversationIDs)
+
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Remove the status from all the conversations it's in.
+		if _, err := tx.NewDelete().
+			Table("conversation_to_statuses").
+			Where("? = ?", bun.Ident("status_id"), statusID).
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error deleting conversation-status links for status %s: %w", statusID, err)
+		}
+
+		for _, conversation := range conversations {
+			if conversation.LastStatusID != statusID {
+				// The last status of this
+				// conversation is unchanged.
+				continue
+			}
+
+			// Find the latest remaining status in this conversation.
+			var lastStatusIDs []string
+			if err := tx.NewSelect().
+				Table("conversation_to_statuses").
+				Column("status_id").
+				Where("? = ?", bun.Ident("conversation_id"), conversation.ID).
+				OrderExpr("? DESC", bun.Ident("status_id")).
+				Limit(1).
+				Scan(ctx, &lastStatusIDs); // nocollapse
+			err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return gtserror.Newf("error finding last status of conversation %s: %w", conversation.ID, err)
+			}
+
+			if len(lastStatusIDs) == 0 {
+				// No statuses are left in this
+				// conversation, so delete it.
+				if _, err := tx.NewDelete().
+					Table("conversations").
+					Where("? = ?", bun.Ident("id"), conversation.ID).
+					Exec(ctx); err != nil {
+					return gtserror.Newf("error deleting empty conversation %s: %w", conversation.ID, err)
+				}
+				continue
+			}
+
+			// Point the conversation at its new last status.
+			if _, err := tx.NewUpdate().
+				Table("conversations").
+				Set("? = ?", bun.Ident("last_status_id"), lastStatusIDs[0]).
+				Set("? = ?", bun.Ident("updated_at"), time.Now()).
+				Where("? = ?", bun.Ident("id"), conversation.ID).
+				Exec(ctx); err != nil {
+				return gtserror.Newf("error updating last status of conversation %s: %w", conversation.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
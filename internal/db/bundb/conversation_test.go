@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ConversationTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+// putConversation creates a conversation owned by local_account_2 with
+// local_account_1, containing the given statuses, the last being newest.
+func (suite *ConversationTestSuite) putConversation(ctx context.Context, statuses ...*gtsmodel.Status) *gtsmodel.Conversation {
+	var (
+		owner = suite.testAccounts["local_account_2"]
+		other = suite.testAccounts["local_account_1"]
+	)
+
+	conversation := &gtsmodel.Conversation{
+		ID:               "01J0ZTQ0T6RHX0GBYEC4V3X4PM",
+		AccountID:        owner.ID,
+		OtherAccountIDs:  []string{other.ID},
+		OtherAccountsKey: gtsmodel.ConversationOtherAccountsKey([]string{other.ID}),
+		ThreadID:         statuses[0].ThreadID,
+		LastStatusID:     statuses[len(statuses)-1].ID,
+		Read:             util.Ptr(false),
+	}
+
+	if err := suite.db.UpsertConversation(ctx, conversation); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, status := range statuses {
+		if err := suite.db.LinkConversationToStatus(ctx, conversation.ID, status.ID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	return conversation
+}
+
+func (suite *ConversationTestSuite) TestUpsertGetConversation() {
+	var (
+		ctx          = context.Background()
+		status       = suite.testStatuses["local_account_2_status_6"]
+		conversation = suite.putConversation(ctx, status)
+	)
+
+	// Get by ID.
+	dbConversation, err := suite.db.GetConversationByID(ctx, conversation.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, dbConversation.LastStatusID)
+	suite.Equal(status.ID, dbConversation.LastStatus.ID)
+	suite.Len(dbConversation.OtherAccounts, 1)
+	suite.False(*dbConversation.Read)
+
+	// Get by thread and participants.
+	dbConversation, err = suite.db.GetConversationByThreadAndAccountIDs(
+		ctx,
+		status.ThreadID,
+		conversation.AccountID,
+		conversation.OtherAccountIDs,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(conversation.ID, dbConversation.ID)
+
+	// Mark it read.
+	dbConversation.Read = util.Ptr(true)
+	if err := suite.db.UpsertConversation(ctx, dbConversation, "read"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Get by owner.
+	conversations, err := suite.db.GetConversationsByOwnerAccountID(
+		ctx,
+		conversation.AccountID,
+		&paging.Page{Limit: 20},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(conversations, 1) {
+		suite.Equal(conversation.ID, conversations[0].ID)
+		suite.True(*conversations[0].Read)
+	}
+
+	// Delete it.
+	if err := suite.db.DeleteConversationByID(ctx, conversation.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetConversationByID(ctx, conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	conversations, err = suite.db.GetConversationsByOwnerAccountID(
+		ctx,
+		conversation.AccountID,
+		&paging.Page{Limit: 20},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(conversations)
+}
+
+func (suite *ConversationTestSuite) TestDeleteStatusFromConversations() {
+	var (
+		ctx          = context.Background()
+		oldStatus    = suite.testStatuses["local_account_2_status_6"]
+		newStatus    = suite.testStatuses["local_account_2_status_7"]
+		conversation = suite.putConversation(ctx, oldStatus, newStatus)
+	)
+
+	// Deleting the last status should fall
+	// back to the previous status in the conversation.
+	if err := suite.db.DeleteStatusFromConversations(ctx, newStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbConversation, err := suite.db.GetConversationByID(ctx, conversation.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(oldStatus.ID, dbConversation.LastStatusID)
+
+	// Deleting the only remaining status
+	// should delete the conversation.
+	if err := suite.db.DeleteStatusFromConversations(ctx, oldStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetConversationByID(ctx, conversation.ID)
+	if !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow("", "expected ErrNoEntries, got %v", err)
+	}
+}
+
+func (suite *ConversationTestSuite) TestDeleteConversationsByOwnerAccountID() {
+	var (
+		ctx          = context.Background()
+		conversation = suite.putConversation(ctx, suite.testStatuses["local_account_2_status_6"])
+	)
+
+	if err := suite.db.DeleteConversationsByOwnerAccountID(ctx, conversation.AccountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetConversationByID(ctx, conversation.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestConversationTestSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTestSuite))
+}
@@ -0,0 +1,341 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type featuredTagDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (f *featuredTagDB) GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error) {
+	return f.getFeaturedTag(
+		ctx,
+		"ID",
+		func(featuredTag *gtsmodel.FeaturedTag) error {
+			return f.db.NewSelect().
+				Model(featuredTag).
+				Where("? = ?", bun.Ident("id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (f *featuredTagDB) GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error) {
+	return f.getFeaturedTag(
+		ctx,
+		"AccountID,TagID",
+		func(featuredTag *gtsmodel.FeaturedTag) error {
+			return f.db.NewSelect().
+				Model(featuredTag).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Where("? = ?", bun.Ident("tag_id"), tagID).
+				Scan(ctx)
+		},
+		accountID,
+		tagID,
+	)
+}
+
+func (f *featuredTagDB) getFeaturedTag(
+	ctx context.Context,
+	lookup string,
+	dbQuery func(*gtsmodel.FeaturedTag) error,
+	keyParts ...any,
+) (*gtsmodel.FeaturedTag, error) {
+	// Fetch featured tag from cache with loader callback.
+	featuredTag, err := f.state.Caches.GTS.FeaturedTag.LoadOne(lookup, func() (*gtsmodel.FeaturedTag, error) {
+		var featuredTag gtsmodel.FeaturedTag
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&featuredTag); err != nil {
+			return nil, err
+		}
+
+		return &featuredTag, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return featuredTag, nil
+	}
+
+	if err := f.PopulateFeaturedTag(ctx, featuredTag); err != nil {
+		return nil, err
+	}
+
+	return featuredTag, nil
+}
+
+func (f *featuredTagDB) GetFeaturedTagsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error) {
+	featuredTagIDs, err := f.state.Caches.GTS.FeaturedTagIDs.Load(accountID, func() ([]string, error) {
+		var featuredTagIDs []string
+
+		// Featured tag IDs not in cache, perform DB query.
+		if _, err := f.db.
+			NewSelect().
+			TableExpr("?", bun.Ident("featured_tags")).
+			ColumnExpr("?", bun.Ident("id")).
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			OrderExpr("? ASC", bun.Ident("id")).
+			Exec(ctx, &featuredTagIDs); // nocollapse
+		err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		return featuredTagIDs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return f.getFeaturedTagsByIDs(ctx, featuredTagIDs)
+}
+
+func (f *featuredTagDB) getFeaturedTagsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.FeaturedTag, error) {
+	// Load all featured tag IDs via cache loader callbacks.
+	featuredTags, err := f.state.Caches.GTS.FeaturedTag.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.FeaturedTag, error) {
+			// Preallocate expected length of uncached featured tags.
+			featuredTags := make([]*gtsmodel.FeaturedTag, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := f.db.NewSelect().
+				Model(&featuredTags).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return featuredTags, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the featured tags by their
+	// IDs to ensure in correct order.
+	getID := func(t *gtsmodel.FeaturedTag) string { return t.ID }
+	util.OrderBy(featuredTags, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return featuredTags, nil
+	}
+
+	// Populate all loaded featured tags, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	featuredTags = slices.DeleteFunc(featuredTags, func(featuredTag *gtsmodel.FeaturedTag) bool {
+		if err := f.PopulateFeaturedTag(ctx, featuredTag); err != nil {
+			log.Errorf(ctx, "error populating featured tag %s: %v", featuredTag.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return featuredTags, nil
+}
+
+func (f *featuredTagDB) GetFeaturedTagStatusStats(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) (int, time.Time, error) {
+	// newQ returns a query selecting from status_to_tags joined with
+	// statuses, limited to publicly visible statuses by the featuring
+	// account which use the featured tag.
+	newQ := func() *bun.SelectQuery {
+		return f.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+			Join(
+				"INNER JOIN ? AS ? ON ? = ?",
+				bun.Ident("statuses"), bun.Ident("status"),
+				bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+			).
+			Where("? = ?", bun.Ident("status_to_tag.tag_id"), featuredTag.TagID).
+			Where("? = ?", bun.Ident("status.account_id"), featuredTag.AccountID).
+			Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+				gtsmodel.VisibilityPublic,
+				gtsmodel.VisibilityUnlocked,
+			}))
+	}
+
+	count, err := newQ().Count(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if count == 0 {
+		// Nothing more to do.
+		return 0, time.Time{}, nil
+	}
+
+	// Select ID of the most recent status.
+	var lastStatusID string
+	if err := newQ().
+		Column("status_to_tag.status_id").
+		OrderExpr("? DESC", bun.Ident("status_to_tag.status_id")).
+		Limit(1).
+		Scan(ctx, &lastStatusID); err != nil {
+		return 0, time.Time{}, err
+	}
+
+	lastStatus, err := f.state.DB.GetStatusByID(
+		gtscontext.SetBarebones(ctx),
+		lastStatusID,
+	)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return count, lastStatus.CreatedAt, nil
+}
+
+func (f *featuredTagDB) GetFeaturedTagSuggestionIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	// Select IDs of tags already featured by this account.
+	featuredQ := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("featured_tags"), bun.Ident("featured_tag")).
+		Column("featured_tag.tag_id").
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID)
+
+	var tagIDs []string
+	if err := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Column("status_to_tag.tag_id").
+		Join(
+			"INNER JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? NOT IN (?)", bun.Ident("status_to_tag.tag_id"), featuredQ).
+		Group("status_to_tag.tag_id").
+		OrderExpr("COUNT(*) DESC").
+		Limit(limit).
+		Scan(ctx, &tagIDs); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return tagIDs, nil
+}
+
+func (f *featuredTagDB) PopulateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	var (
+		errs gtserror.MultiError
+		err  error
+	)
+
+	if featuredTag.Account == nil {
+		// Featuring account is not set, fetch from database.
+		featuredTag.Account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			featuredTag.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating featured tag account: %w", err)
+		}
+	}
+
+	if featuredTag.Tag == nil {
+		// Featured tag is not set, fetch from database.
+		featuredTag.Tag, err = f.state.DB.GetTag(
+			ctx,
+			featuredTag.TagID,
+		)
+		if err != nil {
+			errs.Appendf("error populating featured tag tag: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (f *featuredTagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	return f.state.Caches.GTS.FeaturedTag.Store(featuredTag, func() error {
+		_, err := f.db.NewInsert().Model(featuredTag).Exec(ctx)
+		return err
+	})
+}
+
+func (f *featuredTagDB) DeleteFeaturedTagByID(ctx context.Context, id string) error {
+	// Load featured tag into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	_, err := f.GetFeaturedTagByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Drop this now-cached featured tag on return after delete.
+	defer f.state.Caches.GTS.FeaturedTag.Invalidate("ID", id)
+
+	// Finally delete featured tag from DB.
+	_, err = f.db.NewDelete().
+		Table("featured_tags").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (f *featuredTagDB) DeleteFeaturedTagsByAccountID(ctx context.Context, accountID string) error {
+	// Load all featured tags into cache, this *really* isn't
+	// great but it is the only way we can ensure we invalidate
+	// all related caches correctly.
+	_, err := f.GetFeaturedTagsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate all account's featured tags on return.
+	defer f.state.Caches.GTS.FeaturedTag.Invalidate("AccountID", accountID)
+
+	// Finally delete all from DB.
+	_, err = f.db.NewDelete().
+		Table("featured_tags").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
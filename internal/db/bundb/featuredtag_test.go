@@ -0,0 +1,116 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FeaturedTagTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *FeaturedTagTestSuite) TestPutGetDeleteFeaturedTag() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["admin_account"]
+		tag     = suite.testTags["welcome"]
+	)
+
+	// Before featuring, the tag should be suggested,
+	// since admin has used it in one of their statuses.
+	tagIDs, err := suite.db.GetFeaturedTagSuggestionIDs(ctx, account.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{tag.ID}, tagIDs)
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        "01J18E7WTRGYBR1M9TK1CPCV3F",
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	}
+	if err := suite.db.PutFeaturedTag(ctx, featuredTag); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Featuring the same tag again should fail.
+	err = suite.db.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+		ID:        "01J18E86QC1DQ0Y2KX0TJN0QWZ",
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	// Get by account + tag.
+	dbFeaturedTag, err := suite.db.GetFeaturedTag(ctx, account.ID, tag.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(featuredTag.ID, dbFeaturedTag.ID)
+	suite.Equal(tag.Name, dbFeaturedTag.Tag.Name)
+	suite.Equal(account.ID, dbFeaturedTag.Account.ID)
+
+	// Get by account.
+	featuredTags, err := suite.db.GetFeaturedTagsByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(featuredTags, 1) {
+		suite.Equal(featuredTag.ID, featuredTags[0].ID)
+	}
+
+	// Check stats.
+	count, lastStatusAt, err := suite.db.GetFeaturedTagStatusStats(ctx, dbFeaturedTag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, count)
+	suite.True(suite.testStatuses["admin_account_status_1"].CreatedAt.Equal(lastStatusAt))
+
+	// Now featured, the tag should no longer be suggested.
+	tagIDs, err = suite.db.GetFeaturedTagSuggestionIDs(ctx, account.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(tagIDs)
+
+	// Delete it.
+	if err := suite.db.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetFeaturedTagByID(ctx, featuredTag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	featuredTags, err = suite.db.GetFeaturedTagsByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(featuredTags)
+}
+
+func TestFeaturedTagTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagTestSuite))
+}
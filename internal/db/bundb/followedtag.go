@@ -0,0 +1,369 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type followedTagDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (f *followedTagDB) GetFollowedTagByID(ctx context.Context, id string) (*gtsmodel.FollowedTag, error) {
+	return f.getFollowedTag(
+		ctx,
+		"ID",
+		func(followedTag *gtsmodel.FollowedTag) error {
+			return f.db.NewSelect().
+				Model(followedTag).
+				Where("? = ?", bun.Ident("id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (f *followedTagDB) GetFollowedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FollowedTag, error) {
+	return f.getFollowedTag(
+		ctx,
+		"AccountID,TagID",
+		func(followedTag *gtsmodel.FollowedTag) error {
+			return f.db.NewSelect().
+				Model(followedTag).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Where("? = ?", bun.Ident("tag_id"), tagID).
+				Scan(ctx)
+		},
+		accountID,
+		tagID,
+	)
+}
+
+func (f *followedTagDB) getFollowedTag(
+	ctx context.Context,
+	lookup string,
+	dbQuery func(*gtsmodel.FollowedTag) error,
+	keyParts ...any,
+) (*gtsmodel.FollowedTag, error) {
+	// Fetch followed tag from cache with loader callback.
+	followedTag, err := f.state.Caches.GTS.FollowedTag.LoadOne(lookup, func() (*gtsmodel.FollowedTag, error) {
+		var followedTag gtsmodel.FollowedTag
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&followedTag); err != nil {
+			return nil, err
+		}
+
+		return &followedTag, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return followedTag, nil
+	}
+
+	if err := f.PopulateFollowedTag(ctx, followedTag); err != nil {
+		return nil, err
+	}
+
+	return followedTag, nil
+}
+
+func (f *followedTagDB) GetFollowedTagsByAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		followedTagIDs = make([]string, 0, limit)
+	)
+
+	q := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("followed_tags"), bun.Ident("followed_tag")).
+		// Select only IDs from table.
+		Column("followed_tag.id").
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID)
+
+	// Return only followed tags
+	// with id lower than provided maxID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("followed_tag.id"), maxID)
+	}
+
+	// Return only followed tags
+	// with id greater than provided minID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("followed_tag.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// tags returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("followed_tag.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("followed_tag.id"))
+	}
+
+	if err := q.Scan(ctx, &followedTagIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no followed tags early
+	if len(followedTagIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want followed tags
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(followedTagIDs)
+	}
+
+	return f.getFollowedTagsByIDs(ctx, followedTagIDs)
+}
+
+func (f *followedTagDB) getFollowedTagsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.FollowedTag, error) {
+	// Load all followed tag IDs via cache loader callbacks.
+	followedTags, err := f.state.Caches.GTS.FollowedTag.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.FollowedTag, error) {
+			// Preallocate expected length of uncached followed tags.
+			followedTags := make([]*gtsmodel.FollowedTag, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := f.db.NewSelect().
+				Model(&followedTags).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return followedTags, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the followed tags by their
+	// IDs to ensure in correct order.
+	getID := func(t *gtsmodel.FollowedTag) string { return t.ID }
+	util.OrderBy(followedTags, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return followedTags, nil
+	}
+
+	// Populate all loaded followed tags, removing those we fail to
+	// populate (removes needing so many nil checks everywhere).
+	followedTags = slices.DeleteFunc(followedTags, func(followedTag *gtsmodel.FollowedTag) bool {
+		if err := f.PopulateFollowedTag(ctx, followedTag); err != nil {
+			log.Errorf(ctx, "error populating followed tag %s: %v", followedTag.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return followedTags, nil
+}
+
+func (f *followedTagDB) GetFollowedTagIDsByAccountID(ctx context.Context, accountID string) ([]string, error) {
+	return f.state.Caches.GTS.FollowedTagIDs.Load(">"+accountID, func() ([]string, error) {
+		var tagIDs []string
+
+		// Tag IDs not in cache, perform DB query.
+		if _, err := f.db.
+			NewSelect().
+			TableExpr("?", bun.Ident("followed_tags")).
+			ColumnExpr("?", bun.Ident("tag_id")).
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			OrderExpr("? ASC", bun.Ident("id")).
+			Exec(ctx, &tagIDs); // nocollapse
+		err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		return tagIDs, nil
+	})
+}
+
+func (f *followedTagDB) GetFollowedTagAccountIDs(ctx context.Context, tagID string) ([]string, error) {
+	return f.state.Caches.GTS.FollowedTagIDs.Load("<"+tagID, func() ([]string, error) {
+		var accountIDs []string
+
+		// Account IDs not in cache, perform DB query.
+		if _, err := f.db.
+			NewSelect().
+			TableExpr("?", bun.Ident("followed_tags")).
+			ColumnExpr("?", bun.Ident("account_id")).
+			Where("? = ?", bun.Ident("tag_id"), tagID).
+			OrderExpr("? ASC", bun.Ident("id")).
+			Exec(ctx, &accountIDs); // nocollapse
+		err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		return accountIDs, nil
+	})
+}
+
+func (f *followedTagDB) IsFollowingAnyTag(ctx context.Context, accountID string, tagIDs []string) (bool, error) {
+	if len(tagIDs) == 0 {
+		// Nothing to check.
+		return false, nil
+	}
+
+	followedTagIDs, err := f.GetFollowedTagIDsByAccountID(ctx, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, tagID := range tagIDs {
+		if slices.Contains(followedTagIDs, tagID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *followedTagDB) PopulateFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error {
+	var (
+		errs gtserror.MultiError
+		err  error
+	)
+
+	if followedTag.Account == nil {
+		// Following account is not set, fetch from database.
+		followedTag.Account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			followedTag.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating followed tag account: %w", err)
+		}
+	}
+
+	if followedTag.Tag == nil {
+		// Followed tag is not set, fetch from database.
+		followedTag.Tag, err = f.state.DB.GetTag(
+			ctx,
+			followedTag.TagID,
+		)
+		if err != nil {
+			errs.Appendf("error populating followed tag tag: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (f *followedTagDB) PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error {
+	return f.state.Caches.GTS.FollowedTag.Store(followedTag, func() error {
+		_, err := f.db.NewInsert().Model(followedTag).Exec(ctx)
+		return err
+	})
+}
+
+func (f *followedTagDB) DeleteFollowedTagByID(ctx context.Context, id string) error {
+	// Load followed tag into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	_, err := f.GetFollowedTagByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// Drop this now-cached followed tag on return after delete.
+	defer f.state.Caches.GTS.FollowedTag.Invalidate("ID", id)
+
+	// Finally delete followed tag from DB.
+	_, err = f.db.NewDelete().
+		Table("followed_tags").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (f *followedTagDB) DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error {
+	var followedTagIDs []string
+
+	// Select IDs of all followed tags of this account.
+	if err := f.db.
+		NewSelect().
+		Table("followed_tags").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &followedTagIDs); err != nil {
+		return err
+	}
+
+	// Load all followed tags into cache, this *really* isn't
+	// great but it is the only way we can ensure we invalidate
+	// all related caches correctly.
+	_, err := f.getFollowedTagsByIDs(
+		gtscontext.SetBarebones(ctx),
+		followedTagIDs,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate all account's followed tags on return.
+	defer f.state.Caches.GTS.FollowedTag.Invalidate("AccountID", accountID)
+
+	// Finally delete all from DB.
+	_, err = f.db.NewDelete().
+		Table("followed_tags").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
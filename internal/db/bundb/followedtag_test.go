@@ -0,0 +1,128 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type FollowedTagTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *FollowedTagTestSuite) TestPutGetDeleteFollowedTag() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_2"]
+		tag     = suite.testTags["welcome"]
+	)
+
+	following, err := suite.db.IsFollowingAnyTag(ctx, account.ID, []string{tag.ID})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(following)
+
+	followedTag := &gtsmodel.FollowedTag{
+		ID:        "01J1T6AW3D3M3ZT5D9XXKBZ2RE",
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	}
+	if err := suite.db.PutFollowedTag(ctx, followedTag); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Following the same tag again should fail.
+	err = suite.db.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        "01J1T6B4T5CV6GCN8J7WWNAF9S",
+		AccountID: account.ID,
+		TagID:     tag.ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	// Get by account + tag.
+	dbFollowedTag, err := suite.db.GetFollowedTag(ctx, account.ID, tag.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(followedTag.ID, dbFollowedTag.ID)
+	suite.Equal(tag.Name, dbFollowedTag.Tag.Name)
+	suite.Equal(account.ID, dbFollowedTag.Account.ID)
+
+	// Get by account.
+	followedTags, err := suite.db.GetFollowedTagsByAccountID(ctx, account.ID, &paging.Page{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(followedTags, 1) {
+		suite.Equal(followedTag.ID, followedTags[0].ID)
+	}
+
+	// Get followers of tag.
+	accountIDs, err := suite.db.GetFollowedTagAccountIDs(ctx, tag.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{account.ID}, accountIDs)
+
+	following, err = suite.db.IsFollowingAnyTag(ctx, account.ID, []string{
+		suite.testTags["Hashtag"].ID,
+		tag.ID,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(following)
+
+	// Delete it.
+	if err := suite.db.DeleteFollowedTagByID(ctx, followedTag.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetFollowedTagByID(ctx, followedTag.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	followedTags, err = suite.db.GetFollowedTagsByAccountID(ctx, account.ID, &paging.Page{})
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(followedTags)
+
+	accountIDs, err = suite.db.GetFollowedTagAccountIDs(ctx, tag.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(accountIDs)
+
+	following, err = suite.db.IsFollowingAnyTag(ctx, account.ID, []string{tag.ID})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(following)
+}
+
+func TestFollowedTagTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowedTagTestSuite))
+}
@@ -0,0 +1,221 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("invite.id"), id)
+	})
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("invite.code"), code)
+	})
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.Invite, error) {
+	var invite gtsmodel.Invite
+
+	q := i.db.
+		NewSelect().
+		Model(&invite)
+
+	if err := where(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &invite, nil
+	}
+
+	if err := i.PopulateInvite(ctx, &invite); err != nil {
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+func (i *inviteDB) GetInvitesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Invite, error) {
+	var inviteIDs []string
+
+	if err := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+		// Select only IDs from table.
+		Column("invite.id").
+		Where("? = ?", bun.Ident("invite.account_id"), accountID).
+		// Newest invites first.
+		OrderExpr("? DESC", bun.Ident("invite.id")).
+		Scan(ctx, &inviteIDs); err != nil {
+		return nil, err
+	}
+
+	// Allocate return slice (will be at most len inviteIDs).
+	invites := make([]*gtsmodel.Invite, 0, len(inviteIDs))
+	for _, id := range inviteIDs {
+		invite, err := i.GetInviteByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Deleted since
+				// selecting IDs.
+				continue
+			}
+			return nil, err
+		}
+
+		invites = append(invites, invite)
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	var err error
+
+	if invite.Account == nil {
+		// Invite account is not set, fetch from database.
+		invite.Account, err = i.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			invite.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating invite account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.
+		NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(invite).
+		Column(columns...).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	invite.UpdatedAt = time.Now()
+
+	q := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), invite.UpdatedAt).
+		Where("? = ?", bun.Ident("id"), invite.ID)
+
+	if invite.MaxUses > 0 {
+		// Only update if there are uses remaining, so that
+		// concurrent sign-ups can't exceed the max uses.
+		q = q.Where("? < ?", bun.Ident("uses"), invite.MaxUses)
+	}
+
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if ra == 0 {
+		// Invite was used up
+		// (or deleted) since
+		// it was fetched.
+		return db.ErrNoEntries
+	}
+
+	invite.Uses++
+	return nil
+}
+
+func (i *inviteDB) DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	invite.UpdatedAt = time.Now()
+
+	if _, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? - 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), invite.UpdatedAt).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		Where("? > 0", bun.Ident("uses")).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if invite.Uses > 0 {
+		invite.Uses--
+	}
+	return nil
+}
+
+func (i *inviteDB) ExpireInvitesByAccountID(ctx context.Context, accountID string) error {
+	now := time.Now()
+	_, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ?", bun.Ident("expires_at"), now).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("expires_at")).
+				WhereOr("? > ?", bun.Ident("expires_at"), now)
+		}).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestGetInvites() {
+	ctx := context.Background()
+	account := suite.testAccounts["admin_account"]
+
+	invite, err := suite.db.GetInviteByCode(ctx, "k7w3NqZp")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(account.ID, invite.AccountID)
+	suite.NotNil(invite.Account)
+	suite.True(invite.Usable(time.Now()))
+
+	invites, err := suite.db.GetInvitesByAccountID(ctx, account.ID)
+	suite.NoError(err)
+	if suite.Len(invites, 1) {
+		suite.Equal(invite.ID, invites[0].ID)
+	}
+
+	// Fixture invite of local_account_1 has expired.
+	invite, err = suite.db.GetInviteByCode(ctx, "Xr2b8LmT")
+	suite.NoError(err)
+	suite.False(invite.Usable(time.Now()))
+
+	_, err = suite.db.GetInviteByCode(ctx, "nonexistent")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *InviteTestSuite) TestIncrementInviteUses() {
+	ctx := context.Background()
+
+	invite := &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		Code:      "abcd2345",
+		AccountID: suite.testAccounts["local_account_1"].ID,
+		MaxUses:   2,
+	}
+	if err := suite.db.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Two uses should be allowed...
+	suite.NoError(suite.db.IncrementInviteUses(ctx, invite))
+	suite.NoError(suite.db.IncrementInviteUses(ctx, invite))
+	suite.Equal(2, invite.Uses)
+
+	// ...but not a third, even with a
+	// stale copy of the invite model.
+	invite.Uses = 0
+	err := suite.db.IncrementInviteUses(ctx, invite)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, dbInvite.Uses)
+	suite.True(dbInvite.UsedUp())
+
+	// A use given back can be used again.
+	suite.NoError(suite.db.DecrementInviteUses(ctx, dbInvite))
+	suite.Equal(1, dbInvite.Uses)
+	suite.NoError(suite.db.IncrementInviteUses(ctx, dbInvite))
+	suite.Equal(2, dbInvite.Uses)
+}
+
+func (suite *InviteTestSuite) TestExpireInvitesByAccountID() {
+	ctx := context.Background()
+	account := suite.testAccounts["admin_account"]
+
+	if err := suite.db.ExpireInvitesByAccountID(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	invites, err := suite.db.GetInvitesByAccountID(ctx, account.ID)
+	suite.NoError(err)
+	if suite.Len(invites, 1) {
+		suite.False(invites[0].Usable(time.Now()))
+	}
+
+	// Already-expired invites keep their expiry time.
+	expired := testrig.NewTestInvites()["local_account_1_invite_expired"]
+	if err := suite.db.ExpireInvitesByAccountID(ctx, expired.AccountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	invite, err := suite.db.GetInviteByID(ctx, expired.ID)
+	suite.NoError(err)
+	suite.Equal(expired.ExpiresAt.Unix(), invite.ExpiresAt.Unix())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create the invites table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.Invite{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add invite_id column to users.
+			exists, err := doesColumnExist(ctx, tx, "users", "invite_id")
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				if _, err := tx.
+					NewAddColumn().
+					Table("users").
+					ColumnExpr("? CHAR(26)", bun.Ident("invite_id")).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Index invites by creating account, and users by invite
+			// used, so we can look up who was invited by whom.
+			for table, index := range map[string]struct {
+				name   string
+				column string
+			}{
+				"invites": {"invites_account_id_idx", "account_id"},
+				"users":   {"users_invite_id_idx", "invite_id"},
+			} {
+				if _, err := tx.
+					NewCreateIndex().
+					Table(table).
+					Index(index.name).
+					Column(index.column).
+					IfNotExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
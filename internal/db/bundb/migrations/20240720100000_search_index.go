@@ -0,0 +1,374 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		var stmts []string
+		switch db.Dialect().Name() {
+		case dialect.SQLite:
+			stmts = searchIndexSQLite
+		case dialect.PG:
+			stmts = searchIndexPG
+		default:
+			panic("db conn was neither pg not sqlite")
+		}
+
+		// Create the search index tables,
+		// plus any indexes and triggers.
+		if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Index existing statuses and accounts. Each batch
+		// is inserted in its own transaction, and inserts are
+		// idempotent, so an interrupted backfill is resumed
+		// from where it left off next time migrations run.
+		log.Info(ctx, "indexing statuses and accounts for search, this may take a while, please don't interrupt it!")
+		if err := backfillStatusSearch(ctx, db); err != nil {
+			return err
+		}
+		return backfillAccountSearch(ctx, db)
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Dropping the tables also drops
+			// any indexes and triggers on them.
+			for _, table := range []string{
+				"status_search_fts",
+				"status_search",
+				"account_search_fts",
+				"account_search",
+			} {
+				if _, err := tx.
+					NewDropTable().
+					Table(table).
+					IfExists().
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// searchIndexSQLite creates FTS5 tables indexing the
+// text stored in status_search and account_search,
+// kept in sync with them by triggers.
+var searchIndexSQLite = []string{
+	`CREATE TABLE IF NOT EXISTS "status_search" (
+		"id" INTEGER PRIMARY KEY,
+		"status_id" CHAR(26) NOT NULL UNIQUE,
+		"text" TEXT NOT NULL
+	)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "status_search_fts" USING fts5(
+		"text",
+		content='status_search',
+		content_rowid='id',
+		tokenize='unicode61 remove_diacritics 2'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_ai" AFTER INSERT ON "status_search" BEGIN
+		INSERT INTO "status_search_fts" ("rowid", "text") VALUES (new."id", new."text");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_ad" AFTER DELETE ON "status_search" BEGIN
+		INSERT INTO "status_search_fts" ("status_search_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_au" AFTER UPDATE ON "status_search" BEGIN
+		INSERT INTO "status_search_fts" ("status_search_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+		INSERT INTO "status_search_fts" ("rowid", "text") VALUES (new."id", new."text");
+	END`,
+	`CREATE TABLE IF NOT EXISTS "account_search" (
+		"id" INTEGER PRIMARY KEY,
+		"account_id" CHAR(26) NOT NULL UNIQUE,
+		"name" TEXT NOT NULL,
+		"note" TEXT NOT NULL
+	)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "account_search_fts" USING fts5(
+		"name",
+		"note",
+		content='account_search',
+		content_rowid='id',
+		tokenize='unicode61 remove_diacritics 2'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_ai" AFTER INSERT ON "account_search" BEGIN
+		INSERT INTO "account_search_fts" ("rowid", "name", "note") VALUES (new."id", new."name", new."note");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_ad" AFTER DELETE ON "account_search" BEGIN
+		INSERT INTO "account_search_fts" ("account_search_fts", "rowid", "name", "note") VALUES ('delete', old."id", old."name", old."note");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_au" AFTER UPDATE ON "account_search" BEGIN
+		INSERT INTO "account_search_fts" ("account_search_fts", "rowid", "name", "note") VALUES ('delete', old."id", old."name", old."note");
+		INSERT INTO "account_search_fts" ("rowid", "name", "note") VALUES (new."id", new."name", new."note");
+	END`,
+}
+
+// searchIndexPG creates tables with generated tsvector
+// columns, indexed with GIN. Status text is indexed both
+// with the "simple" configuration, and (if different) the
+// configuration for the language of the status, so that
+// searches can match either exact words, or stemmed words.
+var searchIndexPG = []string{
+	`CREATE TABLE IF NOT EXISTS "status_search" (
+		"status_id" CHAR(26) PRIMARY KEY,
+		"config" REGCONFIG NOT NULL DEFAULT 'simple',
+		"text" TEXT NOT NULL,
+		"vector" TSVECTOR GENERATED ALWAYS AS (
+			CASE WHEN "config" = 'simple'::regconfig
+			THEN to_tsvector('simple'::regconfig, "text")
+			ELSE to_tsvector("config", "text") || to_tsvector('simple'::regconfig, "text")
+			END
+		) STORED
+	)`,
+	`CREATE INDEX IF NOT EXISTS "status_search_vector_idx" ON "status_search" USING GIN ("vector")`,
+	`CREATE TABLE IF NOT EXISTS "account_search" (
+		"account_id" CHAR(26) PRIMARY KEY,
+		"name" TEXT NOT NULL,
+		"note" TEXT NOT NULL,
+		"name_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, "name")) STORED,
+		"note_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, "note")) STORED
+	)`,
+	`CREATE INDEX IF NOT EXISTS "account_search_name_vector_idx" ON "account_search" USING GIN ("name_vector")`,
+	`CREATE INDEX IF NOT EXISTS "account_search_note_vector_idx" ON "account_search" USING GIN ("note_vector")`,
+}
+
+// searchIndexConfigs is a copy of the language to
+// Postgres text search configuration mapping used
+// by bundb at the time of this migration.
+var searchIndexConfigs = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nb": "norwegian",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// searchIndexBatchSize is the number of statuses
+// or accounts indexed per backfill transaction.
+const searchIndexBatchSize = 1000
+
+// searchIndexResumeID returns the highest ID already present
+// in the given column of the given search table, allowing a
+// previously interrupted backfill to pick up where it stopped.
+func searchIndexResumeID(ctx context.Context, db *bun.DB, table string, column string) (string, error) {
+	var maxID string
+	if err := db.
+		NewSelect().
+		Table(table).
+		ColumnExpr("COALESCE(MAX(?), '')", bun.Ident(column)).
+		Scan(ctx, &maxID); err != nil {
+		return "", err
+	}
+	return maxID, nil
+}
+
+// backfillStatusSearch indexes all existing statuses,
+// paging through them in batches ordered by ID.
+func backfillStatusSearch(ctx context.Context, db *bun.DB) error {
+	isPG := db.Dialect().Name() == dialect.PG
+
+	maxID, err := searchIndexResumeID(ctx, db, "status_search", "status_id")
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for {
+		var statuses []struct {
+			ID             string `bun:"id"`
+			Content        string `bun:"content"`
+			ContentWarning string `bun:"content_warning"`
+			Language       string `bun:"language"`
+		}
+
+		// Select next batch of statuses, skipping boosts.
+		if err := db.
+			NewSelect().
+			Table("statuses").
+			ColumnExpr("?, COALESCE(?, '') AS ?, COALESCE(?, '') AS ?, COALESCE(?, '') AS ?",
+				bun.Ident("id"),
+				bun.Ident("content"), bun.Ident("content"),
+				bun.Ident("content_warning"), bun.Ident("content_warning"),
+				bun.Ident("language"), bun.Ident("language"),
+			).
+			Where("? > ?", bun.Ident("id"), maxID).
+			Where("? IS NULL", bun.Ident("boost_of_id")).
+			Order("id ASC").
+			Limit(searchIndexBatchSize).
+			Scan(ctx, &statuses); err != nil {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		entries := make([]map[string]interface{}, 0, len(statuses))
+		for _, status := range statuses {
+			statusText := strings.TrimSpace(
+				text.SanitizeToSearchText(status.ContentWarning) + " " +
+					text.SanitizeToSearchText(status.Content),
+			)
+			if statusText == "" {
+				continue
+			}
+
+			entry := map[string]interface{}{
+				"status_id": status.ID,
+				"text":      statusText,
+			}
+
+			if isPG {
+				// All entries in a bulk insert must
+				// have the same keys, so always set
+				// the config, falling back to default.
+				entry["config"] = "simple"
+				lang, _, _ := strings.Cut(status.Language, "-")
+				if config, ok := searchIndexConfigs[strings.ToLower(lang)]; ok {
+					entry["config"] = config
+				}
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if err := insertSearchEntries(ctx, db, "status_search", entries); err != nil {
+			return err
+		}
+
+		count += len(statuses)
+		maxID = statuses[len(statuses)-1].ID
+		log.Infof(ctx, "indexed %d statuses", count)
+	}
+
+	return nil
+}
+
+// backfillAccountSearch indexes all existing accounts,
+// paging through them in batches ordered by ID.
+func backfillAccountSearch(ctx context.Context, db *bun.DB) error {
+	maxID, err := searchIndexResumeID(ctx, db, "account_search", "account_id")
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for {
+		var accounts []struct {
+			ID          string `bun:"id"`
+			Username    string `bun:"username"`
+			DisplayName string `bun:"display_name"`
+			Note        string `bun:"note"`
+		}
+
+		// Select next batch of accounts.
+		if err := db.
+			NewSelect().
+			Table("accounts").
+			ColumnExpr("?, ?, COALESCE(?, '') AS ?, COALESCE(?, '') AS ?",
+				bun.Ident("id"),
+				bun.Ident("username"),
+				bun.Ident("display_name"), bun.Ident("display_name"),
+				bun.Ident("note"), bun.Ident("note"),
+			).
+			Where("? > ?", bun.Ident("id"), maxID).
+			Order("id ASC").
+			Limit(searchIndexBatchSize).
+			Scan(ctx, &accounts); err != nil {
+			return err
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		entries := make([]map[string]interface{}, 0, len(accounts))
+		for _, account := range accounts {
+			entries = append(entries, map[string]interface{}{
+				"account_id": account.ID,
+				"name":       strings.TrimSpace(account.Username + " " + account.DisplayName),
+				"note":       text.SanitizeToSearchText(account.Note),
+			})
+		}
+
+		if err := insertSearchEntries(ctx, db, "account_search", entries); err != nil {
+			return err
+		}
+
+		count += len(accounts)
+		maxID = accounts[len(accounts)-1].ID
+		log.Infof(ctx, "indexed %d accounts", count)
+	}
+
+	return nil
+}
+
+// insertSearchEntries inserts one batch of
+// entries into the given search table, in
+// its own transaction, ignoring conflicts.
+func insertSearchEntries(ctx context.Context, db *bun.DB, table string, entries []map[string]interface{}) error {
+	if len(entries) == 0 {
+		// Nothing to do
+		// in this batch.
+		return nil
+	}
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.
+			NewInsert().
+			Model(&entries).
+			Table(table).
+			On("CONFLICT DO NOTHING").
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Array columns are stored as
+			// VARCHAR on SQLite, as it has
+			// no native array type.
+			var arrayType string
+			switch tx.Dialect().Name() {
+			case dialect.SQLite:
+				arrayType = "VARCHAR"
+			case dialect.PG:
+				arrayType = "VARCHAR ARRAY"
+			default:
+				panic("db conn was neither pg not sqlite")
+			}
+
+			// Add the new two-factor
+			// columns to users.
+			for _, column := range []struct {
+				name string
+				typ  string
+			}{
+				{"two_factor_secret", "VARCHAR"},
+				{"two_factor_backups", arrayType},
+				{"two_factor_enabled_at", "TIMESTAMPTZ"},
+				{"two_factor_last_step", "BIGINT"},
+			} {
+				exists, err := doesColumnExist(ctx, tx, "users", column.name)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("users").
+					ColumnExpr("? "+column.typ, bun.Ident(column.name)).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Interaction policies are stored as
+			// JSON-encoded VARCHAR on both dialects.
+			for _, column := range []struct {
+				table string
+				name  string
+				typ   string
+			}{
+				{"statuses", "interaction_policy", "VARCHAR"},
+				{"statuses", "pending_approval", "BOOLEAN DEFAULT false"},
+				{"scheduled_statuses", "interaction_policy", "VARCHAR"},
+				{"account_settings", "interaction_policy_public", "VARCHAR"},
+				{"account_settings", "interaction_policy_unlisted", "VARCHAR"},
+				{"account_settings", "interaction_policy_followers_only", "VARCHAR"},
+				{"account_settings", "interaction_policy_direct", "VARCHAR"},
+			} {
+				exists, err := doesColumnExist(ctx, tx, column.table, column.name)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table(column.table).
+					ColumnExpr("? "+column.typ, bun.Ident(column.name)).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
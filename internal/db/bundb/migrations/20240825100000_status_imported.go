@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, c := range []struct {
+				name string
+				typ  string
+			}{
+				{"imported", "BOOLEAN NOT NULL DEFAULT false"},
+				{"imported_uri", "VARCHAR"},
+			} {
+				exists, err := doesColumnExist(ctx, tx, "statuses", c.name)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("statuses").
+					ColumnExpr("? "+c.typ, bun.Ident(c.name)).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Imported statuses are looked up
+			// by their URI in the archive when
+			// importing, to skip those already done.
+			_, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_account_id_imported_uri_idx").
+				Column("account_id", "imported_uri").
+				IfNotExists().
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,163 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type moderationPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (m *moderationPolicyDB) GetModerationPolicyByID(ctx context.Context, id string) (*gtsmodel.ModerationPolicy, error) {
+	return m.getModerationPolicy(ctx, "id", id)
+}
+
+func (m *moderationPolicyDB) GetModerationPolicyByDomain(ctx context.Context, domain string) (*gtsmodel.ModerationPolicy, error) {
+	// Normalize the domain as punycode.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	return m.getModerationPolicy(ctx, "domain", domain)
+}
+
+func (m *moderationPolicyDB) GetModerationPolicyByAccountID(ctx context.Context, accountID string) (*gtsmodel.ModerationPolicy, error) {
+	return m.getModerationPolicy(ctx, "account_id", accountID)
+}
+
+func (m *moderationPolicyDB) getModerationPolicy(ctx context.Context, column string, value any) (*gtsmodel.ModerationPolicy, error) {
+	var policy gtsmodel.ModerationPolicy
+
+	q := m.db.
+		NewSelect().
+		Model(&policy).
+		Where("? = ?", bun.Ident("moderation_policy."+column), value)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (m *moderationPolicyDB) GetModerationPolicies(ctx context.Context) ([]*gtsmodel.ModerationPolicy, error) {
+	policies := []*gtsmodel.ModerationPolicy{}
+
+	if err := m.db.
+		NewSelect().
+		Model(&policies).
+		OrderExpr("? ASC", bun.Ident("moderation_policy.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (m *moderationPolicyDB) GetModerationPoliciesForAccount(ctx context.Context, account *gtsmodel.Account) (gtsmodel.ModerationPolicies, error) {
+	policies := []*gtsmodel.ModerationPolicy{}
+
+	q := m.db.
+		NewSelect().
+		Model(&policies)
+
+	if account.IsLocal() {
+		// Local accounts can only
+		// be covered by account policies.
+		q = q.Where("? = ?", bun.Ident("moderation_policy.account_id"), account.ID)
+	} else {
+		// Normalize the domain as punycode.
+		domain, err := util.Punify(account.Domain)
+		if err != nil {
+			return nil, gtserror.Newf("error punifying domain %s: %w", account.Domain, err)
+		}
+
+		// Gather the account domain and each of
+		// its parent domains, eg. for 'a.b.example.org'
+		// this gives 'a.b.example.org', 'b.example.org',
+		// 'example.org' and 'org'.
+		domains := []string{domain}
+		for {
+			i := strings.IndexByte(domain, '.')
+			if i == -1 {
+				break
+			}
+			domain = domain[i+1:]
+			domains = append(domains, domain)
+		}
+
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("moderation_policy.account_id"), account.ID).
+				WhereOr("? IN (?)", bun.Ident("moderation_policy.domain"), bun.In(domains))
+		})
+	}
+
+	if err := q.
+		OrderExpr("? ASC", bun.Ident("moderation_policy.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (m *moderationPolicyDB) PutModerationPolicy(ctx context.Context, policy *gtsmodel.ModerationPolicy) error {
+	_, err := m.db.
+		NewInsert().
+		Model(policy).
+		Exec(ctx)
+	return err
+}
+
+func (m *moderationPolicyDB) UpdateModerationPolicy(ctx context.Context, policy *gtsmodel.ModerationPolicy, columns ...string) error {
+	// Update the policy's last-updated
+	policy.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := m.db.
+		NewUpdate().
+		Model(policy).
+		Column(columns...).
+		Where("? = ?", bun.Ident("moderation_policy.id"), policy.ID).
+		Exec(ctx)
+	return err
+}
+
+func (m *moderationPolicyDB) DeleteModerationPolicyByID(ctx context.Context, id string) error {
+	_, err := m.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("moderation_policies"), bun.Ident("moderation_policy")).
+		Where("? = ?", bun.Ident("moderation_policy.id"), id).
+		Exec(ctx)
+	return err
+}
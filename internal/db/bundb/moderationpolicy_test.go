@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ModerationPolicyTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ModerationPolicyTestSuite) putPolicy(id string, domain string, accountID string) {
+	if err := suite.db.PutModerationPolicy(context.Background(), &gtsmodel.ModerationPolicy{
+		ID:                   id,
+		Domain:               domain,
+		AccountID:            accountID,
+		ForceSensitive:       util.Ptr(false),
+		RejectMedia:          util.Ptr(false),
+		StripPublicTimelines: util.Ptr(false),
+		CreatedByAccountID:   suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *ModerationPolicyTestSuite) TestGetModerationPoliciesForAccount() {
+	ctx := context.Background()
+
+	// Pretend the account is on
+	// a subdomain of its instance.
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["remote_account_1"]
+	account.Domain = "social.fossbros-anonymous.io"
+
+	suite.putPolicy("01J5BCY2W6CVHN2S4FWFN5R3T5", "fossbros-anonymous.io", "")
+	suite.putPolicy("01J5BCY9H0VWF24CNJ2Z9TDQYY", "", account.ID)
+
+	// Policies on other domains/accounts shouldn't be returned.
+	suite.putPolicy("01J5BCYFQ2D4MR1FJ9GJ4X0CZ6", "anonymous.io", "")
+	suite.putPolicy("01J5BCYP5AKRWEQ0QDB1G3KQ0C", "sub.social.fossbros-anonymous.io", "")
+	suite.putPolicy("01J5BCYW0W9QKZ1S1HGY8MNNHD", "", suite.testAccounts["remote_account_2"].ID)
+
+	policies, err := suite.db.GetModerationPoliciesForAccount(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(policies, 2) {
+		suite.Equal("fossbros-anonymous.io", policies[0].Domain)
+		suite.Equal(account.ID, policies[1].AccountID)
+	}
+}
+
+func (suite *ModerationPolicyTestSuite) TestGetModerationPoliciesForLocalAccount() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	suite.putPolicy("01J5BCZ6TQ9ZKZ7C5Q0V7J0J7S", "localhost", "")
+
+	// Local accounts are never covered by domain policies.
+	policies, err := suite.db.GetModerationPoliciesForAccount(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(policies)
+
+	suite.putPolicy("01J5BCZDN2MG8V0PZ1WJ5MRNP4", "", account.ID)
+
+	policies, err = suite.db.GetModerationPoliciesForAccount(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(policies, 1)
+}
+
+func TestModerationPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationPolicyTestSuite))
+}
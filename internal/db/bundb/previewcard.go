@@ -0,0 +1,179 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type previewCardDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (p *previewCardDB) GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, error) {
+	return p.getPreviewCard(
+		ctx,
+		"ID",
+		func(card *gtsmodel.PreviewCard) error {
+			return p.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("preview_card.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (p *previewCardDB) GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, error) {
+	return p.getPreviewCard(
+		ctx,
+		"URL",
+		func(card *gtsmodel.PreviewCard) error {
+			return p.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("preview_card.url"), url).
+				Scan(ctx)
+		},
+		url,
+	)
+}
+
+func (p *previewCardDB) GetPreviewCardByImageID(ctx context.Context, imageID string) (*gtsmodel.PreviewCard, error) {
+	return p.getPreviewCard(
+		ctx,
+		"ImageID",
+		func(card *gtsmodel.PreviewCard) error {
+			return p.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("preview_card.image_id"), imageID).
+				Scan(ctx)
+		},
+		imageID,
+	)
+}
+
+func (p *previewCardDB) getPreviewCard(ctx context.Context, lookup string, dbQuery func(*gtsmodel.PreviewCard) error, keyParts ...any) (*gtsmodel.PreviewCard, error) {
+	// Fetch preview card from database cache with loader callback
+	card, err := p.state.Caches.GTS.PreviewCard.LoadOne(lookup, func() (*gtsmodel.PreviewCard, error) {
+		var card gtsmodel.PreviewCard
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&card); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return card, nil
+	}
+
+	// Further populate the preview card fields where applicable.
+	if err := p.PopulatePreviewCard(ctx, card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (p *previewCardDB) PopulatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if card.ImageID != "" && card.Image == nil {
+		// Thumbnail is not set, fetch from database.
+		card.Image, err = p.state.DB.GetAttachmentByID(
+			ctx, // these are already barebones
+			card.ImageID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating preview card image: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (p *previewCardDB) PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	return p.state.Caches.GTS.PreviewCard.Store(card, func() error {
+		_, err := p.db.NewInsert().Model(card).Exec(ctx)
+		return err
+	})
+}
+
+func (p *previewCardDB) UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, cols ...string) error {
+	card.UpdatedAt = time.Now()
+	if len(cols) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		cols = append(cols, "updated_at")
+	}
+
+	return p.state.Caches.GTS.PreviewCard.Store(card, func() error {
+		_, err := p.db.NewUpdate().
+			Model(card).
+			Column(cols...).
+			Where("? = ?", bun.Ident("preview_card.id"), card.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (p *previewCardDB) DeletePreviewCardByID(ctx context.Context, id string) error {
+	// Load card into cache before attempting a delete,
+	// as we need it cached in order to trigger the invalidate
+	// callback. This in turn invalidates others.
+	card, err := p.GetPreviewCardByID(
+		gtscontext.SetBarebones(ctx),
+		id,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// not an issue.
+			err = nil
+		}
+		return err
+	}
+
+	// On return ensure card invalidated.
+	defer p.state.Caches.GTS.PreviewCard.Invalidate("ID", id)
+
+	// Delete the preview card from the database.
+	_, err = p.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("preview_cards"), bun.Ident("preview_card")).
+		Where("? = ?", bun.Ident("preview_card.id"), card.ID).
+		Exec(ctx)
+	return err
+}
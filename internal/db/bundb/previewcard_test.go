@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type PreviewCardTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *PreviewCardTestSuite) TestPutGetDeletePreviewCard() {
+	var (
+		ctx    = context.Background()
+		image  = suite.testAttachments["admin_account_status_1_attachment_1"]
+		status = suite.testStatuses["local_account_1_status_1"]
+	)
+
+	card := &gtsmodel.PreviewCard{
+		ID:           "01J1EJQ2T3N1PVK6TW6E5MKE6Q",
+		URL:          "https://example.org/some/article",
+		Title:        "Some article",
+		Description:  "An article about something.",
+		Type:         gtsmodel.PreviewCardTypeLink,
+		ProviderName: "example.org",
+		ProviderURL:  "https://example.org",
+		ImageID:      image.ID,
+	}
+	if err := suite.db.PutPreviewCard(ctx, card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Another card for the same URL should fail.
+	err := suite.db.PutPreviewCard(ctx, &gtsmodel.PreviewCard{
+		ID:   "01J1EJQDKQ8Y0ZC6AK3X1GJ5NR",
+		URL:  card.URL,
+		Type: gtsmodel.PreviewCardTypeLink,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	// Get by URL, image should be populated.
+	dbCard, err := suite.db.GetPreviewCardByURL(ctx, card.URL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(card.ID, dbCard.ID)
+	suite.Equal(image.ID, dbCard.Image.ID)
+
+	// Get by image ID.
+	dbCard, err = suite.db.GetPreviewCardByImageID(ctx, image.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(card.ID, dbCard.ID)
+
+	// Attach the card to a status, it should
+	// be populated when fetching the status.
+	status.PreviewCardID = card.ID
+	if err := suite.db.UpdateStatus(ctx, status, "preview_card_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(card.ID, dbStatus.PreviewCard.ID)
+
+	// Update the card.
+	card.Title = "Some updated article"
+	if err := suite.db.UpdatePreviewCard(ctx, card, "title"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbCard, err = suite.db.GetPreviewCardByID(ctx, card.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Some updated article", dbCard.Title)
+
+	// Delete the card.
+	if err := suite.db.DeletePreviewCardByID(ctx, card.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetPreviewCardByID(ctx, card.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Status should still be gettable
+	// despite its card being gone.
+	dbStatus, err = suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Nil(dbStatus.PreviewCard)
+}
+
+func TestPreviewCardTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewCardTestSuite))
+}
@@ -0,0 +1,292 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	return s.getScheduledStatus(
+		ctx,
+		"ID",
+		func(status *gtsmodel.ScheduledStatus) error {
+			return s.db.NewSelect().
+				Model(status).
+				Where("? = ?", bun.Ident("scheduled_status.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (s *scheduledStatusDB) getScheduledStatus(ctx context.Context, lookup string, dbQuery func(*gtsmodel.ScheduledStatus) error, keyParts ...any) (*gtsmodel.ScheduledStatus, error) {
+	// Fetch scheduled status from database cache with loader callback
+	status, err := s.state.Caches.GTS.ScheduledStatus.LoadOne(lookup, func() (*gtsmodel.ScheduledStatus, error) {
+		var status gtsmodel.ScheduledStatus
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&status); err != nil {
+			return nil, err
+		}
+
+		return &status, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return status, nil
+	}
+
+	// Further populate the scheduled status fields where applicable.
+	if err := s.PopulateScheduledStatus(ctx, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesForAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("scheduled_statuses"), bun.Ident("scheduled_status")).
+		// Select only IDs from table.
+		Column("scheduled_status.id").
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	// Return only scheduled statuses
+	// with id lower than provided maxID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("scheduled_status.id"), maxID)
+	}
+
+	// Return only scheduled statuses
+	// with id greater than provided minID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("scheduled_status.id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// statuses returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("scheduled_status.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("scheduled_status.id"))
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no statuses early
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want statuses
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.getScheduledStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error) {
+	var statusIDs []string
+
+	// Select IDs of all scheduled statuses.
+	if err := s.db.
+		NewSelect().
+		Table("scheduled_statuses").
+		Column("id").
+		OrderExpr("? ASC", bun.Ident("scheduled_at")).
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	return s.getScheduledStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *scheduledStatusDB) getScheduledStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.ScheduledStatus, error) {
+	// Allocate return slice (will be at most len ids)
+	statuses := make([]*gtsmodel.ScheduledStatus, 0, len(ids))
+	for _, id := range ids {
+		status, err := s.GetScheduledStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting scheduled status %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s *scheduledStatusDB) CountScheduledStatusesForAccount(ctx context.Context, accountID string, start time.Time, end time.Time) (int, error) {
+	q := s.db.
+		NewSelect().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	if !start.IsZero() {
+		q = q.Where("? >= ?", bun.Ident("scheduled_at"), start)
+	}
+
+	if !end.IsZero() {
+		q = q.Where("? < ?", bun.Ident("scheduled_at"), end)
+	}
+
+	return q.Count(ctx)
+}
+
+func (s *scheduledStatusDB) PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if status.Account == nil {
+		// Scheduling account is not set, fetch from database.
+		status.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status account: %w", err)
+		}
+	}
+
+	if status.ApplicationID != "" && status.Application == nil {
+		// Scheduling application is not set, fetch from database.
+		status.Application, err = s.state.DB.GetApplicationByID(
+			gtscontext.SetBarebones(ctx),
+			status.ApplicationID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating scheduled status application: %w", err)
+		}
+	}
+
+	if !status.MediaAttachmentsPopulated() {
+		// Scheduled status media are out-of-date with IDs, repopulate.
+		status.MediaAttachments, err = s.state.DB.GetAttachmentsByIDs(
+			ctx, // these are already barebones
+			status.MediaIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status media: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	return s.state.Caches.GTS.ScheduledStatus.Store(status, func() error {
+		_, err := s.db.NewInsert().Model(status).Exec(ctx)
+		return err
+	})
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, cols ...string) error {
+	status.UpdatedAt = time.Now()
+	if len(cols) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		cols = append(cols, "updated_at")
+	}
+
+	return s.state.Caches.GTS.ScheduledStatus.Store(status, func() error {
+		_, err := s.db.NewUpdate().
+			Model(status).
+			Column(cols...).
+			Where("? = ?", bun.Ident("scheduled_status.id"), status.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	// Delete scheduled status by ID from database.
+	if _, err := s.db.NewDelete().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate scheduled status by ID from cache.
+	s.state.Caches.GTS.ScheduledStatus.Invalidate("ID", id)
+
+	return nil
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error {
+	// Delete all scheduled statuses of account from database.
+	if _, err := s.db.NewDelete().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate all scheduled statuses of account from cache.
+	s.state.Caches.GTS.ScheduledStatus.Invalidate("AccountID", accountID)
+
+	return nil
+}
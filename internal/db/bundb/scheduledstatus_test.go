@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutGetDeleteScheduledStatus() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		media   = suite.testAttachments["local_account_1_unattached_1"]
+		now     = time.Now()
+	)
+
+	scheduleds := []*gtsmodel.ScheduledStatus{
+		{
+			ID:            "01J1HQ2XWGZWRJ4GB6K7QVF0PS",
+			AccountID:     account.ID,
+			ScheduledAt:   now.Add(time.Hour),
+			Text:          "hello future",
+			Sensitive:     util.Ptr(false),
+			MediaIDs:      []string{media.ID},
+			ApplicationID: suite.testApplications["application_1"].ID,
+		},
+		{
+			ID:             "01J1HQ3A7S6J5DD1V3PAVKZ5ZQ",
+			AccountID:      account.ID,
+			ScheduledAt:    now.Add(48 * time.Hour),
+			Text:           "vote later",
+			Sensitive:      util.Ptr(true),
+			Visibility:     gtsmodel.VisibilityFollowersOnly,
+			PollOptions:    []string{"yes", "no"},
+			PollExpiresIn:  3600,
+			PollMultiple:   util.Ptr(false),
+			PollHideTotals: util.Ptr(true),
+		},
+	}
+
+	for _, scheduled := range scheduleds {
+		if err := suite.db.PutScheduledStatus(ctx, scheduled); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Get by ID, should be fully populated.
+	dbScheduled, err := suite.db.GetScheduledStatusByID(ctx, scheduleds[0].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(account.ID, dbScheduled.Account.ID)
+	suite.Equal(scheduleds[0].ApplicationID, dbScheduled.Application.ID)
+	suite.Len(dbScheduled.MediaAttachments, 1)
+	suite.Equal(media.ID, dbScheduled.MediaAttachments[0].ID)
+
+	dbScheduled, err = suite.db.GetScheduledStatusByID(ctx, scheduleds[1].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{"yes", "no"}, dbScheduled.PollOptions)
+	suite.True(*dbScheduled.PollHideTotals)
+
+	// Get page for account, newest first.
+	page, err := suite.db.GetScheduledStatusesForAccount(ctx, account.ID, &paging.Page{Limit: 10})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(page, 2)
+	suite.Equal(scheduleds[1].ID, page[0].ID)
+
+	// Count all for account, and within a time range.
+	count, err := suite.db.CountScheduledStatusesForAccount(ctx, account.ID, time.Time{}, time.Time{})
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	count, err = suite.db.CountScheduledStatusesForAccount(ctx, account.ID, now, now.Add(24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	// Get all, ordered by scheduled time.
+	all, err := suite.db.GetAllScheduledStatuses(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(all, 2)
+	suite.Equal(scheduleds[0].ID, all[0].ID)
+
+	// Reschedule the first status.
+	scheduleds[0].ScheduledAt = now.Add(72 * time.Hour)
+	if err := suite.db.UpdateScheduledStatus(ctx, scheduleds[0], "scheduled_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	all, err = suite.db.GetAllScheduledStatuses(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(scheduleds[1].ID, all[0].ID)
+
+	// Delete one by ID.
+	if err := suite.db.DeleteScheduledStatusByID(ctx, scheduleds[0].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, scheduleds[0].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Delete the rest by account.
+	if err := suite.db.DeleteScheduledStatusesByAccountID(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetScheduledStatusByID(ctx, scheduleds[1].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetScheduledStatusesForAccount(ctx, account.ID, nil)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
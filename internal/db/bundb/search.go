@@ -20,54 +20,55 @@ package bundb
 import (
 	"context"
 	"strings"
+	"unicode"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// searchDB implements full-text search of statuses and
+// accounts, using FTS5 virtual tables with SQLite, and
+// tsvector columns indexed with GIN with Postgres.
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
+// The searchable text of statuses and accounts is stored
+// in the status_search and account_search tables, which
+// are kept up to date as statuses and accounts are put,
+// updated and deleted (see searchindex.go).
 //
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Results of full-text searches are returned in ranked
+// order, best match first, so they can only be paged
+// through using offset: maxID and minID are ignored for
+// them. Results of searches that aren't ranked (eg.,
+// searches for only operators without terms, or usernames
+// starting with the query) are returned newest first, and
+// can be paged through with either offset, or maxID/minID.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
 }
 
-// Query example (SQLite):
+// Query example (SQLite), ranked by match and paged by offset:
+//
+//	SELECT "account"."id" FROM "accounts" AS "account"
+//	JOIN "account_search" ON ("account_search"."account_id" = "account"."id")
+//	JOIN "account_search_fts" ON ("account_search_fts"."rowid" = "account_search"."id")
+//	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
+//	AND ("account"."id" IN (SELECT "follow"."target_account_id" FROM "follows" AS "follow" WHERE ("follow"."account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
+//	AND ("account_search_fts" MATCH '"turtle"*')
+//	ORDER BY bm25("account_search_fts", 10.0, 1.0), "account"."id" DESC LIMIT 10 OFFSET 20
+//
+// Query example (SQLite), for usernames and paged by ID:
 //
 //	SELECT "account"."id" FROM "accounts" AS "account"
 //	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
+//	AND (("account"."username") LIKE 'turtle%' ESCAPE '\')
 //	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
 //	ORDER BY "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
@@ -88,6 +89,7 @@ func (s *searchDB) SearchForAccounts(
 	var (
 		accountIDs  = make([]string, 0, limit)
 		frontToBack = true
+		words       []string
 	)
 
 	q := s.db.
@@ -105,20 +107,6 @@ func (s *searchDB) SearchForAccounts(
 				WhereOr("? != ?", bun.Ident("account.domain"), bun.Ident("account.username"))
 		})
 
-	// Return only items with a LOWER id than maxID.
-	if maxID == "" {
-		maxID = id.Highest
-	}
-	q = q.Where("? < ?", bun.Ident("account.id"), maxID)
-
-	if minID != "" {
-		// Return only items with a HIGHER id than minID.
-		q = q.Where("? > ?", bun.Ident("account.id"), minID)
-
-		// page up
-		frontToBack = false
-	}
-
 	if following {
 		// Select only from accounts followed by accountID.
 		q = q.Where(
@@ -136,10 +124,35 @@ func (s *searchDB) SearchForAccounts(
 		q = whereStartsLike(q, bun.Ident("account.username"), query)
 	} else {
 		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
+		// Search for accounts with words in
+		// their name (or note, if following)
+		// starting with each word of query.
+		words = searchWords(query)
+		if len(words) == 0 {
+			return nil, nil
+		}
+
+		q = s.matchAccounts(q, words, following)
+	}
+
+	if len(words) == 0 {
+		// Results are only ordered by ID (and so
+		// may be paged by ID) when not ranked by
+		// words, else they're paged using offset.
+
+		// Return only items with a LOWER id than maxID.
+		if maxID == "" {
+			maxID = id.Highest
+		}
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+
+		if minID != "" {
+			// Return only items with a HIGHER id than minID.
+			q = q.Where("? > ?", bun.Ident("account.id"), minID)
+
+			// page up
+			frontToBack = false
+		}
 	}
 
 	if limit > 0 {
@@ -147,10 +160,20 @@ func (s *searchDB) SearchForAccounts(
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	if offset > 0 {
+		// Skip the given number of accounts.
+		q = q.Offset(offset)
+	}
+
+	switch {
+	case len(words) > 0:
+		// Best matches first,
+		// then newest first.
+		q = s.orderAccountsByRank(q, words)
+	case frontToBack:
 		// Page down.
 		q = q.Order("account.id DESC")
-	} else {
+	default:
 		// Page up.
 		q = q.Order("account.id ASC")
 	}
@@ -198,77 +221,110 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
-// accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
+// matchAccounts joins the account search index to the given
+// query, and selects only accounts with words starting with
+// each of the given words in their name, or in their name or
+// note if `following` is true.
+func (s *searchDB) matchAccounts(q *bun.SelectQuery, words []string, following bool) *bun.SelectQuery {
+	q = q.Join(
+		"JOIN ? ON ? = ?",
+		bun.Ident("account_search"),
+		bun.Ident("account_search.account_id"),
+		bun.Ident("account.id"),
 	)
 
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
+	switch d := s.db.Dialect().Name(); d {
+
+	case dialect.SQLite:
+		match := ftsPrefixQuery(words)
+		if !following {
+			// Restrict to name column.
+			match = "name : (" + match + ")"
 		}
-	}
 
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
+		return q.
+			Join(
+				"JOIN ? ON ? = ?",
+				bun.Ident("account_search_fts"),
+				bun.Ident("account_search_fts.rowid"),
+				bun.Ident("account_search.id"),
+			).
+			Where("? MATCH ?", bun.Ident("account_search_fts"), match)
 
-	switch d := s.db.Dialect().Name(); {
+	case dialect.PG:
+		tsquery := tsPrefixQuery(words)
+		return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			q = q.Where(
+				"? @@ to_tsquery('simple', ?)",
+				bun.Ident("account_search.name_vector"), tsquery,
+			)
+
+			if following {
+				q = q.WhereOr(
+					"? @@ to_tsquery('simple', ?)",
+					bun.Ident("account_search.note_vector"), tsquery,
+				)
+			}
+
+			return q
+		})
 
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
 
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
+// orderAccountsByRank orders the results of a query
+// built with matchAccounts by best match first, then
+// by newest first. Matches in name rank above note.
+func (s *searchDB) orderAccountsByRank(q *bun.SelectQuery, words []string) *bun.SelectQuery {
+	switch d := s.db.Dialect().Name(); d {
 
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
+	case dialect.SQLite:
+		// bm25 weights name matches 10x note matches,
+		// and returns better matches as lower values.
+		q = q.OrderExpr("bm25(?, 10.0, 1.0)", bun.Ident("account_search_fts"))
 
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
+	case dialect.PG:
+		// ts_rank returns better matches as higher
+		// values, and 0 if the vector didn't match.
+		tsquery := tsPrefixQuery(words)
+		q = q.
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC", bun.Ident("account_search.name_vector"), tsquery).
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC", bun.Ident("account_search.note_vector"), tsquery)
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
 	}
 
-	return accountText.ColumnExpr(query, args...)
+	return q.Order("account.id DESC")
 }
 
-// Query example (SQLite):
+// Query example (SQLite), ranked by match and paged by offset:
+//
+//	SELECT "status"."id"
+//	FROM "statuses" AS "status"
+//	JOIN "status_search" ON ("status_search"."status_id" = "status"."id")
+//	JOIN "status_search_fts" ON ("status_search_fts"."rowid" = "status_search"."id")
+//	WHERE ("status"."boost_of_id" IS NULL)
+//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	AND ("status_search_fts" MATCH '"hello" "big world"')
+//	ORDER BY "status_search_fts"."rank", "status"."id" DESC LIMIT 10 OFFSET 20
+//
+// Query example (SQLite), for operators only and paged by ID:
 //
 //	SELECT "status"."id"
 //	FROM "statuses" AS "status"
 //	WHERE ("status"."boost_of_id" IS NULL)
 //	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	AND ("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF')
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
 //	ORDER BY "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
-	query string,
-	fromAccountID string,
+	query *db.StatusSearchQuery,
 	maxID string,
 	minID string,
 	limit int,
@@ -283,6 +339,7 @@ func (s *searchDB) SearchForStatuses(
 	var (
 		statusIDs   = make([]string, 0, limit)
 		frontToBack = true
+		terms       = searchTerms(query.Terms)
 	)
 
 	q := s.db.
@@ -299,40 +356,74 @@ func (s *searchDB) SearchForStatuses(
 				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
 				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID)
 		})
-	if fromAccountID != "" {
-		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
+
+	if query.FromAccountID != "" {
+		// Select only statuses created by fromAccountID.
+		q = q.Where("? = ?", bun.Ident("status.account_id"), query.FromAccountID)
 	}
 
-	// Return only items with a LOWER id than maxID.
-	if maxID == "" {
-		maxID = id.Highest
+	if query.HasMedia {
+		// Select only statuses with attachments.
+		q = q.Where("EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+			Column("media_attachment.id").
+			Where("? = ?", bun.Ident("media_attachment.status_id"), bun.Ident("status.id")))
 	}
-	q = q.Where("? < ?", bun.Ident("status.id"), maxID)
 
-	if minID != "" {
-		// return only statuses HIGHER (ie., newer) than minID
-		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	if !query.Before.IsZero() {
+		// Select only statuses created before Before.
+		q = q.Where("? < ?", bun.Ident("status.created_at"), query.Before)
+	}
 
-		// page up
-		frontToBack = false
+	if !query.After.IsZero() {
+		// Select only statuses created after After.
+		q = q.Where("? > ?", bun.Ident("status.created_at"), query.After)
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
+	if len(terms) > 0 {
+		// Select only statuses
+		// matching all terms.
+		q = s.matchStatuses(q, terms, query.Language)
+	} else {
+		// Results are only ordered by ID (and so
+		// may be paged by ID) when not ranked by
+		// terms, else they're paged using offset.
 
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+		// Return only items with a LOWER id than maxID.
+		if maxID == "" {
+			maxID = id.Highest
+		}
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+
+		if minID != "" {
+			// return only statuses HIGHER (ie., newer) than minID
+			q = q.Where("? > ?", bun.Ident("status.id"), minID)
+
+			// page up
+			frontToBack = false
+		}
+	}
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	if offset > 0 {
+		// Skip the given number of statuses.
+		q = q.Offset(offset)
+	}
+
+	switch {
+	case len(terms) > 0:
+		// Best matches first,
+		// then newest first.
+		q = s.orderStatusesByRank(q, terms, query.Language)
+	case frontToBack:
 		// Page down.
 		q = q.Order("status.id DESC")
-	} else {
+	default:
 		// Page up.
 		q = q.Order("status.id ASC")
 	}
@@ -370,32 +461,154 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
+// matchStatuses joins the status search index to the
+// given query, and selects only statuses containing all
+// of the given terms. With Postgres, terms also match
+// other forms of the same words in the given language.
+func (s *searchDB) matchStatuses(q *bun.SelectQuery, terms []string, lang string) *bun.SelectQuery {
+	q = q.Join(
+		"JOIN ? ON ? = ?",
+		bun.Ident("status_search"),
+		bun.Ident("status_search.status_id"),
+		bun.Ident("status.id"),
+	)
 
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
 	switch d := s.db.Dialect().Name(); d {
 
 	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		return q.
+			Join(
+				"JOIN ? ON ? = ?",
+				bun.Ident("status_search_fts"),
+				bun.Ident("status_search_fts.rowid"),
+				bun.Ident("status_search.id"),
+			).
+			Where("? MATCH ?", bun.Ident("status_search_fts"), ftsPhraseQuery(terms))
 
 	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		return q.Where("? @@ (?)",
+			bun.Ident("status_search.vector"),
+			tsPhraseQuery(terms, searchConfig(lang)),
+		)
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
 	}
+}
+
+// orderStatusesByRank orders the results of a query
+// built with matchStatuses by best match first, then
+// by newest first.
+func (s *searchDB) orderStatusesByRank(q *bun.SelectQuery, terms []string, lang string) *bun.SelectQuery {
+	switch d := s.db.Dialect().Name(); d {
 
-	return statusText
+	case dialect.SQLite:
+		// rank is bm25, which returns
+		// better matches as lower values.
+		q = q.Order("status_search_fts.rank")
+
+	case dialect.PG:
+		// ts_rank_cd returns better matches as higher
+		// values, favouring terms close to each other.
+		q = q.OrderExpr("ts_rank_cd(?, (?)) DESC",
+			bun.Ident("status_search.vector"),
+			tsPhraseQuery(terms, searchConfig(lang)),
+		)
+
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+	}
+
+	return q.Order("status.id DESC")
+}
+
+// searchWords splits the given query into words, dropping
+// any that can't be searched for as they contain no letters
+// or numbers (eg., stray punctuation).
+func searchWords(query string) []string {
+	return searchTerms(strings.Fields(query))
+}
+
+// searchTerms returns only the given terms which can be
+// searched for, ie., that contain any letters or numbers.
+func searchTerms(terms []string) []string {
+	searchable := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if strings.IndexFunc(term, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsNumber(r)
+		}) >= 0 {
+			searchable = append(searchable, term)
+		}
+	}
+	return searchable
+}
+
+// ftsPrefixQuery returns an FTS5 query
+// matching text with words starting
+// with each of the given words.
+func ftsPrefixQuery(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = ftsQuote(word) + "*"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ftsPhraseQuery returns an FTS5 query
+// matching text containing each of the
+// given terms, as words or phrases.
+func ftsPhraseQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = ftsQuote(term)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// ftsQuote quotes the given term as an FTS5 string,
+// so any punctuation in it is treated as text, and
+// not as query syntax. FTS5 tokenizes the string,
+// so multiple words in it are matched as a phrase.
+func ftsQuote(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
+
+// tsPrefixQuery returns a Postgres tsquery
+// string matching text with words starting
+// with each of the given words.
+func tsPrefixQuery(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = tsQuote(word) + ":*"
+	}
+	return strings.Join(quoted, " & ")
+}
+
+// tsQuote quotes the given word
+// as a Postgres tsquery lexeme.
+func tsQuote(word string) string {
+	word = strings.ReplaceAll(word, `\`, `\\`)
+	word = strings.ReplaceAll(word, `'`, `''`)
+	return `'` + word + `'`
+}
+
+// tsPhraseQuery returns a Postgres tsquery expression
+// matching text containing each of the given terms, as
+// words or phrases, either exactly (simple config), or
+// stemmed with the given text search configuration.
+func tsPhraseQuery(terms []string, config string) schema.QueryWithArgs {
+	var (
+		exprs = make([]string, len(terms))
+		args  = make([]interface{}, 0, 3*len(terms))
+	)
+
+	for i, term := range terms {
+		exprs[i] = "(phraseto_tsquery('simple', ?) || phraseto_tsquery(?::regconfig, ?))"
+		args = append(args, term, config, term)
+	}
+
+	return schema.SafeQuery(strings.Join(exprs, " && "), args)
 }
 
 // Query example (SQLite):
@@ -455,6 +668,11 @@ func (s *searchDB) SearchForTags(
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 {
+		// Skip the given number of tags.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("tag.id DESC")
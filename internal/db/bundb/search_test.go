@@ -23,6 +23,9 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type SearchTestSuite struct {
@@ -107,22 +110,117 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"hello"}}, "", "", 10, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesPaging() {
+	testAccount := suite.testAccounts["local_account_1"]
+	query := &db.StatusSearchQuery{Terms: []string{"hello"}}
+
+	// Results ranked by terms ignore ID paging.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, query, id.Lowest, "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// They're paged using offset instead.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, query, "", "", 10, 1)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 	fromAccount := suite.testAccounts["local_account_2"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"hi"}, FromAccountID: fromAccount.ID}, "", "", 10, 0)
 	suite.NoError(err)
 	if suite.Len(statuses, 1) {
 		suite.Equal(fromAccount.ID, statuses[0].AccountID)
 	}
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesPhrase() {
+	testAccount := suite.testAccounts["local_account_1"]
+	testStatus := suite.testStatuses["local_account_1_status_4"]
+
+	// Words of the phrase in order.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"trent reznor"}}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(testStatus.ID, statuses[0].ID)
+	}
+
+	// Words of the phrase out of order.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"reznor trent"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Words that are only part of other words.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"rezn"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesHasMedia() {
+	testAccount := suite.testAccounts["local_account_1"]
+	testStatus := suite.testStatuses["local_account_1_status_4"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"cow"}, HasMedia: true}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(testStatus.ID, statuses[0].ID)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"sloths"}, HasMedia: true}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesBeforeAfter() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// No terms, just dates: newest first.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{
+		After:  testrig.TimeMustParse("2022-05-20T00:00:00Z"),
+		Before: testrig.TimeMustParse("2022-05-21T00:00:00Z"),
+	}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 2) {
+		suite.Equal(suite.testStatuses["local_account_1_status_6"].ID, statuses[0].ID)
+		suite.Equal(suite.testStatuses["local_account_1_status_5"].ID, statuses[1].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesReindex() {
+	testAccount := suite.testAccounts["local_account_1"]
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_1_status_6"]
+
+	// Update the text of the status.
+	testStatus.Content = "<p>what do you think of <em>turtles</em>?</p>"
+	err := suite.db.UpdateStatus(context.Background(), testStatus, "content")
+	suite.NoError(err)
+
+	// Old text shouldn't match any more.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"sloths"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// New text should.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"think of turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Deleted statuses shouldn't match.
+	err = suite.db.DeleteStatusByID(context.Background(), testStatus.ID)
+	suite.NoError(err)
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Terms: []string{"think of turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
@@ -0,0 +1,232 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// searchIndexBatchSize is the number of statuses
+// or accounts to load at once when rebuilding the
+// full-text search index.
+const searchIndexBatchSize = 1000
+
+// searchConfigs maps ISO 639-1 language codes to the
+// Postgres text search configuration for that language.
+// Only configurations shipped with every supported
+// version of Postgres are included; other languages
+// just use the "simple" configuration.
+//
+// Keep in sync with the search index migration.
+var searchConfigs = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nb": "norwegian",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// searchConfig returns the Postgres text search
+// configuration to use for the given language.
+func searchConfig(lang string) string {
+	// Strip any region etc, eg. "en-GB" -> "en".
+	lang, _, _ = strings.Cut(lang, "-")
+	if config, ok := searchConfigs[strings.ToLower(lang)]; ok {
+		return config
+	}
+	return "simple"
+}
+
+// statusSearchText returns the text of
+// status to index for full-text search.
+func statusSearchText(status *gtsmodel.Status) string {
+	if status.BoostOfID != "" {
+		// Boosts have no
+		// text of their own.
+		return ""
+	}
+
+	return strings.TrimSpace(
+		text.SanitizeToSearchText(status.ContentWarning) + " " +
+			text.SanitizeToSearchText(status.Content),
+	)
+}
+
+// indexStatus updates the full-text search index
+// entry of the given status, using the given tx.
+func indexStatus(ctx context.Context, tx bun.IDB, status *gtsmodel.Status) error {
+	statusText := statusSearchText(status)
+	if statusText == "" {
+		// Nothing to search for,
+		// drop any existing entry.
+		_, err := tx.
+			NewDelete().
+			Table("status_search").
+			Where("? = ?", bun.Ident("status_id"), status.ID).
+			Exec(ctx)
+		return err
+	}
+
+	entry := map[string]interface{}{
+		"status_id": status.ID,
+		"text":      statusText,
+	}
+	columns := []string{"text"}
+
+	if tx.Dialect().Name() == dialect.PG {
+		// Postgres also stores the text search
+		// configuration for the status language.
+		entry["config"] = searchConfig(status.Language)
+		columns = append(columns, "config")
+	}
+
+	return upsertSearchEntry(ctx, tx, "status_search", "status_id", entry, columns)
+}
+
+// indexAccount updates the full-text search index
+// entry of the given account, using the given tx.
+func indexAccount(ctx context.Context, tx bun.IDB, account *gtsmodel.Account) error {
+	entry := map[string]interface{}{
+		"account_id": account.ID,
+		"name":       strings.TrimSpace(account.Username + " " + account.DisplayName),
+		"note":       text.SanitizeToSearchText(account.Note),
+	}
+
+	return upsertSearchEntry(ctx, tx, "account_search", "account_id", entry, []string{"name", "note"})
+}
+
+// upsertSearchEntry inserts the given entry into the
+// given search index table, or updates the given
+// columns of it if it's already in there.
+func upsertSearchEntry(
+	ctx context.Context,
+	tx bun.IDB,
+	table string,
+	key string,
+	entry map[string]interface{},
+	columns []string,
+) error {
+	q := tx.
+		NewInsert().
+		Model(&entry).
+		Table(table).
+		On("CONFLICT (?) DO UPDATE", bun.Ident(key))
+
+	for _, column := range columns {
+		q = q.Set("? = EXCLUDED.?", bun.Ident(column), bun.Ident(column))
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
+
+func (s *searchDB) RebuildSearchIndex(ctx context.Context) error {
+	return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Clear out the existing index.
+		for _, table := range []string{"status_search", "account_search"} {
+			if _, err := tx.
+				NewDelete().
+				Table(table).
+				Where("TRUE").
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Index all statuses, in batches by ID.
+		var count int
+		for maxID := ""; ; {
+			var statuses []*gtsmodel.Status
+			if err := tx.
+				NewSelect().
+				Model(&statuses).
+				Column("id", "content", "content_warning", "language", "boost_of_id").
+				Where("? > ?", bun.Ident("id"), maxID).
+				Order("id ASC").
+				Limit(searchIndexBatchSize).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			if len(statuses) == 0 {
+				break
+			}
+
+			for _, status := range statuses {
+				if err := indexStatus(ctx, tx, status); err != nil {
+					return err
+				}
+			}
+
+			count += len(statuses)
+			maxID = statuses[len(statuses)-1].ID
+			log.Debugf(ctx, "indexed %d statuses for search", count)
+		}
+
+		// Index all accounts, in batches by ID.
+		count = 0
+		for maxID := ""; ; {
+			var accounts []*gtsmodel.Account
+			if err := tx.
+				NewSelect().
+				Model(&accounts).
+				Column("id", "username", "display_name", "note").
+				Where("? > ?", bun.Ident("id"), maxID).
+				Order("id ASC").
+				Limit(searchIndexBatchSize).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			if len(accounts) == 0 {
+				break
+			}
+
+			for _, account := range accounts {
+				if err := indexAccount(ctx, tx, account); err != nil {
+					return err
+				}
+			}
+
+			count += len(accounts)
+			maxID = accounts[len(accounts)-1].ID
+			log.Debugf(ctx, "indexed %d accounts for search", count)
+		}
+
+		return nil
+	})
+}
@@ -129,6 +129,20 @@ func (s *statusDB) GetStatusByPollID(ctx context.Context, pollID string) (*gtsmo
 	)
 }
 
+func (s *statusDB) GetStatusByImportedURI(ctx context.Context, accountID string, uri string) (*gtsmodel.Status, error) {
+	return s.getStatus(
+		ctx,
+		"AccountID,ImportedURI",
+		func(status *gtsmodel.Status) error {
+			return s.db.NewSelect().Model(status).
+				Where("? = ?", bun.Ident("status.account_id"), accountID).
+				Where("? = ?", bun.Ident("status.imported_uri"), uri).
+				Scan(ctx)
+		},
+		accountID, uri,
+	)
+}
+
 func (s *statusDB) GetStatusBoost(ctx context.Context, boostOfID string, byAccountID string) (*gtsmodel.Status, error) {
 	return s.getStatus(
 		ctx,
@@ -181,7 +195,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -254,6 +268,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.PreviewCardID != "" && status.PreviewCard == nil {
+		// Status preview card is not set, fetch from database.
+		status.PreviewCard, err = s.state.DB.GetPreviewCardByID(
+			ctx,
+			status.PreviewCardID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating status preview card: %w", err)
+		}
+	}
+
 	if !status.AttachmentsPopulated() {
 		// Status attachments are out-of-date with IDs, repopulate.
 		status.Attachments, err = s.state.DB.GetAttachmentsByIDs(
@@ -298,6 +323,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if !status.EditsPopulated() {
+		// Status edits are out-of-date with IDs, repopulate.
+		status.Edits, err = s.state.DB.GetStatusEditsByIDs(
+			ctx, // populates edit attachments
+			status.EditIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating status edits: %w", err)
+		}
+	}
+
 	if status.CreatedWithApplicationID != "" && status.CreatedWithApplication == nil {
 		// Populate the status' expected CreatedWithApplication (not always set).
 		status.CreatedWithApplication, err = s.state.DB.GetApplicationByID(
@@ -383,9 +419,13 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error
 				}
 			}
 
-			// Finally, insert the status
-			_, err := tx.NewInsert().Model(status).Exec(ctx)
-			return err
+			// Insert the status
+			if _, err := tx.NewInsert().Model(status).Exec(ctx); err != nil {
+				return err
+			}
+
+			// Finally, index the status for search.
+			return indexStatus(ctx, tx, status)
 		})
 	})
 }
@@ -449,6 +489,38 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
+			// If tags were updated, drop links between
+			// this status and any tags it no longer uses.
+			if len(columns) == 0 || slices.Contains(columns, "tags") {
+				q := tx.
+					NewDelete().
+					Table("status_to_tags").
+					Where("? = ?", bun.Ident("status_id"), status.ID)
+				if len(status.TagIDs) > 0 {
+					q = q.Where("? NOT IN (?)", bun.Ident("tag_id"), bun.In(status.TagIDs))
+				}
+				if _, err := q.Exec(ctx); err != nil &&
+					!errors.Is(err, db.ErrNoEntries) {
+					return err
+				}
+			}
+
+			// If emojis were updated, drop links between
+			// this status and any emojis it no longer uses.
+			if len(columns) == 0 || slices.Contains(columns, "emojis") {
+				q := tx.
+					NewDelete().
+					Table("status_to_emojis").
+					Where("? = ?", bun.Ident("status_id"), status.ID)
+				if len(status.EmojiIDs) > 0 {
+					q = q.Where("? NOT IN (?)", bun.Ident("emoji_id"), bun.In(status.EmojiIDs))
+				}
+				if _, err := q.Exec(ctx); err != nil &&
+					!errors.Is(err, db.ErrNoEntries) {
+					return err
+				}
+			}
+
 			// If the status is threaded, create
 			// link between thread and status.
 			if status.ThreadID != "" {
@@ -466,14 +538,26 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
-			// Finally, update the status
-			_, err := tx.
+			// Update the status
+			if _, err := tx.
 				NewUpdate().
 				Model(status).
 				Column(columns...).
 				Where("? = ?", bun.Ident("status.id"), status.ID).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Finally, if the text was
+			// updated, reindex for search.
+			if len(columns) == 0 ||
+				slices.Contains(columns, "content") ||
+				slices.Contains(columns, "content_warning") ||
+				slices.Contains(columns, "language") {
+				return indexStatus(ctx, tx, status)
+			}
+
+			return nil
 		})
 	})
 }
@@ -526,6 +610,15 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// Delete the status search index entry.
+		if _, err := tx.
+			NewDelete().
+			Table("status_search").
+			Where("? = ?", bun.Ident("status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
@@ -677,3 +770,88 @@ func (s *statusDB) getStatusBoostIDs(ctx context.Context, statusID string) ([]st
 		return statusIDs, nil
 	})
 }
+
+func (s *statusDB) GetPrunableStatuses(ctx context.Context, olderThan time.Time, maxID string, limit int) ([]*gtsmodel.Status, error) {
+	statusIDs := make([]string, 0, limit)
+
+	// Subquery to select
+	// IDs of local accounts.
+	localAccountIDs := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NULL", bun.Ident("account.domain"))
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.local"), false).
+		Where("? < ?", bun.Ident("status.id"), maxID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("status.fetched_at")).
+				WhereOr("? < ?", bun.Ident("status.fetched_at"), olderThan)
+		}).
+		// Remote statuses are only threaded
+		// when a local account is involved.
+		Where("? IS NULL", bun.Ident("status.thread_id")).
+		// Not faved by a local account.
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+			Where("? = ?", bun.Ident("status_fave.status_id"), bun.Ident("status.id")).
+			Where("? IN (?)", bun.Ident("status_fave.account_id"), localAccountIDs)).
+		// Not bookmarked (only local accounts bookmark).
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_bookmarks"), bun.Ident("status_bookmark")).
+			Where("? = ?", bun.Ident("status_bookmark.status_id"), bun.Ident("status.id"))).
+		// Not boosted or replied to by a local account.
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("local_status")).
+			Where("? = ?", bun.Ident("local_status.local"), true).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? = ?", bun.Ident("local_status.boost_of_id"), bun.Ident("status.id")).
+					WhereOr("? = ?", bun.Ident("local_status.in_reply_to_id"), bun.Ident("status.id"))
+			})).
+		// Not a boost of a local status.
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("boosted_status")).
+			Where("? = ?", bun.Ident("boosted_status.id"), bun.Ident("status.boost_of_id")).
+			Where("? = ?", bun.Ident("boosted_status.local"), true)).
+		// Not voted in by a local account.
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("poll_votes"), bun.Ident("poll_vote")).
+			Where("? = ?", bun.Ident("poll_vote.poll_id"), bun.Ident("status.poll_id")).
+			Where("? IN (?)", bun.Ident("poll_vote.account_id"), localAccountIDs)).
+		// Not by a reported account, to
+		// keep any reported statuses.
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
+			Where("? = ?", bun.Ident("report.target_account_id"), bun.Ident("status.account_id"))).
+		// Not pinned by an account
+		// followed by a local account.
+		Where("NOT EXISTS (?)", s.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+			Where("? IS NOT NULL", bun.Ident("status.pinned_at")).
+			Where("? = ?", bun.Ident("follow.target_account_id"), bun.Ident("status.account_id")).
+			Where("? IN (?)", bun.Ident("follow.account_id"), localAccountIDs)).
+		Order("status.id DESC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetStatusesByIDs(ctx, statusIDs)
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusEditTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestPutGetDeleteStatusEdits() {
+	var (
+		ctx    = context.Background()
+		status = suite.testStatuses["local_account_1_status_1"]
+		edits  = []*gtsmodel.StatusEdit{
+			{
+				ID:             "01J0TDM7Q5T9RDYAMJ1MQ5ZVXY",
+				Content:        status.Content,
+				ContentWarning: status.ContentWarning,
+				Text:           status.Text,
+				Language:       status.Language,
+				Sensitive:      util.Ptr(true),
+				AttachmentIDs:  status.AttachmentIDs,
+				StatusID:       status.ID,
+				CreatedAt:      status.CreatedAt,
+			},
+			{
+				ID:            "01J0TDN3QFDR6NH9BZN4PTCZ1R",
+				Content:       "<p>an edited status</p>",
+				Text:          "an edited status",
+				Language:      "en",
+				Sensitive:     util.Ptr(false),
+				AttachmentIDs: []string{},
+				StatusID:      status.ID,
+			},
+		}
+		ids = []string{edits[0].ID, edits[1].ID}
+	)
+
+	for _, edit := range edits {
+		if err := suite.db.PutStatusEdit(ctx, edit); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	edit, err := suite.db.GetStatusEditByID(ctx, edits[1].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("an edited status", edit.Text)
+	suite.False(*edit.Sensitive)
+
+	dbEdits, err := suite.db.GetStatusEditsByIDs(ctx, ids)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbEdits, 2)
+	suite.Equal(ids[0], dbEdits[0].ID)
+	suite.Equal(ids[1], dbEdits[1].ID)
+	suite.True(dbEdits[0].AttachmentsPopulated())
+
+	if err := suite.db.DeleteStatusEdits(ctx, ids); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetStatusEditByID(ctx, edits[0].ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
@@ -19,9 +19,13 @@ package bundb
 
 import (
 	"context"
+	"errors"
 	"strings"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -131,3 +135,29 @@ func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) error {
 
 	return nil
 }
+
+func (t *tagDB) GetOrCreateTag(ctx context.Context, name string) (*gtsmodel.Tag, error) {
+	// Check if we have a tag with this name already.
+	tag, err := t.GetTagByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting tag %s: %w", name, err)
+	}
+
+	if tag != nil {
+		// We had it!
+		return tag, nil
+	}
+
+	// We didn't have a tag with
+	// this name, create one.
+	tag = &gtsmodel.Tag{
+		ID:   id.NewULID(),
+		Name: name,
+	}
+
+	if err := t.PutTag(ctx, tag); err != nil {
+		return nil, gtserror.Newf("db error putting new tag %s: %w", name, err)
+	}
+
+	return tag, nil
+}
@@ -129,13 +129,43 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	// accountID can see its own posts in the timeline.
 	targetAccountIDs[len(targetAccountIDs)-1] = accountID
 
-	// Select only statuses authored by
-	// accounts with IDs in the slice.
-	q = q.Where(
-		"? IN (?)",
-		bun.Ident("status.account_id"),
-		bun.In(targetAccountIDs),
-	)
+	// Also see which hashtags accountID follows,
+	// as public statuses using any of these should
+	// be included too. These IDs are also cached.
+	followedTagIDs, err := t.state.DB.GetFollowedTagIDsByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting followed tags for account %s: %w", accountID, err)
+	}
+
+	if len(followedTagIDs) == 0 {
+		// Select only statuses authored by
+		// accounts with IDs in the slice.
+		q = q.Where(
+			"? IN (?)",
+			bun.Ident("status.account_id"),
+			bun.In(targetAccountIDs),
+		)
+	} else {
+		// Select statuses authored by accounts
+		// with IDs in the slice, or original public
+		// statuses which use one of the followed tags.
+		taggedQ := t.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+			Column("status_to_tag.status_id").
+			Where("? IN (?)", bun.Ident("status_to_tag.tag_id"), bun.In(followedTagIDs))
+
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IN (?)", bun.Ident("status.account_id"), bun.In(targetAccountIDs)).
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+						Where("? IS NULL", bun.Ident("status.boost_of_id")).
+						Where("? IN (?)", bun.Ident("status.id"), taggedQ)
+				})
+		})
+	}
 
 	if err := q.Scan(ctx, &statusIDs); err != nil {
 		return nil, err
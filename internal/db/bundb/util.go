@@ -60,37 +60,11 @@ func likeOperator(query *bun.SelectQuery) string {
 	return ""
 }
 
-// whereLike appends a WHERE clause to the
-// given SelectQuery, which searches for
-// matches of `search` in the given subQuery
-// using LIKE (SQLite) or ILIKE (Postgres).
-func whereLike(
-	query *bun.SelectQuery,
-	subject interface{},
-	search string,
-) *bun.SelectQuery {
-	// Escape existing wildcard + escape
-	// chars in the search query string.
-	search = likeEscaper.Replace(search)
-
-	// Add our own wildcards back in; search
-	// zero or more chars around the query.
-	search = `%` + search + `%`
-
-	// Get appropriate operator.
-	like := likeOperator(query)
-
-	// Append resulting WHERE
-	// clause to the main query.
-	return query.Where(
-		"(?) ? ? ESCAPE ?",
-		subject, bun.Safe(like), search, `\`,
-	)
-}
-
-// whereStartsLike is like whereLike,
-// but only searches for strings that
-// START WITH `search`.
+// whereStartsLike appends a WHERE clause
+// to the given SelectQuery, which searches
+// for strings in subject that START WITH
+// `search`, using LIKE (SQLite) or ILIKE
+// (Postgres).
 func whereStartsLike(
 	query *bun.SelectQuery,
 	subject interface{},
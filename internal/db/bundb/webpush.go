@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type webPushDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (w *webPushDB) CreateVAPIDKeyPair(ctx context.Context) error {
+	exists, err := exists(ctx, w.db.
+		NewSelect().
+		Table("vapid_key_pairs").
+		Column("id"),
+	)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Info(ctx, "vapid key pair already exists")
+		return nil
+	}
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		log.Errorf(ctx, "error creating new vapid key: %s", err)
+		return err
+	}
+
+	keyPair := &gtsmodel.VAPIDKeyPair{
+		ID:      id.NewULID(),
+		Public:  base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Private: base64.RawURLEncoding.EncodeToString(key.Bytes()),
+	}
+
+	if _, err := w.db.
+		NewInsert().
+		Model(keyPair).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "created vapid key pair %s", keyPair.ID)
+	return nil
+}
+
+func (w *webPushDB) GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error) {
+	var keyPair gtsmodel.VAPIDKeyPair
+
+	if err := w.db.
+		NewSelect().
+		Model(&keyPair).
+		// Oldest, in case
+		// of any races.
+		Order("id").
+		Limit(1).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &keyPair, nil
+}
+
+func (w *webPushDB) GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error) {
+	return w.state.Caches.GTS.WebPushSubscription.LoadOne(
+		"TokenID",
+		func() (*gtsmodel.WebPushSubscription, error) {
+			var subscription gtsmodel.WebPushSubscription
+
+			if err := w.db.
+				NewSelect().
+				Model(&subscription).
+				Where("? = ?", bun.Ident("web_push_subscription.token_id"), tokenID).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return &subscription, nil
+		},
+		tokenID,
+	)
+}
+
+func (w *webPushDB) GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error) {
+	var subscriptionIDs []string
+
+	// Select IDs of all subscriptions of account.
+	if err := w.db.
+		NewSelect().
+		Table("web_push_subscriptions").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Order("id").
+		Scan(ctx, &subscriptionIDs); err != nil {
+		return nil, err
+	}
+
+	if len(subscriptionIDs) == 0 {
+		return nil, nil
+	}
+
+	// Load all subscription IDs via cache loader callbacks.
+	subscriptions, err := w.state.Caches.GTS.WebPushSubscription.LoadIDs("ID",
+		subscriptionIDs,
+		func(uncached []string) ([]*gtsmodel.WebPushSubscription, error) {
+			// Preallocate expected length of uncached subscriptions.
+			subscriptions := make([]*gtsmodel.WebPushSubscription, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := w.db.NewSelect().
+				Model(&subscriptions).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return subscriptions, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the subscriptions by their
+	// IDs to ensure in correct order.
+	getID := func(s *gtsmodel.WebPushSubscription) string { return s.ID }
+	util.OrderBy(subscriptions, subscriptionIDs, getID)
+
+	return subscriptions, nil
+}
+
+func (w *webPushDB) PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	return w.state.Caches.GTS.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.
+			NewInsert().
+			Model(subscription).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, cols ...string) error {
+	subscription.UpdatedAt = time.Now()
+	if len(cols) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		cols = append(cols, "updated_at")
+	}
+
+	return w.state.Caches.GTS.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.
+			NewUpdate().
+			Model(subscription).
+			Column(cols...).
+			Where("? = ?", bun.Ident("web_push_subscription.id"), subscription.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error {
+	// Delete subscription of token from database.
+	if _, err := w.db.
+		NewDelete().
+		Table("web_push_subscriptions").
+		Where("? = ?", bun.Ident("token_id"), tokenID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate subscription of token from cache.
+	w.state.Caches.GTS.WebPushSubscription.Invalidate("TokenID", tokenID)
+
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error {
+	// Delete all subscriptions of account from database.
+	if _, err := w.db.
+		NewDelete().
+		Table("web_push_subscriptions").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate all subscriptions of account from cache.
+	w.state.Caches.GTS.WebPushSubscription.Invalidate("AccountID", accountID)
+
+	return nil
+}
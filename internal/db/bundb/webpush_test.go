@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type WebPushTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebPushTestSuite) TestCreateVAPIDKeyPair() {
+	ctx := context.Background()
+
+	// Test rig already has a key pair.
+	keyPair, err := suite.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Creating again shouldn't replace it.
+	if err := suite.db.CreateVAPIDKeyPair(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	again, err := suite.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(keyPair.ID, again.ID)
+	suite.Equal(keyPair.Private, again.Private)
+}
+
+func (suite *WebPushTestSuite) TestWebPushSubscriptions() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	var subscriptions []*gtsmodel.WebPushSubscription
+	for _, token := range []*gtsmodel.Token{
+		suite.testTokens["local_account_1"],
+		suite.testTokens["local_account_1_client_application_token"],
+	} {
+		subscription := &gtsmodel.WebPushSubscription{
+			ID:           id.NewULID(),
+			AccountID:    account.ID,
+			TokenID:      token.ID,
+			Endpoint:     "https://push.example.org/" + token.ID,
+			Auth:         "DlSmzvwRm1PeB2WSCA0Gag",
+			P256dh:       "BIf9jZr0QsNo6GSwhQBn0ZmBqg1ZkvnUlB5LZgI2AhzhdKKrmPKDoYkj8GlhLm5Z8ssuqg5rU0ZvVsWAo5bYyPg",
+			AlertMention: util.Ptr(true),
+			Policy:       gtsmodel.WebPushNotificationPolicyAll,
+		}
+		if err := suite.db.PutWebPushSubscription(ctx, subscription); err != nil {
+			suite.FailNow(err.Error())
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	// Only one subscription per token.
+	duplicate := *subscriptions[0]
+	duplicate.ID = id.NewULID()
+	suite.ErrorIs(suite.db.PutWebPushSubscription(ctx, &duplicate), db.ErrAlreadyExists)
+
+	byAccount, err := suite.db.GetWebPushSubscriptionsByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(byAccount, 2)
+	suite.Equal(subscriptions[0].ID, byAccount[0].ID)
+	suite.Equal(subscriptions[1].ID, byAccount[1].ID)
+
+	// Update policy of first subscription.
+	update := *subscriptions[0]
+	update.Policy = gtsmodel.WebPushNotificationPolicyFollower
+	if err := suite.db.UpdateWebPushSubscription(ctx, &update, "policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	byToken, err := suite.db.GetWebPushSubscriptionByTokenID(ctx, update.TokenID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.WebPushNotificationPolicyFollower, byToken.Policy)
+	suite.True(byToken.AlertEnabled(gtsmodel.NotificationMention))
+	suite.False(byToken.AlertEnabled(gtsmodel.NotificationFollow))
+
+	// Delete one by token, then the rest by account.
+	if err := suite.db.DeleteWebPushSubscriptionByTokenID(ctx, update.TokenID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, update.TokenID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	if err := suite.db.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	byAccount, err = suite.db.GetWebPushSubscriptionsByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(byAccount)
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPushTestSuite))
+}
@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type workerTaskDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (w *workerTaskDB) GetWorkerTaskByID(ctx context.Context, id uint) (*gtsmodel.WorkerTask, error) {
+	var task gtsmodel.WorkerTask
+
+	if err := w.db.
+		NewSelect().
+		Model(&task).
+		Where("? = ?", bun.Ident("worker_task.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (w *workerTaskDB) GetPendingWorkerTasks(ctx context.Context) ([]*gtsmodel.WorkerTask, error) {
+	var tasks []*gtsmodel.WorkerTask
+
+	if err := w.db.
+		NewSelect().
+		Model(&tasks).
+		Where("? IS NULL", bun.Ident("worker_task.failed_at")).
+		OrderExpr("? ASC", bun.Ident("worker_task.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return tasks, nil
+}
+
+func (w *workerTaskDB) GetFailedWorkerTasks(ctx context.Context, limit int) ([]*gtsmodel.WorkerTask, error) {
+	var tasks []*gtsmodel.WorkerTask
+
+	q := w.db.
+		NewSelect().
+		Model(&tasks).
+		Where("? IS NOT NULL", bun.Ident("worker_task.failed_at")).
+		OrderExpr("? DESC", bun.Ident("worker_task.id"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return tasks, nil
+}
+
+func (w *workerTaskDB) CountFailedWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType) (int, error) {
+	return w.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("worker_tasks"), bun.Ident("worker_task")).
+		Where("? = ?", bun.Ident("worker_task.worker_type"), workerType).
+		Where("? IS NOT NULL", bun.Ident("worker_task.failed_at")).
+		Count(ctx)
+}
+
+func (w *workerTaskDB) PutWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask) error {
+	_, err := w.db.
+		NewInsert().
+		Model(task).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx)
+	return err
+}
+
+func (w *workerTaskDB) UpdateWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask, columns ...string) error {
+	_, err := w.db.
+		NewUpdate().
+		Model(task).
+		Column(columns...).
+		Where("? = ?", bun.Ident("worker_task.id"), task.ID).
+		Exec(ctx)
+	return err
+}
+
+func (w *workerTaskDB) DeleteWorkerTaskByID(ctx context.Context, id uint) error {
+	_, err := w.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("worker_tasks"), bun.Ident("worker_task")).
+		Where("? = ?", bun.Ident("worker_task.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (w *workerTaskDB) CountFailedWorkerTasksOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
+	return w.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("worker_tasks"), bun.Ident("worker_task")).
+		Where("? < ?", bun.Ident("worker_task.failed_at"), olderThan).
+		Count(ctx)
+}
+
+func (w *workerTaskDB) DeleteFailedWorkerTasksOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
+	res, err := w.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("worker_tasks"), bun.Ident("worker_task")).
+		Where("? < ?", bun.Ident("worker_task.failed_at"), olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type WorkerTaskTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WorkerTaskTestSuite) TestWorkerTasks() {
+	ctx := context.Background()
+
+	// Put a few tasks, which should be
+	// given auto-incremented IDs in order.
+	var tasks []*gtsmodel.WorkerTask
+	for _, workerType := range []gtsmodel.WorkerType{
+		gtsmodel.DeliveryWorker,
+		gtsmodel.ClientWorker,
+		gtsmodel.DeliveryWorker,
+	} {
+		task := &gtsmodel.WorkerTask{
+			WorkerType: workerType,
+			TaskData:   []byte(`{}`),
+			CreatedAt:  time.Now(),
+		}
+		if err := suite.db.PutWorkerTask(ctx, task); err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.NotZero(task.ID)
+		if len(tasks) > 0 {
+			suite.Greater(task.ID, tasks[len(tasks)-1].ID)
+		}
+		tasks = append(tasks, task)
+	}
+
+	// All should be pending.
+	pending, err := suite.db.GetPendingWorkerTasks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(pending, 3)
+	suite.Equal(tasks[0].ID, pending[0].ID)
+	suite.Equal(tasks[2].ID, pending[2].ID)
+
+	_, err = suite.db.GetFailedWorkerTasks(ctx, 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Fail the last delivery.
+	tasks[2].FailedAt = time.Now()
+	tasks[2].Error = "oh no"
+	if err := suite.db.UpdateWorkerTask(ctx, tasks[2], "failed_at", "error"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pending, err = suite.db.GetPendingWorkerTasks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(pending, 2)
+
+	failed, err := suite.db.GetFailedWorkerTasks(ctx, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(failed, 1)
+	suite.Equal(tasks[2].ID, failed[0].ID)
+	suite.Equal("oh no", failed[0].Error)
+	suite.True(failed[0].Failed())
+
+	count, err := suite.db.CountFailedWorkerTasks(ctx, gtsmodel.DeliveryWorker)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, count)
+
+	count, err = suite.db.CountFailedWorkerTasks(ctx, gtsmodel.ClientWorker)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(count)
+
+	// Delete the tasks.
+	for _, task := range tasks {
+		if err := suite.db.DeleteWorkerTaskByID(ctx, task.ID); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	_, err = suite.db.GetWorkerTaskByID(ctx, tasks[0].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestWorkerTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTaskTestSuite))
+}
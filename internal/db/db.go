@@ -25,32 +25,45 @@ const (
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountArchive
 	Admin
+	Announcement
 	Application
 	Basic
+	Conversation
 	Domain
 	Emoji
+	FeaturedTag
+	FollowedTag
 	HeaderFilter
 	Instance
+	Invite
 	Filter
 	List
 	Marker
 	Media
 	Mention
+	ModerationPolicy
 	Move
 	Notification
 	Poll
+	PreviewCard
 	Relationship
+	Relay
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
 	Tag
 	Thread
 	Timeline
 	User
 	Tombstone
+	WebPush
+	WorkerTask
 }
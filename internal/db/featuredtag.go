@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FeaturedTag interface {
+	// GetFeaturedTagByID fetches the FeaturedTag with given ID from the database.
+	GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTag fetches the FeaturedTag of the given tag by the given account from the database.
+	GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTagsByAccountID fetches all FeaturedTags of the given account from the database.
+	GetFeaturedTagsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTagStatusStats returns the number of public / unlisted statuses
+	// by the featuring account that use the featured tag, and the creation time
+	// of the most recent of those statuses (zero if there are none).
+	GetFeaturedTagStatusStats(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) (int, time.Time, error)
+
+	// GetFeaturedTagSuggestionIDs returns up to limit IDs of the tags most used by the
+	// given account in its statuses, which the account is not already featuring.
+	GetFeaturedTagSuggestionIDs(ctx context.Context, accountID string, limit int) ([]string, error)
+
+	// PopulateFeaturedTag ensures the given FeaturedTag's sub-models are populated.
+	PopulateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// PutFeaturedTag inserts the given new FeaturedTag into the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// DeleteFeaturedTagByID deletes the FeaturedTag with given ID from the database.
+	DeleteFeaturedTagByID(ctx context.Context, id string) error
+
+	// DeleteFeaturedTagsByAccountID deletes all FeaturedTags of the given account from the database.
+	DeleteFeaturedTagsByAccountID(ctx context.Context, accountID string) error
+}
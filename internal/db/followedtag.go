@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type FollowedTag interface {
+	// GetFollowedTagByID fetches the FollowedTag with given ID from the database.
+	GetFollowedTagByID(ctx context.Context, id string) (*gtsmodel.FollowedTag, error)
+
+	// GetFollowedTag fetches the FollowedTag of the given tag by the given account from the database.
+	GetFollowedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FollowedTag, error)
+
+	// GetFollowedTagsByAccountID fetches a page of FollowedTags of the given account from the database.
+	GetFollowedTagsByAccountID(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.FollowedTag, error)
+
+	// GetFollowedTagIDsByAccountID fetches the IDs of all tags followed by the given account.
+	GetFollowedTagIDsByAccountID(ctx context.Context, accountID string) ([]string, error)
+
+	// GetFollowedTagAccountIDs fetches the IDs of all accounts following the given tag.
+	GetFollowedTagAccountIDs(ctx context.Context, tagID string) ([]string, error)
+
+	// IsFollowingAnyTag returns whether the given account follows any of the given tags.
+	IsFollowingAnyTag(ctx context.Context, accountID string, tagIDs []string) (bool, error)
+
+	// PopulateFollowedTag ensures the given FollowedTag's sub-models are populated.
+	PopulateFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error
+
+	// PutFollowedTag inserts the given new FollowedTag into the database.
+	PutFollowedTag(ctx context.Context, followedTag *gtsmodel.FollowedTag) error
+
+	// DeleteFollowedTagByID deletes the FollowedTag with given ID from the database.
+	DeleteFollowedTagByID(ctx context.Context, id string) error
+
+	// DeleteFollowedTagsByAccountID deletes all FollowedTags of the given account from the database.
+	DeleteFollowedTagsByAccountID(ctx context.Context, accountID string) error
+}
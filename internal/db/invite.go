@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Invite handles getting/creation/deletion/updating of sign-up invites.
+type Invite interface {
+	// GetInviteByID gets one invite by its db id.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode gets one invite by its invite code.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvitesByAccountID gets all invites created by the given account, newest first.
+	GetInvitesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Invite, error)
+
+	// PopulateInvite ensures that all sub-models of an invite are populated (e.g. account).
+	PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// PutInvite puts the given invite in the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates one invite by its db id, only on selected columns if provided (else, all).
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// IncrementInviteUses atomically increments the uses count of the given invite,
+	// returning ErrNoEntries if the invite was already used up by the time of update.
+	IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// DecrementInviteUses atomically decrements the uses count of the given invite,
+	// giving back a use taken by IncrementInviteUses for a sign-up that then failed.
+	DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// ExpireInvitesByAccountID sets all not-yet-expired invites created by the given account to expire now.
+	// Invites are expired rather than deleted, so that sign-ups made with them can still be traced.
+	ExpireInvitesByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// ModerationPolicy handles getting/creation/deletion/updating of domain and account moderation policies.
+type ModerationPolicy interface {
+	// GetModerationPolicyByID gets one moderation policy by its db id.
+	GetModerationPolicyByID(ctx context.Context, id string) (*gtsmodel.ModerationPolicy, error)
+
+	// GetModerationPolicyByDomain gets the moderation policy for exactly the given domain.
+	GetModerationPolicyByDomain(ctx context.Context, domain string) (*gtsmodel.ModerationPolicy, error)
+
+	// GetModerationPolicyByAccountID gets the moderation policy for the given account ID.
+	GetModerationPolicyByAccountID(ctx context.Context, accountID string) (*gtsmodel.ModerationPolicy, error)
+
+	// GetModerationPolicies gets all moderation policies, oldest first.
+	GetModerationPolicies(ctx context.Context) ([]*gtsmodel.ModerationPolicy, error)
+
+	// GetModerationPoliciesForAccount gets all moderation policies covering
+	// the given account, ie., a policy set on the account itself, and
+	// any policies set on the account's domain or one of its parents.
+	GetModerationPoliciesForAccount(ctx context.Context, account *gtsmodel.Account) (gtsmodel.ModerationPolicies, error)
+
+	// PutModerationPolicy puts the given moderation policy in the database.
+	PutModerationPolicy(ctx context.Context, policy *gtsmodel.ModerationPolicy) error
+
+	// UpdateModerationPolicy updates the given moderation policy, setting the provided columns (empty for all).
+	UpdateModerationPolicy(ctx context.Context, policy *gtsmodel.ModerationPolicy, columns ...string) error
+
+	// DeleteModerationPolicyByID deletes one moderation policy by its db id.
+	DeleteModerationPolicyByID(ctx context.Context, id string) error
+}
@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type PreviewCard interface {
+	// GetPreviewCardByID fetches the PreviewCard with given ID from the database.
+	GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, error)
+
+	// GetPreviewCardByURL fetches the PreviewCard for the given page URL from the database.
+	GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, error)
+
+	// GetPreviewCardByImageID fetches the PreviewCard using the given media attachment ID as thumbnail.
+	GetPreviewCardByImageID(ctx context.Context, imageID string) (*gtsmodel.PreviewCard, error)
+
+	// PopulatePreviewCard ensures the given PreviewCard is fully populated with all other related database models.
+	PopulatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error
+
+	// PutPreviewCard puts the given PreviewCard in the database.
+	PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error
+
+	// UpdatePreviewCard updates the PreviewCard in the database, only on selected columns if provided (else, all).
+	UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, cols ...string) error
+
+	// DeletePreviewCardByID deletes the PreviewCard with given ID from the database.
+	DeletePreviewCardByID(ctx context.Context, id string) error
+}
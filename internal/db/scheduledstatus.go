@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatus interface {
+	// GetScheduledStatusByID fetches the ScheduledStatus with given ID from the database.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAccount fetches a page of ScheduledStatuses of the given account from the database.
+	GetScheduledStatusesForAccount(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetAllScheduledStatuses fetches all pending ScheduledStatuses from the database.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAccount counts the ScheduledStatuses of the given account
+	// which are scheduled at or after start, and before end. Zero times are unbounded.
+	CountScheduledStatusesForAccount(ctx context.Context, accountID string, start time.Time, end time.Time) (int, error)
+
+	// PopulateScheduledStatus ensures the given ScheduledStatus is fully populated with all other related database models.
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus puts the given ScheduledStatus in the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the ScheduledStatus in the database, only on selected columns if provided (else, all).
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, cols ...string) error
+
+	// DeleteScheduledStatusByID deletes the ScheduledStatus with given ID from the database.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+
+	// DeleteScheduledStatusesByAccountID deletes all ScheduledStatuses of the given account from the database.
+	DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error
+}
@@ -19,18 +19,62 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type Search interface {
 	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	//
+	// If query starts with '@', accounts with usernames starting with the rest of the query are
+	// returned, newest first. Otherwise, accounts with all words of the query at the start of words
+	// in their username or display name (and bio, if following) are returned, best matches first.
+	// Best match results are paged using offset only, as maxID and minID are ignored for them.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
-	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
-	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// SearchForStatuses uses the given query to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
+	// If the query has any terms, statuses are returned best matches first, otherwise newest first.
+	// Best match results are paged using offset only, as maxID and minID are ignored for them.
+	SearchForStatuses(ctx context.Context, requestingAccountID string, query *StatusSearchQuery, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
+
+	// RebuildSearchIndex clears and rebuilds the full-text search index of all statuses
+	// and accounts. The index is normally kept up to date as statuses and accounts are
+	// stored, so this is only needed if it has got out of sync somehow, eg., after
+	// restoring database tables from a backup. It may take a long time to run.
+	RebuildSearchIndex(ctx context.Context) error
+}
+
+// StatusSearchQuery is a full-text search
+// query for statuses, parsed into its
+// terms and any search operators.
+type StatusSearchQuery struct {
+	// Terms that statuses must all contain.
+	// Each term is either a single word, or
+	// a phrase of words that must appear
+	// next to each other, in order.
+	Terms []string
+
+	// Language of the terms (ISO 639-1), if
+	// known. Where supported by the database,
+	// this is used to also match different
+	// forms of the same word (eg., "running"
+	// and "runs" when searching for "run").
+	Language string
+
+	// FromAccountID, if set, limits results
+	// to statuses created by this account.
+	FromAccountID string
+
+	// HasMedia, if true, limits results to
+	// statuses with media attachments.
+	HasMedia bool
+
+	// Before and After, if set, limit results
+	// to statuses created before / after them.
+	Before time.Time
+	After  time.Time
 }
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -37,6 +38,9 @@ type Status interface {
 	// GetStatusByPollID fetches the status from the database with matching poll_id column.
 	GetStatusByPollID(ctx context.Context, pollID string) (*gtsmodel.Status, error)
 
+	// GetStatusByImportedURI fetches the status of given account ID that was imported from an account archive with matching imported_uri column.
+	GetStatusByImportedURI(ctx context.Context, accountID string, uri string) (*gtsmodel.Status, error)
+
 	// GetStatusBoost fetches the status whose boost_of_id column refers to boostOfID, authored by given account ID.
 	GetStatusBoost(ctx context.Context, boostOfID string, byAccountID string) (*gtsmodel.Status, error)
 
@@ -78,4 +82,12 @@ type Status interface {
 
 	// GetStatusChildren gets the child statuses of a given status.
 	GetStatusChildren(ctx context.Context, statusID string) ([]*gtsmodel.Status, error)
+
+	// GetPrunableStatuses fetches up to limit remote statuses with ID less than maxID, which
+	// haven't been fetched since olderThan, and which no local account has interacted with.
+	// That is, statuses which aren't faved, boosted, bookmarked, replied to or voted in by a
+	// local account, which aren't part of a thread involving a local account, which aren't
+	// boosts of a local status, which aren't pinned by an account followed by a local account,
+	// and which aren't by an account that's been reported (so reported statuses are kept).
+	GetPrunableStatuses(ctx context.Context, olderThan time.Time, maxID string, limit int) ([]*gtsmodel.Status, error)
 }
@@ -34,6 +34,10 @@ type Tag interface {
 	// PutTag inserts the given tag in the database.
 	PutTag(ctx context.Context, tag *gtsmodel.Tag) error
 
+	// GetOrCreateTag gets a single tag using the given name,
+	// inserting a new tag with that name if there wasn't one.
+	GetOrCreateTag(ctx context.Context, name string) (*gtsmodel.Tag, error)
+
 	// GetTags gets multiple tags.
 	GetTags(ctx context.Context, ids []string) ([]*gtsmodel.Tag, error)
 }
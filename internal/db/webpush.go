@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type WebPush interface {
+	// CreateVAPIDKeyPair generates and stores a new VAPID key pair
+	// for this instance, if one doesn't exist yet in the database.
+	CreateVAPIDKeyPair(ctx context.Context) error
+
+	// GetVAPIDKeyPair fetches this instance's VAPID key pair from the database.
+	GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error)
+
+	// GetWebPushSubscriptionByTokenID fetches the WebPushSubscription created by the given OAuth token from the database.
+	GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error)
+
+	// GetWebPushSubscriptionsByAccountID fetches all WebPushSubscriptions of the given account from the database.
+	GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error)
+
+	// PutWebPushSubscription puts the given WebPushSubscription in the database.
+	PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error
+
+	// UpdateWebPushSubscription updates the WebPushSubscription in the database, only on selected columns if provided (else, all).
+	UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, cols ...string) error
+
+	// DeleteWebPushSubscriptionByTokenID deletes the WebPushSubscription created by the given OAuth token from the database.
+	DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error
+
+	// DeleteWebPushSubscriptionsByAccountID deletes all WebPushSubscriptions of the given account from the database.
+	DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WorkerTask contains functionality for the write-ahead
+// persistence of queued worker tasks, allowing them to
+// be replayed on startup should the process have exited.
+type WorkerTask interface {
+	// GetWorkerTaskByID fetches the worker task with given ID from the database.
+	GetWorkerTaskByID(ctx context.Context, id uint) (*gtsmodel.WorkerTask, error)
+
+	// GetPendingWorkerTasks fetches all worker tasks that have not
+	// failed processing from the database, in the order queued.
+	GetPendingWorkerTasks(ctx context.Context) ([]*gtsmodel.WorkerTask, error)
+
+	// GetFailedWorkerTasks fetches up to limit of the
+	// most recently failed worker tasks from the database.
+	GetFailedWorkerTasks(ctx context.Context, limit int) ([]*gtsmodel.WorkerTask, error)
+
+	// CountFailedWorkerTasks counts the failed worker
+	// tasks of the given worker type in the database.
+	CountFailedWorkerTasks(ctx context.Context, workerType gtsmodel.WorkerType) (int, error)
+
+	// PutWorkerTask inserts the given new worker task into
+	// the database, setting its ID to the auto-incremented ID.
+	PutWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask) error
+
+	// UpdateWorkerTask updates the given worker task
+	// in the database, only updating the given columns.
+	UpdateWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask, columns ...string) error
+
+	// DeleteWorkerTaskByID deletes the worker task with given ID from the database.
+	DeleteWorkerTaskByID(ctx context.Context, id uint) error
+
+	// CountFailedWorkerTasksOlderThan counts the worker
+	// tasks in the database that failed before given time.
+	CountFailedWorkerTasksOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+
+	// DeleteFailedWorkerTasksOlderThan deletes the worker tasks in the database
+	// that failed before given time, returning the number of tasks deleted.
+	DeleteFailedWorkerTasksOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+}
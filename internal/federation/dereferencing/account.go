@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/superseriousbusiness/activity/pub"
@@ -33,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -107,6 +110,10 @@ func (d *Dereferencer) GetAccountByURI(ctx context.Context, requestUser string,
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account, accountable); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -219,6 +226,10 @@ func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUs
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account, accountable); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -350,6 +361,10 @@ func (d *Dereferencer) RefreshAccount(
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest, accountable); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -402,6 +417,10 @@ func (d *Dereferencer) RefreshAccountAsync(
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest, accountable); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -595,6 +614,7 @@ func (d *Dereferencer) enrichAccount(
 		// version of this account as a parameter.
 		// Dereference latest version of the account.
 		rsp, err := tsport.Dereference(ctx, uri)
+		countDereference(ctx, "account", err)
 		if err != nil {
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
 			return nil, nil, gtserror.SetUnretrievable(err)
@@ -729,6 +749,18 @@ func (d *Dereferencer) enrichAccount(
 	latestAcc.ID = account.ID
 	latestAcc.FetchedAt = time.Now()
 
+	// Carry over verification of any profile
+	// field links that haven't changed, these
+	// are (re)verified periodically by us.
+	for _, field := range latestAcc.Fields {
+		for _, prevField := range account.Fields {
+			if prevField.Value == field.Value {
+				field.VerifiedAt = prevField.VerifiedAt
+				break
+			}
+		}
+	}
+
 	// Ensure the account's avatar media is populated, passing in existing to check for chages.
 	if err := d.fetchAccountAvatar(ctx, requestUser, account, latestAcc); err != nil {
 		log.Errorf(ctx, "error fetching remote avatar for account %s: %v", uri, err)
@@ -1187,3 +1219,120 @@ outerLoop:
 
 	return nil
 }
+
+// dereferenceAccountFeaturedTags dereferences an account's featuredTags collection (if set on the accountable).
+// Each discovered hashtag is fetched from the database or created, and the account's featured tags are then
+// replaced with the discovered tags.
+func (d *Dereferencer) dereferenceAccountFeaturedTags(ctx context.Context, requestUser string, account *gtsmodel.Account, accountable ap.Accountable) error {
+	// The featuredTags property isn't part of
+	// the vocab, so check for it in unknowns.
+	withUnknown, ok := accountable.(ap.WithUnknownProperties)
+	if !ok {
+		return nil
+	}
+
+	var tags []*gtsmodel.Tag
+
+	if uri := ap.GetFeaturedTags(withUnknown); uri != nil {
+		collect, err := d.dereferenceCollection(ctx, requestUser, uri)
+		if err != nil {
+			return err
+		}
+
+		for {
+			// Get next collect item.
+			item := collect.NextItem()
+			if item == nil {
+				break
+			}
+
+			// Featured tags are always embedded
+			// as Hashtag objects, skip anything else.
+			t := item.GetType()
+			if t == nil || t.GetTypeName() != ap.TagHashtag {
+				continue
+			}
+
+			hashtaggable, ok := t.(ap.Hashtaggable)
+			if !ok {
+				continue
+			}
+
+			tag, err := ap.ExtractHashtag(hashtaggable)
+			if err != nil {
+				log.Debugf(ctx, "error extracting hashtag from featured tags collection %s: %v", uri, err)
+				continue
+			}
+
+			normalized, ok := text.NormalizeHashtag(tag.Name)
+			if !ok {
+				continue
+			}
+
+			// We store tag names lowercased.
+			name := strings.ToLower(normalized)
+
+			// Look for existing tag with name in the database.
+			existing, err := d.state.DB.GetTagByName(ctx, name)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return gtserror.Newf("db error getting tag %s: %w", name, err)
+			} else if existing != nil {
+				tags = append(tags, existing)
+				continue
+			}
+
+			// Insert this tag with new name into the database.
+			tag = &gtsmodel.Tag{ID: id.NewULID(), Name: name}
+			if err := d.state.DB.PutTag(ctx, tag); err != nil {
+				log.Errorf(ctx, "db error putting tag %s: %v", name, err)
+				continue
+			}
+
+			tags = append(tags, tag)
+		}
+	}
+
+	// Get previous featured tags so we can diff.
+	wasFeatured, err := d.state.DB.GetFeaturedTagsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting account featured tags: %w", err)
+	}
+
+	// Remove featured tags no longer included.
+	for _, featuredTag := range wasFeatured {
+		if slices.ContainsFunc(tags, func(tag *gtsmodel.Tag) bool {
+			return tag.ID == featuredTag.TagID
+		}) {
+			continue
+		}
+
+		if err := d.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+			log.Errorf(ctx, "error deleting featured tag %s: %v", featuredTag.ID, err)
+		}
+	}
+
+	// Add newly featured tags.
+	for _, tag := range tags {
+		if slices.ContainsFunc(wasFeatured, func(featuredTag *gtsmodel.FeaturedTag) bool {
+			return featuredTag.TagID == tag.ID
+		}) {
+			continue
+		}
+
+		featuredTag := &gtsmodel.FeaturedTag{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			TagID:     tag.ID,
+		}
+
+		if err := d.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil &&
+			!errors.Is(err, db.ErrAlreadyExists) {
+			log.Errorf(ctx, "error putting featured tag for tag %s: %v", tag.ID, err)
+		}
+	}
+
+	return nil
+}
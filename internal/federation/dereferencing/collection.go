@@ -39,6 +39,7 @@ func (d *Dereferencer) dereferenceCollection(ctx context.Context, username strin
 	}
 
 	rsp, err := transport.Dereference(ctx, pageIRI)
+	countDereference(ctx, "collection", err)
 	if err != nil {
 		return nil, gtserror.Newf("error dereferencing %s: %w", pageIRI.String(), err)
 	}
@@ -67,6 +68,7 @@ func (d *Dereferencer) dereferenceCollectionPage(ctx context.Context, username s
 	}
 
 	rsp, err := transport.Dereference(ctx, pageIRI)
+	countDereference(ctx, "collection", err)
 	if err != nil {
 		return nil, gtserror.Newf("error deferencing %s: %w", pageIRI.String(), err)
 	}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// dereferenceTotal counts remote ActivityPub
+// dereferences, by object type and result.
+var dereferenceTotal metric.Int64Counter
+
+func init() {
+	var err error
+	dereferenceTotal, err = otel.
+		Meter("github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing").
+		Int64Counter(
+			"gotosocial.dereference.total",
+			metric.WithDescription("Number of remote dereferences, by type (account, status, collection) and result (success, failure)"),
+		)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// countDereference increments the dereference
+// counter for a remote fetch of given object type.
+func countDereference(ctx context.Context, typ string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	dereferenceTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("type", typ),
+		attribute.String("result", result),
+	))
+}
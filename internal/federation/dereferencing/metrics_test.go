@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCountDereference(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	ctx := context.Background()
+	countDereference(ctx, "account", nil)
+	countDereference(ctx, "account", errors.New("oh no"))
+	countDereference(ctx, "status", nil)
+	countDereference(ctx, "status", nil)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	totals := make(map[attribute.Set]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "gotosocial.dereference.total" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				totals[dp.Attributes] = dp.Value
+			}
+		}
+	}
+
+	for _, expect := range []struct {
+		typ    string
+		result string
+		count  int64
+	}{
+		{"account", "success", 1},
+		{"account", "failure", 1},
+		{"status", "success", 2},
+		{"status", "failure", 0},
+		{"collection", "success", 0},
+	} {
+		attrs := attribute.NewSet(
+			attribute.String("type", expect.typ),
+			attribute.String("result", expect.result),
+		)
+		if count := totals[attrs]; count != expect.count {
+			t.Errorf("expected %d %s %s dereferences, got %d", expect.count, expect.result, expect.typ, count)
+		}
+	}
+}
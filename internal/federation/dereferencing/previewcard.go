@@ -0,0 +1,647 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxPreviewPageSize is the maximum number of bytes
+	// of a linked page that will be read when looking
+	// for preview metadata. Metadata is expected to be
+	// in the <head> of a page, so this is plenty.
+	maxPreviewPageSize = 1 << 20 // 1MiB
+
+	// maxOEmbedSize is the maximum number
+	// of bytes of an oEmbed response to read.
+	maxOEmbedSize = 64 << 10 // 64KiB
+
+	// Maximum lengths of preview card text
+	// fields, anything longer gets truncated.
+	maxPreviewTitleLen       = 256
+	maxPreviewDescriptionLen = 1024
+)
+
+// previewCardFreshness is the window in which
+// a stored preview card is not refetched.
+const previewCardFreshness = 7 * 24 * time.Hour
+
+// RefreshStatusPreviewCardAsync enqueues a worker
+// function to (re)generate the preview card of the
+// status with ID, reloading the status from the
+// database at that point so the latest is used.
+func (d *Dereferencer) RefreshStatusPreviewCardAsync(statusID string) {
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		status, err := d.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %s: %v", statusID, err)
+			return
+		}
+
+		if err := d.RefreshStatusPreviewCard(ctx, status); err != nil {
+			log.Errorf(ctx, "error refreshing status %s preview card: %v", statusID, err)
+		}
+	})
+}
+
+// RefreshStatusPreviewCard ensures the preview card of the given
+// status corresponds to the first previewable link in its content,
+// fetching and storing a new preview card for the link if needed.
+// Status will be updated in the database if its card has changed.
+//
+// Linked pages are always fetched as the instance actor, and links
+// to blocked domains, or to addresses disallowed by the http client,
+// will never be fetched.
+func (d *Dereferencer) RefreshStatusPreviewCard(ctx context.Context, status *gtsmodel.Status) error {
+	var cardID string
+
+	if link := previewLink(status.Content); link != nil {
+		// Get (or fetch) preview card for link.
+		card, err := d.getPreviewCard(ctx, link)
+		if err != nil {
+			return err
+		}
+
+		if card != nil {
+			cardID = card.ID
+		}
+	}
+
+	if cardID == status.PreviewCardID {
+		// Nothing
+		// changed.
+		return nil
+	}
+
+	// Update status with the new card.
+	status.PreviewCardID = cardID
+	status.PreviewCard = nil
+	if err := d.state.DB.UpdateStatus(ctx,
+		status,
+		"preview_card_id",
+	); err != nil {
+		return gtserror.Newf("error updating status: %w", err)
+	}
+
+	// Status has changed, ensure it's not
+	// served from timelines in its old form.
+	if err := d.state.Timelines.Home.UnprepareItemFromAllTimelines(ctx, status.ID); err != nil {
+		log.Errorf(ctx, "error unpreparing status from home timelines: %v", err)
+	}
+
+	if err := d.state.Timelines.List.UnprepareItemFromAllTimelines(ctx, status.ID); err != nil {
+		log.Errorf(ctx, "error unpreparing status from list timelines: %v", err)
+	}
+
+	return nil
+}
+
+// getPreviewCard returns the stored preview card for the given link
+// if it's still fresh, else fetching a new card for the link. A nil
+// card and nil error are returned if the link is not previewable.
+func (d *Dereferencer) getPreviewCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	linkStr := link.String()
+
+	// Look for an existing card for this link.
+	card, err := d.state.DB.GetPreviewCardByURL(ctx, linkStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting preview card for %s: %w", linkStr, err)
+	}
+
+	if card != nil && time.Since(card.UpdatedAt) < previewCardFreshness {
+		// Card is fresh.
+		return card, nil
+	}
+
+	// Fetch latest preview of the linked page.
+	latest, err := d.fetchPreviewCard(ctx, link)
+	if err != nil {
+		if card != nil {
+			// Keep using the old card.
+			log.Warnf(ctx, "error refreshing preview card for %s: %v", linkStr, err)
+			return card, nil
+		}
+
+		// Unpreviewable pages are a fact of life, so
+		// only log and treat link as having no card.
+		log.Debugf(ctx, "error fetching preview card for %s: %v", linkStr, err)
+		return nil, nil
+	}
+
+	if card == nil {
+		// This is a new card, insert it.
+		latest.ID = id.NewULID()
+		if err := d.state.DB.PutPreviewCard(ctx, latest); err != nil {
+			if !errors.Is(err, db.ErrAlreadyExists) {
+				return nil, gtserror.Newf("error putting preview card: %w", err)
+			}
+
+			// Raced with another status linking
+			// to the same page, use theirs instead.
+			return d.state.DB.GetPreviewCardByURL(ctx, linkStr)
+		}
+
+		return latest, nil
+	}
+
+	// Update the existing card in place.
+	latest.ID = card.ID
+	latest.CreatedAt = card.CreatedAt
+	if err := d.state.DB.UpdatePreviewCard(ctx, latest); err != nil {
+		return nil, gtserror.Newf("error updating preview card: %w", err)
+	}
+
+	return latest, nil
+}
+
+// fetchPreviewCard fetches the page at link, building
+// a preview card from its OpenGraph, Twitter card and
+// oEmbed metadata, and fetching its thumbnail if any.
+// The returned card has no ID set.
+func (d *Dereferencer) fetchPreviewCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	if err := d.checkPreviewURL(ctx, link); err != nil {
+		return nil, err
+	}
+
+	// Linked pages are not fediverse content,
+	// so always use the instance actor transport.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("failed getting instance transport: %w", err)
+	}
+
+	// Fetch the linked page.
+	rsp, err := d.previewGET(ctx, tsport, link, "text/html,application/xhtml+xml")
+	if err != nil {
+		return nil, err
+	}
+
+	if !isPreviewHTML(rsp.Header.Get("Content-Type")) {
+		_ = rsp.Body.Close()
+		return nil, gtserror.Newf("%s is not an html page", link)
+	}
+
+	// Parse metadata from the (limited) page body.
+	meta, err := parsePreviewMeta(io.LimitReader(rsp.Body, maxPreviewPageSize))
+	_ = rsp.Body.Close()
+	if err != nil {
+		return nil, gtserror.Newf("error parsing %s: %w", link, err)
+	}
+
+	// Resolve relative links in page against the
+	// final page URL (in case of redirects).
+	pageURL := rsp.Request.URL
+
+	card := &gtsmodel.PreviewCard{
+		URL:         link.String(),
+		Type:        gtsmodel.PreviewCardTypeLink,
+		Title:       meta.first("og:title", "twitter:title", "title"),
+		Description: meta.first("og:description", "twitter:description", "description"),
+	}
+
+	// Default the provider to the site name / host.
+	card.ProviderName = meta.first("og:site_name")
+	if card.ProviderName == "" {
+		card.ProviderName = pageURL.Hostname()
+	}
+	card.ProviderURL = pageURL.Scheme + "://" + pageURL.Host
+
+	// Get the page's preferred preview image.
+	imageURL := meta.first("og:image:secure_url", "og:image:url", "og:image", "twitter:image", "twitter:image:src")
+
+	if href := meta.oembed; href != "" {
+		// Page advertises an oEmbed endpoint, use
+		// it to fill in / improve the card details.
+		oembedURL, err := pageURL.Parse(href)
+		if err == nil {
+			err = d.applyOEmbed(ctx, tsport, oembedURL, card, &imageURL)
+		}
+		if err != nil {
+			log.Debugf(ctx, "error fetching oembed for %s: %v", link, err)
+		}
+	}
+
+	if card.Title == "" && card.Description == "" && card.HTML == "" {
+		// Nothing worth previewing.
+		return nil, gtserror.Newf("%s has no preview metadata", link)
+	}
+
+	// Tidy up text fields.
+	card.Title = truncate(text.SanitizeToPlaintext(card.Title), maxPreviewTitleLen)
+	card.Description = truncate(text.SanitizeToPlaintext(card.Description), maxPreviewDescriptionLen)
+	card.AuthorName = truncate(text.SanitizeToPlaintext(card.AuthorName), maxPreviewTitleLen)
+	card.ProviderName = truncate(text.SanitizeToPlaintext(card.ProviderName), maxPreviewTitleLen)
+
+	if imageURL != "" {
+		// Fetch the card thumbnail, if permitted.
+		image, err := d.fetchPreviewImage(ctx, pageURL, imageURL, card.Title)
+		if err != nil {
+			log.Debugf(ctx, "error fetching preview image for %s: %v", link, err)
+		}
+
+		if image != nil {
+			card.ImageID = image.ID
+			card.Image = image
+		}
+	}
+
+	return card, nil
+}
+
+// oembed models the fields we use from an oEmbed response.
+// See: https://oembed.com/#section2.3
+type oembed struct {
+	Type         string      `json:"type"`
+	Title        string      `json:"title"`
+	AuthorName   string      `json:"author_name"`
+	AuthorURL    string      `json:"author_url"`
+	ProviderName string      `json:"provider_name"`
+	ProviderURL  string      `json:"provider_url"`
+	HTML         string      `json:"html"`
+	URL          string      `json:"url"`
+	Width        json.Number `json:"width"`
+	Height       json.Number `json:"height"`
+	ThumbnailURL string      `json:"thumbnail_url"`
+}
+
+// applyOEmbed fetches the oEmbed response at oembedURL, and
+// updates the given card (and card image URL) with its details.
+func (d *Dereferencer) applyOEmbed(
+	ctx context.Context,
+	tsport transport.Transport,
+	oembedURL *url.URL,
+	card *gtsmodel.PreviewCard,
+	imageURL *string,
+) error {
+	if err := d.checkPreviewURL(ctx, oembedURL); err != nil {
+		return err
+	}
+
+	rsp, err := d.previewGET(ctx, tsport, oembedURL, "application/json")
+	if err != nil {
+		return err
+	}
+
+	var oe oembed
+
+	// Decode the (limited) oEmbed JSON response body.
+	err = json.NewDecoder(io.LimitReader(rsp.Body, maxOEmbedSize)).Decode(&oe)
+	_ = rsp.Body.Close()
+	if err != nil {
+		return gtserror.Newf("error decoding oembed: %w", err)
+	}
+
+	if oe.Title != "" {
+		card.Title = oe.Title
+	}
+	if oe.AuthorName != "" {
+		card.AuthorName = oe.AuthorName
+	}
+	if isHTTPURL(oe.AuthorURL) {
+		card.AuthorURL = oe.AuthorURL
+	}
+	if oe.ProviderName != "" {
+		card.ProviderName = oe.ProviderName
+	}
+	if isHTTPURL(oe.ProviderURL) {
+		card.ProviderURL = oe.ProviderURL
+	}
+	if isHTTPURL(oe.ThumbnailURL) {
+		*imageURL = oe.ThumbnailURL
+	}
+
+	width, _ := strconv.Atoi(oe.Width.String())
+	height, _ := strconv.Atoi(oe.Height.String())
+
+	switch gtsmodel.PreviewCardType(oe.Type) {
+	case gtsmodel.PreviewCardTypePhoto:
+		if !isHTTPURL(oe.URL) {
+			break
+		}
+		card.Type = gtsmodel.PreviewCardTypePhoto
+		card.EmbedURL = oe.URL
+		card.Width, card.Height = width, height
+		if *imageURL == "" {
+			*imageURL = oe.URL
+		}
+
+	case gtsmodel.PreviewCardTypeVideo,
+		gtsmodel.PreviewCardTypeRich:
+		// Only allow through sanitized iframes.
+		embedHTML := text.SanitizeToEmbedHTML(oe.HTML)
+		if embedHTML == "" {
+			break
+		}
+		card.Type = gtsmodel.PreviewCardType(oe.Type)
+		card.HTML = embedHTML
+		card.Width, card.Height = width, height
+	}
+
+	return nil
+}
+
+// fetchPreviewImage fetches the preview card image at
+// imageURL (relative to pageURL), as owned by the instance
+// account. Note a placeholder may be returned with an error.
+func (d *Dereferencer) fetchPreviewImage(
+	ctx context.Context,
+	pageURL *url.URL,
+	imageURL string,
+	description string,
+) (*gtsmodel.MediaAttachment, error) {
+	// Resolve image relative to page.
+	url, err := pageURL.Parse(imageURL)
+	if err != nil {
+		return nil, gtserror.Newf("invalid image url %q: %w", imageURL, err)
+	}
+
+	if err := d.checkPreviewURL(ctx, url); err != nil {
+		return nil, err
+	}
+
+	// Card images are stored
+	// as instance account media.
+	instAcc, err := d.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	urlStr := url.String()
+	return d.GetMedia(ctx,
+		"", // instance actor
+		instAcc.ID,
+		urlStr,
+		media.AdditionalMediaInfo{
+			RemoteURL:   &urlStr,
+			Description: &description,
+		},
+	)
+}
+
+// checkPreviewURL checks whether given URL is permitted to
+// be fetched for preview cards, i.e. it's http(s), and not
+// a domain that is blocked. Other network level permissions
+// are enforced by the http client itself.
+func (d *Dereferencer) checkPreviewURL(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return gtserror.Newf("%s is not http(s)", u)
+	}
+
+	if blocked, err := d.state.DB.IsDomainBlocked(ctx, u.Hostname()); err != nil {
+		return gtserror.Newf("error checking blocked domain: %w", err)
+	} else if blocked {
+		err = gtserror.Newf("%s is blocked", u.Hostname())
+		return gtserror.SetUnretrievable(err)
+	}
+
+	return nil
+}
+
+// previewGET performs a GET request for the given URL using
+// transport, returning an error on any non-200 response, or
+// if the request was redirected to a non-permitted URL. Each
+// redirect is checked before it's followed, so non-permitted
+// URLs in a chain of redirects are never contacted.
+func (d *Dereferencer) previewGET(
+	ctx context.Context,
+	tsport transport.Transport,
+	u *url.URL,
+	accept string,
+) (*http.Response, error) {
+	ctx = gtscontext.SetHTTPClientRedirectFunc(ctx, func(r *http.Request) error {
+		return d.checkPreviewURL(r.Context(), r.URL)
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", accept)
+
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		err := gtserror.NewFromResponse(rsp)
+		_ = rsp.Body.Close()
+		return nil, err
+	}
+
+	return rsp, nil
+}
+
+// isPreviewHTML returns whether content-type is html.
+func isPreviewHTML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/html" ||
+		mediaType == "application/xhtml+xml"
+}
+
+// isHTTPURL returns whether str is an absolute http(s) URL.
+func isHTTPURL(str string) bool {
+	u, err := url.Parse(str)
+	return err == nil && u.Host != "" &&
+		(u.Scheme == "http" || u.Scheme == "https")
+}
+
+// truncate truncates str to at most n runes.
+func truncate(str string, n int) string {
+	if r := []rune(str); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return str
+}
+
+// previewMeta contains the preview metadata
+// found in an html page, keyed by meta tag
+// property / name, and "title" for <title>.
+type previewMeta struct {
+	values map[string]string
+	oembed string
+}
+
+// first returns the first non-empty value of given keys.
+func (m *previewMeta) first(keys ...string) string {
+	for _, key := range keys {
+		if v := strings.TrimSpace(m.values[key]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parsePreviewMeta parses preview metadata from the html
+// page in r, stopping once the page <body> is reached.
+func parsePreviewMeta(r io.Reader) (*previewMeta, error) {
+	meta := &previewMeta{values: make(map[string]string)}
+	tokenizer := html.NewTokenizer(r)
+
+	var inTitle bool
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, err
+			}
+			return meta, nil
+
+		case html.TextToken:
+			if inTitle && meta.values["title"] == "" {
+				meta.values["title"] = string(tokenizer.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if atom.Lookup(name) == atom.Title {
+				inTitle = false
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Body:
+				// Metadata should be in head.
+				return meta, nil
+
+			case atom.Title:
+				inTitle = true
+
+			case atom.Meta:
+				attrs := tokenAttrs(tokenizer, hasAttr)
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+				key = strings.ToLower(key)
+				if key != "" && meta.values[key] == "" {
+					meta.values[key] = attrs["content"]
+				}
+
+			case atom.Link:
+				attrs := tokenAttrs(tokenizer, hasAttr)
+				if meta.oembed == "" &&
+					strings.EqualFold(attrs["rel"], "alternate") &&
+					strings.EqualFold(attrs["type"], "application/json+oembed") {
+					meta.oembed = attrs["href"]
+				}
+			}
+		}
+	}
+}
+
+// tokenAttrs returns the attributes of the current tag
+// token, keyed by lowercase attribute name.
+func tokenAttrs(tokenizer *html.Tokenizer, hasAttr bool) map[string]string {
+	attrs := make(map[string]string)
+	for hasAttr {
+		var key, val []byte
+		key, val, hasAttr = tokenizer.TagAttr()
+		attrs[strings.ToLower(string(key))] = string(val)
+	}
+	return attrs
+}
+
+// previewLink returns the first link in given status
+// html content that should be previewed, skipping
+// mentions, hashtags and links to this instance.
+func previewLink(content string) *url.URL {
+	if content == "" {
+		return nil
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return nil
+
+		case html.StartTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if atom.Lookup(name) != atom.A {
+				continue
+			}
+
+			attrs := tokenAttrs(tokenizer, hasAttr)
+
+			// Skip mentions and hashtags.
+			class := strings.Fields(attrs["class"])
+			if slices.Contains(class, "mention") ||
+				slices.Contains(class, "hashtag") {
+				continue
+			}
+
+			rel := strings.Fields(attrs["rel"])
+			if slices.Contains(rel, "tag") {
+				continue
+			}
+
+			link, err := url.Parse(attrs["href"])
+			if err != nil ||
+				(link.Scheme != "http" && link.Scheme != "https") ||
+				link.Host == "" {
+				continue
+			}
+
+			// Skip links to ourselves.
+			if link.Host == config.GetHost() ||
+				link.Host == config.GetAccountDomain() {
+				continue
+			}
+
+			// Drop any fragment
+			// (same page anyway).
+			link.Fragment = ""
+			link.RawFragment = ""
+
+			return link
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PreviewCardTestSuite struct {
+	DereferencerStandardTestSuite
+
+	server       *httptest.Server
+	testStatuses map[string]*gtsmodel.Status
+}
+
+func (suite *PreviewCardTestSuite) SetupTest() {
+	suite.DereferencerStandardTestSuite.SetupTest()
+	suite.testStatuses = testrig.NewTestStatuses()
+
+	image, err := os.ReadFile("../../../testrig/media/thoughtsofdog-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+<head>
+<title>Fallback title</title>
+<meta property="og:title" content="Thoughts of dog">
+<meta property="og:description" content="A dog thinks about things.">
+<meta property="og:site_name" content="Dog News">
+<meta property="og:image" content="/dog.jpg">
+</head>
+<body><meta property="og:title" content="Ignored"></body>
+</html>`)
+	})
+	mux.HandleFunc("/video", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head>
+<meta name="twitter:title" content="Dog video">
+<link rel="alternate" type="application/json+oembed" href="/oembed.json">
+</head></html>`)
+	})
+	mux.HandleFunc("/oembed.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+  "type": "video",
+  "version": "1.0",
+  "title": "Dog video (oembed)",
+  "author_name": "dog",
+  "author_url": "https://example.org/@dog",
+  "provider_name": "DogTube",
+  "width": "640",
+  "height": 360,
+  "html": "<iframe src=\"https://example.org/embed/dog\" width=\"640\" height=\"360\" onload=\"alert(1)\"></iframe><script>alert(1)</script>"
+}`)
+	})
+	mux.HandleFunc("/dog.jpg", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write(image)
+	})
+	mux.HandleFunc("/plain.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "just some text")
+	})
+	suite.server = httptest.NewServer(mux)
+}
+
+func (suite *PreviewCardTestSuite) TearDownTest() {
+	suite.server.Close()
+	suite.DereferencerStandardTestSuite.TearDownTest()
+}
+
+// dereferencer returns a dereferencer using a real http client,
+// permitted to access loopback addresses only if allowLoopback.
+func (suite *PreviewCardTestSuite) dereferencer(allowLoopback bool) *dereferencing.Dereferencer {
+	var cfg httpclient.Config
+	if allowLoopback {
+		cfg.AllowRanges = []netip.Prefix{
+			netip.MustParsePrefix("127.0.0.0/8"),
+		}
+	}
+
+	d := dereferencing.NewDereferencer(
+		&suite.state,
+		typeutils.NewConverter(&suite.state),
+		testrig.NewTestTransportController(&suite.state, httpclient.New(cfg)),
+		visibility.NewFilter(&suite.state),
+		testrig.NewTestMediaManager(&suite.state),
+	)
+	return &d
+}
+
+// statusLinking returns a copy of a test status
+// with content linking to given server path.
+func (suite *PreviewCardTestSuite) statusLinking(path string) *gtsmodel.Status {
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_1_status_1"]
+	status.Content = `<p>hey <span class="h-card"><a href="http://localhost:8080/@1happyturtle" class="u-url mention">@<span>1happyturtle</span></a></span> look at ` +
+		`<a href="http://localhost:8080/tags/dogs" class="mention hashtag" rel="tag">#<span>dogs</span></a> ` +
+		`<a href="` + suite.server.URL + path + `" rel="nofollow noreferrer noopener" target="_blank">this</a></p>`
+	return status
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardOpenGraph() {
+	ctx := context.Background()
+	status := suite.statusLinking("/article")
+
+	err := suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.NotEmpty(status.PreviewCardID)
+
+	// Card should be stored in the database.
+	card, err := suite.db.GetPreviewCardByID(ctx, status.PreviewCardID)
+	suite.NoError(err)
+	suite.Equal(suite.server.URL+"/article", card.URL)
+	suite.Equal(gtsmodel.PreviewCardTypeLink, card.Type)
+	suite.Equal("Thoughts of dog", card.Title)
+	suite.Equal("A dog thinks about things.", card.Description)
+	suite.Equal("Dog News", card.ProviderName)
+	suite.Equal(suite.server.URL, card.ProviderURL)
+
+	// Thumbnail should have been fetched.
+	suite.NotNil(card.Image)
+	suite.True(*card.Image.Cached)
+	suite.Equal(suite.server.URL+"/dog.jpg", card.Image.RemoteURL)
+
+	// Status should be updated with card.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal(card.ID, dbStatus.PreviewCardID)
+	suite.NotNil(dbStatus.PreviewCard)
+
+	// A second status linking the page should share the card.
+	status2 := suite.statusLinking("/article")
+	status2.ID = id.NewULID()
+	status2.PreviewCardID = ""
+	err = suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status2)
+	suite.NoError(err)
+	suite.Equal(card.ID, status2.PreviewCardID)
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardOEmbed() {
+	ctx := context.Background()
+	status := suite.statusLinking("/video")
+
+	err := suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+
+	card, err := suite.db.GetPreviewCardByID(ctx, status.PreviewCardID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PreviewCardTypeVideo, card.Type)
+	suite.Equal("Dog video (oembed)", card.Title)
+	suite.Equal("dog", card.AuthorName)
+	suite.Equal("https://example.org/@dog", card.AuthorURL)
+	suite.Equal("DogTube", card.ProviderName)
+	suite.Equal(640, card.Width)
+	suite.Equal(360, card.Height)
+	suite.Equal(`<iframe src="https://example.org/embed/dog" width="640" height="360" sandbox="allow-scripts allow-same-origin allow-popups allow-presentation"></iframe>`, card.HTML)
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardNotHTML() {
+	ctx := context.Background()
+	status := suite.statusLinking("/plain.txt")
+
+	err := suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardDomainBlocked() {
+	ctx := context.Background()
+	status := suite.statusLinking("/article")
+
+	err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             "127.0.0.1",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	})
+	suite.NoError(err)
+
+	err = suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+}
+
+// redirectServer starts a server on another loopback
+// address to which the test server's /redirect path
+// redirects, returning the host it's served on, and
+// a count of the requests made to it.
+func (suite *PreviewCardTestSuite) redirectServer() (string, *atomic.Int32) {
+	l, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	handler := suite.server.Config.Handler
+	requests := new(atomic.Int32)
+	other := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	other.Listener = l
+	other.Start()
+	suite.T().Cleanup(other.Close)
+
+	suite.server.Config.Handler.(*http.ServeMux).HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+"/article", http.StatusFound)
+	})
+
+	return "127.0.0.2", requests
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardRedirect() {
+	ctx := context.Background()
+	_, requests := suite.redirectServer()
+	status := suite.statusLinking("/redirect")
+
+	err := suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.NotEmpty(status.PreviewCardID)
+	suite.NotZero(requests.Load())
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardRedirectDomainBlocked() {
+	ctx := context.Background()
+	host, requests := suite.redirectServer()
+	status := suite.statusLinking("/redirect")
+
+	// Block only the domain redirected to.
+	err := suite.db.CreateDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             host,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	})
+	suite.NoError(err)
+
+	err = suite.dereferencer(true).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+
+	// Blocked domain should
+	// never have been contacted.
+	suite.Zero(requests.Load())
+}
+
+func (suite *PreviewCardTestSuite) TestRefreshPreviewCardReservedAddr() {
+	ctx := context.Background()
+	status := suite.statusLinking("/article")
+
+	// Default http client must not be
+	// permitted to fetch from loopback.
+	err := suite.dereferencer(false).RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+}
+
+func TestPreviewCardTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewCardTestSuite))
+}
@@ -392,6 +392,7 @@ func (d *Dereferencer) enrichStatus(
 	if apubStatus == nil {
 		// Dereference latest version of the status.
 		rsp, err := tsport.Dereference(ctx, uri)
+		countDereference(ctx, "status", err)
 		if err != nil {
 			err := gtserror.Newf("error dereferencing %s: %w", uri, err)
 			return nil, nil, gtserror.SetUnretrievable(err)
@@ -500,6 +501,8 @@ func (d *Dereferencer) enrichStatus(
 	latestStatus.UpdatedAt = status.UpdatedAt
 	latestStatus.FetchedAt = time.Now()
 	latestStatus.Local = status.Local
+	latestStatus.PreviewCardID = status.PreviewCardID
+	latestStatus.PreviewCard = status.PreviewCard
 
 	// Check if this is a permitted status we should accept.
 	permit, err := d.isPermittedStatus(ctx, status, latestStatus)
@@ -551,12 +554,23 @@ func (d *Dereferencer) enrichStatus(
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
 	} else {
+		// Check for edits to the status since we last saw it, storing previous version.
+		if err := d.handleStatusEdit(ctx, status, latestStatus); err != nil {
+			return nil, nil, gtserror.Newf("error handling edit for status %s: %w", uri, err)
+		}
+
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
 			return nil, nil, gtserror.Newf("error updating database: %w", err)
 		}
 	}
 
+	if isNew || latestStatus.Content != status.Content {
+		// Links in the status may have changed,
+		// (re)generate the preview card async.
+		d.RefreshStatusPreviewCardAsync(latestStatus.ID)
+	}
+
 	return latestStatus, apubStatus, nil
 }
 
@@ -619,27 +633,50 @@ func (d *Dereferencer) isPermittedStatus(
 		return onFail()
 	}
 
-	// Default to true
-	permitted = true
+	if !*status.InReplyTo.Local {
+		// Replies to remote statuses are
+		// for the remote to police, assume
+		// the remote has done its job.
+		return true, nil
+	}
 
-	if *status.InReplyTo.Local {
-		// Check visibility of inReplyTo to status author.
-		permitted, err = d.visibility.StatusVisible(ctx,
-			status.Account,
-			status.InReplyTo,
-		)
-		if err != nil {
-			return false, gtserror.Newf("error checking in-reply-to visibility: %w", err)
-		}
+	// Check visibility of inReplyTo to status author.
+	permitted, err = d.visibility.StatusVisible(ctx,
+		status.Account,
+		status.InReplyTo,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking in-reply-to visibility: %w", err)
 	}
 
-	if permitted &&
-		*status.InReplyTo.Replyable {
-		// Status is reply-able to.
-		return true, nil
+	if !permitted {
+		return onFail()
 	}
 
-	return onFail()
+	// Check interaction policy of inReplyTo permits reply.
+	permission, err := d.visibility.StatusReplyable(ctx,
+		status.Account,
+		status.InReplyTo,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking in-reply-to interaction policy: %w", err)
+	}
+
+	switch permission {
+	case gtsmodel.PolicyPermissionPermitted:
+		status.PendingApproval = util.Ptr(false)
+		return true, nil
+
+	case gtsmodel.PolicyPermissionWithApproval:
+		// Reply is permitted pending approval,
+		// unless we've already approved it.
+		status.PendingApproval = util.Ptr(existing == nil ||
+			existing.ID == "" || existing.IsPendingApproval())
+		return true, nil
+
+	default:
+		return onFail()
+	}
 }
 
 func (d *Dereferencer) fetchStatusMentions(
@@ -836,6 +873,74 @@ func (d *Dereferencer) fetchStatusTags(
 	return nil
 }
 
+// handleStatusEdit carries over existing edit history to the latest
+// version of a status and, if the status has been edited since we
+// last saw it, stores the existing version as a historical edit.
+func (d *Dereferencer) handleStatusEdit(
+	ctx context.Context,
+	existing *gtsmodel.Status,
+	status *gtsmodel.Status,
+) error {
+	// Carry over existing edit history.
+	status.EditIDs = existing.EditIDs
+	status.Edits = existing.Edits
+
+	if !statusEdited(existing, status) {
+		// Nothing changed; keep any
+		// previously set edit time.
+		if status.EditedAt.IsZero() {
+			status.EditedAt = existing.EditedAt
+		}
+		return nil
+	}
+
+	// Snapshot the existing
+	// version as an edit.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		Content:        existing.Content,
+		ContentWarning: existing.ContentWarning,
+		Text:           existing.Text,
+		Language:       existing.Language,
+		Sensitive:      existing.Sensitive,
+		AttachmentIDs:  existing.AttachmentIDs,
+		StatusID:       existing.ID,
+		CreatedAt:      existing.EditedAt,
+	}
+
+	if edit.CreatedAt.IsZero() {
+		// Never edited before,
+		// this is the original.
+		edit.CreatedAt = existing.CreatedAt
+	}
+
+	if existing.Poll != nil {
+		edit.PollOptions = existing.Poll.Options
+
+		if status.Poll == nil || existing.PollID != status.PollID {
+			// Poll was replaced, so store
+			// its final vote counts with edit.
+			edit.PollVotes = existing.Poll.Votes
+		}
+	}
+
+	// Insert this new edit into the database.
+	if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		return gtserror.Newf("error putting edit in database: %w", err)
+	}
+
+	status.EditIDs = append(slices.Clone(status.EditIDs), edit.ID)
+	status.Edits = append(slices.Clone(status.Edits), edit)
+
+	if status.EditedAt.IsZero() {
+		// Remote didn't provide an
+		// edit time, so just use now.
+		status.EditedAt = time.Now()
+	}
+
+	return nil
+}
+
 func (d *Dereferencer) fetchStatusPoll(
 	ctx context.Context,
 	existing *gtsmodel.Status,
@@ -846,10 +951,14 @@ func (d *Dereferencer) fetchStatusPoll(
 		insertStatusPoll = func(ctx context.Context, status *gtsmodel.Status) error {
 			var err error
 
-			// Generate new ID for poll from the status CreatedAt.
-			// TODO: update this to use "edited_at" when we add
-			//       support for edited status revision history.
-			status.Poll.ID, err = id.NewULIDFromTime(status.CreatedAt)
+			// Generate new ID for poll from the status
+			// EditedAt, or CreatedAt if never edited.
+			createdAt := status.EditedAt
+			if createdAt.IsZero() {
+				createdAt = status.CreatedAt
+			}
+
+			status.Poll.ID, err = id.NewULIDFromTime(createdAt)
 			if err != nil {
 				log.Errorf(ctx, "invalid created at date (falling back to 'now'): %v", err)
 				status.Poll.ID = id.NewULID() // just use "now"
@@ -50,6 +50,28 @@ func emojiChanged(existing, latest *gtsmodel.Emoji) bool {
 		existing.ImageStaticRemoteURL != latest.ImageStaticRemoteURL
 }
 
+// statusEdited returns whether a status has changed in a
+// way that indicates it has been edited by its author, i.e.
+// if its content, warning, sensitivity, attachments or poll
+// options have changed since we last saw it.
+func statusEdited(existing, latest *gtsmodel.Status) bool {
+	if existing.Content != latest.Content ||
+		existing.ContentWarning != latest.ContentWarning ||
+		*existing.Sensitive != *latest.Sensitive ||
+		!slices.Equal(existing.AttachmentIDs, latest.AttachmentIDs) {
+		return true
+	}
+
+	switch {
+	case existing.Poll == nil && latest.Poll == nil:
+		return false
+	case existing.Poll == nil || latest.Poll == nil:
+		return true
+	default:
+		return !slices.Equal(existing.Poll.Options, latest.Poll.Options)
+	}
+}
+
 // pollChanged returns whether a poll has changed in way that
 // indicates that this should be an entirely new poll. i.e. if
 // the available options have changed, or the expiry has increased.
@@ -25,6 +25,7 @@ import (
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -66,6 +67,20 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				// Cast the vocab.Type object to known AS type.
 				asFollow := objType.(vocab.ActivityStreamsFollow)
 
+				// Check whether this accepts a relay Follow
+				// sent by our instance actor, and handle it.
+				relay, err := f.getRelayForFollow(ctx, receivingAcct, ap.GetJSONLDId(asFollow))
+				if err != nil {
+					return err
+				}
+
+				if relay != nil {
+					if err := f.setRelayState(ctx, relay, requestingAcct, gtsmodel.RelayStateAccepted); err != nil {
+						return err
+					}
+					continue
+				}
+
 				// convert the follow to something we can understand
 				gtsFollow, err := f.converter.ASFollowToFollow(ctx, asFollow)
 				if err != nil {
@@ -89,7 +104,7 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 					return err
 				}
 
-				f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+				f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 					APObjectType:   ap.ActivityFollow,
 					APActivityType: ap.ActivityAccept,
 					GTSModel:       follow,
@@ -107,6 +122,21 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 
 			// Extract IRI from object.
 			iri := object.GetIRI()
+
+			// Check whether this accepts a relay Follow
+			// sent by our instance actor, and handle it.
+			relay, err := f.getRelayForFollow(ctx, receivingAcct, iri)
+			if err != nil {
+				return err
+			}
+
+			if relay != nil {
+				if err := f.setRelayState(ctx, relay, requestingAcct, gtsmodel.RelayStateAccepted); err != nil {
+					return err
+				}
+				continue
+			}
+
 			if !uris.IsFollowPath(iri) {
 				continue
 			}
@@ -137,7 +167,7 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 				return err
 			}
 
-			f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+			f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 				APObjectType:   ap.ActivityFollow,
 				APActivityType: ap.ActivityAccept,
 				GTSModel:       follow,
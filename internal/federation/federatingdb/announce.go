@@ -19,15 +19,21 @@ package federatingdb
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"slices"
 
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
@@ -69,6 +75,18 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Announces from relays we're subscribed to
+	// bring new statuses in, rather than boosts.
+	isRelay, err := f.isAcceptedRelay(ctx, requestingAcct)
+	if err != nil {
+		return err
+	}
+
+	if isRelay {
+		f.relayAnnounce(ctx, receivingAcct, requestingAcct, announce)
+		return nil
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
@@ -80,8 +98,24 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		return nil
 	}
 
+	// If this boosts one of our statuses, ensure its
+	// interaction policy permits the requester to boost
+	// it, marking the boost as pending approval if need be.
+	permitted, err := f.checkAnnouncePermitted(ctx, requestingAcct, boost)
+	if err != nil {
+		return gtserror.Newf("error checking boost permission: %w", err)
+	}
+
+	if !permitted {
+		log.Debugf(ctx,
+			"announce %s of status %s not permitted; dropping it",
+			boost.URI, boost.BoostOfURI,
+		)
+		return nil
+	}
+
 	// This is a new boost. Process side effects asynchronously.
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ActivityAnnounce,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       boost,
@@ -91,3 +125,64 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 
 	return nil
 }
+
+// checkAnnouncePermitted checks whether the given requester is
+// permitted to boost the target of the given boost wrapper, if
+// the target is a local status. If the target's interaction
+// policy requires approval, the boost is marked as pending.
+func (f *federatingDB) checkAnnouncePermitted(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	boost *gtsmodel.Status,
+) (bool, error) {
+	boostOfURI, err := url.Parse(boost.BoostOfURI)
+	if err != nil || boostOfURI.Host != config.GetHost() {
+		// Not a boost of one
+		// of ours (or invalid,
+		// handled elsewhere).
+		return true, nil
+	}
+
+	target, err := f.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		boost.BoostOfURI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting status %s: %w", boost.BoostOfURI, err)
+	}
+
+	if target == nil || !target.IsLocal() {
+		// Nothing
+		// to check.
+		return true, nil
+	}
+
+	// Check status visibility
+	// and policy permits boost.
+	boostable, err := f.visFilter.StatusBoostable(ctx,
+		requester,
+		target,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error seeing if status %s is boostable: %w", target.ID, err)
+	}
+
+	if !boostable {
+		return false, nil
+	}
+
+	// Check whether boost needs approval.
+	permission, err := f.visFilter.StatusAnnounceable(ctx,
+		requester,
+		target,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error seeing if status %s is announceable: %w", target.ID, err)
+	}
+
+	if permission == gtsmodel.PolicyPermissionWithApproval {
+		boost.PendingApproval = util.Ptr(true)
+	}
+
+	return true, nil
+}
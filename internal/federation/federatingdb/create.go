@@ -26,6 +26,7 @@ import (
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -130,7 +131,7 @@ func (f *federatingDB) activityBlock(ctx context.Context, asType vocab.Type, rec
 		return fmt.Errorf("activityBlock: database error inserting block: %s", err)
 	}
 
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ActivityBlock,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       block,
@@ -297,7 +298,7 @@ func (f *federatingDB) createPollOptionables(
 	}
 
 	// Enqueue message to the fedi API worker with poll vote(s).
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APActivityType: ap.ActivityCreate,
 		APObjectType:   ap.ActivityQuestion,
 		GTSModel: &gtsmodel.PollVote{
@@ -326,6 +327,27 @@ func (f *federatingDB) createStatusable(
 	statusable ap.Statusable,
 	forwarded bool,
 ) error {
+	if forwarded {
+		// Statuses forwarded by a relay we're subscribed
+		// to are wanted regardless of their relevance to
+		// the receiver; they're fetched from origin below.
+		isRelay, err := f.isAcceptedRelay(ctx, requester)
+		if err != nil {
+			return err
+		}
+
+		if isRelay {
+			f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				APIRI:          ap.GetJSONLDId(statusable),
+				Receiving:      receiver,
+				Requesting:     requester,
+			})
+			return nil
+		}
+	}
+
 	// Check whether this status is both
 	// relevant, and doesn't look like spam.
 	err := f.spamFilter.StatusableOK(ctx,
@@ -367,6 +389,25 @@ func (f *federatingDB) createStatusable(
 		return gtserror.Newf("error checking relevancy/spam: %w", err)
 	}
 
+	if !forwarded {
+		// Check whether this is a reply to one of our
+		// statuses that the requester is forbidden from
+		// replying to by its interaction policy. Replies
+		// requiring approval are handled on dereference.
+		forbidden, err := f.isReplyForbidden(ctx, requester, statusable)
+		if err != nil {
+			return gtserror.Newf("error checking reply permission: %w", err)
+		}
+
+		if forbidden {
+			log.Debugf(ctx,
+				"status %s is a reply forbidden by interaction policy; dropping it",
+				ap.GetJSONLDId(statusable),
+			)
+			return nil
+		}
+	}
+
 	// If we do have a forward, we should ignore the content
 	// and instead deref based on the URI of the statusable.
 	//
@@ -377,7 +418,7 @@ func (f *federatingDB) createStatusable(
 
 		// Pass the statusable URI (APIri) into the processor
 		// worker and do the rest of the processing asynchronously.
-		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 			APObjectType:   ap.ObjectNote,
 			APActivityType: ap.ActivityCreate,
 			APIRI:          ap.GetJSONLDId(statusable),
@@ -391,7 +432,7 @@ func (f *federatingDB) createStatusable(
 
 	// Do the rest of the processing asynchronously. The processor
 	// will handle inserting/updating + further dereferencing the status.
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ObjectNote,
 		APActivityType: ap.ActivityCreate,
 		APIRI:          nil,
@@ -404,6 +445,43 @@ func (f *federatingDB) createStatusable(
 	return nil
 }
 
+// isReplyForbidden returns whether the given statusable is a
+// reply to a local status, with an interaction policy that
+// forbids the given requester from replying to it.
+func (f *federatingDB) isReplyForbidden(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+) (bool, error) {
+	inReplyToURI := ap.ExtractInReplyToURI(statusable)
+	if inReplyToURI == nil || inReplyToURI.Host != config.GetHost() {
+		// Not a reply to
+		// one of ours.
+		return false, nil
+	}
+
+	inReplyTo, err := f.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		inReplyToURI.String(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting status %s: %w", inReplyToURI, err)
+	}
+
+	if inReplyTo == nil || !inReplyTo.IsLocal() {
+		// Nothing
+		// to check.
+		return false, nil
+	}
+
+	permission, err := f.visFilter.StatusReplyable(ctx, requester, inReplyTo)
+	if err != nil {
+		return false, gtserror.Newf("error seeing if status %s is replyable: %w", inReplyTo.ID, err)
+	}
+
+	return permission == gtsmodel.PolicyPermissionForbidden, nil
+}
+
 /*
 	FOLLOW HANDLERS
 */
@@ -439,7 +517,7 @@ func (f *federatingDB) activityFollow(ctx context.Context, asType vocab.Type, re
 		return fmt.Errorf("activityFollow: database error inserting follow request: %s", err)
 	}
 
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ActivityFollow,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       followRequest,
@@ -472,6 +550,24 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		)
 	}
 
+	if fave.Status.IsLocal() {
+		// Check interaction policy of our status
+		// permits the fave. Approval isn't supported
+		// for faves, so anything else is forbidden.
+		permission, err := f.visFilter.StatusLikeable(ctx,
+			requestingAccount,
+			fave.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("activityLike: error checking fave permission: %w", err)
+		}
+
+		if permission != gtsmodel.PolicyPermissionPermitted {
+			log.Debugf(ctx, "like %s forbidden by interaction policy; dropping it", fave.URI)
+			return nil
+		}
+	}
+
 	fave.ID = id.NewULID()
 
 	if err := f.state.DB.PutStatusFave(ctx, fave); err != nil {
@@ -484,7 +580,7 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return fmt.Errorf("activityLike: database error inserting fave: %w", err)
 	}
 
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ActivityLike,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       fave,
@@ -536,7 +632,7 @@ func (f *federatingDB) activityFlag(ctx context.Context, asType vocab.Type, rece
 		return fmt.Errorf("activityFlag: database error inserting report: %w", err)
 	}
 
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ActivityFlag,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       report,
@@ -112,7 +112,7 @@ func (f *federatingDB) deleteAccount(
 		}
 
 		log.Debugf(ctx, "deleting account: %s", account.URI)
-		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 			APObjectType:   ap.ActorPerson,
 			APActivityType: ap.ActivityDelete,
 			GTSModel:       account,
@@ -151,7 +151,7 @@ func (f *federatingDB) deleteStatus(
 		}
 
 		log.Debugf(ctx, "deleting status: %s", status.URI)
-		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 			APObjectType:   ap.ObjectNote,
 			APActivityType: ap.ActivityDelete,
 			GTSModel:       status,
@@ -170,7 +170,7 @@ func (f *federatingDB) Move(ctx context.Context, move vocab.ActivityStreamsMove)
 
 	// We had a Move already or stored a new Move.
 	// Pass back to a worker for async processing.
-	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+	f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
 		APObjectType:   ap.ActorPerson,
 		APActivityType: ap.ActivityMove,
 		GTSModel:       stubMove,
@@ -21,10 +21,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
@@ -50,6 +52,27 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 
 	for _, obj := range ap.ExtractObjects(reject) {
 
+		// Check whether this rejects a relay Follow
+		// sent by our instance actor, and handle it.
+		var followIRI *url.URL
+		if obj.IsIRI() {
+			followIRI = obj.GetIRI()
+		} else if t := obj.GetType(); t != nil {
+			followIRI = ap.GetJSONLDId(t)
+		}
+
+		relay, err := f.getRelayForFollow(ctx, receivingAcct, followIRI)
+		if err != nil {
+			return err
+		}
+
+		if relay != nil {
+			if err := f.setRelayState(ctx, relay, requestingAcct, gtsmodel.RelayStateRejected); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if obj.IsIRI() {
 			// we have just the URI of whatever is being rejected, so we need to find out what it is
 			rejectedObjectIRI := obj.GetIRI()
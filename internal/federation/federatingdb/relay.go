@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// getRelayForFollow returns the relay subscription
+// with the given Follow IRI, if the Follow was sent
+// by our instance actor to a relay. Returns nil if
+// the Follow is not a relay Follow.
+func (f *federatingDB) getRelayForFollow(
+	ctx context.Context,
+	receiver *gtsmodel.Account,
+	followIRI *url.URL,
+) (*gtsmodel.Relay, error) {
+	if followIRI == nil || !receiver.IsInstance() {
+		// Only the instance
+		// actor follows relays.
+		return nil, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, followIRI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting relay for follow %s: %w", followIRI, err)
+	}
+
+	return relay, nil
+}
+
+// setRelayState updates the given relay to the given state
+// in response to an Accept or Reject of our Follow, recording
+// the requester as the relay actor.
+func (f *federatingDB) setRelayState(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	requester *gtsmodel.Account,
+	state gtsmodel.RelayState,
+) error {
+	inbox, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing relay inbox %s: %w", relay.InboxURI, err)
+	}
+
+	// Make sure the response to our Follow came
+	// from the same host we sent the Follow to.
+	if requester.Domain != inbox.Hostname() {
+		return gtserror.Newf(
+			"requester %s does not belong to relay %s",
+			requester.URI, relay.InboxURI,
+		)
+	}
+
+	relay.ActorURI = requester.URI
+	relay.State = state
+	if err := f.state.DB.UpdateRelay(ctx, relay, "actor_uri", "state"); err != nil {
+		return gtserror.Newf("db error updating relay %s: %w", relay.ID, err)
+	}
+
+	return nil
+}
+
+// isAcceptedRelay returns whether the given
+// requester is the actor of an accepted relay.
+func (f *federatingDB) isAcceptedRelay(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (bool, error) {
+	isRelay, err := f.state.DB.IsAcceptedRelayActor(ctx, requester.URI)
+	if err != nil {
+		return false, gtserror.Newf("db error checking relay actor %s: %w", requester.URI, err)
+	}
+	return isRelay, nil
+}
+
+// relayAnnounce handles an Announce sent by an accepted relay.
+// Rather than creating a boost by the relay actor, each remote
+// status IRI in the Announce is passed to the processor to be
+// dereferenced from its origin, which places it in the public
+// (federated) timeline.
+func (f *federatingDB) relayAnnounce(
+	ctx context.Context,
+	receiver *gtsmodel.Account,
+	requester *gtsmodel.Account,
+	announce ap.WithObject,
+) {
+	for _, object := range ap.ExtractObjects(announce) {
+		var iri *url.URL
+
+		if object.IsIRI() {
+			iri = object.GetIRI()
+		} else if t := object.GetType(); t != nil {
+			// Relays may embed the announced object,
+			// but we fetch it ourselves from origin
+			// rather than trusting the relay's copy.
+			iri = ap.GetJSONLDId(t)
+		}
+
+		if iri == nil ||
+			iri.Host == config.GetHost() ||
+			iri.Host == config.GetAccountDomain() {
+			// Nothing to do.
+			continue
+		}
+
+		log.Debugf(ctx, "relay %s announced %s", requester.URI, iri)
+
+		f.state.Workers.Federator.Queue.Push(ctx, &messages.FromFediAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			APIRI:          iri,
+			Receiving:      receiver,
+			Requesting:     requester,
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// putRelay puts a relay in the given state in the database,
+// with the inbox on the host of the given (fake) relay actor.
+func (suite *RelayTestSuite) putRelay(
+	instanceAcct *gtsmodel.Account,
+	relayActor *gtsmodel.Account,
+	state gtsmodel.RelayState,
+) *gtsmodel.Relay {
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:                 relayID,
+		InboxURI:           "http://" + relayActor.Domain + "/inbox",
+		FollowURI:          uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+		State:              state,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	if state == gtsmodel.RelayStateAccepted {
+		relay.ActorURI = relayActor.URI
+	}
+
+	if err := suite.db.PutRelay(context.Background(), relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return relay
+}
+
+// setRelayResponse sets the actor of the given
+// Accept or Reject, and the Follow IRI as its object.
+func setRelayResponse(
+	activity interface {
+		ap.WithActor
+		ap.WithObject
+	},
+	actor *gtsmodel.Account,
+	followURI string,
+) {
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actor.URI))
+	activity.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(followURI))
+	activity.SetActivityStreamsObject(objectProp)
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollow() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayActor   = suite.testAccounts["remote_account_1"]
+		relay        = suite.putRelay(instanceAcct, relayActor, gtsmodel.RelayStatePending)
+	)
+
+	ctx := createTestContext(instanceAcct, relayActor)
+	accept := streams.NewActivityStreamsAccept()
+	setRelayResponse(accept, relayActor, relay.FollowURI)
+
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relay, err := suite.db.GetRelayByID(context.Background(), relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.RelayStateAccepted, relay.State)
+	suite.Equal(relayActor.URI, relay.ActorURI)
+
+	// Nothing should be sent to the processor.
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollowWrongHost() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayActor   = suite.testAccounts["remote_account_1"]
+		otherActor   = suite.testAccounts["remote_account_2"]
+		relay        = suite.putRelay(instanceAcct, relayActor, gtsmodel.RelayStatePending)
+	)
+
+	// Accept comes from a host
+	// other than the relay's.
+	ctx := createTestContext(instanceAcct, otherActor)
+	accept := streams.NewActivityStreamsAccept()
+	setRelayResponse(accept, otherActor, relay.FollowURI)
+
+	err := suite.federatingDB.Accept(ctx, accept)
+	suite.Error(err)
+
+	relay, err = suite.db.GetRelayByID(context.Background(), relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStatePending, relay.State)
+}
+
+func (suite *RelayTestSuite) TestRejectRelayFollow() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayActor   = suite.testAccounts["remote_account_1"]
+		relay        = suite.putRelay(instanceAcct, relayActor, gtsmodel.RelayStatePending)
+	)
+
+	ctx := createTestContext(instanceAcct, relayActor)
+	reject := streams.NewActivityStreamsReject()
+	setRelayResponse(reject, relayActor, relay.FollowURI)
+
+	if err := suite.federatingDB.Reject(ctx, reject); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relay, err := suite.db.GetRelayByID(context.Background(), relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStateRejected, relay.State)
+}
+
+func (suite *RelayTestSuite) TestRelayAnnounce() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayActor   = suite.testAccounts["remote_account_1"]
+		_            = suite.putRelay(instanceAcct, relayActor, gtsmodel.RelayStateAccepted)
+	)
+
+	ctx := createTestContext(instanceAcct, relayActor)
+	announce := suite.testActivities["announce_forwarded_1_zork"]
+
+	if err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The announced status should be passed to the
+	// processor to dereference, rather than a boost.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("expected message for processor")
+	}
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Nil(msg.GTSModel)
+	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIRI.String())
+}
+
+func (suite *RelayTestSuite) TestPendingRelayAnnounce() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayActor   = suite.testAccounts["remote_account_1"]
+		relay        = suite.putRelay(instanceAcct, relayActor, gtsmodel.RelayStatePending)
+	)
+
+	// Actor is set, but the relay isn't
+	// accepted, so treat it as a normal boost.
+	relay.ActorURI = relayActor.URI
+	if err := suite.db.UpdateRelay(context.Background(), relay, "actor_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(instanceAcct, relayActor)
+	announce := suite.testActivities["announce_forwarded_1_zork"]
+
+	if err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("expected message for processor")
+	}
+	suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
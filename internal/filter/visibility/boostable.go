@@ -53,8 +53,18 @@ func (f *Filter) StatusBoostable(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
-	if !*status.Boostable {
-		log.Trace(ctx, "status marked not boostable")
+	// Check whether status interaction
+	// policy permits requester to boost.
+	permission, err := f.StatusAnnounceable(ctx,
+		requester,
+		status,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if permission == gtsmodel.PolicyPermissionForbidden {
+		log.Trace(ctx, "status interaction policy forbids boost")
 		return false, nil
 	}
 
@@ -93,6 +93,14 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 		return true, nil
 	}
 
+	if status.IsImported() {
+		// Imported statuses were already seen (or
+		// not) when they were originally posted, so
+		// don't surface them again to followers.
+		log.Trace(ctx, "ignoring imported status")
+		return false, nil
+	}
+
 	if status.MentionsAccount(owner.ID) {
 		// Can always see when you are mentioned.
 		return true, nil
@@ -190,8 +198,19 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 	}
 
 	if follow == nil {
-		log.Trace(ctx, "ignoring status from unfollowed author")
-		return false, nil
+		// Owner doesn't follow the author, but may
+		// follow one of the hashtags used by status.
+		followingTag, err := f.isFollowedTagTimelineable(ctx, owner, status)
+		if err != nil {
+			return false, err
+		}
+
+		if !followingTag {
+			log.Trace(ctx, "ignoring status from unfollowed author")
+			return false, nil
+		}
+
+		return true, nil
 	}
 
 	if status.BoostOfID != "" && !*follow.ShowReblogs {
@@ -203,6 +222,25 @@ func (f *Filter) isStatusHomeTimelineable(ctx context.Context, owner *gtsmodel.A
 	return true, nil
 }
 
+// isFollowedTagTimelineable checks whether the given status
+// from an unfollowed author should be included on owner's home
+// timeline on account of the owner following one of its hashtags.
+func (f *Filter) isFollowedTagTimelineable(ctx context.Context, owner *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" {
+		// Only public, original statuses
+		// are shown through followed tags.
+		return false, nil
+	}
+
+	following, err := f.state.DB.IsFollowingAnyTag(ctx, owner.ID, status.TagIDs)
+	if err != nil {
+		return false, gtserror.Newf("error checking followed tags for account %s: %w", owner.ID, err)
+	}
+
+	return following, nil
+}
+
 func (f *Filter) isVisibleConversation(
 	ctx context.Context,
 	owner *gtsmodel.Account,
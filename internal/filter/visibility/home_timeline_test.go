@@ -55,6 +55,27 @@ func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingStatusHomeTimel
 	suite.True(timelineable)
 }
 
+func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingImportedStatusNotHomeTimelineable() {
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	// Copy a status by a followed account,
+	// and mark it as imported from an archive.
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_2_status_1"]
+	testStatus.ID = "01J5QVPFB0PS0M8ZHT84FWZ9X8"
+	testStatus.Imported = util.Ptr(true)
+
+	timelineable, err := suite.filter.StatusHomeTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+
+	// Author should still see it.
+	timelineable, err = suite.filter.StatusHomeTimelineable(ctx, suite.testAccounts["local_account_2"], testStatus)
+	suite.NoError(err)
+	suite.True(timelineable)
+}
+
 func (suite *StatusStatusHomeTimelineableTestSuite) TestFollowingBoostedStatusHomeTimelineable() {
 	ctx := context.Background()
 
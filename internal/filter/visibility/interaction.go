@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// StatusLikeable checks whether the interaction policy
+// of the given status permits requester to like it.
+//
+// This does not check status visibility to requester.
+func (f *Filter) StatusLikeable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (gtsmodel.PolicyPermission, error) {
+	return f.statusInteractable(ctx,
+		requester,
+		status,
+		status.GetInteractionPolicy().CanLike,
+	)
+}
+
+// StatusReplyable checks whether the interaction policy
+// of the given status permits requester to reply to it.
+//
+// This does not check status visibility to requester.
+func (f *Filter) StatusReplyable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (gtsmodel.PolicyPermission, error) {
+	return f.statusInteractable(ctx,
+		requester,
+		status,
+		status.GetInteractionPolicy().CanReply,
+	)
+}
+
+// StatusAnnounceable checks whether the interaction policy
+// of the given status permits requester to announce (boost) it.
+//
+// This does not check status visibility to requester, nor the
+// visibility-level restrictions on boosting applied by StatusBoostable().
+func (f *Filter) StatusAnnounceable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (gtsmodel.PolicyPermission, error) {
+	return f.statusInteractable(ctx,
+		requester,
+		status,
+		status.GetInteractionPolicy().CanAnnounce,
+	)
+}
+
+// statusInteractable determines which groups of accounts in an interaction
+// policy requester belongs to, in relation to the given status, and returns
+// the permission granted to those groups by the given policy rules.
+func (f *Filter) statusInteractable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	rules gtsmodel.PolicyRules,
+) (gtsmodel.PolicyPermission, error) {
+	if requester.ID == status.AccountID {
+		// Author can always
+		// interact with their
+		// own statuses.
+		return gtsmodel.PolicyPermissionPermitted, nil
+	}
+
+	// Everyone is a member of the public.
+	values := []gtsmodel.PolicyValue{gtsmodel.PolicyValuePublic}
+
+	if !status.MentionsPopulated() {
+		var err error
+
+		// Status needs its mentions populating, fetch these from database.
+		status.Mentions, err = f.state.DB.GetMentions(ctx, status.MentionIDs)
+		if err != nil {
+			return gtsmodel.PolicyPermissionForbidden, gtserror.Newf("error populating status %s mentions: %w", status.ID, err)
+		}
+	}
+
+	if status.MentionsAccount(requester.ID) {
+		values = append(values, gtsmodel.PolicyValueMentioned)
+	}
+
+	// Check requester follows status author.
+	follows, err := f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		status.AccountID,
+	)
+	if err != nil {
+		return gtsmodel.PolicyPermissionForbidden, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, status.AccountID, err)
+	}
+
+	if follows {
+		values = append(values, gtsmodel.PolicyValueFollowers)
+	}
+
+	return rules.Permission(values...), nil
+}
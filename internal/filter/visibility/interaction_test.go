@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusInteractionTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusInteractionTestSuite) TestDefaultPolicyReplyable() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testAccount := suite.testAccounts["remote_account_1"]
+	ctx := context.Background()
+
+	permission, err := suite.filter.StatusReplyable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+
+	suite.Equal(gtsmodel.PolicyPermissionPermitted, permission)
+}
+
+func (suite *StatusInteractionTestSuite) TestLegacyNotReplyable() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.Replyable = util.Ptr(false)
+	testAccount := suite.testAccounts["remote_account_1"]
+	ctx := context.Background()
+
+	permission, err := suite.filter.StatusReplyable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+
+	suite.Equal(gtsmodel.PolicyPermissionForbidden, permission)
+}
+
+func (suite *StatusInteractionTestSuite) TestFollowersReplyable() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanReply: gtsmodel.PolicyRules{
+			Always:       gtsmodel.PolicyValues{gtsmodel.PolicyValueAuthor, gtsmodel.PolicyValueFollowers},
+			WithApproval: gtsmodel.PolicyValues{gtsmodel.PolicyValuePublic},
+		},
+	}
+	ctx := context.Background()
+
+	// admin_account follows local_account_1.
+	permission, err := suite.filter.StatusReplyable(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PolicyPermissionPermitted, permission)
+
+	// remote_account_1 doesn't, so needs approval.
+	permission, err = suite.filter.StatusReplyable(ctx, suite.testAccounts["remote_account_1"], testStatus)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PolicyPermissionWithApproval, permission)
+}
+
+func (suite *StatusInteractionTestSuite) TestAuthorOnlyAnnounceable() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanAnnounce: gtsmodel.PolicyRules{
+			Always: gtsmodel.PolicyValues{gtsmodel.PolicyValueAuthor},
+		},
+	}
+	ctx := context.Background()
+
+	permission, err := suite.filter.StatusAnnounceable(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PolicyPermissionPermitted, permission)
+
+	permission, err = suite.filter.StatusAnnounceable(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PolicyPermissionForbidden, permission)
+
+	boostable, err := suite.filter.StatusBoostable(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.False(boostable)
+}
+
+func (suite *StatusInteractionTestSuite) TestMentionedLikeable() {
+	testStatus := new(gtsmodel.Status)
+	*testStatus = *suite.testStatuses["local_account_2_status_5"]
+	testStatus.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanLike: gtsmodel.PolicyRules{
+			Always: gtsmodel.PolicyValues{gtsmodel.PolicyValueMentioned},
+		},
+	}
+	ctx := context.Background()
+
+	// local_account_1 is mentioned in the status.
+	permission, err := suite.filter.StatusLikeable(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PolicyPermissionPermitted, permission)
+
+	permission, err = suite.filter.StatusLikeable(ctx, suite.testAccounts["admin_account"], testStatus)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.PolicyPermissionForbidden, permission)
+}
+
+func TestStatusInteractionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusInteractionTestSuite))
+}
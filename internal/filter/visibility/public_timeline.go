@@ -80,6 +80,22 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	// Don't show imported statuses on timeline.
+	if status.IsImported() {
+		return false, nil
+	}
+
+	// Check moderation policies covering the status author.
+	strip, err := f.isStrippedFromPublicTimelines(ctx, status)
+	if err != nil {
+		return false, err
+	}
+
+	if strip {
+		log.Trace(ctx, "status author stripped from public timelines")
+		return false, nil
+	}
+
 	// Check whether status is visible to requesting account.
 	visible, err := f.StatusVisible(ctx, requester, status)
 	if err != nil {
@@ -121,3 +137,28 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 	// level status. Show on public timeline.
 	return true, nil
 }
+
+// isStrippedFromPublicTimelines returns whether a moderation policy
+// requires statuses by the given status' author to be stripped from
+// public timelines.
+func (f *Filter) isStrippedFromPublicTimelines(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	account := status.Account
+	if account == nil {
+		// Fetch the status author from DB.
+		var err error
+		account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status author %s: %w", status.AccountID, err)
+		}
+	}
+
+	policies, err := f.state.DB.GetModerationPoliciesForAccount(ctx, account)
+	if err != nil {
+		return false, gtserror.Newf("error getting moderation policies for %s: %w", account.URI, err)
+	}
+
+	return policies.StripPublicTimelines(), nil
+}
@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type StatusPublicTimelineableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusPublicTimelineableTestSuite) putAccountPolicy(account *gtsmodel.Account, strip bool) {
+	if err := suite.db.PutModerationPolicy(context.Background(), &gtsmodel.ModerationPolicy{
+		ID:                   "01J5BBJ4ZV2H4W2HN2HN7TJ5QP",
+		AccountID:            account.ID,
+		ForceSensitive:       util.Ptr(true),
+		RejectMedia:          util.Ptr(false),
+		StripPublicTimelines: util.Ptr(strip),
+		CreatedByAccountID:   suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestPublicStatusPublicTimelineable() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testAccount := suite.testAccounts["local_account_2"]
+	ctx := context.Background()
+
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+
+	suite.True(timelineable)
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestStrippedStatusNotPublicTimelineable() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testAccount := suite.testAccounts["local_account_2"]
+	ctx := context.Background()
+
+	suite.putAccountPolicy(suite.testAccounts["local_account_1"], true)
+
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+
+	suite.False(timelineable)
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestSensitizedStatusPublicTimelineable() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	testAccount := suite.testAccounts["local_account_2"]
+	ctx := context.Background()
+
+	// Policy without strip should not
+	// affect public timeline visibility.
+	suite.putAccountPolicy(suite.testAccounts["local_account_1"], false)
+
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, testAccount, testStatus)
+	suite.NoError(err)
+
+	suite.True(timelineable)
+}
+
+func TestStatusPublicTimelineableTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPublicTimelineableTestSuite))
+}
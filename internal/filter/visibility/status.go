@@ -90,6 +90,18 @@ func (f *Filter) isStatusVisible(ctx context.Context, requester *gtsmodel.Accoun
 		return false, nil
 	}
 
+	if status.IsPendingApproval() {
+		// Replies and boosts pending approval are only
+		// visible to their author, and to the author of
+		// the status they're interacting with.
+		if requester == nil || (requester.ID != status.AccountID &&
+			requester.ID != status.InReplyToAccountID &&
+			requester.ID != status.BoostOfAccountID) {
+			log.Trace(ctx, "status pending approval not visible to requester")
+			return false, nil
+		}
+	}
+
 	if status.Visibility == gtsmodel.VisibilityPublic {
 		// This status will be visible to all.
 		return true, nil
@@ -44,6 +44,7 @@ const (
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	httpClientRedirectFnKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -142,6 +143,19 @@ func SetHTTPClientSignFunc(ctx context.Context, fn func(*http.Request) error) co
 	return context.WithValue(ctx, httpClientSignFnKey, fn)
 }
 
+// HTTPClientRedirectFunc returns an httpclient redirect checking function for the current
+// client request context. This is called with each redirected request before it's followed.
+func HTTPClientRedirectFunc(ctx context.Context) func(*http.Request) error {
+	fn, _ := ctx.Value(httpClientRedirectFnKey).(func(*http.Request) error)
+	return fn
+}
+
+// SetHTTPClientRedirectFunc stores the given httpclient redirect checking function and returns
+// the wrapped context. See HTTPClientRedirectFunc() for further information on the function value.
+func SetHTTPClientRedirectFunc(ctx context.Context, fn func(*http.Request) error) context.Context {
+	return context.WithValue(ctx, httpClientRedirectFnKey, fn)
+}
+
 // HTTPSignatureVerifier returns an http signature verifier for the current ActivityPub
 // request chain. This verifier can be called to authenticate the current request.
 func HTTPSignatureVerifier(ctx context.Context) httpsig.VerifierWithOptions {
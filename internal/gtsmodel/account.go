@@ -196,8 +196,8 @@ type AccountToEmoji struct {
 }
 
 // Field represents a key value field on an account, for things like pronouns, website, etc.
-// VerifiedAt is optional, to be used only if Value is a URL to a webpage that contains the
-// username of the user.
+// VerifiedAt is optional, to be used only if Value is a URL to a webpage that contains a
+// rel="me" link back to the profile of the account.
 type Field struct {
 	Name       string    // Name of this field.
 	Value      string    // Value of this field.
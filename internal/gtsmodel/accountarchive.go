@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountArchive represents an archive of a local account's
+// data (profile, statuses, media, likes, bookmarks), requested
+// by the account owner and generated asynchronously.
+type AccountArchive struct {
+	ID          string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID   string              `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account this archive belongs to
+	Account     *Account            `bun:"-"`                                                           // account corresponding to accountID
+	State       AccountArchiveState `bun:",nullzero,notnull,default:'pending'"`                         // state of archive generation
+	StoragePath string              `bun:",nullzero"`                                                   // path of the archive zip file in storage, set once complete
+	Size        int64               `bun:",nullzero"`                                                   // size of the archive zip file in bytes, set once complete
+}
+
+// IsPending returns whether the archive
+// is still waiting to be generated.
+func (a *AccountArchive) IsPending() bool {
+	return a.State == AccountArchiveStatePending
+}
+
+// IsComplete returns whether the archive
+// has been generated and can be downloaded.
+func (a *AccountArchive) IsComplete() bool {
+	return a.State == AccountArchiveStateComplete
+}
+
+// AccountArchiveState is the state of an account archive.
+type AccountArchiveState string
+
+const (
+	AccountArchiveStatePending  AccountArchiveState = "pending"  // Queued or in progress.
+	AccountArchiveStateComplete AccountArchiveState = "complete" // Generated and stored.
+	AccountArchiveStateFailed   AccountArchiveState = "failed"   // Generation failed.
+)
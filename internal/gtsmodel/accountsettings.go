@@ -21,15 +21,43 @@ import "time"
 
 // AccountSettings models settings / preferences for a local, non-instance account.
 type AccountSettings struct {
-	AccountID         string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
-	CreatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
-	UpdatedAt         time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
-	Privacy           Visibility `bun:",nullzero"`                                                   // Default post privacy for this account
-	Sensitive         *bool      `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
-	Language          string     `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
-	StatusContentType string     `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
-	Theme             string     `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
-	CustomCSS         string     `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
-	EnableRSS         *bool      `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
-	HideCollections   *bool      `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	AccountID                      string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this settings.
+	CreatedAt                      time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt                      time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	Privacy                        Visibility         `bun:",nullzero"`                                                   // Default post privacy for this account
+	Sensitive                      *bool              `bun:",nullzero,notnull,default:false"`                             // Set posts from this account to sensitive by default?
+	Language                       string             `bun:",nullzero,notnull,default:'en'"`                              // What language does this account post in?
+	StatusContentType              string             `bun:",nullzero"`                                                   // What is the default format for statuses posted by this account (only for local accounts).
+	Theme                          string             `bun:",nullzero"`                                                   // Preset CSS theme filename selected by this Account (empty string if nothing set).
+	CustomCSS                      string             `bun:",nullzero"`                                                   // Custom CSS that should be displayed for this Account's profile and statuses.
+	EnableRSS                      *bool              `bun:",nullzero,notnull,default:false"`                             // enable RSS feed subscription for this account's public posts at [URL]/feed
+	HideCollections                *bool              `bun:",nullzero,notnull,default:false"`                             // Hide this account's followers/following collections.
+	InteractionPolicyPublic        *InteractionPolicy `bun:",nullzero"`                                                   // Default interaction policy for public statuses posted by this account.
+	InteractionPolicyUnlisted      *InteractionPolicy `bun:",nullzero"`                                                   // Default interaction policy for unlisted statuses posted by this account.
+	InteractionPolicyFollowersOnly *InteractionPolicy `bun:",nullzero"`                                                   // Default interaction policy for followers-only (and mutuals-only) statuses posted by this account.
+	InteractionPolicyDirect        *InteractionPolicy `bun:",nullzero"`                                                   // Default interaction policy for direct statuses posted by this account.
+}
+
+// InteractionPolicyFor returns this account's default interaction
+// policy for statuses of the given visibility, falling back to the
+// instance default if the account hasn't set one.
+func (s *AccountSettings) InteractionPolicyFor(visibility Visibility) *InteractionPolicy {
+	var policy *InteractionPolicy
+
+	switch visibility {
+	case VisibilityPublic:
+		policy = s.InteractionPolicyPublic
+	case VisibilityUnlocked:
+		policy = s.InteractionPolicyUnlisted
+	case VisibilityFollowersOnly, VisibilityMutualsOnly:
+		policy = s.InteractionPolicyFollowersOnly
+	case VisibilityDirect:
+		policy = s.InteractionPolicyDirect
+	}
+
+	if policy == nil {
+		policy = DefaultInteractionPolicyFor(visibility)
+	}
+
+	return policy
 }
@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Announcement models an instance-wide
+// announcement made by an admin, to
+// be shown to local users in their client.
+type Announcement struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Text        string    `bun:""`                                                            // raw text of the announcement, as submitted by the admin
+	Content     string    `bun:""`                                                            // html-formatted content of the announcement
+	StartsAt    time.Time `bun:"type:timestamptz,nullzero"`                                   // time at which the announcement should start being shown, if any
+	EndsAt      time.Time `bun:"type:timestamptz,nullzero"`                                   // time at which the announcement should stop being shown, if any
+	AllDay      *bool     `bun:",nullzero,notnull,default:false"`                             // starts at and ends at are days, rather than times
+	Published   *bool     `bun:",nullzero,notnull,default:false"`                             // announcement is visible to non-admins
+	PublishedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when was the announcement (first) published
+	EmojiIDs    []string  `bun:"emojis,array"`                                                // database IDs of any emojis used in this announcement
+	Emojis      []*Emoji  `bun:"-"`                                                           // emojis corresponding to emojiIDs
+}
+
+// IsActive returns whether the announcement is
+// published and within its start and end times,
+// if any, at the given time.
+func (a *Announcement) IsActive(now time.Time) bool {
+	if !*a.Published {
+		return false
+	}
+	if !a.StartsAt.IsZero() && now.Before(a.StartsAt) {
+		return false
+	}
+	if !a.EndsAt.IsZero() && !now.Before(a.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// EmojisPopulated returns whether emojis
+// are populated according to current EmojiIDs.
+func (a *Announcement) EmojisPopulated() bool {
+	if len(a.EmojiIDs) != len(a.Emojis) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range a.EmojiIDs {
+		if a.Emojis[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+// AnnouncementDismissal marks that an account
+// has read and dismissed an announcement.
+type AnnouncementDismissal struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                          // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                       // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_dismissal_announcement_account,nullzero,notnull"` // id of the dismissed announcement
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_dismissal_announcement_account,nullzero,notnull"` // id of the account that dismissed the announcement
+}
+
+// AnnouncementReaction models an emoji
+// reaction by an account to an announcement.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                              // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                           // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_reaction_announcement_account_name,nullzero,notnull"` // id of the announcement reacted to
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_reaction_announcement_account_name,nullzero,notnull"` // id of the account that reacted
+	Name           string    `bun:",unique:announcement_reaction_announcement_account_name,nullzero,notnull"`              // unicode emoji, or custom emoji shortcode, of the reaction
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                // id of the custom emoji of the reaction, if any
+	Emoji          *Emoji    `bun:"-"`                                                                                     // custom emoji corresponding to emojiID
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermissionSubscription models a subscription to
+// a remotely-hosted list of domain permissions (blocks or
+// allows), which is fetched and applied periodically.
+type DomainPermissionSubscription struct {
+	ID                    string                   `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Priority              uint8                    `bun:""`                                                            // Priority of this subscription compared to others of the same permission type; higher wins.
+	Title                 string                   `bun:",nullzero"`                                                   // Moniker for this subscription, visible to admins.
+	PermissionType        DomainPermissionType     `bun:",notnull"`                                                    // Permission type (block/allow) of entries created by this subscription.
+	AdoptOrphans          *bool                    `bun:",nullzero,notnull,default:false"`                             // Take ownership of matching domain permissions that have no subscription ID.
+	RemoveOrphans         *bool                    `bun:",nullzero,notnull,default:false"`                             // Remove (rather than just orphan) entries that are no longer on the list.
+	CreatedByAccountID    string                   `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this subscription.
+	CreatedByAccount      *Account                 `bun:"-"`                                                           // Account corresponding to CreatedByAccountID.
+	URI                   string                   `bun:",nullzero,notnull,unique"`                                    // URI of the remote list.
+	ContentType           DomainPermSubContentType `bun:",nullzero,notnull"`                                           // Content type of the remote list.
+	FetchedAt             time.Time                `bun:"type:timestamptz,nullzero"`                                   // Time of the most recent fetch attempt, successful or otherwise.
+	SuccessfullyFetchedAt time.Time                `bun:"type:timestamptz,nullzero"`                                   // Time of the most recent successful fetch.
+	ETag                  string                   `bun:"etag,nullzero"`                                               // ETag of the list as of the last successful fetch, if it was given.
+	LastModified          time.Time                `bun:"type:timestamptz,nullzero"`                                   // Last-Modified time of the list as of the last successful fetch, if it was given.
+	Error                 string                   `bun:",nullzero"`                                                   // If the most recent fetch or apply failed, the error that occurred.
+}
+
+// DomainPermSubContentType is the
+// expected content type of a remote
+// domain permission list.
+type DomainPermSubContentType string
+
+const (
+	DomainPermSubContentTypeUnknown DomainPermSubContentType = ""
+	DomainPermSubContentTypeCSV     DomainPermSubContentType = "text/csv"         // Mastodon-style CSV export.
+	DomainPermSubContentTypeJSON    DomainPermSubContentType = "application/json" // GoToSocial-style JSON export.
+	DomainPermSubContentTypePlain   DomainPermSubContentType = "text/plain"       // Newline-separated list of domains.
+)
+
+// NewDomainPermSubContentType parses the given
+// string as a DomainPermSubContentType, returning
+// DomainPermSubContentTypeUnknown if not recognized.
+func NewDomainPermSubContentType(in string) DomainPermSubContentType {
+	switch contentType := DomainPermSubContentType(in); contentType {
+	case DomainPermSubContentTypeCSV,
+		DomainPermSubContentTypeJSON,
+		DomainPermSubContentTypePlain:
+		return contentType
+	default:
+		return DomainPermSubContentTypeUnknown
+	}
+}
@@ -0,0 +1,106 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "slices"
+
+// PolicyValue describes a group of accounts
+// to which an interaction policy rule applies.
+type PolicyValue string
+
+const (
+	PolicyValuePublic    PolicyValue = "public"    // PolicyValuePublic -- anyone at all.
+	PolicyValueFollowers PolicyValue = "followers" // PolicyValueFollowers -- accounts following the status author.
+	PolicyValueMentioned PolicyValue = "mentioned" // PolicyValueMentioned -- accounts mentioned in the status.
+	PolicyValueAuthor    PolicyValue = "author"    // PolicyValueAuthor -- the status author only.
+)
+
+// PolicyValues is a slice of PolicyValue.
+type PolicyValues []PolicyValue
+
+// Contains returns whether values contains any of the given values.
+func (v PolicyValues) Contains(values ...PolicyValue) bool {
+	return slices.ContainsFunc(v, func(value PolicyValue) bool {
+		return slices.Contains(values, value)
+	})
+}
+
+// PolicyRules describes which groups of accounts
+// may perform an interaction with a status outright,
+// and which may do so pending approval of the author.
+type PolicyRules struct {
+	Always       PolicyValues `json:"always,omitempty"`        // Groups of accounts permitted to interact without approval.
+	WithApproval PolicyValues `json:"with_approval,omitempty"` // Groups of accounts permitted to interact pending author approval.
+}
+
+// PolicyPermission is the result of
+// checking an interaction against a policy.
+type PolicyPermission int
+
+const (
+	PolicyPermissionForbidden    PolicyPermission = iota // PolicyPermissionForbidden -- interaction is not permitted.
+	PolicyPermissionWithApproval                         // PolicyPermissionWithApproval -- interaction is permitted pending author approval.
+	PolicyPermissionPermitted                            // PolicyPermissionPermitted -- interaction is permitted outright.
+)
+
+// Permission returns the permission granted by these rules
+// to an account belonging to the given groups of accounts.
+func (r PolicyRules) Permission(values ...PolicyValue) PolicyPermission {
+	switch {
+	case r.Always.Contains(values...):
+		return PolicyPermissionPermitted
+	case r.WithApproval.Contains(values...):
+		return PolicyPermissionWithApproval
+	default:
+		return PolicyPermissionForbidden
+	}
+}
+
+// InteractionPolicy models who may like,
+// reply to, or announce (boost) a status.
+type InteractionPolicy struct {
+	CanLike     PolicyRules `json:"can_like"`     // Who may like the status.
+	CanReply    PolicyRules `json:"can_reply"`    // Who may reply to the status.
+	CanAnnounce PolicyRules `json:"can_announce"` // Who may announce (boost) the status.
+}
+
+// DefaultInteractionPolicyFor returns the default interaction
+// policy for statuses of the given visibility, used where no
+// other policy has been set on a status or account.
+func DefaultInteractionPolicyFor(visibility Visibility) *InteractionPolicy {
+	switch visibility {
+	case VisibilityFollowersOnly, VisibilityMutualsOnly:
+		return &InteractionPolicy{
+			CanLike:     PolicyRules{Always: PolicyValues{PolicyValueAuthor, PolicyValueFollowers, PolicyValueMentioned}},
+			CanReply:    PolicyRules{Always: PolicyValues{PolicyValueAuthor, PolicyValueFollowers, PolicyValueMentioned}},
+			CanAnnounce: PolicyRules{Always: PolicyValues{PolicyValueAuthor}},
+		}
+	case VisibilityDirect:
+		return &InteractionPolicy{
+			CanLike:     PolicyRules{Always: PolicyValues{PolicyValueAuthor, PolicyValueMentioned}},
+			CanReply:    PolicyRules{Always: PolicyValues{PolicyValueAuthor, PolicyValueMentioned}},
+			CanAnnounce: PolicyRules{Always: PolicyValues{PolicyValueAuthor}},
+		}
+	default:
+		return &InteractionPolicy{
+			CanLike:     PolicyRules{Always: PolicyValues{PolicyValuePublic}},
+			CanReply:    PolicyRules{Always: PolicyValues{PolicyValuePublic}},
+			CanAnnounce: PolicyRules{Always: PolicyValues{PolicyValuePublic}},
+		}
+	}
+}
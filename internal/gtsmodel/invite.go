@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite code created by a local
+// account, which can be used to sign up to the instance.
+type Invite struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Code      string    `bun:",nullzero,notnull,unique"`                                    // Code to be given on the sign-up form / invite link.
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that created this invite.
+	Account   *Account  `bun:"-"`                                                           // Account corresponding to AccountID.
+	MaxUses   int       `bun:",notnull,default:0"`                                          // Number of sign-ups this invite allows. 0 means unlimited.
+	Uses      int       `bun:",notnull,default:0"`                                          // Number of sign-ups performed using this invite so far.
+	ExpiresAt time.Time `bun:"type:timestamptz,nullzero"`                                   // Time after which this invite can no longer be used. Zero means never.
+}
+
+// Expired returns whether the invite
+// has expired as of the given time.
+func (i *Invite) Expired(now time.Time) bool {
+	return !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+// UsedUp returns whether the invite has
+// reached its maximum number of uses.
+func (i *Invite) UsedUp() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// Usable returns whether the invite can
+// still be used to sign up at given time.
+func (i *Invite) Usable(now time.Time) bool {
+	return !i.Expired(now) && !i.UsedUp()
+}
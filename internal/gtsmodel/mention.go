@@ -57,6 +57,14 @@ type Mention struct {
 	//
 	// This will not be put in the database, it's just for convenience.
 	TargetAccountURL string `bun:"-"`
+	// IsNew indicates whether this mention was freshly
+	// created, rather than reused from an earlier
+	// version of the status (i.e. on status edit).
+	//
+	// This is only set while parsing status content, and
+	// will not be put in the database; compare CreatedAt
+	// to the status EditedAt when processing asynchronously.
+	IsNew bool `bun:"-"`
 	// A pointer to the gtsmodel account of the mentioned account.
 }
 
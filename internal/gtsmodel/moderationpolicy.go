@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ModerationPolicy represents a set of moderation
+// measures applied to all content from either one
+// domain (and its subdomains), or one account.
+//
+// Exactly one of Domain or AccountID will be set.
+type ModerationPolicy struct {
+	ID                   string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt            time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt            time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain               string    `bun:",nullzero,unique"`                                            // domain this policy applies to, eg. 'whatever.com'
+	AccountID            string    `bun:"type:CHAR(26),nullzero,unique"`                               // id of the account this policy applies to
+	ForceSensitive       *bool     `bun:",nullzero,notnull,default:false"`                             // mark all statuses covered by this policy as sensitive
+	ContentWarning       string    `bun:""`                                                            // content warning text to append to all statuses covered by this policy
+	RejectMedia          *bool     `bun:",nullzero,notnull,default:false"`                             // don't fetch or store media attached to statuses covered by this policy
+	StripPublicTimelines *bool     `bun:",nullzero,notnull,default:false"`                             // don't show statuses covered by this policy on public timelines
+	PrivateComment       string    `bun:""`                                                            // private comment on this policy, viewable to admins
+	CreatedByAccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // account ID of the creator of this policy
+}
+
+// ModerationPolicies is a convenience type
+// for checking the combined effect of all the
+// moderation policies covering an account.
+type ModerationPolicies []*ModerationPolicy
+
+// ForceSensitive returns whether any policy
+// requires statuses to be marked as sensitive.
+func (ps ModerationPolicies) ForceSensitive() bool {
+	for _, p := range ps {
+		if *p.ForceSensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentWarnings returns all non-empty content
+// warning texts set by policies, in order.
+func (ps ModerationPolicies) ContentWarnings() []string {
+	var cws []string
+	for _, p := range ps {
+		if p.ContentWarning != "" {
+			cws = append(cws, p.ContentWarning)
+		}
+	}
+	return cws
+}
+
+// RejectMedia returns whether any policy
+// requires status media to be rejected.
+func (ps ModerationPolicies) RejectMedia() bool {
+	for _, p := range ps {
+		if *p.RejectMedia {
+			return true
+		}
+	}
+	return false
+}
+
+// StripPublicTimelines returns whether any policy requires
+// statuses to be stripped from public timelines.
+func (ps ModerationPolicies) StripPublicTimelines() bool {
+	for _, p := range ps {
+		if *p.StripPublicTimelines {
+			return true
+		}
+	}
+	return false
+}
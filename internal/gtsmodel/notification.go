@@ -47,4 +47,6 @@ const (
 	NotificationPoll          NotificationType = "poll"           // NotificationPoll -- a poll you voted in or created has ended
 	NotificationStatus        NotificationType = "status"         // NotificationStatus -- someone you enabled notifications for has posted a status.
 	NotificationSignup        NotificationType = "admin.sign_up"  // NotificationSignup -- someone has submitted a new account sign-up to the instance.
+	NotificationPendingReply  NotificationType = "pending.reply"  // NotificationPendingReply -- someone has replied to one of your statuses, pending your approval.
+	NotificationPendingReblog NotificationType = "pending.reblog" // NotificationPendingReblog -- someone has boosted one of your statuses, pending your approval.
 )
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// PreviewCard represents a rich preview of a web page linked
+// to in a status, generated from OpenGraph / Twitter card /
+// oEmbed metadata retrieved from the page. A single preview
+// card may be shared between multiple statuses linking the
+// same URL.
+type PreviewCard struct {
+	ID           string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated (ie., refetched)
+	URL          string           `bun:",unique,nullzero,notnull"`                                    // URL of the previewed page.
+	Title        string           `bun:",nullzero"`                                                   // Title of the previewed page.
+	Description  string           `bun:",nullzero"`                                                   // Description of the previewed page.
+	Type         PreviewCardType  `bun:",nullzero,notnull"`                                           // Type of the preview card.
+	AuthorName   string           `bun:",nullzero"`                                                   // Name of the author of the previewed page.
+	AuthorURL    string           `bun:",nullzero"`                                                   // URL of the author of the previewed page.
+	ProviderName string           `bun:",nullzero"`                                                   // Name of the provider (site) of the previewed page.
+	ProviderURL  string           `bun:",nullzero"`                                                   // URL of the provider (site) of the previewed page.
+	HTML         string           `bun:",nullzero"`                                                   // oEmbed HTML to embed, for video / rich cards.
+	Width        int              `bun:",nullzero"`                                                   // Width of the embed / image, in pixels.
+	Height       int              `bun:",nullzero"`                                                   // Height of the embed / image, in pixels.
+	EmbedURL     string           `bun:",nullzero"`                                                   // URL of the embedded image, for photo cards.
+	ImageID      string           `bun:"type:CHAR(26),nullzero"`                                      // ID of the thumbnail media attachment, if any.
+	Image        *MediaAttachment `bun:"-"`                                                           // Thumbnail media attachment corresponding to ImageID.
+}
+
+// PreviewCardType represents the type of a preview card,
+// corresponding to the oEmbed type of the previewed page.
+type PreviewCardType string
+
+const (
+	// PreviewCardTypeLink is a plain link preview.
+	PreviewCardTypeLink PreviewCardType = "link"
+	// PreviewCardTypePhoto is a preview of an image.
+	PreviewCardTypePhoto PreviewCardType = "photo"
+	// PreviewCardTypeVideo is a preview of an embeddable video.
+	PreviewCardTypeVideo PreviewCardType = "video"
+	// PreviewCardTypeRich is a preview of embeddable rich content.
+	PreviewCardTypeRich PreviewCardType = "rich"
+)
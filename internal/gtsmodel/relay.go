@@ -0,0 +1,50 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Relay models a subscription of this instance to a
+// LitePub / Mastodon-style relay. The instance actor
+// follows the relay, and (once accepted) public posts
+// are exchanged with it in both directions.
+type Relay struct {
+	ID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	InboxURI           string     `bun:",nullzero,notnull,unique"`                                    // Inbox of the relay, to which Follows and public posts are delivered.
+	ActorURI           string     `bun:",nullzero"`                                                   // URI of the relay actor, set when the relay responds to our Follow.
+	FollowURI          string     `bun:",nullzero,notnull,unique"`                                    // URI of the Follow sent by the instance actor to the relay.
+	State              RelayState `bun:",nullzero,notnull,default:'pending'"`                         // State of this relay subscription.
+	CreatedByAccountID string     `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the admin who added this relay.
+}
+
+// IsAccepted returns whether the relay
+// has accepted our Follow request.
+func (r *Relay) IsAccepted() bool {
+	return r.State == RelayStateAccepted
+}
+
+// RelayState is the state of a relay subscription.
+type RelayState string
+
+const (
+	RelayStatePending  RelayState = "pending"  // Follow sent, awaiting a response.
+	RelayStateAccepted RelayState = "accepted" // Follow accepted by the relay.
+	RelayStateRejected RelayState = "rejected" // Follow rejected by the relay.
+)
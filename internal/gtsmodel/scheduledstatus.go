@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that a local account has
+// scheduled to be published at a later time. The parameters of
+// the status are stored as provided by the client, and are only
+// processed into a Status when the scheduled status is published.
+type ScheduledStatus struct {
+	ID                string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string             `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account that scheduled this status
+	Account           *Account           `bun:"-"`                                                           // account corresponding to accountID
+	ScheduledAt       time.Time          `bun:"type:timestamptz,nullzero,notnull"`                           // time at which the status should be published
+	Text              string             `bun:""`                                                            // text of the status to publish
+	SpoilerText       string             `bun:""`                                                            // content warning of the status to publish
+	Sensitive         *bool              `bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
+	Visibility        Visibility         `bun:",nullzero"`                                                   // visibility of the status, empty means account default
+	Federated         *bool              `bun:",nullzero"`                                                   // advanced visibility flag: federated
+	Boostable         *bool              `bun:",nullzero"`                                                   // advanced visibility flag: boostable
+	Replyable         *bool              `bun:",nullzero"`                                                   // advanced visibility flag: replyable
+	Likeable          *bool              `bun:",nullzero"`                                                   // advanced visibility flag: likeable
+	InteractionPolicy *InteractionPolicy `bun:",nullzero"`                                                   // interaction policy of the status, nil means account default
+	InReplyToID       string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status to reply to, if any
+	Language          string             `bun:",nullzero"`                                                   // language of the status, empty means account default
+	ContentType       string             `bun:",nullzero"`                                                   // content type with which to parse the text, empty means account default
+	MediaIDs          []string           `bun:"attachments,array"`                                           // ids of media attachments to attach to the status
+	MediaAttachments  []*MediaAttachment `bun:"-"`                                                           // attachments corresponding to mediaIDs
+	PollOptions       []string           `bun:",array"`                                                      // options of the poll to attach to the status, if any
+	PollExpiresIn     int                `bun:",nullzero"`                                                   // duration in seconds the poll should be open for, once published
+	PollMultiple      *bool              `bun:",nullzero"`                                                   // allow multiple choices on the poll?
+	PollHideTotals    *bool              `bun:",nullzero"`                                                   // hide poll vote counts until the poll ends?
+	ApplicationID     string             `bun:"type:CHAR(26),nullzero"`                                      // id of the application used to schedule the status
+	Application       *Application       `bun:"-"`                                                           // application corresponding to applicationID
+}
+
+// MediaAttachmentsPopulated returns whether media attachments
+// are populated according to current MediaIDs.
+func (s *ScheduledStatus) MediaAttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if s.MediaAttachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
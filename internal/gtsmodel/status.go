@@ -29,6 +29,7 @@ type Status struct {
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was last edited by owning account at this time.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
@@ -57,6 +58,10 @@ type Status struct {
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
+	EditIDs                  []string           `bun:"edits,array"`                                                 // Database IDs of historical edits (revisions) of this status
+	Edits                    []*StatusEdit      `bun:"-"`                                                           // Edits corresponding to editIDs
+	PreviewCardID            string             `bun:"type:CHAR(26),nullzero"`                                      // id of the preview card generated for the first link in this status
+	PreviewCard              *PreviewCard       `bun:"-"`                                                           // preview card corresponding to previewCardID
 	ContentWarning           string             `bun:",nullzero"`                                                   // cw string for this status
 	Visibility               Visibility         `bun:",nullzero,notnull"`                                           // visibility entry for this status
 	Sensitive                *bool              `bun:",nullzero,notnull,default:false"`                             // mark the status as sensitive?
@@ -69,6 +74,10 @@ type Status struct {
 	Boostable                *bool              `bun:",notnull"`                                                    // This status can be boosted/reblogged
 	Replyable                *bool              `bun:",notnull"`                                                    // This status can be replied to
 	Likeable                 *bool              `bun:",notnull"`                                                    // This status can be liked/faved
+	InteractionPolicy        *InteractionPolicy `bun:",nullzero"`                                                   // Who may like, reply to, or boost this status; if nil, the default policy for the status visibility applies.
+	PendingApproval          *bool              `bun:",nullzero,default:false"`                                     // This status is a reply or boost awaiting approval from the author of the status it interacts with.
+	Imported                 *bool              `bun:",nullzero,notnull,default:false"`                             // This status was imported from an account archive, rather than posted on this instance.
+	ImportedURI              string             `bun:",nullzero"`                                                   // URI of this status in the account archive it was imported from, if imported.
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -147,6 +156,20 @@ func (s *Status) EmojisPopulated() bool {
 	return true
 }
 
+// EditsPopulated returns whether edits are populated according to current EditIDs.
+func (s *Status) EditsPopulated() bool {
+	if len(s.EditIDs) != len(s.Edits) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.EditIDs {
+		if s.Edits[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
 // EmojissUpToDate returns whether status emoji attachments of receiving status are up-to-date
 // according to emoji attachments of the passed status, by comparing their emoji URIs. We don't
 // use IDs as this is used to determine whether there are new emojis to fetch.
@@ -211,6 +234,47 @@ func (s *Status) IsLocal() bool {
 	return s.Local != nil && *s.Local
 }
 
+// IsPendingApproval returns true if this status is a reply
+// or boost that is awaiting approval from the author of the
+// status it interacts with.
+func (s *Status) IsPendingApproval() bool {
+	return s.PendingApproval != nil && *s.PendingApproval
+}
+
+// IsImported returns true if this status was
+// imported from an account archive, with its
+// original (backdated) creation time.
+func (s *Status) IsImported() bool {
+	return s.Imported != nil && *s.Imported
+}
+
+// GetInteractionPolicy returns the interaction policy in effect
+// for this status. This is the policy set on the status if there
+// is one, else the default policy for the status visibility, taking
+// account of any legacy boostable / replyable / likeable flags.
+func (s *Status) GetInteractionPolicy() *InteractionPolicy {
+	if s.InteractionPolicy != nil {
+		return s.InteractionPolicy
+	}
+
+	policy := DefaultInteractionPolicyFor(s.Visibility)
+	authorOnly := PolicyRules{Always: PolicyValues{PolicyValueAuthor}}
+
+	if s.Likeable != nil && !*s.Likeable {
+		policy.CanLike = authorOnly
+	}
+
+	if s.Replyable != nil && !*s.Replyable {
+		policy.CanReply = authorOnly
+	}
+
+	if s.Boostable != nil && !*s.Boostable {
+		policy.CanAnnounce = authorOnly
+	}
+
+	return policy
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription models a Web Push subscription (RFC 8030)
+// made by a client app using an OAuth token. There can be at
+// most one subscription per token.
+type WebPushSubscription struct {
+	ID                 string                    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time                 `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time                 `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID          string                    `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account that owns this subscription
+	TokenID            string                    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // id of the oauth token that created this subscription
+	Endpoint           string                    `bun:",nullzero,notnull"`                                           // push service url to which notifications are POSTed
+	Auth               string                    `bun:",nullzero,notnull"`                                           // base64-encoded auth secret of the user agent
+	P256dh             string                    `bun:",nullzero,notnull"`                                           // base64-encoded P-256 ECDH public key of the user agent
+	AlertFollow        *bool                     `bun:",nullzero,notnull,default:false"`                             // push follow notifications
+	AlertFollowRequest *bool                     `bun:",nullzero,notnull,default:false"`                             // push follow request notifications
+	AlertFavourite     *bool                     `bun:",nullzero,notnull,default:false"`                             // push favourite notifications
+	AlertMention       *bool                     `bun:",nullzero,notnull,default:false"`                             // push mention notifications
+	AlertReblog        *bool                     `bun:",nullzero,notnull,default:false"`                             // push reblog notifications
+	AlertPoll          *bool                     `bun:",nullzero,notnull,default:false"`                             // push poll notifications
+	AlertStatus        *bool                     `bun:",nullzero,notnull,default:false"`                             // push status notifications
+	AlertSignup        *bool                     `bun:",nullzero,notnull,default:false"`                             // push admin sign-up notifications
+	Policy             WebPushNotificationPolicy `bun:",nullzero,notnull,default:'all'"`                             // which accounts to push notifications from
+}
+
+// AlertEnabled returns whether notifications of
+// the given type should be pushed to this subscription.
+func (w *WebPushSubscription) AlertEnabled(notificationType NotificationType) bool {
+	var alert *bool
+
+	switch notificationType {
+	case NotificationFollow:
+		alert = w.AlertFollow
+	case NotificationFollowRequest:
+		alert = w.AlertFollowRequest
+	case NotificationFave:
+		alert = w.AlertFavourite
+	case NotificationMention, NotificationPendingReply:
+		alert = w.AlertMention
+	case NotificationReblog, NotificationPendingReblog:
+		alert = w.AlertReblog
+	case NotificationPoll:
+		alert = w.AlertPoll
+	case NotificationStatus:
+		alert = w.AlertStatus
+	case NotificationSignup:
+		alert = w.AlertSignup
+	}
+
+	return alert != nil && *alert
+}
+
+// WebPushNotificationPolicy represents the accounts
+// from which a Web Push subscription receives notifications.
+type WebPushNotificationPolicy string
+
+const (
+	WebPushNotificationPolicyAll      WebPushNotificationPolicy = "all"      // WebPushNotificationPolicyAll -- push notifications from anyone
+	WebPushNotificationPolicyFollowed WebPushNotificationPolicy = "followed" // WebPushNotificationPolicyFollowed -- push notifications only from accounts the user follows
+	WebPushNotificationPolicyFollower WebPushNotificationPolicy = "follower" // WebPushNotificationPolicyFollower -- push notifications only from accounts that follow the user
+	WebPushNotificationPolicyNone     WebPushNotificationPolicy = "none"     // WebPushNotificationPolicyNone -- push no notifications
+)
+
+// VAPIDKeyPair models the instance's VAPID key pair (RFC 8292),
+// with which it identifies itself to Web Push services.
+// There is only ever one stored in the database.
+type VAPIDKeyPair struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Public    string    `bun:",nullzero,notnull"`                                           // base64url-encoded uncompressed P-256 public key
+	Private   string    `bun:",nullzero,notnull"`                                           // base64url-encoded P-256 private key
+}
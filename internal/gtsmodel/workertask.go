@@ -19,6 +19,8 @@ package gtsmodel
 
 import "time"
 
+// WorkerType denotes which
+// worker pool a task belongs to.
 type WorkerType uint8
 
 const (
@@ -27,15 +29,42 @@ const (
 	ClientWorker    WorkerType = 3
 )
 
+// String returns a stringified, lowercase
+// form of the WorkerType, for use in logs
+// and the admin API, or "" if not known.
+func (w WorkerType) String() string {
+	switch w {
+	case DeliveryWorker:
+		return "delivery"
+	case FederatorWorker:
+		return "federator"
+	case ClientWorker:
+		return "client"
+	default:
+		return ""
+	}
+}
+
 // WorkerTask represents a queued worker task
-// that was persisted to the database on shutdown.
-// This is only ever used on startup to pickup
-// where we left off, and on shutdown to prevent
-// queued tasks from being lost. It is simply a
-// means to store a blob of serialized task data.
+// that has been written ahead to the database
+// on being queued. It is deleted again once the
+// task has been processed, so any tasks remaining
+// on startup were lost on exit, and are replayed.
+//
+// Tasks that fail to process are kept, with the
+// failure time and error, for admin inspection.
+// These are never automatically replayed.
 type WorkerTask struct {
-	ID         uint      `bun:""`
-	WorkerType uint8     `bun:""`
-	TaskData   []byte    `bun:""`
-	CreatedAt  time.Time `bun:""`
+	ID         uint       `bun:",pk,autoincrement"`                                           // Auto-incremented ID of this task, also denotes queue order.
+	WorkerType WorkerType `bun:",nullzero,notnull"`                                           // Type of worker pool this task should be queued in.
+	TaskData   []byte     `bun:",nullzero,notnull"`                                           // Serialized task data blob, as given by worker message Serialize().
+	CreatedAt  time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was this task queued.
+	FailedAt   time.Time  `bun:"type:timestamptz,nullzero"`                                   // When did this task fail processing, if at all.
+	Error      string     `bun:",nullzero"`                                                   // Error this task failed processing with, if any.
+}
+
+// Failed returns whether this
+// task has failed processing.
+func (t *WorkerTask) Failed() bool {
+	return !t.FailedAt.IsZero()
 }
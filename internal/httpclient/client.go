@@ -52,6 +52,9 @@ var (
 
 	// ErrBodyTooLarge is returned when a received response body is above predefined limit (default 40MB).
 	ErrBodyTooLarge = errors.New("body size too large")
+
+	// ErrRedirectRejected is returned if a redirect was rejected by the request context's redirect checking function.
+	ErrRedirectRejected = errors.New("redirect rejected")
 )
 
 // Config provides configuration details for setting up a new
@@ -151,6 +154,7 @@ func New(cfg Config) *Client {
 
 	// Prepare client fields.
 	c.client.Timeout = cfg.Timeout
+	c.client.CheckRedirect = checkRedirect
 	c.bodyMax = cfg.MaxBodySize
 
 	// Prepare transport TLS config.
@@ -192,6 +196,23 @@ func New(cfg Config) *Client {
 	return &c
 }
 
+// checkRedirect is used as the http.Client{}.CheckRedirect function, stopping after
+// 10 redirects like the default, and calling any redirect checking function set on the
+// request context with each redirected request, see gtscontext.HTTPClientRedirectFunc().
+func checkRedirect(r *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	if fn := gtscontext.HTTPClientRedirectFunc(r.Context()); fn != nil {
+		if err := fn(r); err != nil {
+			return fmt.Errorf("%w: %w", ErrRedirectRejected, err)
+		}
+	}
+
+	return nil
+}
+
 // Do will essentially perform http.Client{}.Do() with retry-backoff functionality.
 func (c *Client) Do(r *http.Request) (rsp *http.Response, err error) {
 
@@ -315,6 +336,7 @@ func (c *Client) do(r *Request) (rsp *http.Response, retry bool, err error) {
 			context.Canceled,
 			ErrBodyTooLarge,
 			ErrReservedAddr,
+			ErrRedirectRejected,
 		) {
 			// Non-retryable errors.
 			return nil, false, err
@@ -0,0 +1,1234 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	"github.com/abema/go-mp4"
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+const (
+	waveformWidth  = 512 // width of generated waveform images
+	waveformHeight = 128 // height of generated waveform images
+	waveformBars   = 128 // number of bars drawn in waveform images
+)
+
+// waveformColor is the color of bars drawn in waveform images.
+var waveformColor = color.RGBA{223, 136, 58, 255}
+
+// gtsAudio contains an audio file with all
+// metadata tags stripped, alongside the audio
+// metadata and cover art that was parsed from it.
+type gtsAudio struct {
+	data       []byte    // cleaned audio file data
+	cover      []byte    // encoded cover art image, if any
+	frontCover bool      // whether cover is a "front cover" picture
+	peaks      []float32 // waveform peak levels in range 0-1, if decodable
+	duration   float32   // in seconds
+	bitrate    uint64    // in bits per second
+}
+
+// cleanAudio parses the given audio file data of the given
+// type (file extension), returning it with all metadata tags
+// (including any embedded pictures) stripped from it, along
+// with the audio metadata and cover art that was found. Note
+// that the given data slice may be modified in place.
+func cleanAudio(ext string, data []byte) (*gtsAudio, error) {
+	var (
+		audio *gtsAudio
+		err   error
+	)
+
+	switch ext {
+	case "mp3":
+		audio, err = cleanMP3(data)
+	case "ogg":
+		audio, err = cleanOgg(data)
+	case "flac":
+		audio, err = cleanFLAC(data)
+	case "m4a":
+		audio, err = cleanM4A(data)
+	case "wav":
+		audio, err = cleanWAV(data)
+	default:
+		err = fmt.Errorf("unsupported audio type: %s", ext)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if audio.duration <= 0 {
+		return nil, errors.New("error determining audio metadata: [duration]")
+	}
+
+	if audio.bitrate == 0 {
+		// Fall back to the average bitrate over the whole file.
+		audio.bitrate = uint64(float64(len(audio.data)) * 8 / float64(audio.duration))
+	}
+
+	return audio, nil
+}
+
+// setCover sets the given embedded picture as the cover art
+// of the audio, unless a "front cover" (picture type 3) has
+// already been found, as that's the one we want to show.
+func (a *gtsAudio) setCover(picType uint32, pic []byte) {
+	if len(pic) == 0 || a.frontCover {
+		return
+	}
+
+	if a.cover == nil || picType == 3 {
+		// Copy the picture, as the source
+		// data may be zeroed during cleaning.
+		a.cover = bytes.Clone(pic)
+		a.frontCover = (picType == 3)
+	}
+}
+
+// thumbnail returns an image to represent the audio; its
+// embedded cover art if it can be decoded, else a waveform
+// (or flat line, if no peaks were decoded) image.
+func (a *gtsAudio) thumbnail(ctx context.Context) *gtsImage {
+	if a.cover != nil {
+		img, err := decodeImage(bytes.NewReader(a.cover),
+			imaging.AutoOrientation(true),
+		)
+		if err == nil {
+			return img
+		}
+
+		log.Warnf(ctx, "error decoding audio cover art: %v", err)
+	}
+
+	return waveformImage(a.peaks)
+}
+
+// waveformImage draws an image of the given waveform peaks.
+func waveformImage(peaks []float32) *gtsImage {
+	img := blankImage(waveformWidth, waveformHeight).image.(*image.RGBA)
+
+	barWidth := waveformWidth / waveformBars
+	for i := 0; i < waveformBars; i++ {
+		var level float32
+		if len(peaks) == waveformBars {
+			level = peaks[i]
+		}
+
+		// Always draw at least a
+		// thin line for each bar.
+		height := max(2, int(level*waveformHeight))
+		top := (waveformHeight - height) / 2
+
+		draw.Draw(img, image.Rect(
+			i*barWidth, top,
+			(i+1)*barWidth-1, top+height,
+		), &image.Uniform{waveformColor}, image.Point{}, draw.Src)
+	}
+
+	return &gtsImage{image: img}
+}
+
+// cleanMP3 strips any ID3v2, ID3v1 and APEv2 tags
+// (and junk before the first frame) from MP3 data.
+func cleanMP3(data []byte) (*gtsAudio, error) {
+	var audio gtsAudio
+
+	// Skip over any leading ID3v2
+	// tags (there may be several).
+	for bytes.HasPrefix(data, []byte("ID3")) {
+		n, err := parseID3v2(&audio, data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+	}
+
+	// Drop any trailing tags.
+	data = trimMP3Tags(data)
+
+	// Find first frame of audio data.
+	off, hdr, ok := findMP3Frame(data)
+	if !ok {
+		return nil, errors.New("no mpeg audio frames found")
+	}
+	data = data[off:]
+
+	if frames := hdr.vbrFrames(data); frames > 0 {
+		// Frame count is known from VBR header.
+		audio.duration = float32(float64(frames) *
+			float64(hdr.samples) / float64(hdr.sampleRate))
+	} else {
+		// Assume constant bitrate throughout.
+		audio.duration = float32(float64(len(data)) *
+			8 / float64(hdr.bitrate))
+	}
+
+	audio.data = data
+	return &audio, nil
+}
+
+// trimMP3Tags trims any trailing ID3v1,
+// APEv2 or appended ID3v2.4 tags from data.
+func trimMP3Tags(data []byte) []byte {
+	for {
+		n := len(data)
+		switch {
+
+		// ID3v1 tags are a fixed 128 bytes.
+		case n >= 128 && string(data[n-128:n-125]) == "TAG":
+			data = data[:n-128]
+
+		// APEv2 tags end with a 32 byte footer.
+		case n >= 32 && string(data[n-32:n-24]) == "APETAGEX":
+			size := uint64(binary.LittleEndian.Uint32(data[n-20:]))
+			if flags := binary.LittleEndian.Uint32(data[n-12:]); flags&(1<<31) != 0 {
+				size += 32 // tag also has a header
+			}
+			if size > uint64(n) {
+				return data
+			}
+			data = data[:n-int(size)]
+
+		// Appended ID3v2.4 tags end with a 10 byte footer.
+		case n >= 10 && string(data[n-10:n-7]) == "3DI":
+			size := 20 + id3Syncsafe(data[n-4:])
+			if size > n {
+				return data
+			}
+			data = data[:n-size]
+
+		default:
+			return data
+		}
+	}
+}
+
+// mp3Header contains details parsed
+// from an MPEG audio frame header.
+type mp3Header struct {
+	version    byte // 3 = MPEG-1, 2 = MPEG-2, 0 = MPEG-2.5
+	layer      byte // 1, 2 or 3
+	mono       bool // single channel
+	bitrate    int  // in bits per second
+	sampleRate int  // in hz
+	samples    int  // samples per frame
+	size       int  // frame size in bytes
+}
+
+// mp3Bitrates contains bitrates in kbps by bitrate index, for (in
+// order): MPEG-1 layers 1, 2 and 3, then MPEG-2 layer 1, 2 and 3.
+var mp3Bitrates = [6][15]int{
+	{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+}
+
+// parseMP3Header parses an MPEG audio frame
+// header from the start of b, if there is one.
+func parseMP3Header(b []byte) (mp3Header, bool) {
+	var hdr mp3Header
+
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return hdr, false
+	}
+
+	hdr.version = (b[1] >> 3) & 3
+	hdr.layer = 4 - (b[1]>>1)&3
+	brIdx := b[2] >> 4
+	srIdx := (b[2] >> 2) & 3
+
+	if hdr.version == 1 || hdr.layer == 4 ||
+		brIdx == 0 || brIdx == 15 || srIdx == 3 {
+		// Reserved, free format or bad values.
+		return hdr, false
+	}
+
+	table := int(hdr.layer) - 1
+	if hdr.version != 3 {
+		table += 3
+	}
+	hdr.bitrate = mp3Bitrates[table][brIdx] * 1000
+
+	hdr.sampleRate = [3]int{44100, 48000, 32000}[srIdx]
+	switch hdr.version {
+	case 2:
+		hdr.sampleRate /= 2
+	case 0:
+		hdr.sampleRate /= 4
+	}
+
+	switch {
+	case hdr.layer == 1:
+		hdr.samples = 384
+	case hdr.layer == 2 || hdr.version == 3:
+		hdr.samples = 1152
+	default:
+		hdr.samples = 576
+	}
+
+	padding := int(b[2]>>1) & 1
+	if hdr.layer == 1 {
+		hdr.size = (12*hdr.bitrate/hdr.sampleRate + padding) * 4
+	} else {
+		hdr.size = hdr.samples/8*hdr.bitrate/hdr.sampleRate + padding
+	}
+
+	hdr.mono = (b[3] >> 6) == 3
+	return hdr, true
+}
+
+// findMP3Frame returns the offset and header of the first
+// MPEG audio frame in data, checking that it's followed by
+// another matching frame to avoid false frame syncs.
+func findMP3Frame(data []byte) (int, mp3Header, bool) {
+	for off := 0; off+4 <= len(data); off++ {
+		hdr, ok := parseMP3Header(data[off:])
+		if !ok {
+			continue
+		}
+
+		next := off + hdr.size
+		if next == len(data) {
+			// Single frame.
+			return off, hdr, true
+		}
+
+		if nextHdr, ok := parseMP3Header(data[min(next, len(data)):]); ok &&
+			nextHdr.version == hdr.version &&
+			nextHdr.layer == hdr.layer &&
+			nextHdr.sampleRate == hdr.sampleRate {
+			return off, hdr, true
+		}
+	}
+
+	return 0, mp3Header{}, false
+}
+
+// vbrFrames returns the frame count from any Xing / Info
+// or VBRI header in the (first) frame at start of data.
+func (hdr mp3Header) vbrFrames(data []byte) int {
+	if hdr.layer != 3 {
+		return 0
+	}
+
+	// Xing headers follow the side information.
+	var sideInfo int
+	switch {
+	case hdr.version == 3 && hdr.mono:
+		sideInfo = 17
+	case hdr.version == 3:
+		sideInfo = 32
+	case hdr.mono:
+		sideInfo = 9
+	default:
+		sideInfo = 17
+	}
+
+	if off := 4 + sideInfo; len(data) >= off+12 {
+		switch string(data[off : off+4]) {
+		case "Xing", "Info":
+			flags := binary.BigEndian.Uint32(data[off+4:])
+			if flags&1 != 0 {
+				return int(binary.BigEndian.Uint32(data[off+8:]))
+			}
+			return 0
+		}
+	}
+
+	// VBRI headers are always 32 bytes after the frame header.
+	if off := 4 + 32; len(data) >= off+18 && string(data[off:off+4]) == "VBRI" {
+		return int(binary.BigEndian.Uint32(data[off+14:]))
+	}
+
+	return 0
+}
+
+// parseID3v2 parses the ID3v2 tag at the start of data, setting
+// any attached picture as cover art on audio. It returns the
+// length of the tag, so it can be skipped over.
+func parseID3v2(audio *gtsAudio, data []byte) (int, error) {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return 0, errors.New("invalid id3v2 tag")
+	}
+
+	major := data[3]
+	flags := data[5]
+	size := id3Syncsafe(data[6:10])
+
+	n := 10 + size
+	if flags&0x10 != 0 {
+		n += 10 // tag has a footer
+	}
+
+	if n > len(data) {
+		return 0, errors.New("truncated id3v2 tag")
+	}
+
+	if major < 2 || major > 4 {
+		// Unknown version, we can
+		// only skip over this tag.
+		return n, nil
+	}
+
+	body := data[10 : 10+size]
+	if flags&0x80 != 0 && major < 4 {
+		// Whole tag is unsynchronised.
+		body = id3Deunsync(body)
+	}
+
+	if flags&0x40 != 0 {
+		// Skip extended header.
+		var skip int
+		switch {
+		case major == 2:
+			// Indicates compression in
+			// v2.2, so can't parse frames.
+			return n, nil
+		case len(body) < 4:
+			return n, nil
+		case major == 3:
+			skip = 4 + int(binary.BigEndian.Uint32(body))
+		default:
+			skip = id3Syncsafe(body)
+		}
+		if skip > len(body) || skip < 0 {
+			return n, nil
+		}
+		body = body[skip:]
+	}
+
+	idLen, hdrLen := 4, 10
+	if major == 2 {
+		idLen, hdrLen = 3, 6
+	}
+
+	for len(body) >= hdrLen && body[0] != 0 {
+		id := string(body[:idLen])
+
+		var (
+			size   int
+			format byte // frame format flags
+		)
+
+		switch major {
+		case 2:
+			size = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(body[4:8]))
+			format = body[9]
+		case 4:
+			size = id3Syncsafe(body[4:8])
+			format = body[9]
+		}
+
+		if size < 0 || size > len(body)-hdrLen {
+			break
+		}
+
+		frame := body[hdrLen : hdrLen+size]
+		body = body[hdrLen+size:]
+
+		if id != "APIC" && id != "PIC" {
+			continue
+		}
+
+		frame, ok := id3FrameData(frame, major, format)
+		if !ok {
+			continue
+		}
+
+		picType, pic := parseID3Picture(frame, major == 2)
+		audio.setCover(picType, pic)
+	}
+
+	return n, nil
+}
+
+// id3FrameData returns the data of an ID3v2 frame with
+// given format flags, or false if it cannot be read.
+func id3FrameData(frame []byte, major byte, format byte) ([]byte, bool) {
+	switch major {
+	case 3:
+		if format&0xC0 != 0 {
+			// Compressed / encrypted.
+			return nil, false
+		}
+		if format&0x20 != 0 && len(frame) > 0 {
+			// Skip group identifier.
+			frame = frame[1:]
+		}
+
+	case 4:
+		if format&0x0C != 0 {
+			// Compressed / encrypted.
+			return nil, false
+		}
+		if format&0x40 != 0 && len(frame) > 0 {
+			// Skip group identifier.
+			frame = frame[1:]
+		}
+		if format&0x01 != 0 && len(frame) >= 4 {
+			// Skip data length indicator.
+			frame = frame[4:]
+		}
+		if format&0x02 != 0 {
+			// Frame is unsynchronised.
+			frame = id3Deunsync(frame)
+		}
+	}
+
+	return frame, true
+}
+
+// parseID3Picture parses the picture type and image data from
+// an ID3v2 APIC frame (or a v2.2 PIC frame, if v22 is set).
+func parseID3Picture(b []byte, v22 bool) (uint32, []byte) {
+	if len(b) < 1 {
+		return 0, nil
+	}
+
+	enc := b[0]
+	b = b[1:]
+
+	// Skip image format / MIME type.
+	if v22 {
+		if len(b) < 3 {
+			return 0, nil
+		}
+		b = b[3:]
+	} else {
+		i := bytes.IndexByte(b, 0)
+		if i < 0 {
+			return 0, nil
+		}
+		b = b[i+1:]
+	}
+
+	if len(b) < 1 {
+		return 0, nil
+	}
+
+	picType := uint32(b[0])
+	b = b[1:]
+
+	// Skip description, which is terminated
+	// according to the text encoding used.
+	if enc == 1 || enc == 2 {
+		// UTF-16, terminated by 0x0000.
+		i := 0
+		for ; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				break
+			}
+		}
+		if i+1 >= len(b) {
+			return 0, nil
+		}
+		b = b[i+2:]
+	} else {
+		i := bytes.IndexByte(b, 0)
+		if i < 0 {
+			return 0, nil
+		}
+		b = b[i+1:]
+	}
+
+	return picType, b
+}
+
+// id3Syncsafe decodes a 4 byte ID3v2 "syncsafe" integer.
+func id3Syncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 |
+		int(b[1]&0x7F)<<14 |
+		int(b[2]&0x7F)<<7 |
+		int(b[3]&0x7F)
+}
+
+// id3Deunsync reverses ID3v2 unsynchronisation,
+// replacing all 0xFF 0x00 sequences with 0xFF.
+func id3Deunsync(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+// cleanFLAC strips all metadata blocks other than STREAMINFO
+// and SEEKTABLE from FLAC data, which it parses duration from.
+func cleanFLAC(data []byte) (*gtsAudio, error) {
+	if !bytes.HasPrefix(data, []byte("fLaC")) {
+		return nil, errors.New("invalid flac stream marker")
+	}
+
+	var (
+		audio      gtsAudio
+		keep       [][]byte
+		keepSize   int
+		sampleRate uint64
+		samples    uint64
+	)
+
+	off := 4
+	for last := false; !last; {
+		if off+4 > len(data) {
+			return nil, errors.New("truncated flac metadata")
+		}
+
+		last = data[off]&0x80 != 0
+		typ := data[off] & 0x7F
+		size := int(data[off+1])<<16 | int(data[off+2])<<8 | int(data[off+3])
+
+		end := off + 4 + size
+		if end > len(data) {
+			return nil, errors.New("truncated flac metadata")
+		}
+
+		block := data[off:end]
+		off = end
+
+		switch typ {
+
+		// STREAMINFO
+		case 0:
+			if size < 18 {
+				return nil, errors.New("invalid flac streaminfo")
+			}
+			info := block[4:]
+			sampleRate = uint64(info[10])<<12 | uint64(info[11])<<4 | uint64(info[12])>>4
+			samples = uint64(info[13]&0x0F)<<32 | uint64(binary.BigEndian.Uint32(info[14:18]))
+
+		// SEEKTABLE
+		case 3:
+
+		// PICTURE
+		case 6:
+			audio.setCover(parseFLACPicture(block[4:]))
+			continue
+
+		case 127:
+			return nil, errors.New("invalid flac metadata block type")
+
+		default:
+			continue
+		}
+
+		keep = append(keep, block)
+		keepSize += len(block)
+	}
+
+	if len(keep) == 0 || keep[0][0]&0x7F != 0 {
+		return nil, errors.New("missing flac streaminfo")
+	}
+
+	if sampleRate > 0 {
+		audio.duration = float32(float64(samples) / float64(sampleRate))
+	}
+
+	// Rebuild the file with only the kept metadata blocks,
+	// ensuring only the final one is flagged as being last.
+	out := make([]byte, 0, 4+keepSize+len(data)-off)
+	out = append(out, "fLaC"...)
+	for i, block := range keep {
+		start := len(out)
+		out = append(out, block...)
+		out[start] &= 0x7F
+		if i == len(keep)-1 {
+			out[start] |= 0x80
+		}
+	}
+	out = append(out, data[off:]...)
+
+	audio.data = out
+	return &audio, nil
+}
+
+// parseFLACPicture parses the picture type and image data from
+// a FLAC PICTURE block (also used by Vorbis comment pictures).
+func parseFLACPicture(b []byte) (uint32, []byte) {
+	// next reads a length-prefixed field from b.
+	next := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		n := uint64(binary.BigEndian.Uint32(b))
+		if uint64(len(b)-4) < n {
+			return nil, false
+		}
+		field := b[4 : 4+n]
+		b = b[4+n:]
+		return field, true
+	}
+
+	if len(b) < 4 {
+		return 0, nil
+	}
+	picType := binary.BigEndian.Uint32(b)
+	b = b[4:]
+
+	mime, ok := next()
+	if !ok || string(mime) == "-->" {
+		// Picture is a URL link.
+		return 0, nil
+	}
+
+	if _, ok := next(); !ok {
+		// Description.
+		return 0, nil
+	}
+
+	// Skip width, height, depth, colors.
+	if len(b) < 16 {
+		return 0, nil
+	}
+	b = b[16:]
+
+	pic, ok := next()
+	if !ok {
+		return 0, nil
+	}
+
+	return picType, pic
+}
+
+// oggPage is a single page of an Ogg bitstream.
+type oggPage struct {
+	data    []byte // entire page
+	body    []byte // page body
+	segs    []byte // segment table
+	granule int64  // granule position
+	serial  uint32 // logical bitstream serial
+}
+
+// oggPacket is a single packet of a logical Ogg bitstream,
+// which may be spread across parts of multiple pages.
+type oggPacket struct {
+	parts [][]byte // page body slices
+	pages []int    // page index of each part
+}
+
+// bytes returns a copy of the packet data.
+func (p *oggPacket) bytes() []byte {
+	var b []byte
+	for _, part := range p.parts {
+		b = append(b, part...)
+	}
+	return b
+}
+
+// write writes b over the packet data in place.
+func (p *oggPacket) write(b []byte) {
+	for _, part := range p.parts {
+		b = b[copy(part, b):]
+	}
+}
+
+// cleanOgg blanks the comment header packet of Ogg Opus or Vorbis
+// data in place, parsing duration from the last granule position.
+func cleanOgg(data []byte) (*gtsAudio, error) {
+	var audio gtsAudio
+
+	pages, err := parseOggPages(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pages) == 0 {
+		return nil, errors.New("no ogg pages found")
+	}
+
+	// Gather the identification and comment
+	// header packets of the first bitstream.
+	serial := pages[0].serial
+	packets := make([]oggPacket, 0, 2)
+	var packet oggPacket
+
+outer:
+	for i, page := range pages {
+		if page.serial != serial {
+			continue
+		}
+
+		off := 0
+		for _, seg := range page.segs {
+			packet.parts = append(packet.parts, page.body[off:off+int(seg)])
+			packet.pages = append(packet.pages, i)
+			off += int(seg)
+
+			if seg < 255 {
+				// Segments < 255 end a packet.
+				packets = append(packets, packet)
+				packet = oggPacket{}
+				if len(packets) == 2 {
+					break outer
+				}
+			}
+		}
+	}
+
+	if len(packets) < 2 {
+		return nil, errors.New("missing ogg header packets")
+	}
+
+	var (
+		ident    = packets[0].bytes()
+		comments = packets[1].bytes()
+		magic    string
+		framing  bool // vorbis comments end with framing bit
+		rate     float64
+		skip     int64 // samples to skip at start
+	)
+
+	switch {
+	case bytes.HasPrefix(ident, []byte("OpusHead")) && len(ident) >= 12:
+		magic = "OpusTags"
+		rate = 48000 // opus granule is always 48khz
+		skip = int64(binary.LittleEndian.Uint16(ident[10:]))
+
+	case bytes.HasPrefix(ident, []byte("\x01vorbis")) && len(ident) >= 16:
+		magic = "\x03vorbis"
+		framing = true
+		rate = float64(binary.LittleEndian.Uint32(ident[12:]))
+
+	default:
+		return nil, errors.New("unsupported ogg codec")
+	}
+
+	if !bytes.HasPrefix(comments, []byte(magic)) {
+		return nil, errors.New("invalid ogg comment header")
+	}
+
+	// Look for cover art among comments.
+	parseVorbisComments(&audio, comments[len(magic):])
+
+	// Overwrite comments with an empty comment header of the same
+	// length, so no packet / page boundaries need to change.
+	blank := make([]byte, len(comments))
+	copy(blank, magic)
+	if framing && len(blank) > len(magic)+8 {
+		blank[len(magic)+8] = 1
+	}
+	packets[1].write(blank)
+
+	// Update checksums of modified pages.
+	for i, idx := range packets[1].pages {
+		if i == 0 || idx != packets[1].pages[i-1] {
+			oggSetCRC(pages[idx].data)
+		}
+	}
+
+	// Duration is determined by the last
+	// granule position in the bitstream.
+	for i := len(pages) - 1; i >= 0; i-- {
+		page := pages[i]
+		if page.serial == serial && page.granule >= 0 {
+			if rate > 0 && page.granule > skip {
+				audio.duration = float32(float64(page.granule-skip) / rate)
+			}
+			break
+		}
+	}
+
+	audio.data = data
+	return &audio, nil
+}
+
+// parseOggPages parses all pages of Ogg data.
+func parseOggPages(data []byte) ([]oggPage, error) {
+	var pages []oggPage
+
+	for len(data) > 0 {
+		if len(data) < 27 || string(data[:4]) != "OggS" {
+			return nil, errors.New("invalid ogg page")
+		}
+
+		hdrLen := 27 + int(data[26])
+		if len(data) < hdrLen {
+			return nil, errors.New("truncated ogg page")
+		}
+
+		segs := data[27:hdrLen]
+		bodyLen := 0
+		for _, seg := range segs {
+			bodyLen += int(seg)
+		}
+
+		if len(data) < hdrLen+bodyLen {
+			return nil, errors.New("truncated ogg page")
+		}
+
+		pages = append(pages, oggPage{
+			data:    data[:hdrLen+bodyLen],
+			body:    data[hdrLen : hdrLen+bodyLen],
+			segs:    segs,
+			granule: int64(binary.LittleEndian.Uint64(data[6:14])),
+			serial:  binary.LittleEndian.Uint32(data[14:18]),
+		})
+
+		data = data[hdrLen+bodyLen:]
+	}
+
+	return pages, nil
+}
+
+// parseVorbisComments parses Vorbis comments (as used by
+// Ogg Opus and Vorbis), setting any cover art on audio.
+func parseVorbisComments(audio *gtsAudio, b []byte) {
+	// next reads a length-prefixed field from b.
+	next := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		n := uint64(binary.LittleEndian.Uint32(b))
+		if uint64(len(b)-4) < n {
+			return nil, false
+		}
+		field := b[4 : 4+n]
+		b = b[4+n:]
+		return field, true
+	}
+
+	// Skip vendor string.
+	if _, ok := next(); !ok || len(b) < 4 {
+		return
+	}
+
+	count := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+
+	for i := uint32(0); i < count; i++ {
+		comment, ok := next()
+		if !ok {
+			return
+		}
+
+		key, value, _ := strings.Cut(string(comment), "=")
+		switch strings.ToUpper(key) {
+
+		// Base64 encoded FLAC picture block.
+		case "METADATA_BLOCK_PICTURE":
+			if b, err := base64.StdEncoding.DecodeString(value); err == nil {
+				audio.setCover(parseFLACPicture(b))
+			}
+
+		// Base64 encoded image (old, unofficial).
+		case "COVERART":
+			if b, err := base64.StdEncoding.DecodeString(value); err == nil {
+				audio.setCover(0, b)
+			}
+		}
+	}
+}
+
+// oggCRCTable is the lookup table for Ogg page checksums.
+var oggCRCTable = func() (table [256]uint32) {
+	for i := range table {
+		r := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if r&0x80000000 != 0 {
+				r = r<<1 ^ 0x04C11DB7
+			} else {
+				r <<= 1
+			}
+		}
+		table[i] = r
+	}
+	return
+}()
+
+// oggSetCRC recalculates and sets the checksum of an Ogg page.
+func oggSetCRC(page []byte) {
+	binary.LittleEndian.PutUint32(page[22:26], 0)
+
+	var crc uint32
+	for _, b := range page {
+		crc = crc<<8 ^ oggCRCTable[byte(crc>>24)^b]
+	}
+
+	binary.LittleEndian.PutUint32(page[22:26], crc)
+}
+
+// cleanWAV strips all chunks other than "fmt ", "fact" and
+// "data" from WAV data, which it parses duration from. It
+// also decodes waveform peaks from uncompressed PCM audio.
+func cleanWAV(data []byte) (*gtsAudio, error) {
+	if len(data) < 12 ||
+		string(data[:4]) != "RIFF" ||
+		string(data[8:12]) != "WAVE" {
+		return nil, errors.New("invalid wav header")
+	}
+
+	var (
+		audio     gtsAudio
+		ids       []string
+		keep      [][]byte
+		fmtChunk  []byte
+		dataChunk []byte
+	)
+
+	for off := 12; off+8 <= len(data); {
+		id := string(data[off : off+4])
+		size := uint64(binary.LittleEndian.Uint32(data[off+4:]))
+
+		start := off + 8
+		end := uint64(start) + size
+		if end > uint64(len(data)) {
+			// Truncated, or a streamed file
+			// written with placeholder sizes.
+			end = uint64(len(data))
+		}
+
+		chunk := data[start:end]
+
+		// Chunks are word aligned.
+		off = int(end + end&1)
+
+		switch id {
+		case "fmt ":
+			fmtChunk = chunk
+		case "data":
+			dataChunk = chunk
+		case "fact":
+		case "id3 ", "ID3 ":
+			// Some tools embed an ID3v2 tag.
+			_, _ = parseID3v2(&audio, chunk)
+			continue
+		default:
+			continue
+		}
+
+		ids = append(ids, id)
+		keep = append(keep, chunk)
+	}
+
+	if len(fmtChunk) < 16 || dataChunk == nil {
+		return nil, errors.New("missing wav fmt or data chunk")
+	}
+
+	format := binary.LittleEndian.Uint16(fmtChunk)
+	if format == 0xFFFE && len(fmtChunk) >= 26 {
+		// WAVE_FORMAT_EXTENSIBLE,
+		// get the real sub-format.
+		format = binary.LittleEndian.Uint16(fmtChunk[24:])
+	}
+
+	channels := binary.LittleEndian.Uint16(fmtChunk[2:])
+	byteRate := binary.LittleEndian.Uint32(fmtChunk[8:])
+	bits := binary.LittleEndian.Uint16(fmtChunk[14:])
+
+	if byteRate == 0 {
+		return nil, errors.New("invalid wav byte rate")
+	}
+
+	audio.duration = float32(float64(len(dataChunk)) / float64(byteRate))
+	audio.bitrate = uint64(byteRate) * 8
+
+	if format == 1 && channels > 0 && bits%8 == 0 && bits <= 32 {
+		audio.peaks = pcmPeaks(dataChunk, int(bits/8))
+	}
+
+	// Rebuild the file with only the kept chunks.
+	out := make([]byte, 12, len(data))
+	copy(out, "RIFF")
+	copy(out[8:], "WAVE")
+	for i, chunk := range keep {
+		out = append(out, ids[i]...)
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(chunk)))
+		out = append(out, chunk...)
+		if len(chunk)&1 != 0 {
+			out = append(out, 0)
+		}
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+
+	audio.data = out
+	return &audio, nil
+}
+
+// pcmPeaks calculates the peak level within each waveform bar's
+// section of interleaved little-endian PCM samples of given width.
+func pcmPeaks(pcm []byte, width int) []float32 {
+	count := len(pcm) / width
+	if count == 0 {
+		return nil
+	}
+
+	peaks := make([]float32, waveformBars)
+	for i := 0; i < count; i++ {
+		s := pcm[i*width : (i+1)*width]
+
+		var v float32
+		switch width {
+		case 1:
+			// 8-bit samples are unsigned.
+			v = float32(int(s[0])-128) / (1 << 7)
+		case 2:
+			v = float32(int16(binary.LittleEndian.Uint16(s))) / (1 << 15)
+		case 3:
+			v = float32(int32(uint32(s[0])<<8|uint32(s[1])<<16|uint32(s[2])<<24)>>8) / (1 << 23)
+		case 4:
+			v = float32(int32(binary.LittleEndian.Uint32(s))) / (1 << 31)
+		}
+
+		if v < 0 {
+			v = -v
+		}
+
+		bar := int(int64(i) * waveformBars / int64(count))
+		peaks[bar] = min(1, max(peaks[bar], v))
+	}
+
+	return peaks
+}
+
+// cleanM4A blanks all metadata (udta / meta) boxes in M4A
+// data in place, by converting them to zeroed "free" boxes so
+// that no offsets need to change, and probes it for duration.
+func cleanM4A(data []byte) (*gtsAudio, error) {
+	var audio gtsAudio
+
+	info, err := mp4.Probe(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error during mp4 probe: %w", err)
+	}
+
+	for _, tr := range info.Tracks {
+		if tr.AVC != nil || tr.Timescale == 0 {
+			// Not an audio track.
+			continue
+		}
+
+		if br := tr.Samples.GetBitrate(tr.Timescale); br > audio.bitrate {
+			audio.bitrate = br
+		} else if br := info.Segments.GetBitrate(tr.TrackID, tr.Timescale); br > audio.bitrate {
+			audio.bitrate = br
+		}
+
+		if d := float64(tr.Duration) / float64(tr.Timescale); d > float64(audio.duration) {
+			audio.duration = float32(d)
+		}
+	}
+
+	var found bool
+	mp4Boxes(data, func(typ string, _, payload []byte) {
+		if typ != "moov" {
+			return
+		}
+		found = true
+
+		mp4Boxes(payload, func(typ string, box, payload []byte) {
+			switch typ {
+			case "udta", "meta":
+				mp4FindCover(&audio, typ, payload)
+				mp4Free(box, payload)
+
+			case "trak":
+				mp4Boxes(payload, func(typ string, box, payload []byte) {
+					if typ == "udta" || typ == "meta" {
+						mp4Free(box, payload)
+					}
+				})
+			}
+		})
+	})
+
+	if !found {
+		return nil, errors.New("missing mp4 moov box")
+	}
+
+	audio.data = data
+	return &audio, nil
+}
+
+// mp4Boxes calls fn for each MP4 box directly within
+// data, passing the box type, entire box and payload.
+func mp4Boxes(data []byte, fn func(typ string, box, payload []byte)) {
+	for len(data) >= 8 {
+		size := uint64(binary.BigEndian.Uint32(data))
+		hdr := uint64(8)
+
+		switch size {
+		case 0:
+			// Box extends to end.
+			size = uint64(len(data))
+		case 1:
+			// Box has 64-bit size.
+			if len(data) < 16 {
+				return
+			}
+			size = binary.BigEndian.Uint64(data[8:])
+			hdr = 16
+		}
+
+		if size < hdr || size > uint64(len(data)) {
+			return
+		}
+
+		fn(string(data[4:8]), data[:size], data[hdr:size])
+		data = data[size:]
+	}
+}
+
+// mp4FindCover searches an MP4 metadata box of the given
+// type for iTunes-style "covr" cover art, setting on audio.
+func mp4FindCover(audio *gtsAudio, typ string, payload []byte) {
+	switch typ {
+	case "covr":
+		mp4Boxes(payload, func(typ string, _, payload []byte) {
+			// Skip data type and locale.
+			if typ == "data" && len(payload) > 8 {
+				audio.setCover(3, payload[8:])
+			}
+		})
+		return
+
+	case "meta":
+		// ISO meta boxes have 4 bytes of version
+		// and flags before their children, where
+		// QuickTime meta boxes start with "hdlr".
+		if len(payload) >= 8 && string(payload[4:8]) != "hdlr" {
+			payload = payload[4:]
+		}
+
+	case "udta", "ilst":
+
+	default:
+		return
+	}
+
+	mp4Boxes(payload, func(typ string, _, payload []byte) {
+		mp4FindCover(audio, typ, payload)
+	})
+}
+
+// mp4Free converts the given MP4 box to a zeroed "free" box.
+func mp4Free(box, payload []byte) {
+	copy(box[4:8], "free")
+	clear(payload)
+}
@@ -39,6 +39,11 @@ var SupportedMIMETypes = []string{
 	mimeImagePng,
 	mimeImageWebp,
 	mimeVideoMp4,
+	mimeAudioMpeg,
+	mimeAudioOgg,
+	mimeAudioFlac,
+	mimeAudioM4a,
+	mimeAudioWav,
 }
 
 var SupportedEmojiMIMETypes = []string{
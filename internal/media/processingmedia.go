@@ -30,6 +30,8 @@ import (
 	terminator "codeberg.org/superseriousbusiness/exif-terminator"
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
+	"github.com/h2non/filetype/matchers"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -49,6 +51,7 @@ type ProcessingMedia struct {
 	proc   runners.Processor         // proc helps synchronize only a singular running processing instance
 	err    error                     // error stores permanent error value when done
 	mgr    *Manager                  // mgr instance (access to db / storage)
+	audio  *gtsAudio                 // audio metadata / cover art, parsed when storing audio
 }
 
 // ID returns the ID of the underlying media.
@@ -222,6 +225,15 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		return gtserror.Newf("error parsing file type: %w", err)
 	}
 
+	if info == filetype.Unknown {
+		if hdr, ok := parseMP3Header(hdrBuf); ok && hdr.layer == 3 {
+			// The filetype library only recognises mp3
+			// files starting with an ID3 tag or MPEG-1
+			// frame without CRC, so check for others.
+			info = matchers.TypeMp3
+		}
+	}
+
 	// Recombine header bytes with remaining stream
 	r := io.MultiReader(bytes.NewReader(hdrBuf), rc)
 
@@ -236,6 +248,29 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	case "gif":
 		// No problem
 
+	case "mp3", "ogg", "flac", "m4a", "wav":
+		// Audio is cleaned in memory, so read it
+		// all in, up to the max allowed media size.
+		maxSize := config.GetMediaVideoMaxSize()
+		data, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+		if err != nil {
+			return gtserror.Newf("error reading audio: %w", err)
+		}
+
+		if len(data) > int(maxSize) {
+			return gtserror.Newf("audio size greater than max allowed %s", maxSize)
+		}
+
+		// Strip metadata tags from the audio, keeping
+		// hold of the audio metadata and cover art.
+		p.audio, err = cleanAudio(info.Extension, data)
+		if err != nil {
+			return gtserror.Newf("error cleaning audio: %w", err)
+		}
+
+		r = bytes.NewReader(p.audio.data)
+		p.audio.data = nil
+
 	case "jpg", "jpeg", "png", "webp":
 		if fileSize > 0 {
 			// A file size was provided so we can clean
@@ -377,6 +412,23 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		// Mark as no longer unknown type now
 		// we know for sure we can decode it.
 		p.media.Type = gtsmodel.FileTypeVideo
+
+	// .mp3, .ogg, .flac, .m4a, .wav audio type
+	case mimeAudioMpeg, mimeAudioOgg, mimeAudioFlac, mimeAudioM4a, mimeAudioWav:
+		if p.audio == nil {
+			return gtserror.New("audio metadata not parsed")
+		}
+
+		// Use cover art or waveform as image.
+		fullImg = p.audio.thumbnail(ctx)
+
+		// Set audio metadata in attachment info.
+		p.media.FileMeta.Original.Duration = &p.audio.duration
+		p.media.FileMeta.Original.Bitrate = &p.audio.bitrate
+
+		// Mark as no longer unknown type now
+		// we know for sure we can decode it.
+		p.media.Type = gtsmodel.FileTypeAudio
 	}
 
 	// fullImg should be in-memory by
@@ -385,11 +437,14 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 		return gtserror.Newf("error closing file: %w", err)
 	}
 
-	// Set full-size dimensions in attachment info.
-	p.media.FileMeta.Original.Width = fullImg.Width()
-	p.media.FileMeta.Original.Height = fullImg.Height()
-	p.media.FileMeta.Original.Size = fullImg.Size()
-	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	if p.media.Type != gtsmodel.FileTypeAudio {
+		// Set full-size dimensions in attachment info
+		// (the image for audio is only a thumbnail).
+		p.media.FileMeta.Original.Width = fullImg.Width()
+		p.media.FileMeta.Original.Height = fullImg.Height()
+		p.media.FileMeta.Original.Size = fullImg.Size()
+		p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
+	}
 
 	// Get smaller thumbnail image
 	thumbImg := fullImg.Thumbnail()
@@ -27,6 +27,7 @@ import (
 const (
 	mimeImage = "image"
 	mimeVideo = "video"
+	mimeAudio = "audio"
 
 	mimeJpeg      = "jpeg"
 	mimeImageJpeg = mimeImage + "/" + mimeJpeg
@@ -42,6 +43,21 @@ const (
 
 	mimeMp4      = "mp4"
 	mimeVideoMp4 = mimeVideo + "/" + mimeMp4
+
+	mimeMpeg      = "mpeg"
+	mimeAudioMpeg = mimeAudio + "/" + mimeMpeg
+
+	mimeOgg      = "ogg"
+	mimeAudioOgg = mimeAudio + "/" + mimeOgg
+
+	mimeFlac      = "x-flac"
+	mimeAudioFlac = mimeAudio + "/" + mimeFlac
+
+	mimeM4a      = "m4a"
+	mimeAudioM4a = mimeAudio + "/" + mimeM4a
+
+	mimeWav      = "x-wav"
+	mimeAudioWav = mimeAudio + "/" + mimeWav
 )
 
 type Size string
@@ -59,6 +75,7 @@ const (
 	TypeHeader     Type = "header"     // TypeHeader is the key for profile header requests
 	TypeAvatar     Type = "avatar"     // TypeAvatar is the key for profile avatar requests
 	TypeEmoji      Type = "emoji"      // TypeEmoji is the key for emoji type requests
+	TypeArchive    Type = "archive"    // TypeArchive is the key for account data archives (not served by the fileserver)
 )
 
 // AdditionalMediaInfo represents additional information that
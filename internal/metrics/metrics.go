@@ -25,11 +25,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
-	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/technologize/otel-go-contrib/otelginmetrics"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/extra/bunotel"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
@@ -41,7 +43,7 @@ const (
 	serviceName = "GoToSocial"
 )
 
-func Initialize(db db.DB) error {
+func Initialize(state *state.State) error {
 	if !config.GetMetricsEnabled() {
 		return nil
 	}
@@ -80,7 +82,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_users",
 		metric.WithDescription("Total number of users on this instance"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			userCount, err := db.CountInstanceUsers(c, thisInstance)
+			userCount, err := state.DB.CountInstanceUsers(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -96,7 +98,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_statuses",
 		metric.WithDescription("Total number of statuses on this instance"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			statusCount, err := db.CountInstanceStatuses(c, thisInstance)
+			statusCount, err := state.DB.CountInstanceStatuses(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -112,7 +114,7 @@ func Initialize(db db.DB) error {
 		"gotosocial.instance.total_federating_instances",
 		metric.WithDescription("Total number of other instances this instance is federating with"),
 		metric.WithInt64Callback(func(c context.Context, o metric.Int64Observer) error {
-			federatingCount, err := db.CountInstanceDomains(c, thisInstance)
+			federatingCount, err := state.DB.CountInstanceDomains(c, thisInstance)
 			if err != nil {
 				return err
 			}
@@ -124,7 +126,124 @@ func Initialize(db db.DB) error {
 		return err
 	}
 
-	return nil
+	if err := initWorkerMetrics(meter, state); err != nil {
+		return err
+	}
+
+	if err := initCacheMetrics(meter, state); err != nil {
+		return err
+	}
+
+	return initTimelineMetrics(meter, state)
+}
+
+// initWorkerMetrics registers gauges for the queue length of each worker pool.
+func initWorkerMetrics(meter metric.Meter, state *state.State) error {
+	_, err := meter.Int64ObservableGauge(
+		"gotosocial.workers.queue_length",
+		metric.WithDescription("Number of jobs waiting in each worker pool queue"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			for pool, length := range map[string]int{
+				"delivery":    state.Workers.Delivery.Queue.Len(),
+				"client":      state.Workers.Client.Queue.Len(),
+				"federator":   state.Workers.Federator.Queue.Len(),
+				"dereference": state.Workers.Dereference.Queue.Len(),
+				"processing":  state.Workers.Processing.Queue.Len(),
+				"import":      state.Workers.Import.Queue.Len(),
+			} {
+				o.Observe(int64(length), metric.WithAttributes(
+					attribute.String("pool", pool),
+				))
+			}
+			return nil
+		}),
+	)
+	return err
+}
+
+// initCacheMetrics registers hit / miss counters
+// and size / capacity gauges for each type of cache.
+func initCacheMetrics(meter metric.Meter, state *state.State) error {
+	hits, err := meter.Int64ObservableCounter(
+		"gotosocial.cache.hits",
+		metric.WithDescription("Number of cache lookups that found a cached value"),
+	)
+	if err != nil {
+		return err
+	}
+
+	misses, err := meter.Int64ObservableCounter(
+		"gotosocial.cache.misses",
+		metric.WithDescription("Number of cache lookups that did not find a cached value"),
+	)
+	if err != nil {
+		return err
+	}
+
+	size, err := meter.Int64ObservableGauge(
+		"gotosocial.cache.size",
+		metric.WithDescription("Number of items currently in the cache"),
+	)
+	if err != nil {
+		return err
+	}
+
+	capacity, err := meter.Int64ObservableGauge(
+		"gotosocial.cache.capacity",
+		metric.WithDescription("Maximum number of items the cache can hold"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for name, stats := range state.Caches.Stats() {
+			attrs := metric.WithAttributes(attribute.String("cache", name))
+			o.ObserveInt64(hits, int64(stats.Hits), attrs)
+			o.ObserveInt64(misses, int64(stats.Misses), attrs)
+			o.ObserveInt64(size, int64(stats.Len), attrs)
+			o.ObserveInt64(capacity, int64(stats.Cap), attrs)
+		}
+		return nil
+	}, hits, misses, size, capacity)
+	return err
+}
+
+// initTimelineMetrics registers gauges for the number of
+// timelines, and timelined items, held by each timeline manager.
+func initTimelineMetrics(meter metric.Meter, state *state.State) error {
+	timelines, err := meter.Int64ObservableGauge(
+		"gotosocial.timelines.total",
+		metric.WithDescription("Number of timelines currently held in memory"),
+	)
+	if err != nil {
+		return err
+	}
+
+	items, err := meter.Int64ObservableGauge(
+		"gotosocial.timelines.items",
+		metric.WithDescription("Number of items across all timelines currently held in memory"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for name, manager := range map[string]timeline.Manager{
+			"home": state.Timelines.Home,
+			"list": state.Timelines.List,
+		} {
+			if manager == nil {
+				continue
+			}
+			t, i := manager.Size()
+			attrs := metric.WithAttributes(attribute.String("manager", name))
+			o.ObserveInt64(timelines, int64(t), attrs)
+			o.ObserveInt64(items, int64(i), attrs)
+		}
+		return nil
+	}, timelines, items)
+	return err
 }
 
 func InstrumentGin() gin.HandlerFunc {
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
+)
+
+// RequireScope returns a new gin middleware which checks
+// that the oauth token set on the gin context by TokenCheck
+// was granted at least one of the given scopes. If it wasn't,
+// the request is aborted with 403 Forbidden.
+//
+// Requests without a token are passed through untouched,
+// since it's up to each handler to decide whether or not
+// it requires authentication.
+func RequireScope(scopes ...oauth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		i, ok := c.Get(oauth.SessionAuthorizedToken)
+		if !ok {
+			// No token,
+			// nothing to check.
+			return
+		}
+
+		if ti, ok := i.(oauth2.TokenInfo); ok {
+			granted := oauth.ParseScopes(ti.GetScope())
+			for _, scope := range scopes {
+				if granted.Permits(scope) {
+					// Token has
+					// the goods.
+					return
+				}
+			}
+		}
+
+		apiutil.Data(c,
+			http.StatusForbidden,
+			apiutil.AppJSON,
+			apiutil.ErrorInsufficientScope,
+		)
+		c.Abort()
+	}
+}
@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4/models"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) TestRequireScope() {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	type scopeTest struct {
+		tokenScope   *string
+		required     []oauth.Scope
+		expectedCode int
+	}
+
+	scope := func(s string) *string { return &s }
+
+	for _, test := range []scopeTest{
+		{
+			// No token at all, let
+			// the handler decide.
+			tokenScope:   nil,
+			required:     []oauth.Scope{oauth.ScopeWriteStatuses},
+			expectedCode: http.StatusOK,
+		},
+		{
+			tokenScope:   scope("read write follow push"),
+			required:     []oauth.Scope{oauth.ScopeWriteStatuses},
+			expectedCode: http.StatusOK,
+		},
+		{
+			// Legacy user scope.
+			tokenScope:   scope("user admin"),
+			required:     []oauth.Scope{oauth.ScopeWriteStatuses},
+			expectedCode: http.StatusOK,
+		},
+		{
+			tokenScope:   scope("read"),
+			required:     []oauth.Scope{oauth.ScopeWriteStatuses},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			tokenScope:   scope("read:statuses"),
+			required:     []oauth.Scope{oauth.ScopeReadStatuses},
+			expectedCode: http.StatusOK,
+		},
+		{
+			tokenScope:   scope("read:statuses"),
+			required:     []oauth.Scope{oauth.ScopeReadAccounts},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			tokenScope:   scope("profile"),
+			required:     []oauth.Scope{oauth.ScopeReadAccounts, oauth.ScopeProfile},
+			expectedCode: http.StatusOK,
+		},
+		{
+			tokenScope:   scope(""),
+			required:     []oauth.Scope{oauth.ScopeReadStatuses},
+			expectedCode: http.StatusForbidden,
+		},
+	} {
+		var (
+			recorder = httptest.NewRecorder()
+			_, e     = gin.CreateTestContext(recorder)
+		)
+
+		if test.tokenScope != nil {
+			token := models.NewToken()
+			token.SetScope(*test.tokenScope)
+			e.Use(func(c *gin.Context) {
+				c.Set(oauth.SessionAuthorizedToken, token)
+			})
+		}
+
+		e.GET("/", middleware.RequireScope(test.required...), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		suite.Equal(test.expectedCode, recorder.Code, "token scope %v, required %v", test.tokenScope, test.required)
+	}
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, &ScopeTestSuite{})
+}
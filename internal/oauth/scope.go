@@ -0,0 +1,291 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scope represents one OAuth scope that may be
+// requested by an application, and granted to a
+// token, eg., "read", "write:statuses" etc.
+//
+// Scopes are hierarchical: a token granted a scope
+// is also granted every scope nested beneath it, so
+// "read" permits "read:statuses", and "admin:write"
+// permits "admin:write:accounts".
+type Scope string
+
+// Scopes supported by GoToSocial.
+// These are largely the same as those supported by
+// Mastodon, with a few GoToSocial-specific additions.
+const (
+	ScopeRead              Scope = "read"
+	ScopeReadAccounts      Scope = "read:accounts"
+	ScopeReadBlocks        Scope = "read:blocks"
+	ScopeReadBookmarks     Scope = "read:bookmarks"
+	ScopeReadCustomEmojis  Scope = "read:custom_emojis"
+	ScopeReadFavourites    Scope = "read:favourites"
+	ScopeReadFilters       Scope = "read:filters"
+	ScopeReadFollows       Scope = "read:follows"
+	ScopeReadLists         Scope = "read:lists"
+	ScopeReadMedia         Scope = "read:media"
+	ScopeReadMutes         Scope = "read:mutes"
+	ScopeReadNotifications Scope = "read:notifications"
+	ScopeReadReports       Scope = "read:reports"
+	ScopeReadSearch        Scope = "read:search"
+	ScopeReadStatuses      Scope = "read:statuses"
+	ScopeReadStreaming     Scope = "read:streaming"
+	ScopeReadUser          Scope = "read:user"
+
+	ScopeWrite              Scope = "write"
+	ScopeWriteAccounts      Scope = "write:accounts"
+	ScopeWriteBlocks        Scope = "write:blocks"
+	ScopeWriteBookmarks     Scope = "write:bookmarks"
+	ScopeWriteConversations Scope = "write:conversations"
+	ScopeWriteFavourites    Scope = "write:favourites"
+	ScopeWriteFilters       Scope = "write:filters"
+	ScopeWriteFollows       Scope = "write:follows"
+	ScopeWriteLists         Scope = "write:lists"
+	ScopeWriteMedia         Scope = "write:media"
+	ScopeWriteMutes         Scope = "write:mutes"
+	ScopeWriteNotifications Scope = "write:notifications"
+	ScopeWriteReports       Scope = "write:reports"
+	ScopeWriteStatuses      Scope = "write:statuses"
+	ScopeWriteUser          Scope = "write:user"
+
+	// ScopeFollow is deprecated by Mastodon in favour of
+	// the more granular read/write scopes, but is still
+	// requested by many clients. It permits reading and
+	// writing follows, blocks, and mutes.
+	ScopeFollow Scope = "follow"
+
+	ScopePush    Scope = "push"
+	ScopeProfile Scope = "profile"
+
+	// ScopeUser is GoToSocial's original non-admin scope,
+	// from before granular scopes were enforced. It's kept
+	// as an alias for "read write follow push", so that
+	// applications and tokens granted it keep working.
+	ScopeUser Scope = "user"
+
+	// ScopeAdmin is GoToSocial's original admin scope,
+	// which permits every admin:read and admin:write scope.
+	ScopeAdmin Scope = "admin"
+
+	ScopeAdminRead             Scope = "admin:read"
+	ScopeAdminReadAccounts     Scope = "admin:read:accounts"
+	ScopeAdminReadReports      Scope = "admin:read:reports"
+	ScopeAdminReadDomainAllows Scope = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks Scope = "admin:read:domain_blocks"
+
+	ScopeAdminWrite             Scope = "admin:write"
+	ScopeAdminWriteAccounts     Scope = "admin:write:accounts"
+	ScopeAdminWriteReports      Scope = "admin:write:reports"
+	ScopeAdminWriteDomainAllows Scope = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks Scope = "admin:write:domain_blocks"
+)
+
+// DefaultScope is the scope used when an
+// application or authorization request
+// doesn't specify which scopes it wants.
+const DefaultScope = ScopeRead
+
+// knownScopes contains every Scope
+// that GoToSocial will hand out.
+var knownScopes = func() map[Scope]struct{} {
+	m := make(map[Scope]struct{})
+	for _, scope := range []Scope{
+		ScopeRead,
+		ScopeReadAccounts,
+		ScopeReadBlocks,
+		ScopeReadBookmarks,
+		ScopeReadCustomEmojis,
+		ScopeReadFavourites,
+		ScopeReadFilters,
+		ScopeReadFollows,
+		ScopeReadLists,
+		ScopeReadMedia,
+		ScopeReadMutes,
+		ScopeReadNotifications,
+		ScopeReadReports,
+		ScopeReadSearch,
+		ScopeReadStatuses,
+		ScopeReadStreaming,
+		ScopeReadUser,
+		ScopeWrite,
+		ScopeWriteAccounts,
+		ScopeWriteBlocks,
+		ScopeWriteBookmarks,
+		ScopeWriteConversations,
+		ScopeWriteFavourites,
+		ScopeWriteFilters,
+		ScopeWriteFollows,
+		ScopeWriteLists,
+		ScopeWriteMedia,
+		ScopeWriteMutes,
+		ScopeWriteNotifications,
+		ScopeWriteReports,
+		ScopeWriteStatuses,
+		ScopeWriteUser,
+		ScopeFollow,
+		ScopePush,
+		ScopeProfile,
+		ScopeUser,
+		ScopeAdmin,
+		ScopeAdminRead,
+		ScopeAdminReadAccounts,
+		ScopeAdminReadReports,
+		ScopeAdminReadDomainAllows,
+		ScopeAdminReadDomainBlocks,
+		ScopeAdminWrite,
+		ScopeAdminWriteAccounts,
+		ScopeAdminWriteReports,
+		ScopeAdminWriteDomainAllows,
+		ScopeAdminWriteDomainBlocks,
+	} {
+		m[scope] = struct{}{}
+	}
+	return m
+}()
+
+// followScopes contains the scopes
+// permitted by the legacy follow scope.
+var followScopes = map[Scope]struct{}{
+	ScopeReadFollows:  {},
+	ScopeWriteFollows: {},
+	ScopeReadBlocks:   {},
+	ScopeWriteBlocks:  {},
+	ScopeReadMutes:    {},
+	ScopeWriteMutes:   {},
+}
+
+// userScopes contains the scopes
+// aliased by the legacy user scope.
+var userScopes = Scopes{
+	ScopeRead,
+	ScopeWrite,
+	ScopeFollow,
+	ScopePush,
+}
+
+// Permits returns true if a token granted
+// this scope may act with the required scope.
+func (s Scope) Permits(required Scope) bool {
+	switch {
+	case s == required:
+		return true
+	case strings.HasPrefix(string(required), string(s)+":"):
+		return true
+	case s == ScopeFollow:
+		_, ok := followScopes[required]
+		return ok
+	case s == ScopeUser:
+		return userScopes.Permits(required)
+	default:
+		return false
+	}
+}
+
+// Scopes is a set of Scope, as
+// requested by an application or
+// granted to an oauth token.
+type Scopes []Scope
+
+// ParseScopes parses the given space-separated
+// scope string, as used in oauth requests and
+// stored on tokens, into Scopes. Duplicates are
+// dropped; use Validate to check for unknown scopes.
+func ParseScopes(str string) Scopes {
+	fields := strings.Fields(str)
+	scopes := make(Scopes, 0, len(fields))
+	for _, field := range fields {
+		scope := Scope(field)
+		if !scopes.Contains(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// Validate returns an error if any of these
+// scopes are not known to GoToSocial.
+func (s Scopes) Validate() error {
+	var unknown []string
+	for _, scope := range s {
+		if _, ok := knownScopes[scope]; !ok {
+			unknown = append(unknown, string(scope))
+		}
+	}
+
+	if len(unknown) != 0 {
+		return fmt.Errorf("unknown scope(s): %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// Contains returns true if these Scopes
+// contain exactly the given Scope.
+func (s Scopes) Contains(scope Scope) bool {
+	for _, have := range s {
+		if have == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Permits returns true if any of
+// these Scopes permit the required Scope.
+func (s Scopes) Permits(required Scope) bool {
+	if required == ScopeUser && !s.Contains(ScopeUser) {
+		// Legacy user scope is permitted
+		// by having everything it aliases.
+		return s.PermitsAll(userScopes)
+	}
+
+	for _, have := range s {
+		if have.Permits(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermitsAll returns true if these Scopes
+// permit every one of the required Scopes.
+func (s Scopes) PermitsAll(required Scopes) bool {
+	for _, scope := range required {
+		if !s.Permits(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns these Scopes in the
+// space-separated format used by oauth.
+func (s Scopes) String() string {
+	strs := make([]string, len(s))
+	for i, scope := range s {
+		strs[i] = string(scope)
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) TestParseScopes() {
+	scopes := oauth.ParseScopes("  read write:statuses read\tpush ")
+	suite.Equal(oauth.Scopes{
+		oauth.ScopeRead,
+		oauth.ScopeWriteStatuses,
+		oauth.ScopePush,
+	}, scopes)
+	suite.Equal("read write:statuses push", scopes.String())
+	suite.NoError(scopes.Validate())
+}
+
+func (suite *ScopeTestSuite) TestValidateUnknown() {
+	err := oauth.ParseScopes("read user admin write:nonsense nonsense").Validate()
+	suite.EqualError(err, "unknown scope(s): write:nonsense, nonsense")
+}
+
+func (suite *ScopeTestSuite) TestPermits() {
+	for _, test := range []struct {
+		granted  string
+		required oauth.Scope
+		permits  bool
+	}{
+		{"read", oauth.ScopeReadStatuses, true},
+		{"read", oauth.ScopeWriteStatuses, false},
+		{"read:statuses", oauth.ScopeRead, false},
+		{"read:statuses", oauth.ScopeReadStatuses, true},
+		{"write", oauth.ScopeWriteMedia, true},
+		{"follow", oauth.ScopeWriteBlocks, true},
+		{"follow", oauth.ScopeReadMutes, true},
+		{"follow", oauth.ScopeWriteStatuses, false},
+		{"admin", oauth.ScopeAdminWriteAccounts, true},
+		{"admin:read", oauth.ScopeAdminReadReports, true},
+		{"admin:read", oauth.ScopeAdminWriteReports, false},
+		{"read write follow push", oauth.ScopeAdminRead, false},
+		{"user", oauth.ScopeReadStatuses, true},
+		{"user", oauth.ScopeWriteBlocks, true},
+		{"user", oauth.ScopePush, true},
+		{"user", oauth.ScopeProfile, false},
+		{"user", oauth.ScopeAdminRead, false},
+		{"read write follow push", oauth.ScopeUser, true},
+		{"read write", oauth.ScopeUser, false},
+		{"", oauth.ScopeRead, false},
+	} {
+		permits := oauth.ParseScopes(test.granted).Permits(test.required)
+		suite.Equal(test.permits, permits, "granted %q, required %q", test.granted, test.required)
+	}
+}
+
+func (suite *ScopeTestSuite) TestPermitsAll() {
+	granted := oauth.ParseScopes("read write follow push")
+	suite.True(granted.PermitsAll(oauth.ParseScopes("read:statuses write")))
+	suite.False(granted.PermitsAll(oauth.ParseScopes("read admin:read")))
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, &ScopeTestSuite{})
+}
@@ -123,11 +123,48 @@ func New(ctx context.Context, database db.DB) Server {
 		return userID, nil
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
+	srv.SetClientScopeHandler(func(tgr *oauth2.TokenGenerateRequest) (bool, error) {
+		reqCtx := ctx
+		if tgr.Request != nil {
+			reqCtx = tgr.Request.Context()
+		}
+		return clientScopeAllowed(reqCtx, database, tgr)
+	})
 	return &s{
 		server: srv,
 	}
 }
 
+// clientScopeAllowed checks that the scopes in the given
+// token generation request are known, and were registered by
+// the requesting application, filling in the default scope
+// if none were requested.
+func clientScopeAllowed(ctx context.Context, database db.DB, tgr *oauth2.TokenGenerateRequest) (bool, error) {
+	if tgr.Scope == "" {
+		tgr.Scope = string(DefaultScope)
+	}
+
+	requested := ParseScopes(tgr.Scope)
+	if err := requested.Validate(); err != nil {
+		log.Debugf(ctx, "client %s requested invalid scope: %v", tgr.ClientID, err)
+		return false, nil
+	}
+
+	app, err := database.GetApplicationByClientID(ctx, tgr.ClientID)
+	if err != nil {
+		return false, gtserror.Newf("db error getting application for client %s: %w", tgr.ClientID, err)
+	}
+
+	if !ParseScopes(app.Scopes).PermitsAll(requested) {
+		log.Debugf(ctx, "client %s requested scope %s outside of its registered scope %s", tgr.ClientID, tgr.Scope, app.Scopes)
+		return false, nil
+	}
+
+	// Store scopes normalized.
+	tgr.Scope = requested.String()
+	return true, nil
+}
+
 // HandleTokenRequest wraps the oauth2 library's HandleTokenRequest function
 func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserror.WithCode) {
 	ctx := r.Context()
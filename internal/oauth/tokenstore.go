@@ -30,6 +30,11 @@ import (
 	"github.com/superseriousbusiness/oauth2/v4/models"
 )
 
+// tokenLastUsedFreq is the granularity
+// with which token last used times are
+// updated in the database.
+const tokenLastUsedFreq = 5 * time.Minute
+
 // tokenStore is an implementation of oauth2.TokenStore, which uses our db interface as a storage backend.
 type tokenStore struct {
 	oauth2.TokenStore
@@ -133,12 +138,25 @@ func (ts *tokenStore) GetByCode(ctx context.Context, code string) (oauth2.TokenI
 	return DBTokenToToken(token), nil
 }
 
-// GetByAccess selects a token from the DB based on the Access field
+// GetByAccess selects a token from the DB based on the Access field.
+//
+// As this is called whenever an access token is validated, it also
+// takes care of updating the LastUsed time of the token, at most once
+// per tokenLastUsedFreq, to avoid a db write on every single request.
 func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.TokenInfo, error) {
 	token, err := ts.db.GetTokenByAccess(ctx, access)
 	if err != nil {
 		return nil, err
 	}
+
+	if now := time.Now(); now.Sub(token.LastUsed) > tokenLastUsedFreq {
+		token.LastUsed = now
+		if err := ts.db.UpdateToken(ctx, token, "last_used"); err != nil {
+			// Not critical, token is still valid.
+			log.Errorf(ctx, "error updating token last used: %v", err)
+		}
+	}
+
 	return DBTokenToToken(token), nil
 }
 
@@ -18,6 +18,8 @@
 package account
 
 import (
+	"sync"
+
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -43,6 +45,16 @@ type Processor struct {
 	federator    *federation.Federator
 	parseMention gtsmodel.ParseMentionFunc
 	themes       *Themes
+
+	// fieldVerifier holds state for
+	// verifying profile field links.
+	fieldVerifier *fieldVerifier
+
+	// importing holds the IDs of accounts
+	// with a CSV import currently queued
+	// or in progress, to allow only one
+	// import per account at a time.
+	importing *sync.Map
 }
 
 // New returns a new account processor.
@@ -65,5 +77,8 @@ func New(
 		federator:    federator,
 		parseMention: parseMention,
 		themes:       PopulateThemes(),
+
+		fieldVerifier: new(fieldVerifier),
+		importing:     new(sync.Map),
 	}
 }
@@ -0,0 +1,706 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// archiveInterval is the minimum time between
+	// two archive requests by the same account.
+	archiveInterval = 7 * 24 * time.Hour
+
+	// archiveStaleAfter is the time after which a pending
+	// archive is considered to have failed, eg., because
+	// the instance restarted while it was being generated.
+	archiveStaleAfter = 24 * time.Hour
+
+	// archivePageSize is the number of
+	// statuses etc. fetched at a time
+	// while generating an archive.
+	archivePageSize = 100
+
+	// activityStreamsContext is the JSON-LD
+	// context of archived collections.
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+)
+
+// ArchiveGet returns the most recently
+// requested archive of the requester.
+func (p *Processor) ArchiveGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archive, errWithCode := p.getLatestArchive(ctx, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if archive == nil {
+		const text = "no archive has been requested"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.apiArchive(ctx, archive)
+}
+
+// ArchiveCreate requests a new archive of the requester's
+// profile, statuses, media, likes and bookmarks, which is
+// generated in the background. Previous archives are removed
+// once the new archive has been generated.
+func (p *Processor) ArchiveCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	latest, errWithCode := p.getLatestArchive(ctx, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if latest != nil {
+		if latest.IsPending() {
+			const text = "an archive is already being generated"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		if latest.IsComplete() && time.Since(latest.CreatedAt) < archiveInterval {
+			const text = "an archive can only be requested once every 7 days"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Account:   requester,
+		State:     gtsmodel.AccountArchiveStatePending,
+	}
+
+	if err := p.state.DB.PutAccountArchive(ctx, archive); err != nil {
+		err = gtserror.Newf("db error putting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Generate the archive in the background.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.generateArchive(ctx, archive)
+	})
+
+	return p.apiArchive(ctx, archive)
+}
+
+// ArchiveGetFile returns the content of the
+// requester's most recently generated archive.
+func (p *Processor) ArchiveGetFile(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.Content, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchivesForAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var archive *gtsmodel.AccountArchive
+	for _, a := range archives {
+		if a.IsComplete() {
+			archive = a
+			break
+		}
+	}
+
+	if archive == nil {
+		const text = "no archive has been generated"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	content := &apimodel.Content{
+		ContentType:    "application/zip",
+		ContentLength:  archive.Size,
+		ContentUpdated: archive.UpdatedAt,
+	}
+
+	// If running on S3 storage with proxying disabled then
+	// just fetch pre-signed URL instead of the content.
+	if url := p.state.Storage.URL(ctx, archive.StoragePath); url != nil {
+		content.URL = url
+		return content, nil
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, archive.StoragePath)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			const text = "archive file not found"
+			return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+		}
+		err = gtserror.Newf("error getting archive %s from storage: %w", archive.StoragePath, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	content.Content = rc
+	return content, nil
+}
+
+// getLatestArchive returns the most recently requested archive
+// of the given account, or nil if there is none. If the archive
+// has been pending for too long, it's marked as failed first.
+func (p *Processor) getLatestArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*gtsmodel.AccountArchive, gtserror.WithCode) {
+	archive, err := p.state.DB.GetAccountArchiveForAccountID(ctx, account.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil
+		}
+		err = gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive.IsPending() && time.Since(archive.CreatedAt) > archiveStaleAfter {
+		archive.State = gtsmodel.AccountArchiveStateFailed
+		if err := p.state.DB.UpdateAccountArchive(ctx, archive, "state"); err != nil {
+			err = gtserror.Newf("db error updating archive: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return archive, nil
+}
+
+// apiArchive converts the given archive to its api model,
+// including when the next archive may be requested.
+func (p *Processor) apiArchive(
+	ctx context.Context,
+	archive *gtsmodel.AccountArchive,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	apiArchive, err := p.converter.AccountArchiveToAPIAccountArchive(ctx, archive)
+	if err != nil {
+		err = gtserror.Newf("error converting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	next := time.Now()
+	if archive.IsComplete() {
+		next = archive.CreatedAt.Add(archiveInterval)
+	}
+	apiArchive.NextRequestAt = util.FormatISO8601(next)
+
+	return apiArchive, nil
+}
+
+// generateArchive writes the zipped archive to storage,
+// updating its state accordingly, and removes any older
+// archives of the same account once it's complete.
+func (p *Processor) generateArchive(
+	ctx context.Context,
+	archive *gtsmodel.AccountArchive,
+) {
+	l := log.WithContext(ctx).WithField("archive", archive.ID)
+
+	// Get up-to-date, fully populated account.
+	account, err := p.state.DB.GetAccountByID(ctx, archive.AccountID)
+	if err != nil {
+		l.Errorf("db error getting account: %v", err)
+		p.failArchive(ctx, archive)
+		return
+	}
+
+	storagePath := uris.StoragePathForAttachment(
+		account.ID,
+		string(media.TypeArchive),
+		string(media.SizeOriginal),
+		archive.ID,
+		"zip",
+	)
+
+	// Stream the zip file straight into
+	// storage as it's being written.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(p.writeArchive(ctx, account, pw))
+	}()
+
+	size, err := p.state.Storage.PutStream(ctx, storagePath, pr)
+
+	// Ensure writer unblocked
+	// if storage errored early.
+	_ = pr.CloseWithError(err)
+
+	if err != nil {
+		l.Errorf("error storing archive: %v", err)
+		if err := p.state.Storage.Delete(ctx, storagePath); err != nil && !storage.IsNotFound(err) {
+			l.Errorf("error removing failed archive from storage: %v", err)
+		}
+		p.failArchive(ctx, archive)
+		return
+	}
+
+	archive.State = gtsmodel.AccountArchiveStateComplete
+	archive.StoragePath = storagePath
+	archive.Size = size
+	if err := p.state.DB.UpdateAccountArchive(ctx, archive,
+		"state",
+		"storage_path",
+		"size",
+	); err != nil {
+		l.Errorf("db error updating archive: %v", err)
+		return
+	}
+
+	l.Infof("generated %d byte archive for %s", size, account.Username)
+
+	// Remove older archives.
+	archives, err := p.state.DB.GetAccountArchivesForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		l.Errorf("db error getting archives: %v", err)
+		return
+	}
+
+	for _, old := range archives {
+		if old.ID == archive.ID {
+			continue
+		}
+
+		if err := p.deleteArchive(ctx, old); err != nil {
+			l.Errorf("error removing old archive %s: %v", old.ID, err)
+		}
+	}
+}
+
+// failArchive marks the given archive as failed.
+func (p *Processor) failArchive(
+	ctx context.Context,
+	archive *gtsmodel.AccountArchive,
+) {
+	archive.State = gtsmodel.AccountArchiveStateFailed
+	if err := p.state.DB.UpdateAccountArchive(ctx, archive, "state"); err != nil {
+		log.Errorf(ctx, "db error updating archive %s: %v", archive.ID, err)
+	}
+}
+
+// deleteArchive removes the given
+// archive from storage and the db.
+func (p *Processor) deleteArchive(
+	ctx context.Context,
+	archive *gtsmodel.AccountArchive,
+) error {
+	if archive.StoragePath != "" {
+		err := p.state.Storage.Delete(ctx, archive.StoragePath)
+		if err != nil && !storage.IsNotFound(err) {
+			return gtserror.Newf("error removing archive from storage: %w", err)
+		}
+	}
+
+	if err := p.state.DB.DeleteAccountArchiveByID(ctx, archive.ID); err != nil {
+		return gtserror.Newf("db error deleting archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchive writes a zip file to w, containing:
+//
+//   - actor.json: the account as an ActivityPub actor, plus avatar and header files.
+//   - outbox.json: the account's statuses and boosts as an ActivityPub outbox.
+//   - likes.json: URIs of statuses liked by the account.
+//   - bookmarks.json: URIs of statuses bookmarked by the account.
+//   - media_attachments/: media attached to the account's statuses.
+//
+// This is similar to the layout of a Mastodon account archive,
+// with media URLs rewritten to paths within the archive.
+func (p *Processor) writeArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	w io.Writer,
+) error {
+	zw := zip.NewWriter(w)
+
+	// Media files to add to
+	// the archive, by path.
+	files := make(map[string]string)
+
+	if err := p.writeArchiveActor(ctx, zw, account, files); err != nil {
+		return gtserror.Newf("error writing actor: %w", err)
+	}
+
+	if err := p.writeArchiveOutbox(ctx, zw, account, files); err != nil {
+		return gtserror.Newf("error writing outbox: %w", err)
+	}
+
+	if err := p.writeArchiveLikes(ctx, zw, account); err != nil {
+		return gtserror.Newf("error writing likes: %w", err)
+	}
+
+	if err := p.writeArchiveBookmarks(ctx, zw, account); err != nil {
+		return gtserror.Newf("error writing bookmarks: %w", err)
+	}
+
+	for name, storagePath := range files {
+		if err := p.writeArchiveFile(ctx, zw, name, storagePath); err != nil {
+			return gtserror.Newf("error writing %s: %w", name, err)
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeArchiveActor writes the given account as actor.json,
+// with avatar and header URLs pointing to files in the archive.
+func (p *Processor) writeArchiveActor(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+	files map[string]string,
+) error {
+	person, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	actor, err := ap.Serialize(person)
+	if err != nil {
+		return err
+	}
+
+	if a := account.AvatarMediaAttachment; a != nil && a.File.Path != "" {
+		name := "avatar" + path.Ext(a.File.Path)
+		files[name] = a.File.Path
+		setArchiveURL(actor["icon"], name)
+	}
+
+	if a := account.HeaderMediaAttachment; a != nil && a.File.Path != "" {
+		name := "header" + path.Ext(a.File.Path)
+		files[name] = a.File.Path
+		setArchiveURL(actor["image"], name)
+	}
+
+	return writeArchiveJSON(zw, "actor.json", actor)
+}
+
+// writeArchiveOutbox writes the given account's statuses and boosts
+// as outbox.json, an ordered collection of Create and Announce
+// activities. Attachment URLs are rewritten to point to files in the
+// archive, which are added to files.
+func (p *Processor) writeArchiveOutbox(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+	files map[string]string,
+) error {
+	fw, err := zw.Create("outbox.json")
+	if err != nil {
+		return err
+	}
+
+	// Write the collection a piece at a time,
+	// to avoid holding every status in memory.
+	if err := writeString(fw, `{"@context":"`+activityStreamsContext+`","id":"outbox.json","type":"OrderedCollection","orderedItems":[`); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(fw)
+	enc.SetEscapeHTML(false)
+
+	var (
+		total int
+		maxID string
+	)
+
+	for {
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			archivePageSize,
+			false, // include replies
+			false, // include boosts
+			maxID,
+			"",
+			false, // not just media
+			false, // all visibilities
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Get next page from last status.
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			item, err := p.archiveOutboxItem(ctx, account, status, files)
+			if err != nil {
+				log.Errorf(ctx, "error converting status %s: %v", status.ID, err)
+				continue
+			}
+
+			if total > 0 {
+				if err := writeString(fw, ","); err != nil {
+					return err
+				}
+			}
+
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+
+			total++
+		}
+	}
+
+	return writeString(fw, `],"totalItems":`+strconv.Itoa(total)+`}`)
+}
+
+// archiveOutboxItem converts the given status to
+// a serialized outbox activity for the archive.
+func (p *Processor) archiveOutboxItem(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	files map[string]string,
+) (map[string]interface{}, error) {
+	if status.BoostOfID != "" {
+		announce, err := p.converter.BoostToAS(ctx, status, account, status.BoostOfAccount)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := ap.Serialize(announce)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(item, "@context")
+		return item, nil
+	}
+
+	statusable, err := p.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := ap.Serialize(typeutils.WrapStatusableInCreate(statusable, false))
+	if err != nil {
+		return nil, err
+	}
+
+	delete(item, "@context")
+
+	// Map each local attachment URL to a file in the archive.
+	names := make(map[string]string, len(status.Attachments))
+	for _, a := range status.Attachments {
+		if a.File.Path == "" {
+			// Not stored locally.
+			continue
+		}
+
+		name := "media_attachments/" + a.ID + path.Ext(a.File.Path)
+		files[name] = a.File.Path
+		names[a.URL] = name
+	}
+
+	if object, ok := item["object"].(map[string]interface{}); ok && len(names) != 0 {
+		// Attachments are serialized as a
+		// single object when there's only one.
+		switch attachments := object["attachment"].(type) {
+		case map[string]interface{}:
+			rewriteArchiveURL(attachments, names)
+		case []interface{}:
+			for _, attachment := range attachments {
+				rewriteArchiveURL(attachment, names)
+			}
+		}
+	}
+
+	return item, nil
+}
+
+// writeArchiveLikes writes the URIs of statuses
+// liked by the given account as likes.json.
+func (p *Processor) writeArchiveLikes(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	var (
+		uris  []string
+		maxID string
+	)
+
+	for {
+		statuses, nextMaxID, _, err := p.state.DB.GetFavedTimeline(ctx,
+			account.ID,
+			maxID,
+			"",
+			archivePageSize,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		if len(statuses) == 0 || nextMaxID == maxID {
+			break
+		}
+
+		for _, status := range statuses {
+			uris = append(uris, status.URI)
+		}
+
+		maxID = nextMaxID
+	}
+
+	return writeArchiveJSON(zw, "likes.json", archiveCollection("likes.json", uris))
+}
+
+// writeArchiveBookmarks writes the URIs of statuses
+// bookmarked by the given account as bookmarks.json.
+func (p *Processor) writeArchiveBookmarks(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, account.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	uris := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		uris = append(uris, bookmark.Status.URI)
+	}
+
+	return writeArchiveJSON(zw, "bookmarks.json", archiveCollection("bookmarks.json", uris))
+}
+
+// writeArchiveFile copies the file at storagePath
+// into the archive with the given name. Missing
+// files are logged and skipped.
+func (p *Processor) writeArchiveFile(
+	ctx context.Context,
+	zw *zip.Writer,
+	name string,
+	storagePath string,
+) error {
+	rc, err := p.state.Storage.GetStream(ctx, storagePath)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			log.Warnf(ctx, "file %s missing from storage", storagePath)
+			return nil
+		}
+		return err
+	}
+	defer rc.Close()
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name: name,
+
+		// Media is generally
+		// already compressed.
+		Method: zip.Store,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fw, rc)
+	return err
+}
+
+// writeArchiveJSON writes v as
+// JSON to the named archive file.
+func writeArchiveJSON(zw *zip.Writer, name string, v any) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(fw)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// archiveCollection returns an ActivityStreams
+// ordered collection with the given id and items.
+func archiveCollection(id string, items []string) map[string]interface{} {
+	if items == nil {
+		items = []string{}
+	}
+
+	return map[string]interface{}{
+		"@context":     activityStreamsContext,
+		"id":           id,
+		"type":         ap.ObjectOrderedCollection,
+		"totalItems":   len(items),
+		"orderedItems": items,
+	}
+}
+
+// setArchiveURL sets the url of the given
+// serialized image object to the archive
+// file name, if it's a JSON object.
+func setArchiveURL(image interface{}, name string) {
+	if m, ok := image.(map[string]interface{}); ok {
+		m["url"] = name
+	}
+}
+
+// rewriteArchiveURL replaces the url of the given
+// serialized attachment with its archive file path,
+// if it's in names (keyed by original url).
+func rewriteArchiveURL(attachment interface{}, names map[string]string) {
+	m, ok := attachment.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	url, _ := m["url"].(string)
+	if name, ok := names[url]; ok {
+		// Archive paths are rooted
+		// at the archive's top level.
+		m["url"] = "/" + name
+	}
+}
+
+// writeString writes s to w.
+func writeString(w io.Writer, s string) error {
+	_, err := io.WriteString(w, s)
+	return err
+}
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreate() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	// No archive yet.
+	_, errWithCode := suite.accountProcessor.ArchiveGet(ctx, requester)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(string(gtsmodel.AccountArchiveStatePending), apiArchive.State)
+
+	// Can't request another while this one is pending.
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(ctx, requester)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Generate the archive.
+	jobCtx, cncl := context.WithTimeout(ctx, 5*time.Second)
+	defer cncl()
+	fn, ok := suite.state.Workers.Processing.Queue.PopCtx(jobCtx)
+	if !ok {
+		suite.FailNow("no archive job queued")
+	}
+	fn(jobCtx)
+
+	apiArchive, errWithCode = suite.accountProcessor.ArchiveGet(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(string(gtsmodel.AccountArchiveStateComplete), apiArchive.State)
+	suite.NotZero(apiArchive.Size)
+
+	// Can't request another until a week has passed.
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(ctx, requester)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	content, errWithCode := suite.accountProcessor.ArchiveGetFile(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer content.Content.Close()
+
+	b, err := io.ReadAll(content.Content)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	for _, name := range []string{
+		"actor.json",
+		"outbox.json",
+		"likes.json",
+		"bookmarks.json",
+	} {
+		suite.Contains(files, name)
+	}
+
+	// Outbox should be a valid collection
+	// containing the account's statuses.
+	rc, err := files["outbox.json"].Open()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer rc.Close()
+
+	var outbox struct {
+		Type         string           `json:"type"`
+		TotalItems   int              `json:"totalItems"`
+		OrderedItems []map[string]any `json:"orderedItems"`
+	}
+	if err := json.NewDecoder(rc).Decode(&outbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("OrderedCollection", outbox.Type)
+	suite.NotZero(outbox.TotalItems)
+	suite.Len(outbox.OrderedItems, outbox.TotalItems)
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -92,7 +92,7 @@ func (p *Processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 	})
 
 	// Batch queue accreted client api messages.
-	p.state.Workers.Client.Queue.Push(msgs...)
+	p.state.Workers.Client.Queue.Push(ctx, msgs...)
 
 	return p.RelationshipGet(ctx, requestingAccount, targetAccountID)
 }
@@ -120,7 +120,7 @@ func (p *Processor) BlockRemove(ctx context.Context, requestingAccount *gtsmodel
 	existingBlock.TargetAccount = targetAccount
 
 	// Process block removal side effects (federation etc).
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ActivityBlock,
 		APActivityType: ap.ActivityUndo,
 		GTSModel:       existingBlock,
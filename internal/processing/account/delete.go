@@ -129,6 +129,11 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 	}
 
+	// Delete any web push subscriptions made with those tokens.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("db error deleting web push subscriptions: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return gtserror.Newf("error stubbifying user: %w", err)
@@ -466,6 +471,55 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting poll votes by account: %w", err)
 	}
 
+	// Delete all conversations owned by given account.
+	// Conversations in which it has only participated
+	// will be deleted as its statuses are deleted.
+	if err := p.state.DB.DeleteConversationsByOwnerAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting conversations owned by account: %w", err)
+	}
+
+	// Delete all hashtags featured by given account.
+	if err := p.state.DB.DeleteFeaturedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting featured tags: %w", err)
+	}
+
+	// Delete all hashtags followed by given account.
+	if err := p.state.DB.DeleteFollowedTagsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting followed tags: %w", err)
+	}
+
+	// Delete all announcement dismissals by given account.
+	if err := p.state.DB.DeleteAnnouncementDismissalsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting announcement dismissals by account: %w", err)
+	}
+
+	// Delete all announcement reactions by given account.
+	if err := p.state.DB.DeleteAnnouncementReactionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting announcement reactions by account: %w", err)
+	}
+
+	// Expire all invites created by given account.
+	if err := p.state.DB.ExpireInvitesByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("error expiring invites by account: %w", err)
+	}
+
+	// Cancel and delete all statuses
+	// scheduled by the given account.
+	if err := p.deleteAccountScheduledStatuses(ctx, account); err != nil {
+		return err
+	}
+
+	// Delete all data archives
+	// requested by given account.
+	if err := p.deleteAccountArchives(ctx, account); err != nil {
+		return err
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
@@ -474,6 +528,44 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 	return nil
 }
 
+func (p *Processor) deleteAccountArchives(ctx context.Context, account *gtsmodel.Account) error {
+	archives, err := p.state.DB.GetAccountArchivesForAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting archives for account: %w", err)
+	}
+
+	for _, archive := range archives {
+		if err := p.deleteArchive(ctx, archive); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Processor) deleteAccountScheduledStatuses(ctx context.Context, account *gtsmodel.Account) error {
+	scheduleds, err := p.state.DB.GetScheduledStatusesForAccount(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+		nil, // all
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses for account: %w", err)
+	}
+
+	// Cancel the pending publish of each.
+	for _, scheduled := range scheduleds {
+		_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+	}
+
+	if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting scheduled statuses by account: %w", err)
+	}
+
+	return nil
+}
+
 // stubbifyAccount renders the given account as a stub,
 // removing most information from it and marking it as
 // suspended.
@@ -0,0 +1,199 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// ExportFollowing returns the accounts followed by the
+// requester as CSV records, in the column format used
+// by Mastodon for following_accounts.csv.
+func (p *Processor) ExportFollowing(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	follows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting follows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(follows)+1)
+	records = append(records, []string{
+		"Account address",
+		"Show boosts",
+		"Notify on new posts",
+		"Languages",
+	})
+
+	for _, follow := range follows {
+		records = append(records, []string{
+			accountAddress(follow.TargetAccount),
+			strconv.FormatBool(*follow.ShowReblogs),
+			strconv.FormatBool(*follow.Notify),
+			"", // GoToSocial doesn't filter follows by language.
+		})
+	}
+
+	return records, nil
+}
+
+// ExportBlocks returns the accounts blocked by the
+// requester as CSV records, in the column format used
+// by Mastodon for blocked_accounts.csv (no header).
+func (p *Processor) ExportBlocks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(blocks))
+	for _, block := range blocks {
+		records = append(records, []string{
+			accountAddress(block.TargetAccount),
+		})
+	}
+
+	return records, nil
+}
+
+// ExportMutes returns the accounts muted by the
+// requester as CSV records, in the column format used
+// by Mastodon for muted_accounts.csv. Expired mutes
+// are left out.
+func (p *Processor) ExportMutes(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	mutes, err := p.state.DB.GetAccountMutes(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting mutes: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(mutes)+1)
+	records = append(records, []string{
+		"Account address",
+		"Hide notifications",
+	})
+
+	now := time.Now()
+	for _, mute := range mutes {
+		if mute.Expired(now) {
+			continue
+		}
+
+		records = append(records, []string{
+			accountAddress(mute.TargetAccount),
+			strconv.FormatBool(*mute.Notifications),
+		})
+	}
+
+	return records, nil
+}
+
+// ExportBookmarks returns the statuses bookmarked by
+// the requester as CSV records, in the column format
+// used by Mastodon for bookmarks.csv (no header).
+func (p *Processor) ExportBookmarks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting bookmarks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	records := make([][]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		records = append(records, []string{
+			bookmark.Status.URI,
+		})
+	}
+
+	return records, nil
+}
+
+// ExportLists returns the lists owned by the requester
+// as CSV records, in the column format used by Mastodon
+// for lists.csv (no header): one row per list member,
+// containing the list title and the member's address.
+func (p *Processor) ExportLists(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	lists, err := p.state.DB.GetListsForAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting lists: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var records [][]string
+	for _, list := range lists {
+		entries, err := p.state.DB.GetListEntries(ctx, list.ID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting entries of list %s: %w", list.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, entry := range entries {
+			targetAccount, err := p.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				entry.Follow.TargetAccountID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "error getting list member %s: %v", entry.Follow.TargetAccountID, err)
+				continue
+			}
+
+			records = append(records, []string{
+				list.Title,
+				accountAddress(targetAccount),
+			})
+		}
+	}
+
+	return records, nil
+}
+
+// accountAddress returns the username@domain address of
+// the given account, as used to identify accounts in CSV
+// exports. Local accounts use the configured account domain.
+func accountAddress(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+	return account.Username + "@" + domain
+}
@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ExportTestSuite) TestExportFollowing() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	records, errWithCode := suite.accountProcessor.ExportFollowing(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !suite.Len(records, 3) {
+		suite.FailNow("")
+	}
+	suite.Equal([]string{"Account address", "Show boosts", "Notify on new posts", "Languages"}, records[0])
+
+	addresses := make([]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		suite.Len(record, 4)
+		addresses = append(addresses, record[0])
+	}
+	suite.ElementsMatch([]string{
+		"admin@localhost:8080",
+		"1happyturtle@localhost:8080",
+	}, addresses)
+}
+
+func (suite *ExportTestSuite) TestExportBlocks() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+
+	records, errWithCode := suite.accountProcessor.ExportBlocks(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal([][]string{
+		{"foss_satan@fossbros-anonymous.io"},
+	}, records)
+}
+
+func (suite *ExportTestSuite) TestExportLists() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	records, errWithCode := suite.accountProcessor.ExportLists(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.ElementsMatch([][]string{
+		{"Cool Ass Posters From This Instance", "admin@localhost:8080"},
+		{"Cool Ass Posters From This Instance", "1happyturtle@localhost:8080"},
+	}, records)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
@@ -0,0 +1,732 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Types of CSV file that can be imported,
+// named as in Mastodon's import form.
+const (
+	ImportTypeFollowing = "following"
+	ImportTypeBlocks    = "blocks"
+	ImportTypeMutes     = "mutes"
+	ImportTypeBookmarks = "bookmarks"
+	ImportTypeLists     = "lists"
+)
+
+// maxImportSize is the maximum accepted
+// size in bytes of an imported CSV file.
+const maxImportSize = 20 * 1024 * 1024
+
+// importInterval is the minimum time waited between
+// imported entries that require contacting a remote
+// instance, so that large imports of follows, blocks
+// etc. don't flood remote inboxes with activities.
+var importInterval = time.Second
+
+// ImportData reads the given Mastodon-format CSV file of
+// importType, and queues it to be processed in the background
+// on behalf of the requester. If overwrite is set, existing
+// entries of that type which aren't in the file are removed
+// once the entries in the file have been processed.
+//
+// Returns a 409 Conflict error if the requester
+// already has an import queued or in progress.
+func (p *Processor) ImportData(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *multipart.FileHeader,
+	importType string,
+	overwrite bool,
+) gtserror.WithCode {
+	var importFn func(context.Context, *gtsmodel.Account, [][]string, bool)
+
+	switch importType {
+	case ImportTypeFollowing:
+		importFn = p.importFollowing
+	case ImportTypeBlocks:
+		importFn = p.importBlocks
+	case ImportTypeMutes:
+		importFn = p.importMutes
+	case ImportTypeBookmarks:
+		importFn = p.importBookmarks
+	case ImportTypeLists:
+		importFn = p.importLists
+	default:
+		const text = "import type must be one of following, blocks, mutes, bookmarks, lists"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if data.Size > maxImportSize {
+		text := fmt.Sprintf("import file size %d exceeds maximum of %d bytes", data.Size, maxImportSize)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	file, err := data.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening import file: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+	defer file.Close()
+
+	csvReader := csv.NewReader(file)
+	csvReader.FieldsPerRecord = -1 // Allow variable.
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		err = fmt.Errorf("error reading import file as CSV: %w", err)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Drop blank lines, and the header
+	// line (if any) of account CSV files.
+	records = cleanImportRecords(records)
+
+	minFields := 1
+	if importType == ImportTypeLists {
+		// Lists need list
+		// title and address.
+		minFields = 2
+	}
+
+	for i, record := range records {
+		if len(record) < minFields {
+			text := fmt.Sprintf("import file line %d has fewer than %d fields", i+1, minFields)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	if len(records) == 0 && !overwrite {
+		const text = "import file contains no entries"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Only allow one import at a time per account, as each
+	// import may take a long time for remote accounts, and
+	// overlapping overwrite imports would undo each other.
+	if _, importing := p.importing.LoadOrStore(requester.ID, struct{}{}); importing {
+		const text = "an import is already in progress for this account; try again once it's finished"
+		return gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	// Process the import in the background,
+	// on the dedicated import worker pool.
+	p.state.Workers.Import.Queue.Push(func(ctx context.Context) {
+		defer p.importing.Delete(requester.ID)
+		log.Infof(ctx, "importing %d %s entries for %s", len(records), importType, requester.Username)
+		importFn(ctx, requester, records, overwrite)
+		log.Infof(ctx, "finished importing %s entries for %s", importType, requester.Username)
+	})
+
+	return nil
+}
+
+// importFollowing follows each account in records, which are in
+// the column format of Mastodon's following_accounts.csv.
+func (p *Processor) importFollowing(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	records [][]string,
+	overwrite bool,
+) {
+	var throttle importThrottle
+	keep := make(map[string]struct{}, len(records))
+
+	for _, record := range records {
+		address := record[0]
+		keep[normalizeAddress(address)] = struct{}{}
+
+		target, err := p.getImportAccount(ctx, requester, &throttle, address)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warnf(ctx, "couldn't get imported account %s: %v", address, err)
+			continue
+		}
+
+		if _, errWithCode := p.FollowCreate(ctx, requester, &apimodel.AccountFollowRequest{
+			ID:      target.ID,
+			Reblogs: util.Ptr(importBool(record, 1, true)),
+			Notify:  util.Ptr(importBool(record, 2, false)),
+		}); errWithCode != nil {
+			log.Warnf(ctx, "couldn't follow imported account %s: %v", address, errWithCode)
+		}
+	}
+
+	if !overwrite {
+		return
+	}
+
+	// Gather targets of existing follows
+	// and follow requests by requester.
+	follows, err := p.state.DB.GetAccountFollows(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting follows: %v", err)
+		return
+	}
+
+	followReqs, err := p.state.DB.GetAccountFollowRequesting(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting follow requests: %v", err)
+		return
+	}
+
+	targets := make([]*gtsmodel.Account, 0, len(follows)+len(followReqs))
+	for _, follow := range follows {
+		targets = append(targets, follow.TargetAccount)
+	}
+	for _, followReq := range followReqs {
+		targets = append(targets, followReq.TargetAccount)
+	}
+
+	// Unfollow each target not in import.
+	for _, target := range targets {
+		if _, ok := keep[strings.ToLower(accountAddress(target))]; ok {
+			continue
+		}
+
+		if target.IsRemote() {
+			// Unfollowing sends an Undo.
+			if err := throttle.wait(ctx); err != nil {
+				return
+			}
+		}
+
+		if _, errWithCode := p.FollowRemove(ctx, requester, target.ID); errWithCode != nil {
+			log.Warnf(ctx, "couldn't unfollow account %s: %v", target.URI, errWithCode)
+		}
+	}
+}
+
+// importBlocks blocks each account in records, which are in
+// the column format of Mastodon's blocked_accounts.csv.
+func (p *Processor) importBlocks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	records [][]string,
+	overwrite bool,
+) {
+	var throttle importThrottle
+	keep := make(map[string]struct{}, len(records))
+
+	for _, record := range records {
+		address := record[0]
+		keep[normalizeAddress(address)] = struct{}{}
+
+		target, err := p.getImportAccount(ctx, requester, &throttle, address)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warnf(ctx, "couldn't get imported account %s: %v", address, err)
+			continue
+		}
+
+		if _, errWithCode := p.BlockCreate(ctx, requester, target.ID); errWithCode != nil {
+			log.Warnf(ctx, "couldn't block imported account %s: %v", address, errWithCode)
+		}
+	}
+
+	if !overwrite {
+		return
+	}
+
+	blocks, err := p.state.DB.GetAccountBlocks(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting blocks: %v", err)
+		return
+	}
+
+	// Unblock each target not in import.
+	for _, block := range blocks {
+		target := block.TargetAccount
+		if _, ok := keep[strings.ToLower(accountAddress(target))]; ok {
+			continue
+		}
+
+		if target.IsRemote() {
+			// Unblocking sends an Undo.
+			if err := throttle.wait(ctx); err != nil {
+				return
+			}
+		}
+
+		if _, errWithCode := p.BlockRemove(ctx, requester, target.ID); errWithCode != nil {
+			log.Warnf(ctx, "couldn't unblock account %s: %v", target.URI, errWithCode)
+		}
+	}
+}
+
+// importMutes mutes each account in records, which are in
+// the column format of Mastodon's muted_accounts.csv.
+func (p *Processor) importMutes(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	records [][]string,
+	overwrite bool,
+) {
+	var throttle importThrottle
+	keep := make(map[string]struct{}, len(records))
+
+	for _, record := range records {
+		address := record[0]
+		keep[normalizeAddress(address)] = struct{}{}
+
+		target, err := p.getImportAccount(ctx, requester, &throttle, address)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warnf(ctx, "couldn't get imported account %s: %v", address, err)
+			continue
+		}
+
+		if _, errWithCode := p.MuteCreate(ctx, requester, target.ID, &apimodel.UserMuteCreateUpdateRequest{
+			Notifications: util.Ptr(importBool(record, 1, true)),
+		}); errWithCode != nil {
+			log.Warnf(ctx, "couldn't mute imported account %s: %v", address, errWithCode)
+		}
+	}
+
+	if !overwrite {
+		return
+	}
+
+	mutes, err := p.state.DB.GetAccountMutes(ctx, requester.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting mutes: %v", err)
+		return
+	}
+
+	// Unmute each target not in import.
+	// Mutes aren't federated, no throttle.
+	for _, mute := range mutes {
+		target := mute.TargetAccount
+		if _, ok := keep[strings.ToLower(accountAddress(target))]; ok {
+			continue
+		}
+
+		if _, errWithCode := p.MuteRemove(ctx, requester, target.ID); errWithCode != nil {
+			log.Warnf(ctx, "couldn't unmute account %s: %v", target.URI, errWithCode)
+		}
+	}
+}
+
+// importBookmarks bookmarks each status in records, which
+// are in the column format of Mastodon's bookmarks.csv.
+func (p *Processor) importBookmarks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	records [][]string,
+	overwrite bool,
+) {
+	var throttle importThrottle
+	keep := make(map[string]struct{}, len(records))
+
+	for _, record := range records {
+		uriStr := record[0]
+		keep[uriStr] = struct{}{}
+
+		if err := p.importBookmark(ctx, requester, &throttle, uriStr); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warnf(ctx, "couldn't bookmark imported status %s: %v", uriStr, err)
+		}
+	}
+
+	if !overwrite {
+		return
+	}
+
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting bookmarks: %v", err)
+		return
+	}
+
+	// Remove each bookmark not in import.
+	for _, bookmark := range bookmarks {
+		if _, ok := keep[bookmark.Status.URI]; ok {
+			continue
+		}
+
+		if err := p.state.DB.DeleteStatusBookmarkByID(ctx, bookmark.ID); err != nil {
+			log.Errorf(ctx, "db error removing bookmark %s: %v", bookmark.ID, err)
+			continue
+		}
+
+		if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, bookmark.StatusID); err != nil {
+			log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+		}
+	}
+}
+
+// importBookmark bookmarks the status
+// with the given URI for requester, if
+// it's visible and not yet bookmarked.
+func (p *Processor) importBookmark(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	throttle *importThrottle,
+	uriStr string,
+) error {
+	uri, err := url.Parse(uriStr)
+	if err != nil {
+		return err
+	}
+
+	if !isLocalDomain(uri.Host) {
+		// Getting the status
+		// may dereference it.
+		if err := throttle.wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	status, _, err := p.federator.GetStatusByURI(ctx, requester.Username, uri)
+	if err != nil {
+		return err
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, requester, status)
+	if err != nil {
+		return err
+	}
+
+	if !visible {
+		return errors.New("status not visible")
+	}
+
+	bookmarked, err := p.state.DB.IsStatusBookmarkedBy(ctx, requester.ID, status.ID)
+	if err != nil {
+		return err
+	}
+
+	if bookmarked {
+		return nil
+	}
+
+	if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+	}); err != nil {
+		return err
+	}
+
+	return p.c.InvalidateTimelinedStatus(ctx, requester.ID, status.ID)
+}
+
+// importLists adds each account in records to the list with
+// the given title, creating lists as necessary. Records are
+// in the column format of Mastodon's lists.csv. Since list
+// entries are follows, only followed accounts can be added.
+func (p *Processor) importLists(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	records [][]string,
+	overwrite bool,
+) {
+	lists, err := p.state.DB.GetListsForAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting lists: %v", err)
+		return
+	}
+
+	// Index existing lists by title.
+	listsByTitle := make(map[string]*gtsmodel.List, len(lists))
+	for _, list := range lists {
+		listsByTitle[list.Title] = list
+	}
+
+	// Addresses in the import, by list ID.
+	keep := make(map[string]map[string]struct{})
+
+	for _, record := range records {
+		title, address := record[0], record[1]
+
+		list, ok := listsByTitle[title]
+		if !ok {
+			list = &gtsmodel.List{
+				ID:            id.NewULID(),
+				Title:         title,
+				AccountID:     requester.ID,
+				RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+			}
+
+			if err := p.state.DB.PutList(ctx, list); err != nil {
+				log.Errorf(ctx, "db error creating list %s: %v", title, err)
+				continue
+			}
+
+			listsByTitle[title] = list
+		}
+
+		if keep[list.ID] == nil {
+			keep[list.ID] = make(map[string]struct{})
+		}
+		keep[list.ID][normalizeAddress(address)] = struct{}{}
+
+		if err := p.importListEntry(ctx, requester, list, address); err != nil {
+			log.Warnf(ctx, "couldn't add imported account %s to list %s: %v", address, title, err)
+		}
+	}
+
+	if !overwrite {
+		return
+	}
+
+	// Remove entries not in import from
+	// each list that was in the import.
+	for listID, addresses := range keep {
+		entries, err := p.state.DB.GetListEntries(ctx, listID, "", "", "", 0)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting entries of list %s: %v", listID, err)
+			continue
+		}
+
+		for _, entry := range entries {
+			target, err := p.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				entry.Follow.TargetAccountID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "db error getting list member %s: %v", entry.Follow.TargetAccountID, err)
+				continue
+			}
+
+			if _, ok := addresses[strings.ToLower(accountAddress(target))]; ok {
+				continue
+			}
+
+			if err := p.state.DB.DeleteListEntry(ctx, entry.ID); err != nil {
+				log.Errorf(ctx, "db error removing list entry %s: %v", entry.ID, err)
+			}
+		}
+	}
+}
+
+// importListEntry adds the followed account
+// with the given address to list, if it's
+// not in the list already.
+func (p *Processor) importListEntry(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	list *gtsmodel.List,
+	address string,
+) error {
+	username, domain, err := splitAddress(address)
+	if err != nil {
+		return err
+	}
+
+	if isLocalDomain(domain) {
+		domain = ""
+	}
+
+	// Followed accounts are already known,
+	// so just look in the db; no need to
+	// contact remote instance (or throttle).
+	target, err := p.state.DB.GetAccountByUsernameDomain(
+		gtscontext.SetBarebones(ctx),
+		username,
+		domain,
+	)
+	if err != nil {
+		return err
+	}
+
+	follow, err := p.state.DB.GetFollow(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		target.ID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return errors.New("account not followed")
+		}
+		return err
+	}
+
+	included, err := p.state.DB.ListIncludesAccount(ctx, list.ID, target.ID)
+	if err != nil {
+		return err
+	}
+
+	if included {
+		return nil
+	}
+
+	return p.state.DB.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+		ID:       id.NewULID(),
+		ListID:   list.ID,
+		FollowID: follow.ID,
+	}})
+}
+
+// getImportAccount gets the account with the given
+// username@domain address, dereferencing it if it's
+// remote. Remote lookups are throttled.
+func (p *Processor) getImportAccount(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	throttle *importThrottle,
+	address string,
+) (*gtsmodel.Account, error) {
+	username, domain, err := splitAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isLocalDomain(domain) {
+		if err := throttle.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	account, _, err := p.federator.GetAccountByUsernameDomain(
+		ctx,
+		requester.Username,
+		username,
+		domain,
+	)
+	return account, err
+}
+
+// importThrottle spaces out work on imported
+// entries that involves remote instances.
+type importThrottle struct {
+	last time.Time
+}
+
+// wait blocks until importInterval has passed since
+// the previous call to wait, or ctx is cancelled.
+func (t *importThrottle) wait(ctx context.Context) error {
+	if d := importInterval - time.Since(t.last); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	t.last = time.Now()
+	return nil
+}
+
+// cleanImportRecords trims whitespace from the
+// given CSV records, drops empty records, and
+// drops the first record if it's a header line.
+func cleanImportRecords(records [][]string) [][]string {
+	cleaned := make([][]string, 0, len(records))
+	for i, record := range records {
+		for j := range record {
+			record[j] = strings.TrimSpace(record[j])
+		}
+
+		if len(record) == 0 || record[0] == "" {
+			// Blank line.
+			continue
+		}
+
+		if i == 0 && strings.EqualFold(record[0], "Account address") {
+			// Header line.
+			continue
+		}
+
+		cleaned = append(cleaned, record)
+	}
+	return cleaned
+}
+
+// importBool parses the boolean in
+// the given field of record, returning
+// def if it's not present or invalid.
+func importBool(record []string, field int, def bool) bool {
+	if field >= len(record) {
+		return def
+	}
+
+	b, err := strconv.ParseBool(record[field])
+	if err != nil {
+		return def
+	}
+
+	return b
+}
+
+// splitAddress splits a username@domain
+// account address (with or without a
+// leading @) into username and domain.
+func splitAddress(address string) (string, string, error) {
+	return util.ExtractNamestringParts("@" + strings.TrimPrefix(address, "@"))
+}
+
+// normalizeAddress returns the given account
+// address lowercased and in the same form as
+// accountAddress(), for comparing addresses.
+func normalizeAddress(address string) string {
+	username, domain, err := splitAddress(address)
+	if err != nil {
+		return strings.ToLower(address)
+	}
+
+	if isLocalDomain(domain) {
+		domain = config.GetAccountDomain()
+	}
+
+	return strings.ToLower(username + "@" + domain)
+}
+
+// isLocalDomain returns whether the given
+// domain (or host) refers to this instance.
+func isLocalDomain(domain string) bool {
+	return domain == "" ||
+		domain == config.GetHost() ||
+		domain == config.GetAccountDomain()
+}
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// csvFileHeader returns a multipart
+// file header containing the given data.
+func (suite *ImportTestSuite) csvFileHeader(data string) *multipart.FileHeader {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	fw, err := w.CreateFormFile("data", "import.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(buf, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return form.File["data"][0]
+}
+
+// runImport runs the import job queued
+// on the import worker queue.
+func (suite *ImportTestSuite) runImport(ctx context.Context) {
+	ctx, cncl := context.WithTimeout(ctx, 5*time.Second)
+	defer cncl()
+
+	fn, ok := suite.state.Workers.Import.Queue.PopCtx(ctx)
+	if !ok {
+		suite.FailNow("no import job queued")
+	}
+	fn(ctx)
+}
+
+func (suite *ImportTestSuite) TestImportBlocksOverwrite() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_2"]
+	admin := suite.testAccounts["admin_account"]
+	fossSatan := suite.testAccounts["remote_account_1"]
+
+	errWithCode := suite.accountProcessor.ImportData(
+		ctx,
+		requester,
+		suite.csvFileHeader("admin@localhost:8080\n"),
+		account.ImportTypeBlocks,
+		true,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.runImport(ctx)
+
+	// Admin should now be blocked.
+	blocked, err := suite.db.IsBlocked(ctx, requester.ID, admin.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(blocked)
+
+	// Existing block not in the file should be removed.
+	blocked, err = suite.db.IsBlocked(ctx, requester.ID, fossSatan.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+}
+
+func (suite *ImportTestSuite) TestImportInProgress() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	importBlocks := func() int {
+		errWithCode := suite.accountProcessor.ImportData(
+			ctx,
+			requester,
+			suite.csvFileHeader("admin@localhost:8080\n"),
+			account.ImportTypeBlocks,
+			false,
+		)
+		if errWithCode != nil {
+			return errWithCode.Code()
+		}
+		return http.StatusOK
+	}
+
+	// First import should be queued.
+	suite.Equal(http.StatusOK, importBlocks())
+
+	// Second import should be refused
+	// while the first is still queued.
+	suite.Equal(http.StatusConflict, importBlocks())
+
+	// Once the first import has run,
+	// another should be accepted.
+	suite.runImport(ctx)
+	suite.Equal(http.StatusOK, importBlocks())
+	suite.runImport(ctx)
+}
+
+func (suite *ImportTestSuite) TestImportBadType() {
+	errWithCode := suite.accountProcessor.ImportData(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.csvFileHeader("admin@localhost:8080\n"),
+		"domain_blocks",
+		false,
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *ImportTestSuite) TestImportEmpty() {
+	errWithCode := suite.accountProcessor.ImportData(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.csvFileHeader("Account address,Show boosts\n\n"),
+		account.ImportTypeFollowing,
+		false,
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
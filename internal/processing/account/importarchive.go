@@ -0,0 +1,815 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// maxArchiveImportSize is the maximum accepted
+	// size in bytes of an imported account archive.
+	maxArchiveImportSize = 2 * 1024 * 1024 * 1024
+
+	// maxArchiveImportFiles is the maximum accepted
+	// number of files in an imported account archive.
+	maxArchiveImportFiles = 100000
+
+	// maxArchiveImportBytes is the maximum total number
+	// of decompressed bytes read from the files of an
+	// imported account archive, across all of them.
+	maxArchiveImportBytes = 2 * maxArchiveImportSize
+
+	// maxArchiveOutboxSize is the maximum decompressed
+	// size in bytes of outbox.json in an imported account
+	// archive. Its statuses are held in memory for sorting.
+	maxArchiveOutboxSize = 256 * 1024 * 1024
+
+	// maxArchiveActorSize is the maximum decompressed
+	// size in bytes of actor.json in an imported archive.
+	maxArchiveActorSize = 1024 * 1024
+)
+
+// errArchiveTooLarge is returned when reading more
+// from an imported archive than its limits allow.
+var errArchiveTooLarge = errors.New("archive contents exceed maximum size")
+
+// archivedStatus is a status parsed from
+// the outbox of an imported account archive.
+type archivedStatus struct {
+	statusable  ap.Statusable
+	uri         string
+	published   time.Time
+	attachments []*archivedAttachment
+}
+
+// archivedAttachment is a media attachment
+// of a status in an imported account archive.
+type archivedAttachment struct {
+	path        string
+	description string
+}
+
+// ImportArchive checks that the given file is a Mastodon-style
+// account archive (a zip file containing outbox.json, and optionally
+// actor.json and media_attachments/), and queues its posts to be
+// imported in the background as statuses of the requester.
+//
+// Imported statuses keep their original creation time, are marked
+// as imported, and are not federated or sent to timelines.
+func (p *Processor) ImportArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *multipart.FileHeader,
+) gtserror.WithCode {
+	if data.Size > maxArchiveImportSize {
+		text := fmt.Sprintf("archive size %d exceeds maximum of %d bytes", data.Size, maxArchiveImportSize)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	file, err := data.Open()
+	if err != nil {
+		err = gtserror.Newf("error opening archive: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+	defer file.Close()
+
+	// Uploaded files are removed at the end of the
+	// request, so copy to a temporary file of our
+	// own that the background import can work from.
+	tmp, err := os.CreateTemp("", "gotosocial-archive-import-*.zip")
+	if err != nil {
+		err = gtserror.Newf("error creating temporary file: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Remove the temporary file
+	// unless the import is queued.
+	queued := false
+	defer func() {
+		if !queued {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	size, err := io.Copy(tmp, file)
+	if err == nil {
+		err = tmp.Close()
+	} else {
+		_ = tmp.Close()
+	}
+	if err != nil {
+		err = gtserror.Newf("error writing temporary file: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Check we've been given a valid zip
+	// file containing an outbox to import.
+	zr, err := zip.OpenReader(tmp.Name())
+	if err != nil {
+		err = fmt.Errorf("error reading archive as zip file: %w", err)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	if len(zr.File) > maxArchiveImportFiles {
+		_ = zr.Close()
+		text := fmt.Sprintf("archive contains more than the maximum of %d files", maxArchiveImportFiles)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	outbox := indexArchiveFiles(zr)["outbox.json"]
+	_ = zr.Close()
+	if outbox == nil {
+		const text = "archive does not contain outbox.json"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if outbox.UncompressedSize64 > maxArchiveOutboxSize {
+		text := fmt.Sprintf("outbox.json exceeds maximum of %d bytes", maxArchiveOutboxSize)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Import in the background, as there may
+	// be a lot of statuses and media to process.
+	path := tmp.Name()
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		defer func() {
+			if err := os.Remove(path); err != nil {
+				log.Errorf(ctx, "error removing %s: %v", path, err)
+			}
+		}()
+
+		log.Infof(ctx, "importing %d byte archive for %s", size, requester.Username)
+		p.importArchive(ctx, requester, path)
+	})
+	queued = true
+
+	return nil
+}
+
+// importArchive imports the posts in the outbox of the account
+// archive at path as statuses of account, along with their media.
+func (p *Processor) importArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	path string,
+) {
+	l := log.WithContext(ctx).WithField("account", account.Username)
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		l.Errorf("error opening archive: %v", err)
+		return
+	}
+	defer zr.Close()
+
+	files := indexArchiveFiles(zr)
+
+	// Limit the total bytes read from
+	// the archive, whatever its files
+	// claim their sizes to be.
+	budget := &archiveBudget{remaining: maxArchiveImportBytes}
+
+	actorURI, followersURI, err := readArchiveActor(files, budget)
+	if err != nil {
+		// Not fatal, we can guess from the outbox.
+		l.Warnf("error reading actor.json: %v", err)
+	}
+
+	statuses, skipped, err := readArchiveOutbox(ctx, files, budget, actorURI)
+	if err != nil {
+		l.Errorf("error reading outbox.json: %v", err)
+		return
+	}
+
+	if followersURI == "" && actorURI != "" {
+		// Mastodon's followers collection.
+		followersURI = actorURI + "/followers"
+	}
+
+	// Import oldest first, so that statuses
+	// exist before any self-replies to them.
+	slices.SortStableFunc(statuses, func(a, b *archivedStatus) int {
+		return a.published.Compare(b.published)
+	})
+
+	// Imported statuses by their
+	// URI in the archive, to
+	// keep threads together.
+	imported := make(map[string]*gtsmodel.Status, len(statuses))
+	var existing int
+
+	for _, s := range statuses {
+		if _, ok := imported[s.uri]; ok {
+			// Already done.
+			continue
+		}
+
+		// Skip statuses imported by an earlier
+		// import of (part of) the same archive.
+		status, err := p.state.DB.GetStatusByImportedURI(
+			gtscontext.SetBarebones(ctx),
+			account.ID,
+			s.uri,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			l.Errorf("db error checking for imported status %s: %v", s.uri, err)
+			return
+		}
+
+		if status != nil {
+			imported[s.uri] = status
+			existing++
+			continue
+		}
+
+		if budget.exhausted() {
+			l.Errorf("stopping import: %v", errArchiveTooLarge)
+			break
+		}
+
+		status, err = p.importArchivedStatus(ctx, account, files, budget, s, followersURI, imported)
+		if err != nil {
+			l.Warnf("error importing status %s: %v", s.uri, err)
+			skipped++
+			continue
+		}
+
+		imported[s.uri] = status
+	}
+
+	// Bring account stats in line with
+	// the newly imported statuses.
+	unlock := p.state.ProcessingLocks.Lock(account.URI)
+	err = p.state.DB.RegenerateAccountStats(ctx, account)
+	unlock()
+	if err != nil {
+		l.Errorf("db error regenerating account stats: %v", err)
+	}
+
+	l.Infof("imported %d statuses, skipped %d, %d already imported", len(imported)-existing, skipped, existing)
+}
+
+// importArchivedStatus creates a new local status for
+// account from the given archived status, along with any
+// attachments it has. Previously imported statuses are
+// used to resolve the status it replies to, if any.
+func (p *Processor) importArchivedStatus(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	files map[string]*zip.File,
+	budget *archiveBudget,
+	archived *archivedStatus,
+	followersURI string,
+	imported map[string]*gtsmodel.Status,
+) (*gtsmodel.Status, error) {
+	statusable := archived.statusable
+
+	visibility, err := ap.ExtractVisibility(statusable, followersURI)
+	if err != nil {
+		return nil, gtserror.Newf("error extracting visibility: %w", err)
+	}
+
+	// Use status creation time for the ID, so
+	// that the status sorts in its original place.
+	statusID, err := id.NewULIDFromTime(archived.published)
+	if err != nil {
+		return nil, gtserror.Newf("error generating id: %w", err)
+	}
+
+	accountURIs := uris.GenerateURIsForAccount(account.Username)
+	content, language := typeutils.ContentToContentLanguage(ctx, ap.ExtractContent(statusable))
+	content = text.SanitizeToHTML(content)
+
+	// Only public and unlisted
+	// statuses can be boosted.
+	boostable := visibility == gtsmodel.VisibilityPublic ||
+		visibility == gtsmodel.VisibilityUnlocked
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 accountURIs.StatusesURI + "/" + statusID,
+		URL:                 accountURIs.StatusesURL + "/" + statusID,
+		CreatedAt:           archived.published,
+		UpdatedAt:           archived.published,
+		Local:               util.Ptr(true),
+		Account:             account,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		ActivityStreamsType: ap.ObjectNote,
+		Content:             content,
+		Text:                text.SanitizeToPlaintext(content),
+		ContentWarning:      text.SanitizeToPlaintext(ap.ExtractSummary(statusable)),
+		Sensitive:           util.Ptr(ap.ExtractSensitive(statusable)),
+		Language:            language,
+		Visibility:          visibility,
+		Federated:           util.Ptr(true),
+		Boostable:           &boostable,
+		Replyable:           util.Ptr(true),
+		Likeable:            util.Ptr(true),
+		Imported:            util.Ptr(true),
+		ImportedURI:         archived.uri,
+	}
+
+	if err := p.importArchivedInReplyTo(ctx, status, statusable, imported); err != nil {
+		return nil, err
+	}
+
+	if status.ThreadID == "" {
+		// Start a new thread from this status.
+		thread := &gtsmodel.Thread{ID: id.NewULID()}
+		if err := p.state.DB.PutThread(ctx, thread); err != nil {
+			return nil, gtserror.Newf("db error inserting thread: %w", err)
+		}
+		status.ThreadID = thread.ID
+	}
+
+	// Attachments are imported
+	// from the archive's media.
+	for _, archivedAttachment := range archived.attachments {
+		attachment, err := p.importArchivedAttachment(ctx, account, files, budget, status, archivedAttachment)
+		if err != nil {
+			log.Warnf(ctx, "error importing attachment %s: %v", archivedAttachment.path, err)
+			continue
+		}
+
+		status.Attachments = append(status.Attachments, attachment)
+		status.AttachmentIDs = append(status.AttachmentIDs, attachment.ID)
+	}
+
+	if status.Content == "" && len(status.AttachmentIDs) == 0 {
+		return nil, gtserror.New("status has no content or attachments")
+	}
+
+	if err := p.state.DB.PutStatus(ctx, status); err != nil {
+		return nil, gtserror.Newf("db error inserting status: %w", err)
+	}
+
+	return status, nil
+}
+
+// importArchivedInReplyTo sets the in-reply-to fields and thread of
+// status from the archived statusable, if it's a reply. Replies to
+// statuses that were imported or are known to this instance are
+// threaded with them; replies to other statuses keep only their
+// original in-reply-to URI.
+func (p *Processor) importArchivedInReplyTo(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	statusable ap.Statusable,
+	imported map[string]*gtsmodel.Status,
+) error {
+	inReplyToURI := ap.ExtractInReplyToURI(statusable)
+	if inReplyToURI == nil {
+		// Not a reply.
+		return nil
+	}
+
+	uri := inReplyToURI.String()
+	inReplyTo := imported[uri]
+
+	if inReplyTo == nil {
+		var err error
+
+		// Status might already be known to us.
+		inReplyTo, err = p.state.DB.GetStatusByURI(
+			gtscontext.SetBarebones(ctx),
+			uri,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting in-reply-to status: %w", err)
+		}
+	}
+
+	if inReplyTo == nil {
+		// Unknown status,
+		// keep the URI only.
+		status.InReplyToURI = uri
+		return nil
+	}
+
+	status.InReplyToID = inReplyTo.ID
+	status.InReplyTo = inReplyTo
+	status.InReplyToURI = inReplyTo.URI
+	status.InReplyToAccountID = inReplyTo.AccountID
+	status.ThreadID = inReplyTo.ThreadID
+	return nil
+}
+
+// importArchivedAttachment stores the file referenced by the given
+// archived attachment as a new media attachment of status. The file
+// is decompressed into memory first, up to the configured maximum
+// media size, so that it's never stored larger than that.
+func (p *Processor) importArchivedAttachment(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	files map[string]*zip.File,
+	budget *archiveBudget,
+	status *gtsmodel.Status,
+	archived *archivedAttachment,
+) (*gtsmodel.MediaAttachment, error) {
+	file := files[archived.path]
+	if file == nil {
+		return nil, gtserror.Newf("%s not found in archive", archived.path)
+	}
+
+	// Same limit as applied
+	// to uploaded media.
+	maxSize := max(
+		config.GetMediaImageMaxSize(),
+		config.GetMediaVideoMaxSize(),
+	)
+
+	rc, err := budget.open(file, int64(maxSize))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		return nil, gtserror.Newf("error reading %s: %w", archived.path, err)
+	}
+
+	data := func(context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil
+	}
+
+	attachment, errWithCode := p.c.StoreLocalMedia(ctx,
+		account.ID,
+		data,
+		media.AdditionalMediaInfo{
+			CreatedAt:   &status.CreatedAt,
+			StatusID:    &status.ID,
+			Description: &archived.description,
+		},
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return attachment, nil
+}
+
+// readArchiveActor returns the ID and followers collection
+// URI of the actor in actor.json of the given archive files.
+func readArchiveActor(files map[string]*zip.File, budget *archiveBudget) (string, string, error) {
+	file := files["actor.json"]
+	if file == nil {
+		return "", "", nil
+	}
+
+	rc, err := budget.open(file, maxArchiveActorSize)
+	if err != nil {
+		return "", "", err
+	}
+	defer rc.Close()
+
+	var actor struct {
+		ID        string `json:"id"`
+		Followers string `json:"followers"`
+	}
+
+	if err := json.NewDecoder(rc).Decode(&actor); err != nil {
+		return "", "", err
+	}
+
+	return actor.ID, actor.Followers, nil
+}
+
+// readArchiveOutbox returns statuses created by the archived actor in
+// outbox.json of the given archive files, and the number of other items
+// which were skipped (eg., boosts). If actorURI is not known, it's
+// taken from the first activity in the outbox.
+//
+// The outbox is decoded one item at a time, so
+// that only the statuses in it are held in memory.
+func readArchiveOutbox(
+	ctx context.Context,
+	files map[string]*zip.File,
+	budget *archiveBudget,
+	actorURI string,
+) ([]*archivedStatus, int, error) {
+	file := files["outbox.json"]
+	if file == nil {
+		return nil, 0, gtserror.New("outbox.json not found")
+	}
+
+	rc, err := budget.open(file, maxArchiveOutboxSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rc.Close()
+
+	var (
+		dec = json.NewDecoder(rc)
+
+		// Items are ActivityStreams objects
+		// whatever else, until the outbox
+		// tells us its context (if it does).
+		outboxContext any = activityStreamsContext
+
+		statuses []*archivedStatus
+		skipped  int
+	)
+
+	if err := readJSONDelim(dec, '{'); err != nil {
+		return nil, 0, err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		switch key {
+		case "@context":
+			if err := dec.Decode(&outboxContext); err != nil {
+				return nil, 0, err
+			}
+
+		case "orderedItems":
+			if err := readJSONDelim(dec, '['); err != nil {
+				return nil, 0, err
+			}
+
+			for dec.More() {
+				var item map[string]any
+				if err := dec.Decode(&item); err != nil {
+					return nil, 0, err
+				}
+
+				status, err := readArchiveOutboxItem(ctx, item, outboxContext, &actorURI)
+				if err != nil {
+					return nil, 0, err
+				}
+
+				if status == nil {
+					skipped++
+					continue
+				}
+
+				statuses = append(statuses, status)
+			}
+
+			if err := readJSONDelim(dec, ']'); err != nil {
+				return nil, 0, err
+			}
+
+		default:
+			// Not interested.
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return statuses, skipped, nil
+}
+
+// readArchiveOutboxItem returns the status in the given raw outbox
+// item, or nil if it doesn't contain a status created by the archived
+// actor. If actorURI is empty, it's set to the actor of the item.
+func readArchiveOutboxItem(
+	ctx context.Context,
+	item map[string]any,
+	outboxContext any,
+	actorURI *string,
+) (*archivedStatus, error) {
+	// Only Create activities contain statuses;
+	// boosts of other statuses aren't imported.
+	typ, _ := item["type"].(string)
+	actor, _ := item["actor"].(string)
+	object, _ := item["object"].(map[string]any)
+	if typ != ap.ActivityCreate || object == nil {
+		return nil, nil
+	}
+
+	if *actorURI == "" {
+		*actorURI = actor
+	} else if actor != *actorURI {
+		// Not created by archived actor.
+		return nil, nil
+	}
+
+	// Archived objects aren't standalone, they
+	// inherit the context of the outbox they're in.
+	if _, ok := object["@context"]; !ok {
+		object["@context"] = outboxContext
+	}
+
+	// Archived attachment URLs are relative to the
+	// archive root, which doesn't resolve as an IRI,
+	// so these are taken from the raw object instead.
+	attachments := readArchivedAttachments(object)
+
+	b, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	statusable, err := ap.ResolveStatusable(ctx, io.NopCloser(bytes.NewReader(b)))
+	if err != nil {
+		log.Warnf(ctx, "error resolving outbox item as status: %v", err)
+		return nil, nil
+	}
+
+	if _, ok := ap.ToPollable(statusable); ok {
+		// Polls can't be imported
+		// without their votes.
+		return nil, nil
+	}
+
+	uri := ap.GetJSONLDId(statusable)
+	published := ap.GetPublished(statusable)
+	if uri == nil || published.IsZero() {
+		return nil, nil
+	}
+
+	return &archivedStatus{
+		statusable:  statusable,
+		uri:         uri.String(),
+		published:   published,
+		attachments: attachments,
+	}, nil
+}
+
+// readJSONDelim reads the next token from dec,
+// returning an error if it isn't the given delimiter.
+func readJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expected %s, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// readArchivedAttachments returns the attachments
+// of the given raw archived object which refer
+// to files by their path in the archive.
+func readArchivedAttachments(object map[string]any) []*archivedAttachment {
+	var raw []any
+	switch a := object["attachment"].(type) {
+	case []any:
+		raw = a
+	case map[string]any:
+		raw = []any{a}
+	}
+
+	attachments := make([]*archivedAttachment, 0, len(raw))
+	for _, r := range raw {
+		attachment, _ := r.(map[string]any)
+		if attachment == nil {
+			continue
+		}
+
+		// URL may be a plain string,
+		// or a Link object with href.
+		var path string
+		switch u := attachment["url"].(type) {
+		case string:
+			path = u
+		case map[string]any:
+			path, _ = u["href"].(string)
+		}
+
+		if path == "" || strings.Contains(path, "://") {
+			// Not a file in the archive.
+			continue
+		}
+
+		// Description may be in
+		// summary or name, like
+		// in ap.ExtractDescription.
+		description, _ := attachment["summary"].(string)
+		if description == "" {
+			description, _ = attachment["name"].(string)
+		}
+
+		attachments = append(attachments, &archivedAttachment{
+			path:        strings.TrimPrefix(path, "/"),
+			description: text.SanitizeToPlaintext(description),
+		})
+	}
+
+	return attachments
+}
+
+// indexArchiveFiles returns the files in the
+// given archive by their path in the archive.
+func indexArchiveFiles(zr *zip.ReadCloser) map[string]*zip.File {
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[strings.TrimPrefix(f.Name, "./")] = f
+	}
+	return files
+}
+
+// archiveBudget tracks the decompressed bytes that may
+// still be read from an imported archive. The sizes in
+// zip file headers can't be trusted, so bytes are
+// counted as they're actually read.
+type archiveBudget struct {
+	remaining int64
+}
+
+// exhausted returns true if the budget
+// for reading the archive has been used up.
+func (b *archiveBudget) exhausted() bool {
+	return b.remaining <= 0
+}
+
+// open opens the given archive file for reading, returning
+// a reader which fails with errArchiveTooLarge once more than
+// limit bytes of the file, or more than the remaining budget for
+// the whole archive, have been read from it.
+func (b *archiveBudget) open(file *zip.File, limit int64) (io.ReadCloser, error) {
+	if file.UncompressedSize64 > uint64(limit) || b.exhausted() {
+		// Don't bother trying
+		// if it's too big already.
+		return nil, fmt.Errorf("%s: %w", file.Name, errArchiveTooLarge)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &archiveFileReader{
+		rc:     rc,
+		name:   file.Name,
+		budget: b,
+		left:   limit,
+	}, nil
+}
+
+// archiveFileReader wraps the reader of one
+// archive file, counting bytes read from it
+// against its own and its archive's limits.
+type archiveFileReader struct {
+	rc     io.ReadCloser
+	name   string
+	budget *archiveBudget
+	left   int64
+}
+
+func (r *archiveFileReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	r.left -= int64(n)
+	r.budget.remaining -= int64(n)
+	if r.left < 0 || r.budget.remaining < 0 {
+		return n, fmt.Errorf("%s: %w", r.name, errArchiveTooLarge)
+	}
+	return n, err
+}
+
+func (r *archiveFileReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,371 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const testArchiveOutbox = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "outbox.json",
+  "type": "OrderedCollection",
+  "totalItems": 4,
+  "orderedItems": [
+    {
+      "id": "https://example.social/users/zork/statuses/2/activity",
+      "type": "Create",
+      "actor": "https://example.social/users/zork",
+      "published": "2021-03-02T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "cc": ["https://example.social/users/zork/followers"],
+      "object": {
+        "id": "https://example.social/users/zork/statuses/2",
+        "type": "Note",
+        "attributedTo": "https://example.social/users/zork",
+        "published": "2021-03-02T10:00:00Z",
+        "inReplyTo": "https://example.social/users/zork/statuses/1",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "cc": ["https://example.social/users/zork/followers"],
+        "content": "<p>and a reply to myself</p>"
+      }
+    },
+    {
+      "id": "https://example.social/users/zork/statuses/1/activity",
+      "type": "Create",
+      "actor": "https://example.social/users/zork",
+      "published": "2021-03-01T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "cc": ["https://example.social/users/zork/followers"],
+      "object": {
+        "id": "https://example.social/users/zork/statuses/1",
+        "type": "Note",
+        "attributedTo": "https://example.social/users/zork",
+        "published": "2021-03-01T10:00:00Z",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "cc": ["https://example.social/users/zork/followers"],
+        "content": "<p>hello from my old instance</p>",
+        "attachment": [
+          {
+            "type": "Document",
+            "mediaType": "image/jpeg",
+            "url": "/media_attachments/files/000/000/001/original/zork.jpg",
+            "name": "a picture of zork"
+          }
+        ]
+      }
+    },
+    {
+      "id": "https://example.social/users/zork/statuses/3/activity",
+      "type": "Create",
+      "actor": "https://example.social/users/zork",
+      "published": "2021-03-03T10:00:00Z",
+      "to": ["https://example.social/users/zork/followers"],
+      "cc": [],
+      "object": {
+        "id": "https://example.social/users/zork/statuses/3",
+        "type": "Note",
+        "attributedTo": "https://example.social/users/zork",
+        "published": "2021-03-03T10:00:00Z",
+        "to": ["https://example.social/users/zork/followers"],
+        "cc": [],
+        "summary": "followers only",
+        "sensitive": true,
+        "content": "<p>just for my followers</p>"
+      }
+    },
+    {
+      "id": "https://example.social/users/zork/statuses/4/activity",
+      "type": "Announce",
+      "actor": "https://example.social/users/zork",
+      "published": "2021-03-04T10:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": "https://somewhere.else/notes/1"
+    }
+  ]
+}`
+
+const testArchiveActor = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.social/users/zork",
+  "type": "Person",
+  "preferredUsername": "zork",
+  "followers": "https://example.social/users/zork/followers"
+}`
+
+type ImportArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// archiveFileHeader returns a multipart file header
+// containing a zip of the given archive files.
+func (suite *ImportArchiveTestSuite) archiveFileHeader(files map[string][]byte) *multipart.FileHeader {
+	zipBuf := &bytes.Buffer{}
+	zw := zip.NewWriter(zipBuf)
+	for name, b := range files {
+		fw, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := fw.Write(b); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	fw, err := w.CreateFormFile("data", "archive.zip")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := fw.Write(zipBuf.Bytes()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(buf, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return form.File["data"][0]
+}
+
+func (suite *ImportArchiveTestSuite) TestImportArchive() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	image, err := os.ReadFile("../../../testrig/media/zork-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	errWithCode := suite.accountProcessor.ImportArchive(ctx,
+		requester,
+		suite.archiveFileHeader(map[string][]byte{
+			"outbox.json": []byte(testArchiveOutbox),
+			"actor.json":  []byte(testArchiveActor),
+			"media_attachments/files/000/000/001/original/zork.jpg": image,
+		}),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Run the queued import.
+	jobCtx, cncl := context.WithTimeout(ctx, 10*time.Second)
+	defer cncl()
+	fn, ok := suite.state.Workers.Processing.Queue.PopCtx(jobCtx)
+	if !ok {
+		suite.FailNow("no import job queued")
+	}
+	fn(jobCtx)
+
+	// Nothing should have been sent
+	// to be federated or timelined.
+	_, ok = suite.getClientMsg(time.Second)
+	suite.False(ok)
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 100, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	imported := make(map[string]*gtsmodel.Status)
+	for _, status := range statuses {
+		if status.IsImported() {
+			imported[status.Content] = status
+		}
+	}
+
+	// Boost should be skipped.
+	if !suite.Len(imported, 3) {
+		suite.FailNow("")
+	}
+
+	first := imported["<p>hello from my old instance</p>"]
+	reply := imported["<p>and a reply to myself</p>"]
+	private := imported["<p>just for my followers</p>"]
+	if first == nil || reply == nil || private == nil {
+		suite.FailNow("imported status missing")
+	}
+
+	// Original creation times should be kept.
+	suite.Equal(time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), first.CreatedAt.UTC())
+	suite.True(first.IsLocal())
+	suite.Equal(gtsmodel.VisibilityPublic, first.Visibility)
+
+	// Attachment should be imported.
+	if suite.Len(first.AttachmentIDs, 1) {
+		attachment, err := suite.db.GetAttachmentByID(ctx, first.AttachmentIDs[0])
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(first.ID, attachment.StatusID)
+		suite.Equal("a picture of zork", attachment.Description)
+		suite.Equal(gtsmodel.FileTypeImage, attachment.Type)
+	}
+
+	// Reply should be threaded with first status.
+	suite.Equal(first.ID, reply.InReplyToID)
+	suite.Equal(first.URI, reply.InReplyToURI)
+	suite.Equal(first.ThreadID, reply.ThreadID)
+	suite.NotEmpty(first.ThreadID)
+
+	// Visibility and CW should be kept.
+	suite.Equal(gtsmodel.VisibilityFollowersOnly, private.Visibility)
+	suite.Equal("followers only", private.ContentWarning)
+	suite.True(*private.Sensitive)
+}
+
+func (suite *ImportArchiveTestSuite) TestImportArchiveAttachmentTooLarge() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	image, err := os.ReadFile("../../../testrig/media/zork-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Allow only media smaller than the image.
+	config.SetMediaImageMaxSize(bytesize.Size(len(image) - 1))
+	config.SetMediaVideoMaxSize(bytesize.Size(len(image) - 1))
+
+	errWithCode := suite.accountProcessor.ImportArchive(ctx,
+		requester,
+		suite.archiveFileHeader(map[string][]byte{
+			"outbox.json": []byte(testArchiveOutbox),
+			"actor.json":  []byte(testArchiveActor),
+			"media_attachments/files/000/000/001/original/zork.jpg": image,
+		}),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Run the queued import.
+	jobCtx, cncl := context.WithTimeout(ctx, 10*time.Second)
+	defer cncl()
+	fn, ok := suite.state.Workers.Processing.Queue.PopCtx(jobCtx)
+	if !ok {
+		suite.FailNow("no import job queued")
+	}
+	fn(jobCtx)
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 100, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should be imported,
+	// but without the attachment.
+	var first *gtsmodel.Status
+	for _, status := range statuses {
+		if status.IsImported() && status.Content == "<p>hello from my old instance</p>" {
+			first = status
+		}
+	}
+	if first == nil {
+		suite.FailNow("imported status missing")
+	}
+	suite.Empty(first.AttachmentIDs)
+}
+
+func (suite *ImportArchiveTestSuite) TestImportArchiveTwice() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	// Import the same archive twice over.
+	for i := 0; i < 2; i++ {
+		errWithCode := suite.accountProcessor.ImportArchive(ctx,
+			requester,
+			suite.archiveFileHeader(map[string][]byte{
+				"outbox.json": []byte(testArchiveOutbox),
+				"actor.json":  []byte(testArchiveActor),
+			}),
+		)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+
+		// Run the queued import.
+		jobCtx, cncl := context.WithTimeout(ctx, 10*time.Second)
+		fn, ok := suite.state.Workers.Processing.Queue.PopCtx(jobCtx)
+		if !ok {
+			cncl()
+			suite.FailNow("no import job queued")
+		}
+		fn(jobCtx)
+		cncl()
+	}
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 100, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Statuses should only
+	// have been imported once.
+	imported := make(map[string]*gtsmodel.Status)
+	for _, status := range statuses {
+		if status.IsImported() {
+			suite.NotContains(imported, status.ImportedURI)
+			imported[status.ImportedURI] = status
+		}
+	}
+	suite.Len(imported, 3)
+
+	// Reply should still be threaded.
+	reply := imported["https://example.social/users/zork/statuses/2"]
+	first := imported["https://example.social/users/zork/statuses/1"]
+	if reply == nil || first == nil {
+		suite.FailNow("imported status missing")
+	}
+	suite.Equal(first.ID, reply.InReplyToID)
+}
+
+func (suite *ImportArchiveTestSuite) TestImportArchiveNoOutbox() {
+	errWithCode := suite.accountProcessor.ImportArchive(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.archiveFileHeader(map[string][]byte{
+			"actor.json": []byte(testArchiveActor),
+		}),
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestImportArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportArchiveTestSuite))
+}
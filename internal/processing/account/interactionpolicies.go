@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// DefaultInteractionPoliciesGet returns the default interaction
+// policies applied by the given account to its new statuses.
+func (p *Processor) DefaultInteractionPoliciesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.DefaultPolicies, gtserror.WithCode) {
+	settings, err := p.state.DB.GetAccountSettings(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("error getting settings for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.defaultPoliciesToAPI(ctx, settings), nil
+}
+
+// DefaultInteractionPoliciesUpdate updates the default interaction
+// policies applied by the given account to its new statuses, leaving
+// unchanged any policies not set on the form.
+func (p *Processor) DefaultInteractionPoliciesUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.UpdateInteractionPoliciesRequest,
+) (*apimodel.DefaultPolicies, gtserror.WithCode) {
+	settings, err := p.state.DB.GetAccountSettings(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("error getting settings for account %s: %w", requester.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var columns []string
+
+	for _, update := range []struct {
+		form   *apimodel.InteractionPolicy
+		dst    **gtsmodel.InteractionPolicy
+		column string
+	}{
+		{form.Public, &settings.InteractionPolicyPublic, "interaction_policy_public"},
+		{form.Unlisted, &settings.InteractionPolicyUnlisted, "interaction_policy_unlisted"},
+		{form.Private, &settings.InteractionPolicyFollowersOnly, "interaction_policy_followers_only"},
+		{form.Direct, &settings.InteractionPolicyDirect, "interaction_policy_direct"},
+	} {
+		if update.form == nil {
+			// Leave
+			// unchanged.
+			continue
+		}
+
+		if err := validate.InteractionPolicy(update.form); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		*update.dst = typeutils.APIInteractionPolicyToInteractionPolicy(update.form)
+		columns = append(columns, update.column)
+	}
+
+	if len(columns) != 0 {
+		if err := p.state.DB.UpdateAccountSettings(ctx, settings, columns...); err != nil {
+			err := gtserror.Newf("error updating settings for account %s: %w", requester.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.defaultPoliciesToAPI(ctx, settings), nil
+}
+
+func (p *Processor) defaultPoliciesToAPI(ctx context.Context, settings *gtsmodel.AccountSettings) *apimodel.DefaultPolicies {
+	return &apimodel.DefaultPolicies{
+		Public:   p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, settings.InteractionPolicyFor(gtsmodel.VisibilityPublic)),
+		Unlisted: p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, settings.InteractionPolicyFor(gtsmodel.VisibilityUnlocked)),
+		Private:  p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, settings.InteractionPolicyFor(gtsmodel.VisibilityFollowersOnly)),
+		Direct:   p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, settings.InteractionPolicyFor(gtsmodel.VisibilityDirect)),
+	}
+}
@@ -0,0 +1,621 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package account
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"golang.org/x/net/html"
+)
+
+const (
+	// fieldLinkFetchInterval is the minimum time between
+	// fetches of profile field links on the same host, so
+	// that we don't hammer any one site when verifying lots
+	// of fields that point to it (eg., code forges).
+	fieldLinkFetchInterval = 5 * time.Second
+
+	// fieldLinkMaxBodySize is the maximum amount
+	// of a linked page that we'll read while looking
+	// for a rel="me" link back to the account.
+	fieldLinkMaxBodySize = 1 << 20 // 1MiB
+
+	// verifyRemoteFieldsEvery is how often the profile
+	// field links of remote accounts are re-verified.
+	verifyRemoteFieldsEvery = 24 * time.Hour
+
+	// verifyRemoteFieldsPageSize is the number of remote
+	// accounts to fetch from the db at once while verifying.
+	verifyRemoteFieldsPageSize = 100
+)
+
+// fieldVerifier holds state used when
+// verifying profile field links.
+type fieldVerifier struct {
+	// next is the earliest time
+	// at which we may next fetch
+	// a link on each host.
+	next   map[string]time.Time
+	nextMu sync.Mutex
+
+	// sweeping is set while a run of
+	// remote fields verification is
+	// in progress, to avoid overlap.
+	sweeping atomic.Bool
+}
+
+// reserve reserves the next free slot for fetching
+// a profile field link on the given host, returning
+// the time at which the link may be fetched.
+func (v *fieldVerifier) reserve(host string) time.Time {
+	v.nextMu.Lock()
+	defer v.nextMu.Unlock()
+
+	now := time.Now()
+
+	if v.next == nil {
+		v.next = make(map[string]time.Time)
+	}
+
+	// Drop entries that are in the past,
+	// so the map doesn't grow forever.
+	if len(v.next) > 1024 {
+		for h, t := range v.next {
+			if t.Before(now) {
+				delete(v.next, h)
+			}
+		}
+	}
+
+	at := v.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	v.next[host] = at.Add(fieldLinkFetchInterval)
+	return at
+}
+
+// wait blocks until a profile field link on the given host
+// may be fetched, or until the context is cancelled.
+func (v *fieldVerifier) wait(ctx context.Context, host string) error {
+	d := time.Until(v.reserve(host))
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// fieldsCheck collects the results of checking
+// each of an account's profile field links in
+// the background, see VerifyFieldsAsync.
+type fieldsCheck struct {
+	account  *gtsmodel.Account
+	verified map[string]bool
+	pending  int
+	mu       sync.Mutex
+}
+
+// done records the result of checking the link in the
+// profile field with the given value, returning true
+// once all of the account's links have been checked.
+func (c *fieldsCheck) done(value string, ok bool, err error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.verified[value] = ok
+	}
+
+	c.pending--
+	return c.pending == 0
+}
+
+// VerifyFieldsAsync verifies the given account's profile
+// field links in the background, see VerifyFields.
+//
+// Each link is fetched on the dereference worker pool, but
+// only queued there once it may be fetched without breaking
+// the limit on fetches per host, so that workers never sit
+// waiting on that limit.
+func (p *Processor) VerifyFieldsAsync(account *gtsmodel.Account) {
+	if account.IsSuspended() {
+		// Nothing to do.
+		return
+	}
+
+	links := fieldLinks(account)
+	if len(links) == 0 {
+		// Nothing to do.
+		return
+	}
+
+	check := &fieldsCheck{
+		account:  account,
+		verified: make(map[string]bool, len(links)),
+		pending:  len(links),
+	}
+
+	for value, link := range links {
+		p.verifyFieldLinkAsync(check, value, link)
+	}
+}
+
+// verifyFieldLinkAsync queues a check of the given profile
+// field link for when its host's fetch limit allows. Once
+// all of the account's links are checked, the results are
+// stored by the worker that checked the last one.
+func (p *Processor) verifyFieldLinkAsync(check *fieldsCheck, value string, link *url.URL) {
+	verify := func(ctx context.Context) {
+		ok, err := p.verifyFieldLink(ctx, check.account, link)
+		if err != nil {
+			log.Debugf(ctx, "could not verify link %s of account %s: %v", link, check.account.ID, err)
+		}
+
+		if !check.done(value, ok, err) {
+			// Other links
+			// still pending.
+			return
+		}
+
+		if err := p.updateVerifiedFields(ctx, check.account.ID, check.verified); err != nil {
+			log.Errorf(ctx, "error verifying fields of account %s: %v", check.account.ID, err)
+		}
+	}
+
+	queue := func() {
+		p.state.Workers.Dereference.Queue.Push(verify)
+	}
+
+	if d := time.Until(p.fieldVerifier.reserve(link.Host)); d > 0 {
+		// Queue once the
+		// limit allows.
+		time.AfterFunc(d, queue)
+		return
+	}
+
+	queue()
+}
+
+// VerifyFields checks each of the given account's profile
+// fields that contain (only) a link, by fetching the linked
+// page and looking for an `<a rel="me">` or `<link rel="me">`
+// pointing back to the account's profile.
+//
+// Fields that link back are marked as verified, and fields that
+// don't are marked as unverified. Fields whose link could not be
+// fetched are left as they are, to be retried another time.
+func (p *Processor) VerifyFields(ctx context.Context, account *gtsmodel.Account) error {
+	if account.IsSuspended() {
+		// Nothing to do.
+		return nil
+	}
+
+	// Check each distinct link first. This
+	// may take a while, since fetches of
+	// links on the same host are spaced out.
+	links := fieldLinks(account)
+	verified := make(map[string]bool, len(links))
+	for value, link := range links {
+		if err := p.fieldVerifier.wait(ctx, link.Host); err != nil {
+			return err
+		}
+
+		ok, err := p.verifyFieldLink(ctx, account, link)
+		if err != nil {
+			log.Debugf(ctx, "could not verify link %s of account %s: %v", link, account.ID, err)
+			continue
+		}
+
+		verified[value] = ok
+	}
+
+	return p.updateVerifiedFields(ctx, account.ID, verified)
+}
+
+// updateVerifiedFields updates the verification time of the
+// profile fields of the account with the given ID, according
+// to whether the link in each field (by value) was verified.
+func (p *Processor) updateVerifiedFields(ctx context.Context, accountID string, verified map[string]bool) error {
+	if len(verified) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Reload the account, as its fields
+	// may have changed while we were busy.
+	account, err := p.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Account was deleted meanwhile.
+			return nil
+		}
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	var (
+		now     = time.Now()
+		changed bool
+	)
+
+	for i, field := range account.Fields {
+		ok, checked := verified[field.Value]
+		if !checked {
+			continue
+		}
+
+		var verifiedAt time.Time
+		if ok {
+			if !field.VerifiedAt.IsZero() {
+				// Already verified,
+				// keep original time.
+				continue
+			}
+			verifiedAt = now
+		} else if field.VerifiedAt.IsZero() {
+			// Already unverified.
+			continue
+		}
+
+		field.VerifiedAt = verifiedAt
+		changed = true
+
+		// Local accounts store raw fields
+		// alongside the formatted ones,
+		// keep these in sync.
+		if i < len(account.FieldsRaw) {
+			account.FieldsRaw[i].VerifiedAt = verifiedAt
+		}
+	}
+
+	if !changed {
+		// Nothing to do.
+		return nil
+	}
+
+	columns := []string{"fields"}
+	if account.IsLocal() {
+		columns = append(columns, "fields_raw")
+	}
+
+	if err := p.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
+		return gtserror.Newf("db error updating account: %w", err)
+	}
+
+	return nil
+}
+
+// verifyFieldLink fetches the page at the given profile field
+// link, and returns whether it has a rel="me" link to the account.
+func (p *Processor) verifyFieldLink(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	link *url.URL,
+) (bool, error) {
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, link.Hostname())
+	if err != nil {
+		return false, gtserror.Newf("db error checking domain block: %w", err)
+	}
+
+	if blocked {
+		// Don't go fetching
+		// from blocked domains.
+		return false, nil
+	}
+
+	// Fetch the link using the instance account transport.
+	tsport, err := p.federator.TransportController().NewTransportForUsername(ctx, "")
+	if err != nil {
+		return false, gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	rc, err := tsport.DereferenceProfileLink(ctx, link)
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	r := io.LimitReader(rc, fieldLinkMaxBodySize)
+	return hasRelMeLink(r, link, account.URL, account.URI), nil
+}
+
+// VerifyRemoteFieldsSchedule schedules periodic
+// re-verification of remote accounts' profile
+// field links, see VerifyRemoteFields.
+func (p *Processor) VerifyRemoteFieldsSchedule() {
+	// Give the instance a little
+	// while to settle after startup.
+	firstVerifyAt := time.Now().Add(time.Hour)
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting remote account fields verification")
+		p.VerifyRemoteFields(ctx)
+		log.Infof(ctx, "finished remote account fields verification after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling remote account fields verification to run every %s; next verification will run at %s",
+		verifyRemoteFieldsEvery, firstVerifyAt,
+	)
+
+	// Schedule verification to execute according to schedule.
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@verifyfields",
+		firstVerifyAt,
+		verifyRemoteFieldsEvery,
+		fn,
+	) {
+		panic("failed to schedule @verifyfields")
+	}
+}
+
+// VerifyRemoteFields pages through all remote accounts in the
+// database, verifying the profile field links of each one.
+func (p *Processor) VerifyRemoteFields(ctx context.Context) {
+	if !p.fieldVerifier.sweeping.CompareAndSwap(false, true) {
+		log.Warn(ctx, "previous remote account fields verification still running, skipping")
+		return
+	}
+	defer p.fieldVerifier.sweeping.Store(false)
+
+	var maxID string
+	for {
+		accounts, err := p.state.DB.GetAccounts(
+			gtscontext.SetBarebones(ctx),
+			"remote",
+			"",
+			false,
+			"",
+			"",
+			"",
+			"",
+			"",
+			netip.Addr{},
+			&paging.Page{
+				Max:   paging.MaxID(maxID),
+				Limit: verifyRemoteFieldsPageSize,
+			},
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting remote accounts: %v", err)
+			return
+		}
+
+		if len(accounts) == 0 {
+			// All done.
+			return
+		}
+
+		// Get next page after
+		// the last account.
+		last := accounts[len(accounts)-1]
+		maxID = last.Domain + "/@" + last.Username
+
+		for _, account := range accounts {
+			if err := p.VerifyFields(ctx, account); err != nil {
+				log.Errorf(ctx, "error verifying fields of account %s: %v", account.ID, err)
+			}
+
+			if ctx.Err() != nil {
+				// Shutting down.
+				return
+			}
+		}
+	}
+}
+
+// fieldLinks returns the distinct http(s) URLs linked
+// to by the given account's profile fields, by value.
+func fieldLinks(account *gtsmodel.Account) map[string]*url.URL {
+	links := make(map[string]*url.URL, len(account.Fields))
+	for _, field := range account.Fields {
+		if _, ok := links[field.Value]; ok {
+			// Already got.
+			continue
+		}
+
+		link := fieldLink(field.Value)
+		if link == nil {
+			// Not a link.
+			continue
+		}
+
+		links[field.Value] = link
+	}
+	return links
+}
+
+// fieldLink returns the http(s) URL linked to by the given
+// profile field value, or nil if the value is not a link.
+//
+// Values may be plain text URLs, or HTML containing nothing
+// but a single anchor, as in fields formatted by this instance
+// or dereferenced from remote ones.
+func fieldLink(value string) *url.URL {
+	var (
+		z       = html.NewTokenizer(strings.NewReader(value))
+		anchors int
+		inA     bool
+		href    string
+		text    strings.Builder
+	)
+
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "a" {
+				continue
+			}
+
+			anchors++
+			inA = true
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				if string(key) == "href" {
+					href = string(val)
+				}
+			}
+
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "a" {
+				inA = false
+			}
+
+		case html.TextToken:
+			if !inA {
+				text.Write(z.Text())
+			}
+		}
+	}
+
+	raw := strings.TrimSpace(text.String())
+	switch {
+	case anchors == 0:
+		// Plain text value,
+		// may be a bare link.
+
+	case anchors == 1 && raw == "":
+		// Value is an anchor
+		// and nothing else.
+		raw = href
+
+	default:
+		// Value contains more
+		// than just a link.
+		return nil
+	}
+
+	if strings.ContainsAny(raw, " \t\n") {
+		return nil
+	}
+
+	link, err := url.Parse(raw)
+	if err != nil ||
+		(link.Scheme != "http" && link.Scheme != "https") ||
+		link.Host == "" {
+		return nil
+	}
+
+	return link
+}
+
+// hasRelMeLink returns whether the HTML page read from r has an
+// `<a rel="me">` or `<link rel="me">` that points to any of the
+// given profile URLs. Relative links are resolved against base.
+func hasRelMeLink(r io.Reader, base *url.URL, profileURLs ...string) bool {
+	targets := make(map[string]struct{}, len(profileURLs))
+	for _, profileURL := range profileURLs {
+		if profileURL == "" {
+			continue
+		}
+
+		u, err := url.Parse(profileURL)
+		if err != nil {
+			continue
+		}
+		targets[normalizeProfileURL(u)] = struct{}{}
+	}
+
+	z := html.NewTokenizer(r)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// EOF or
+			// bad HTML.
+			return false
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if n := string(name); n != "a" && n != "link" {
+				continue
+			}
+
+			var (
+				relMe bool
+				href  string
+			)
+
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				switch string(key) {
+				case "rel":
+					for _, rel := range strings.Fields(string(val)) {
+						if strings.EqualFold(rel, "me") {
+							relMe = true
+						}
+					}
+				case "href":
+					href = string(val)
+				}
+			}
+
+			if !relMe || href == "" {
+				continue
+			}
+
+			u, err := base.Parse(strings.TrimSpace(href))
+			if err != nil {
+				continue
+			}
+
+			if _, ok := targets[normalizeProfileURL(u)]; ok {
+				return true
+			}
+		}
+	}
+}
+
+// normalizeProfileURL returns the given URL in a form suitable for
+// comparison, ignoring case of the scheme and host, any query
+// or fragment, and any trailing slash on the path.
+func normalizeProfileURL(u *url.URL) string {
+	return strings.ToLower(u.Scheme) + "://" +
+		strings.ToLower(u.Host) +
+		strings.TrimSuffix(u.EscapedPath(), "/")
+}
@@ -0,0 +1,220 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelMeTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// processorWithPages returns an account processor whose
+// transport serves the given HTML pages, keyed by URL.
+func (suite *RelMeTestSuite) processorWithPages(pages map[string]string) *account.Processor {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Request:    req,
+			}, nil
+		}
+
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Type": {"text/html"}},
+			Body:          io.NopCloser(bytes.NewReader([]byte(page))),
+			ContentLength: int64(len(page)),
+			Request:       req,
+		}, nil
+	}, "")
+
+	var (
+		transportController = testrig.NewTestTransportController(&suite.state, httpClient)
+		federator           = testrig.NewTestFederator(&suite.state, transportController, suite.mediaManager)
+		filter              = visibility.NewFilter(&suite.state)
+		common              = common.New(&suite.state, suite.mediaManager, suite.tc, federator, filter)
+		processor           = account.New(&common, &suite.state, suite.tc, suite.mediaManager, federator, filter, processing.GetParseMentionFunc(&suite.state, federator))
+	)
+
+	return &processor
+}
+
+func (suite *RelMeTestSuite) TestVerifyFields() {
+	var (
+		ctx          = context.Background()
+		testAccount  = new(gtsmodel.Account)
+		previousTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	processor := suite.processorWithPages(map[string]string{
+		// Links back with <a rel="me">.
+		"https://example.org/about": `<html><body><a href="http://localhost:8080/@the_mighty_zork" rel="me nofollow">me on fedi</a></body></html>`,
+		// Links back with <link rel="me">, relative to the page.
+		"http://localhost:8080/about": `<html><head><link rel="me" href="/@the_mighty_zork/"></head></html>`,
+		// Links back, but without rel="me".
+		"https://somewhere.else/": `<html><body><a href="http://localhost:8080/@the_mighty_zork">zork</a></body></html>`,
+	})
+
+	testAccount.Fields = []*gtsmodel.Field{
+		{Name: "website", Value: `<a href="https://example.org/about" rel="nofollow noreferrer noopener" target="_blank">https://example.org/about</a>`},
+		{Name: "local", Value: "http://localhost:8080/about"},
+		{Name: "elsewhere", Value: "https://somewhere.else/", VerifiedAt: previousTime},
+		{Name: "not a link", Value: "he/him"},
+		{Name: "gone", Value: "https://gone.example.org/", VerifiedAt: previousTime},
+	}
+	testAccount.FieldsRaw = []*gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/about"},
+		{Name: "local", Value: "http://localhost:8080/about"},
+		{Name: "elsewhere", Value: "https://somewhere.else/", VerifiedAt: previousTime},
+		{Name: "not a link", Value: "he/him"},
+		{Name: "gone", Value: "https://gone.example.org/", VerifiedAt: previousTime},
+	}
+	if err := suite.db.UpdateAccount(ctx, testAccount, "fields", "fields_raw"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := processor.VerifyFields(ctx, testAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, fields := range [][]*gtsmodel.Field{
+		dbAccount.Fields,
+		dbAccount.FieldsRaw,
+	} {
+		suite.Len(fields, 5)
+		suite.False(fields[0].VerifiedAt.IsZero())
+		suite.False(fields[1].VerifiedAt.IsZero())
+		suite.True(fields[2].VerifiedAt.IsZero())
+		suite.True(fields[3].VerifiedAt.IsZero())
+
+		// Couldn't be fetched, so
+		// should be left alone.
+		suite.Equal(previousTime, fields[4].VerifiedAt.UTC())
+	}
+}
+
+func (suite *RelMeTestSuite) TestVerifyFieldsAsync() {
+	var (
+		ctx         = context.Background()
+		testAccount = new(gtsmodel.Account)
+	)
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/about": `<a rel="me" href="http://localhost:8080/@the_mighty_zork">`,
+		"https://example.org/more":  `<a rel="me" href="http://localhost:8080/@the_mighty_zork">`,
+		"https://somewhere.else/":   `<a rel="me" href="http://localhost:8080/@the_mighty_zork">`,
+	})
+
+	testAccount.Fields = []*gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/about"},
+		{Name: "more", Value: "https://example.org/more"},
+		{Name: "elsewhere", Value: "https://somewhere.else/"},
+	}
+	if err := suite.db.UpdateAccount(ctx, testAccount, "fields"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	processor.VerifyFieldsAsync(testAccount)
+
+	// Only one link per host should be
+	// queued straight away, the other
+	// waits for the limit on its host.
+	suite.Equal(2, suite.state.Workers.Dereference.Queue.Len())
+
+	for i := 0; i < 3; i++ {
+		jobCtx, cncl := context.WithTimeout(ctx, 10*time.Second)
+		fn, ok := suite.state.Workers.Dereference.Queue.PopCtx(jobCtx)
+		cncl()
+		if !ok {
+			suite.FailNow("verification job not queued")
+		}
+		fn(ctx)
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// All links should be verified.
+	suite.Len(dbAccount.Fields, 3)
+	for _, field := range dbAccount.Fields {
+		suite.False(field.VerifiedAt.IsZero())
+	}
+}
+
+func (suite *RelMeTestSuite) TestVerifyFieldsKeepsVerifiedAt() {
+	var (
+		ctx          = context.Background()
+		testAccount  = new(gtsmodel.Account)
+		previousTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	*testAccount = *suite.testAccounts["remote_account_1"]
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/": `<a rel="me" href="http://fossbros-anonymous.io/users/foss_satan">`,
+	})
+
+	testAccount.Fields = []*gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/", VerifiedAt: previousTime},
+	}
+	if err := suite.db.UpdateAccount(ctx, testAccount, "fields"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := processor.VerifyFields(ctx, testAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Still verified since the
+	// original verification time.
+	suite.Len(dbAccount.Fields, 1)
+	suite.Equal(previousTime, dbAccount.Fields[0].VerifiedAt.UTC())
+}
+
+func TestRelMeTestSuite(t *testing.T) {
+	suite.Run(t, new(RelMeTestSuite))
+}
@@ -121,6 +121,16 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 				Name:  text.SanitizeToPlaintext(name),
 				Value: text.SanitizeToPlaintext(value),
 			}
+
+			// Carry over verification
+			// of unchanged field links.
+			for _, prevFieldRaw := range account.FieldsRaw {
+				if prevFieldRaw.Value == fieldRaw.Value {
+					fieldRaw.VerifiedAt = prevFieldRaw.VerifiedAt
+					break
+				}
+			}
+
 			fieldsRaw = append(fieldsRaw, fieldRaw)
 		}
 
@@ -165,7 +175,9 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		// Process the raw fields we stored earlier.
 		account.Fields = make([]*gtsmodel.Field, 0, len(account.FieldsRaw))
 		for _, fieldRaw := range account.FieldsRaw {
-			field := &gtsmodel.Field{}
+			field := &gtsmodel.Field{
+				VerifiedAt: fieldRaw.VerifiedAt,
+			}
 
 			// Name stays plain, but we still need to
 			// see if there are any emojis set in it.
@@ -338,13 +350,19 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not update account settings %s: %s", account.ID, err))
 	}
 
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ActorPerson,
 		APActivityType: ap.ActivityUpdate,
 		GTSModel:       account,
 		Origin:         account,
 	})
 
+	if form.FieldsAttributes != nil {
+		// (Re)verify any links in the
+		// new fields in the background.
+		p.VerifyFieldsAsync(account)
+	}
+
 	acctSensitive, err := p.converter.AccountToAPIAccountSensitive(ctx, account)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not convert account into apisensitive account: %s", err))
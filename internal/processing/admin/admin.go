@@ -24,7 +24,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
@@ -40,6 +42,10 @@ type Processor struct {
 	media     *media.Manager
 	transport transport.Controller
 	email     email.Sender
+	stream    *stream.Processor
+
+	formatter    *text.Formatter
+	parseMention gtsmodel.ParseMentionFunc
 
 	// admin Actions currently
 	// undergoing processing
@@ -60,6 +66,8 @@ func New(
 	mediaManager *media.Manager,
 	transportController transport.Controller,
 	emailSender email.Sender,
+	stream *stream.Processor,
+	parseMentionFunc gtsmodel.ParseMentionFunc,
 ) Processor {
 	return Processor{
 		c:         common,
@@ -70,6 +78,10 @@ func New(
 		media:     mediaManager,
 		transport: transportController,
 		email:     emailSender,
+		stream:    stream,
+
+		formatter:    text.NewFormatter(state.DB),
+		parseMention: parseMentionFunc,
 		actions: &Actions{
 			r:     make(map[string]*gtsmodel.AdminAction),
 			state: state,
@@ -0,0 +1,358 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// AnnouncementsGet returns all announcements stored on
+// this instance, including unpublished and inactive ones.
+func (p *Processor) AnnouncementsGet(ctx context.Context) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// AnnouncementGet returns one announcement, with the given ID.
+func (p *Processor) AnnouncementGet(ctx context.Context, id string) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAnnouncement(ctx, announcement)
+}
+
+// AnnouncementCreate adds a new announcement to the instance,
+// streaming it to users if it is published and already started.
+func (p *Processor) AnnouncementCreate(
+	ctx context.Context,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement := &gtsmodel.Announcement{
+		ID: id.NewULID(),
+	}
+
+	if errWithCode := p.applyAnnouncementForm(ctx, announcement, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error putting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Stream new announcement
+	// now or when it starts.
+	p.announce(ctx, announcement, false)
+
+	return p.apiAnnouncement(ctx, announcement)
+}
+
+// AnnouncementUpdate updates an existing announcement
+// with the given form, streaming the updated announcement
+// to users if it is published and already started.
+func (p *Processor) AnnouncementUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check whether users can currently see the announcement.
+	wasActive := announcement.IsActive(time.Now())
+
+	if errWithCode := p.applyAnnouncementForm(ctx, announcement, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error updating announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Stream updated announcement
+	// now or when it (re)starts.
+	p.announce(ctx, announcement, wasActive)
+
+	return p.apiAnnouncement(ctx, announcement)
+}
+
+// AnnouncementDelete deletes an existing announcement,
+// along with all dismissals of and reactions to it.
+func (p *Processor) AnnouncementDelete(ctx context.Context, id string) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deletion, while
+	// reactions are still available.
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementByID(ctx, id); err != nil {
+		err := gtserror.Newf("db error deleting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Cancel any pending start / end streams.
+	p.unscheduleAnnouncement(announcement.ID)
+
+	if announcement.IsActive(time.Now()) {
+		// Remove announcement from users' clients.
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// AnnouncementsScheduleAll schedules streaming of all
+// published announcements which have yet to start or end.
+// It is to be called on startup, as scheduled tasks are
+// not persisted when the instance is restarted.
+func (p *Processor) AnnouncementsScheduleAll(ctx context.Context) error {
+	announcements, err := p.state.DB.GetPendingAnnouncements(
+		gtscontext.SetBarebones(ctx),
+		time.Now(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting pending announcements: %w", err)
+	}
+
+	for _, announcement := range announcements {
+		p.scheduleAnnouncement(ctx, announcement)
+	}
+
+	return nil
+}
+
+// getAnnouncement gets the announcement with given
+// ID from the database, returning a 404 if not found.
+func (p *Processor) getAnnouncement(ctx context.Context, id string) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil {
+		err := gtserror.Newf("announcement %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return announcement, nil
+}
+
+// applyAnnouncementForm validates and sets the
+// values of given form on the announcement model.
+func (p *Processor) applyAnnouncementForm(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	form *apimodel.AnnouncementCreateRequest,
+) gtserror.WithCode {
+	var startsAt, endsAt time.Time
+
+	if form.StartsAt != "" {
+		var err error
+		startsAt, err = time.Parse(time.RFC3339, form.StartsAt)
+		if err != nil {
+			text := fmt.Sprintf("starts_at %s could not be parsed as ISO 8601 datetime", form.StartsAt)
+			return gtserror.NewErrorBadRequest(err, text)
+		}
+	}
+
+	if form.EndsAt != "" {
+		var err error
+		endsAt, err = time.Parse(time.RFC3339, form.EndsAt)
+		if err != nil {
+			text := fmt.Sprintf("ends_at %s could not be parsed as ISO 8601 datetime", form.EndsAt)
+			return gtserror.NewErrorBadRequest(err, text)
+		}
+	}
+
+	if !startsAt.IsZero() && !endsAt.IsZero() && !endsAt.After(startsAt) {
+		const text = "ends_at must be after starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Get the instance account to format the text as.
+	instanceAcc, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	formatted := p.formatter.FromMarkdown(ctx,
+		p.parseMention,
+		instanceAcc.ID,
+		"",
+		form.Text,
+	)
+
+	announcement.Text = form.Text
+	announcement.Content = formatted.HTML
+	announcement.StartsAt = startsAt
+	announcement.EndsAt = endsAt
+	announcement.AllDay = &form.AllDay
+	announcement.Published = util.Ptr(util.PtrValueOr(form.Published, true))
+
+	announcement.EmojiIDs = make([]string, 0, len(formatted.Emojis))
+	for _, emoji := range formatted.Emojis {
+		announcement.EmojiIDs = append(announcement.EmojiIDs, emoji.ID)
+	}
+	announcement.Emojis = formatted.Emojis
+
+	if *announcement.Published && announcement.PublishedAt.IsZero() {
+		// First time publishing.
+		announcement.PublishedAt = time.Now()
+	}
+
+	return nil
+}
+
+// announce streams the announcement to users if it is
+// currently active, and schedules streams for when it
+// starts or ends in future. If the announcement was
+// active but no longer is, it is removed from clients.
+func (p *Processor) announce(ctx context.Context, announcement *gtsmodel.Announcement, wasActive bool) {
+	if announcement.IsActive(time.Now()) {
+		p.streamAnnouncement(ctx, announcement.ID)
+	} else if wasActive {
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	p.scheduleAnnouncement(ctx, announcement)
+}
+
+// scheduleAnnouncement (re)schedules streaming of the given announcement
+// for when it starts, and the removal of it for when it ends, if in future.
+func (p *Processor) scheduleAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) {
+	// Drop any existing schedules.
+	p.unscheduleAnnouncement(announcement.ID)
+
+	if !*announcement.Published {
+		// Nothing to stream.
+		return
+	}
+
+	now := time.Now()
+	announcementID := announcement.ID
+
+	if announcement.StartsAt.After(now) {
+		// Stream the announcement once it starts.
+		if !p.state.Workers.Scheduler.AddOnce(
+			announcementID+"_start",
+			announcement.StartsAt,
+			func(ctx context.Context, _ time.Time) {
+				p.streamAnnouncement(ctx, announcementID)
+			},
+		) {
+			log.Errorf(ctx, "failed scheduling start of announcement %s", announcementID)
+		}
+	}
+
+	if announcement.EndsAt.After(now) {
+		// Remove the announcement once it ends.
+		if !p.state.Workers.Scheduler.AddOnce(
+			announcementID+"_end",
+			announcement.EndsAt,
+			func(ctx context.Context, _ time.Time) {
+				p.stream.AnnouncementDelete(ctx, announcementID)
+			},
+		) {
+			log.Errorf(ctx, "failed scheduling end of announcement %s", announcementID)
+		}
+	}
+}
+
+// unscheduleAnnouncement cancels any scheduled
+// streams for the announcement with given ID.
+func (p *Processor) unscheduleAnnouncement(announcementID string) {
+	_ = p.state.Workers.Scheduler.Cancel(announcementID + "_start")
+	_ = p.state.Workers.Scheduler.Cancel(announcementID + "_end")
+}
+
+// streamAnnouncement streams the latest version of the announcement
+// with given ID to all users' clients, if it is currently active.
+func (p *Processor) streamAnnouncement(ctx context.Context, announcementID string) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, announcementID)
+	if err != nil {
+		log.Errorf(ctx, "db error getting announcement %s: %v", announcementID, err)
+		return
+	}
+
+	if !announcement.IsActive(time.Now()) {
+		// Unpublished or
+		// ended meanwhile.
+		return
+	}
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, nil)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement %s: %v", announcementID, err)
+		return
+	}
+
+	p.stream.Announcement(ctx, apiAnnouncement)
+}
+
+// apiAnnouncement converts the given
+// announcement to its api model.
+func (p *Processor) apiAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx, announcement, nil)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement %s: %w", announcement.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiAnnouncement, nil
+}
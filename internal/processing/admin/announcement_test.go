@@ -0,0 +1,214 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AnnouncementTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AnnouncementTestSuite) recv(str *stream.Stream, expectEventType string) stream.Message {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	msg, ok := str.Recv(ctx)
+	if !ok {
+		suite.FailNow("expected a message but message was not received")
+	}
+
+	suite.Equal(expectEventType, msg.Event)
+	return msg
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementLifecycle() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	homeStream, errWithCode := suite.processor.Stream().Open(ctx, requester, stream.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer homeStream.Close()
+
+	// Create an announcement that's already started.
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementCreate(ctx,
+		&apimodel.AnnouncementCreateRequest{
+			Text:     "Instance is **back**! :rainbow:",
+			StartsAt: util.FormatISO8601(time.Now().Add(-time.Hour)),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("<p>Instance is <strong>back</strong>! :rainbow:</p>", apiAnnouncement.Content)
+	suite.True(apiAnnouncement.Published)
+	suite.NotEmpty(apiAnnouncement.PublishedAt)
+	suite.Len(apiAnnouncement.Emojis, 1)
+
+	// It should have been streamed straight away.
+	msg := suite.recv(homeStream, stream.EventTypeAnnouncement)
+	suite.Contains(msg.Payload, apiAnnouncement.ID)
+
+	// And be visible to users.
+	announcements, errWithCode := suite.processor.Announcements().GetAll(ctx, requester, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(announcements, 1)
+	suite.False(announcements[0].Read)
+
+	// React with a custom emoji and a unicode emoji.
+	for _, name := range []string{"rainbow", "🎉"} {
+		if errWithCode := suite.processor.Announcements().AddReaction(ctx, requester, apiAnnouncement.ID, name); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+
+		msg := suite.recv(homeStream, stream.EventTypeAnnouncementReaction)
+		suite.Contains(msg.Payload, `"count":1`)
+	}
+
+	// Unknown custom emoji should be rejected.
+	errWithCode = suite.processor.Announcements().AddReaction(ctx, requester, apiAnnouncement.ID, "not_an_emoji")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	announcements, errWithCode = suite.processor.Announcements().GetAll(ctx, requester, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(announcements[0].Reactions, 2)
+	suite.Equal("rainbow", announcements[0].Reactions[0].Name)
+	suite.True(announcements[0].Reactions[0].Me)
+	suite.NotEmpty(announcements[0].Reactions[0].URL)
+	suite.Equal("🎉", announcements[0].Reactions[1].Name)
+
+	// Remove unicode reaction again.
+	if errWithCode := suite.processor.Announcements().RemoveReaction(ctx, requester, apiAnnouncement.ID, "🎉"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	msg = suite.recv(homeStream, stream.EventTypeAnnouncementReaction)
+	suite.Contains(msg.Payload, `"count":0`)
+
+	// Dismiss the announcement.
+	if errWithCode := suite.processor.Announcements().Dismiss(ctx, requester, apiAnnouncement.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Should now only be returned with dismissed.
+	announcements, errWithCode = suite.processor.Announcements().GetAll(ctx, requester, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(announcements)
+
+	announcements, errWithCode = suite.processor.Announcements().GetAll(ctx, requester, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(announcements, 1)
+	suite.True(announcements[0].Read)
+
+	// Delete the announcement.
+	if _, errWithCode := suite.adminProcessor.AnnouncementDelete(ctx, apiAnnouncement.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	msg = suite.recv(homeStream, stream.EventTypeAnnouncementDelete)
+	suite.Equal(apiAnnouncement.ID, msg.Payload)
+
+	_, errWithCode = suite.adminProcessor.AnnouncementGet(ctx, apiAnnouncement.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementNotStarted() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	// Create an announcement that starts in future.
+	apiAnnouncement, errWithCode := suite.adminProcessor.AnnouncementCreate(ctx,
+		&apimodel.AnnouncementCreateRequest{
+			Text:     "Maintenance tomorrow.",
+			StartsAt: util.FormatISO8601(time.Now().Add(24 * time.Hour)),
+			EndsAt:   util.FormatISO8601(time.Now().Add(48 * time.Hour)),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Admins can see it.
+	all, errWithCode := suite.adminProcessor.AnnouncementsGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(all, 1)
+
+	// Users can't yet.
+	announcements, errWithCode := suite.processor.Announcements().GetAll(ctx, requester, true)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(announcements)
+
+	errWithCode = suite.processor.Announcements().Dismiss(ctx, requester, apiAnnouncement.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Ending before starting is invalid.
+	_, errWithCode = suite.adminProcessor.AnnouncementUpdate(ctx,
+		apiAnnouncement.ID,
+		&apimodel.AnnouncementCreateRequest{
+			Text:     "Maintenance tomorrow.",
+			StartsAt: util.FormatISO8601(time.Now().Add(48 * time.Hour)),
+			EndsAt:   util.FormatISO8601(time.Now().Add(24 * time.Hour)),
+		},
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Move the start time back, so it's now active.
+	apiAnnouncement, errWithCode = suite.adminProcessor.AnnouncementUpdate(ctx,
+		apiAnnouncement.ID,
+		&apimodel.AnnouncementCreateRequest{
+			Text:   "Maintenance ongoing.",
+			EndsAt: util.FormatISO8601(time.Now().Add(24 * time.Hour)),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(apiAnnouncement.StartsAt)
+
+	announcements, errWithCode = suite.processor.Announcements().GetAll(ctx, requester, false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(announcements, 1)
+	suite.Equal("<p>Maintenance ongoing.</p>", announcements[0].Content)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementTestSuite))
+}
@@ -0,0 +1,353 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// apiDomainPermSub is a cheeky shortcut for returning the
+// API version of the given domain permission subscription,
+// or an appropriate error if something goes wrong.
+func (p *Processor) apiDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	apiPermSub, err := p.converter.DomainPermSubToAPIDomainPermSub(ctx, permSub)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting domain permission subscription to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// getDomainPermSub fetches the domain permission
+// subscription with the given ID from the database,
+// returning a suitable error if it can't be found.
+func (p *Processor) getDomainPermSub(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no domain permission subscription exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting domain permission subscription %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return permSub, nil
+}
+
+// DomainPermissionSubscriptionGet returns one
+// domain permission subscription with the given id.
+func (p *Processor) DomainPermissionSubscriptionGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionsGet returns all domain
+// permission subscriptions of the given permission type,
+// in priority order. If permType is unknown, subscriptions
+// of all permission types will be returned.
+func (p *Processor) DomainPermissionSubscriptionsGet(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSubs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPermSubs := make([]*apimodel.DomainPermissionSubscription, 0, len(permSubs))
+	for _, permSub := range permSubs {
+		apiPermSub, errWithCode := p.apiDomainPermSub(ctx, permSub)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiPermSubs = append(apiPermSubs, apiPermSub)
+	}
+
+	return apiPermSubs, nil
+}
+
+// DomainPermissionSubscriptionCreate creates a new domain
+// permission subscription with the given parameters. The
+// subscription is not fetched immediately; it will be
+// processed along with all others at the next scheduled run.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	if form.PermissionType == nil {
+		const text = "permission_type must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	permType := gtsmodel.NewDomainPermissionType(*form.PermissionType)
+	if permType == gtsmodel.DomainPermissionUnknown {
+		text := fmt.Sprintf("permission_type %s not recognized, valid values are block, allow", *form.PermissionType)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.URI == nil {
+		const text = "uri must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ContentType == nil {
+		const text = "content_type must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	permSub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		PermissionType:     permType,
+		AdoptOrphans:       util.Ptr(false),
+		RemoveOrphans:      util.Ptr(false),
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if _, errWithCode := applyDomainPermSubForm(permSub, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, permSub); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			text := fmt.Sprintf("a domain permission subscription already exists with uri %s", permSub.URI)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// DomainPermissionSubscriptionUpdate updates the domain
+// permission subscription with the given id, setting any
+// of the fields provided in the given form.
+func (p *Processor) DomainPermissionSubscriptionUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.PermissionType != nil &&
+		gtsmodel.NewDomainPermissionType(*form.PermissionType) != permSub.PermissionType {
+		const text = "permission_type of an existing subscription cannot be changed"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	columns, errWithCode := applyDomainPermSubForm(permSub, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if len(columns) == 0 {
+		// Nothing to update.
+		return p.apiDomainPermSub(ctx, permSub)
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, permSub, columns...); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			text := fmt.Sprintf("a domain permission subscription already exists with uri %s", permSub.URI)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error updating domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainPermSub(ctx, permSub)
+}
+
+// applyDomainPermSubForm validates and applies the
+// set fields of form to the given subscription,
+// returning the names of the columns that changed.
+func applyDomainPermSubForm(
+	permSub *gtsmodel.DomainPermissionSubscription,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) ([]string, gtserror.WithCode) {
+	var columns []string
+
+	if form.Priority != nil {
+		priority := *form.Priority
+		if priority < 0 || priority > 255 {
+			const text = "priority must be a number in the range 0 to 255"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		permSub.Priority = uint8(priority)
+		columns = append(columns, "priority")
+	}
+
+	if form.Title != nil {
+		permSub.Title = text.SanitizeToPlaintext(*form.Title)
+		columns = append(columns, "title")
+	}
+
+	if form.URI != nil {
+		uriStr := strings.TrimSpace(*form.URI)
+		uri, err := url.Parse(uriStr)
+		if err != nil ||
+			(uri.Scheme != "http" && uri.Scheme != "https") ||
+			uri.Host == "" {
+			text := fmt.Sprintf("uri %s is not a valid http(s) URL", uriStr)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		if uri.String() != permSub.URI {
+			// List has moved, so clear cache
+			// details of the previous list.
+			permSub.URI = uri.String()
+			permSub.ETag = ""
+			permSub.LastModified = time.Time{}
+			columns = append(columns, "uri", "etag", "last_modified")
+		}
+	}
+
+	if form.ContentType != nil {
+		contentType := gtsmodel.NewDomainPermSubContentType(*form.ContentType)
+		if contentType == gtsmodel.DomainPermSubContentTypeUnknown {
+			text := fmt.Sprintf(
+				"content_type %s not recognized, valid values are %s, %s, %s",
+				*form.ContentType,
+				gtsmodel.DomainPermSubContentTypeCSV,
+				gtsmodel.DomainPermSubContentTypeJSON,
+				gtsmodel.DomainPermSubContentTypePlain,
+			)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		permSub.ContentType = contentType
+		columns = append(columns, "content_type")
+	}
+
+	if form.AdoptOrphans != nil {
+		permSub.AdoptOrphans = util.Ptr(*form.AdoptOrphans)
+		columns = append(columns, "adopt_orphans")
+	}
+
+	if form.RemoveOrphans != nil {
+		permSub.RemoveOrphans = util.Ptr(*form.RemoveOrphans)
+		columns = append(columns, "remove_orphans")
+	}
+
+	return columns, nil
+}
+
+// DomainPermissionSubscriptionRemove removes the domain
+// permission subscription with the given id. Domain permissions
+// created by the subscription are removed (with side effects)
+// or orphaned, according to the subscription's orphan policy.
+func (p *Processor) DomainPermissionSubscriptionRemove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deletion.
+	apiPermSub, errWithCode := p.apiDomainPermSub(ctx, permSub)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Deal with any permissions this subscription
+	// created *before* removing the subscription,
+	// so we don't leave dangling subscription IDs.
+	children, err := p.domainPermSubChildren(ctx, permSub)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, child := range children {
+		if errWithCode := p.orphanDomainPerm(ctx, adminAcct, permSub, child); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionSubscription(ctx, permSub.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPermSub, nil
+}
+
+// DomainPermissionSubscriptionTest fetches and parses the
+// list of the domain permission subscription with the given
+// id, without applying it, and returns the parsed entries.
+// Useful for checking that a subscription is set up correctly.
+func (p *Processor) DomainPermissionSubscriptionTest(
+	ctx context.Context,
+	id string,
+) ([]*apimodel.DomainPermission, gtserror.WithCode) {
+	permSub, errWithCode := p.getDomainPermSub(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Always fetch fresh
+	// copy of the list.
+	const skipCache = true
+
+	entries, _, err := p.fetchDomainPermSub(ctx, permSub, skipCache)
+	if err != nil {
+		text := fmt.Sprintf("error fetching or parsing list: %v", err)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,275 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// processAndWait processes all domain permission
+// subscriptions, then waits for side effects of
+// any created / removed permissions to finish.
+func (suite *DomainPermissionSubscriptionTestSuite) processAndWait() {
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(context.Background())
+	suite.waitForActions()
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) waitForActions() {
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin actions to finish")
+	}
+}
+
+// switchToJSONList points the test subscription at
+// the JSON version of its list, which no longer
+// includes one of the domains from the CSV list.
+func (suite *DomainPermissionSubscriptionTestSuite) switchToJSONList(permSub *gtsmodel.DomainPermissionSubscription) {
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionUpdate(
+		context.Background(),
+		permSub.ID,
+		&apimodel.DomainPermissionSubscriptionRequest{
+			URI:         util.Ptr("https://lists.example.org/baddies.json"),
+			ContentType: util.Ptr(string(gtsmodel.DomainPermSubContentTypeJSON)),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessCSV() {
+	var (
+		ctx     = context.Background()
+		permSub = suite.testDomainPermSubs()["subscription_1"]
+	)
+
+	suite.processAndWait()
+
+	// Suspended domains on the list
+	// should now be blocked by this sub.
+	for _, domain := range []string{
+		"bumfaces.net",
+		"peepee.poopoo",
+		"nothanks.com",
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(permSub.ID, block.SubscriptionID)
+	}
+
+	// Silenced domain should be ignored.
+	block, err := suite.db.GetDomainBlock(ctx, "quiet.example")
+	suite.Nil(block)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Public comment should be taken from the list.
+	block, err = suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("big jerks", block.PublicComment)
+
+	// Subscription should be marked as fetched.
+	permSub, err = suite.db.GetDomainPermissionSubscriptionByID(ctx, permSub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(permSub.FetchedAt)
+	suite.Equal(permSub.FetchedAt, permSub.SuccessfullyFetchedAt)
+	suite.Empty(permSub.Error)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessRetainOrphans() {
+	var (
+		ctx     = context.Background()
+		permSub = suite.testDomainPermSubs()["subscription_1"]
+	)
+
+	suite.processAndWait()
+	suite.switchToJSONList(permSub)
+	suite.processAndWait()
+
+	// Domain still on the list
+	// should still be owned by sub.
+	block, err := suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(permSub.ID, block.SubscriptionID)
+
+	// Domain dropped from the list should be
+	// retained, but orphaned from the sub.
+	block, err = suite.db.GetDomainBlock(ctx, "nothanks.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(block.SubscriptionID)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessRemoveOrphans() {
+	var (
+		ctx     = context.Background()
+		permSub = suite.testDomainPermSubs()["subscription_1"]
+	)
+
+	// Set the sub to remove orphans.
+	permSub.RemoveOrphans = util.Ptr(true)
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "remove_orphans"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.processAndWait()
+	suite.switchToJSONList(permSub)
+	suite.processAndWait()
+
+	// Domain dropped from the
+	// list should be unblocked.
+	block, err := suite.db.GetDomainBlock(ctx, "nothanks.com")
+	suite.Nil(block)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Removing the subscription should
+	// remove its remaining blocks too.
+	if _, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(
+		ctx,
+		suite.testAccounts["admin_account"],
+		permSub.ID,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.waitForActions()
+
+	blocks, err := suite.db.GetDomainBlocksBySubscriptionID(ctx, permSub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(blocks)
+
+	block, err = suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	suite.Nil(block)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessAdoptOrphans() {
+	var (
+		ctx     = context.Background()
+		permSub = suite.testDomainPermSubs()["subscription_1"]
+	)
+
+	// Block one of the listed domains
+	// manually, without a subscription.
+	if _, _, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		suite.testAccounts["admin_account"],
+		"bumfaces.net",
+		false,
+		"",
+		"",
+		"",
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.waitForActions()
+
+	// Sub doesn't adopt orphans
+	// so should leave it alone.
+	suite.processAndWait()
+	block, err := suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(block.SubscriptionID)
+
+	// Set the sub to adopt orphans.
+	permSub.AdoptOrphans = util.Ptr(true)
+	if err := suite.db.UpdateDomainPermissionSubscription(ctx, permSub, "adopt_orphans"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Block should now be owned by the sub. Reprocessing
+	// won't be skipped as unmodified, since the mock client
+	// doesn't return cache headers.
+	suite.processAndWait()
+	block, err = suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(permSub.ID, block.SubscriptionID)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestTestPlainList() {
+	var (
+		ctx     = context.Background()
+		permSub = suite.testDomainPermSubs()["subscription_1"]
+	)
+
+	_, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionUpdate(
+		ctx,
+		permSub.ID,
+		&apimodel.DomainPermissionSubscriptionRequest{
+			URI:         util.Ptr("https://lists.example.org/baddies.txt"),
+			ContentType: util.Ptr(string(gtsmodel.DomainPermSubContentTypePlain)),
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	domainPerms, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionTest(ctx, permSub.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	domains := make([]string, len(domainPerms))
+	for i, domainPerm := range domainPerms {
+		domains[i] = domainPerm.Domain.Domain
+	}
+	suite.Equal([]string{"bumfaces.net", "peepee.poopoo", "nothanks.com"}, domains)
+
+	// Testing shouldn't create anything.
+	blocks, err := suite.db.GetDomainBlocksBySubscriptionID(ctx, permSub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(blocks)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) testDomainPermSubs() map[string]*gtsmodel.DomainPermissionSubscription {
+	return testrig.NewTestDomainPermissionSubscriptions()
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
@@ -0,0 +1,685 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainPermissionSubscriptionsSchedule schedules
+// fetching + applying of all domain permission
+// subscriptions using configured parameters.
+//
+// Returns an error if `InstanceSubscriptionsProcessFrom`
+// is not a valid format (hh:mm).
+func (p *Processor) DomainPermissionSubscriptionsSchedule() error {
+	const hourMinute = "15:04"
+
+	var (
+		now            = time.Now()
+		processEvery   = config.GetInstanceSubscriptionsProcessEvery()
+		processFromStr = config.GetInstanceSubscriptionsProcessFrom()
+	)
+
+	// Parse processFromStr as hh:mm.
+	// Resulting time will be on 1 Jan year zero.
+	processFrom, err := time.Parse(hourMinute, processFromStr)
+	if err != nil {
+		return gtserror.Newf(
+			"error parsing '%s' in time format 'hh:mm': %w",
+			processFromStr, err,
+		)
+	}
+
+	// Move from year zero to today.
+	firstProcessAt := time.Date(
+		now.Year(),
+		now.Month(),
+		now.Day(),
+		processFrom.Hour(),
+		processFrom.Minute(),
+		0,
+		0,
+		now.Location(),
+	)
+
+	// Ensure first processing is in the future.
+	for firstProcessAt.Before(now) {
+		firstProcessAt = firstProcessAt.Add(processEvery)
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting domain permission subscriptions processing")
+		p.DomainPermissionSubscriptionsProcess(ctx)
+		log.Infof(ctx, "finished domain permission subscriptions processing after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling domain permission subscriptions processing to run every %s, starting from %s; next processing will run at %s",
+		processEvery, processFromStr, firstProcessAt,
+	)
+
+	// Schedule processing to execute according to schedule.
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@domainpermsubs",
+		firstProcessAt,
+		processEvery,
+		fn,
+	) {
+		panic("failed to schedule @domainpermsubs")
+	}
+
+	return nil
+}
+
+// DomainPermissionSubscriptionsProcess fetches and applies
+// every domain permission subscription on the instance.
+//
+// Allow subscriptions are processed before block subscriptions,
+// so that in blocklist mode any newly-created allows can shield
+// their domains from the side effects of newly-created blocks.
+// Within each permission type, subscriptions are processed in
+// priority order, highest priority first.
+func (p *Processor) DomainPermissionSubscriptionsProcess(ctx context.Context) {
+	for _, permType := range []gtsmodel.DomainPermissionType{
+		gtsmodel.DomainPermissionAllow,
+		gtsmodel.DomainPermissionBlock,
+	} {
+		permSubs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting domain %s subscriptions: %v", permType.String(), err)
+			continue
+		}
+
+		for i, permSub := range permSubs {
+			// Any subscriptions before this one
+			// in the slice have a higher priority.
+			higherPrios := permSubs[:i]
+			p.processDomainPermSub(ctx, permSub, higherPrios)
+		}
+	}
+}
+
+// processDomainPermSub fetches the list of the given
+// subscription and applies it to the instance, storing
+// details of the fetch (and any error) on the subscription.
+func (p *Processor) processDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	higherPrios []*gtsmodel.DomainPermissionSubscription,
+) {
+	l := log.
+		WithContext(ctx).
+		WithField("permSubURI", permSub.URI)
+
+	err := p.fetchAndApplyDomainPermSub(ctx, permSub, higherPrios)
+
+	// Update fetch details on the subscription.
+	permSub.FetchedAt = time.Now()
+	columns := []string{"fetched_at", "error", "etag", "last_modified"}
+
+	if err != nil {
+		l.Warnf("error processing domain permission subscription: %v", err)
+		permSub.Error = err.Error()
+	} else {
+		permSub.SuccessfullyFetchedAt = permSub.FetchedAt
+		permSub.Error = ""
+		columns = append(columns, "successfully_fetched_at")
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(
+		ctx,
+		permSub,
+		columns...,
+	); err != nil {
+		l.Errorf("db error updating domain permission subscription: %v", err)
+	}
+}
+
+// fetchAndApplyDomainPermSub does the work of processDomainPermSub,
+// returning an error if the list could not be fetched or applied.
+func (p *Processor) fetchAndApplyDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	higherPrios []*gtsmodel.DomainPermissionSubscription,
+) error {
+	if permSub.CreatedByAccount == nil {
+		// Side effects of creating / deleting domain
+		// permissions are attributed to this account.
+		var err error
+		permSub.CreatedByAccount, err = p.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			permSub.CreatedByAccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("db error getting subscription creator %s: %w", permSub.CreatedByAccountID, err)
+		}
+	}
+
+	entries, derefResp, err := p.fetchDomainPermSub(ctx, permSub, false)
+	if err != nil {
+		return err
+	}
+
+	// Store cache details of this fetch.
+	permSub.ETag = derefResp.ETag
+	permSub.LastModified = derefResp.LastModified
+
+	if derefResp.Unmodified {
+		// Nothing has changed since the last
+		// fetch, so there's nothing to apply.
+		return nil
+	}
+
+	if len(entries) == 0 {
+		// Don't treat an empty list as a request to remove
+		// every permission from this subscription, as it's
+		// more likely the list host has made a mistake.
+		return errors.New("fetched list contained no domain permissions")
+	}
+
+	errs := p.applyDomainPermSub(ctx, permSub, entries, higherPrios)
+	if err := errs.Combine(); err != nil {
+		// Clear cache details so that the next
+		// fetch isn't skipped as unmodified, and
+		// failed entries can be tried again.
+		permSub.ETag = ""
+		permSub.LastModified = time.Time{}
+		return err
+	}
+
+	return nil
+}
+
+// fetchDomainPermSub fetches and parses the list of
+// the given subscription. If the list has not changed
+// since the last fetch, returned entries will be nil,
+// and derefResp.Unmodified will be true.
+func (p *Processor) fetchDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	skipCache bool,
+) ([]*apimodel.DomainPermission, *transport.DereferenceDomainPermissionsResp, error) {
+	// Fetch the list using the instance account transport.
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, nil, gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	derefResp, err := tsport.DereferenceDomainPermissions(ctx, permSub, skipCache)
+	if err != nil {
+		return nil, nil, gtserror.Newf("error fetching list: %w", err)
+	}
+
+	if derefResp.Unmodified {
+		return nil, derefResp, nil
+	}
+
+	defer derefResp.Body.Close()
+
+	var entries []*apimodel.DomainPermission
+	switch permSub.ContentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		entries, err = parseDomainPermsCSV(derefResp.Body, permSub.PermissionType)
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		entries, err = parseDomainPermsJSON(derefResp.Body)
+	case gtsmodel.DomainPermSubContentTypePlain:
+		entries, err = parseDomainPermsPlain(derefResp.Body)
+	default:
+		err = fmt.Errorf("unrecognized content type %s", permSub.ContentType)
+	}
+
+	if err != nil {
+		return nil, nil, gtserror.Newf("error parsing list: %w", err)
+	}
+
+	return entries, derefResp, nil
+}
+
+// applyDomainPermSub diffs the given entries against the domain
+// permissions currently owned by the subscription, creating,
+// adopting, taking over, and orphaning permissions as necessary.
+func (p *Processor) applyDomainPermSub(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	entries []*apimodel.DomainPermission,
+	higherPrios []*gtsmodel.DomainPermissionSubscription,
+) gtserror.MultiError {
+	var (
+		errs   gtserror.MultiError
+		listed = make(map[string]struct{}, len(entries))
+	)
+
+	for _, entry := range entries {
+		domain := entry.Domain.Domain
+		listed[domain] = struct{}{}
+
+		existing, err := p.getDomainPerm(ctx, permSub.PermissionType, domain)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		if existing == nil {
+			// No permission exists for this domain yet, create
+			// one owned by this subscription (with side effects).
+			if _, _, errWithCode := p.DomainPermissionCreate(
+				ctx,
+				permSub.PermissionType,
+				permSub.CreatedByAccount,
+				domain,
+				entry.Obfuscate,
+				entry.PublicComment,
+				entry.PrivateComment,
+				permSub.ID,
+			); errWithCode != nil {
+				errs.Appendf("error creating domain %s %s: %w", permSub.PermissionType.String(), domain, errWithCode)
+			}
+			continue
+		}
+
+		existingSubID := existing.GetSubscriptionID()
+		switch {
+
+		// Already ours.
+		case existingSubID == permSub.ID:
+			continue
+
+		// Orphan, only take it if we're adopting.
+		case existingSubID == "":
+			if !*permSub.AdoptOrphans {
+				continue
+			}
+
+		// Owned by a subscription with higher priority, leave it.
+		case slices.ContainsFunc(higherPrios, func(s *gtsmodel.DomainPermissionSubscription) bool {
+			return s.ID == existingSubID
+		}):
+			continue
+		}
+
+		// Take ownership of the existing permission. Since it
+		// already exists, there are no side effects to process.
+		if err := p.setDomainPermSubID(ctx, existing, permSub.ID); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	// Deal with any permissions owned by this
+	// subscription which are no longer listed.
+	children, err := p.domainPermSubChildren(ctx, permSub)
+	if err != nil {
+		errs.Append(err)
+		return errs
+	}
+
+	for _, child := range children {
+		if _, ok := listed[child.GetDomain()]; ok {
+			continue
+		}
+
+		if errWithCode := p.orphanDomainPerm(ctx, permSub.CreatedByAccount, permSub, child); errWithCode != nil {
+			errs.Append(errWithCode)
+		}
+	}
+
+	return errs
+}
+
+// getDomainPerm returns the domain permission of the
+// given type for the given domain, or nil if none exists.
+func (p *Processor) getDomainPerm(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (gtsmodel.DomainPermission, error) {
+	var (
+		perm gtsmodel.DomainPermission
+		err  error
+	)
+
+	switch permType {
+	case gtsmodel.DomainPermissionBlock:
+		var block *gtsmodel.DomainBlock
+		block, err = p.state.DB.GetDomainBlock(ctx, domain)
+		if block != nil {
+			perm = block
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		var allow *gtsmodel.DomainAllow
+		allow, err = p.state.DB.GetDomainAllow(ctx, domain)
+		if allow != nil {
+			perm = allow
+		}
+
+	default:
+		err = fmt.Errorf("unrecognized permission type %d", permType)
+	}
+
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting domain %s %s: %w", permType.String(), domain, err)
+	}
+
+	return perm, nil
+}
+
+// domainPermSubChildren returns all domain
+// permissions owned by the given subscription.
+func (p *Processor) domainPermSubChildren(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+) ([]gtsmodel.DomainPermission, error) {
+	var children []gtsmodel.DomainPermission
+
+	switch permSub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocksBySubscriptionID(ctx, permSub.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting domain blocks: %w", err)
+		}
+
+		for _, block := range blocks {
+			children = append(children, block)
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllowsBySubscriptionID(ctx, permSub.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting domain allows: %w", err)
+		}
+
+		for _, allow := range allows {
+			children = append(children, allow)
+		}
+
+	default:
+		return nil, gtserror.Newf("unrecognized permission type %d", permSub.PermissionType)
+	}
+
+	return children, nil
+}
+
+// orphanDomainPerm detaches the given domain permission from
+// the given subscription, either removing it (with side effects)
+// or keeping it without a subscription ID, according to the
+// subscription's orphan policy.
+func (p *Processor) orphanDomainPerm(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	perm gtsmodel.DomainPermission,
+) gtserror.WithCode {
+	if *permSub.RemoveOrphans {
+		_, _, errWithCode := p.DomainPermissionDelete(
+			ctx,
+			perm.GetType(),
+			adminAcct,
+			perm.GetID(),
+		)
+		return errWithCode
+	}
+
+	if err := p.setDomainPermSubID(ctx, perm, ""); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// setDomainPermSubID updates the subscription
+// ID of the given domain permission in the db.
+func (p *Processor) setDomainPermSubID(
+	ctx context.Context,
+	perm gtsmodel.DomainPermission,
+	subscriptionID string,
+) error {
+	var err error
+
+	switch perm := perm.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = subscriptionID
+		err = p.state.DB.UpdateDomainBlock(ctx, perm, "subscription_id")
+
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = subscriptionID
+		err = p.state.DB.UpdateDomainAllow(ctx, perm, "subscription_id")
+
+	default:
+		err = fmt.Errorf("unrecognized domain permission %T", perm)
+	}
+
+	if err != nil {
+		return gtserror.Newf("db error updating domain %s %s: %w", perm.GetType().String(), perm.GetDomain(), err)
+	}
+
+	return nil
+}
+
+// parseDomainPermsCSV parses the given reader as a
+// Mastodon-style CSV export of domain blocks, eg:
+//
+//	#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+//	example.org,suspend,false,false,big jerks,false
+//
+// When parsing for a block list, only entries with
+// severity "suspend" are returned, since other
+// severities aren't supported by GoToSocial.
+func parseDomainPermsCSV(
+	r io.Reader,
+	permType gtsmodel.DomainPermissionType,
+) ([]*apimodel.DomainPermission, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1 // Allow variable.
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// Default to treating the first
+	// column as the domain, with no
+	// header line, unless we find one.
+	var (
+		domainIdx        = 0
+		severityIdx      = -1
+		publicCommentIdx = -1
+		obfuscateIdx     = -1
+	)
+
+	header := records[0]
+	if slices.ContainsFunc(header, func(column string) bool {
+		return strings.TrimPrefix(column, "#") == "domain"
+	}) {
+		// Find indexes of the columns we care about.
+		for i, column := range header {
+			switch strings.TrimPrefix(column, "#") {
+			case "domain":
+				domainIdx = i
+			case "severity":
+				severityIdx = i
+			case "public_comment":
+				publicCommentIdx = i
+			case "obfuscate":
+				obfuscateIdx = i
+			}
+		}
+
+		// Skip header.
+		records = records[1:]
+	}
+
+	// field safely returns the value
+	// at idx of record, or "" if unset.
+	field := func(record []string, idx int) string {
+		if idx < 0 || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	entries := make([]*apimodel.DomainPermission, 0, len(records))
+	for _, record := range records {
+		domain, ok := normalizeListDomain(field(record, domainIdx))
+		if !ok {
+			continue
+		}
+
+		if permType == gtsmodel.DomainPermissionBlock {
+			severity := field(record, severityIdx)
+			if severity != "" && severity != "suspend" {
+				// Can't do anything
+				// with this entry.
+				continue
+			}
+		}
+
+		obfuscate, _ := strconv.ParseBool(field(record, obfuscateIdx))
+		entries = append(entries, &apimodel.DomainPermission{
+			Domain: apimodel.Domain{
+				Domain:        domain,
+				PublicComment: field(record, publicCommentIdx),
+			},
+			Obfuscate: obfuscate,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsJSON parses the given reader as
+// a GoToSocial-style JSON export of domain permissions.
+func parseDomainPermsJSON(r io.Reader) ([]*apimodel.DomainPermission, error) {
+	var apiDomainPerms []*apimodel.DomainPermission
+	if err := json.NewDecoder(r).Decode(&apiDomainPerms); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*apimodel.DomainPermission, 0, len(apiDomainPerms))
+	for _, apiDomainPerm := range apiDomainPerms {
+		if apiDomainPerm == nil {
+			continue
+		}
+
+		domain, ok := normalizeListDomain(apiDomainPerm.Domain.Domain)
+		if !ok {
+			continue
+		}
+
+		// Only keep fields that make
+		// sense when creating a permission.
+		entries = append(entries, &apimodel.DomainPermission{
+			Domain: apimodel.Domain{
+				Domain:        domain,
+				PublicComment: apiDomainPerm.PublicComment,
+			},
+			Obfuscate:      apiDomainPerm.Obfuscate,
+			PrivateComment: apiDomainPerm.PrivateComment,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseDomainPermsPlain parses the given reader as a
+// newline-separated list of domains. Empty lines and
+// lines starting with '#' are ignored.
+func parseDomainPermsPlain(r io.Reader) ([]*apimodel.DomainPermission, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(b), "\n")
+	entries := make([]*apimodel.DomainPermission, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain, ok := normalizeListDomain(line)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, &apimodel.DomainPermission{
+			Domain: apimodel.Domain{
+				Domain: domain,
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// normalizeListDomain normalizes the given
+// domain from a domain permission list as
+// punycode, returning false if it's not a
+// valid domain or if it's our own domain.
+func normalizeListDomain(domain string) (string, bool) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return "", false
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return "", false
+	}
+
+	// Ensure this is a bare hostname,
+	// not a URL or something else.
+	u, err := url.Parse("https://" + domain)
+	if err != nil || u.Host != domain || u.Port() != "" {
+		return "", false
+	}
+
+	// Never apply permissions to ourselves.
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		return "", false
+	}
+
+	return domain, true
+}
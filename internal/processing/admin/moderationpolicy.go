@@ -0,0 +1,277 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// apiModerationPolicy is a cheeky shortcut for returning
+// the API version of the given moderation policy, or an
+// appropriate error if something goes wrong.
+func (p *Processor) apiModerationPolicy(
+	ctx context.Context,
+	policy *gtsmodel.ModerationPolicy,
+) (*apimodel.ModerationPolicy, gtserror.WithCode) {
+	apiPolicy, err := p.converter.ModerationPolicyToAPIModerationPolicy(ctx, policy)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting moderation policy to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiPolicy, nil
+}
+
+// getModerationPolicy fetches the moderation policy
+// with the given ID from the database, returning a
+// suitable error if it can't be found.
+func (p *Processor) getModerationPolicy(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.ModerationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetModerationPolicyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no moderation policy exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting moderation policy %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return policy, nil
+}
+
+// ModerationPolicyGet returns one
+// moderation policy with the given id.
+func (p *Processor) ModerationPolicyGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.ModerationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getModerationPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiModerationPolicy(ctx, policy)
+}
+
+// ModerationPoliciesGet returns all
+// moderation policies, oldest first.
+func (p *Processor) ModerationPoliciesGet(
+	ctx context.Context,
+) ([]*apimodel.ModerationPolicy, gtserror.WithCode) {
+	policies, err := p.state.DB.GetModerationPolicies(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting moderation policies: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiPolicies := make([]*apimodel.ModerationPolicy, 0, len(policies))
+	for _, policy := range policies {
+		apiPolicy, errWithCode := p.apiModerationPolicy(ctx, policy)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiPolicies = append(apiPolicies, apiPolicy)
+	}
+
+	return apiPolicies, nil
+}
+
+// ModerationPolicyCreate creates a new moderation policy
+// targeting either one domain or one account. Policies
+// apply to statuses as they're converted from remote
+// representations, and when filtering public timelines.
+func (p *Processor) ModerationPolicyCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.ModerationPolicyRequest,
+) (*apimodel.ModerationPolicy, gtserror.WithCode) {
+	policy := &gtsmodel.ModerationPolicy{
+		ID:                   id.NewULID(),
+		ForceSensitive:       util.Ptr(false),
+		RejectMedia:          util.Ptr(false),
+		StripPublicTimelines: util.Ptr(false),
+		CreatedByAccountID:   adminAcct.ID,
+	}
+
+	switch {
+	case form.Domain != nil && form.AccountID != nil:
+		const text = "only one of domain or account_id may be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+
+	case form.Domain != nil:
+		domain, ok := normalizeListDomain(*form.Domain)
+		if !ok {
+			text := fmt.Sprintf("domain %s is not a valid remote domain", *form.Domain)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		policy.Domain = domain
+
+	case form.AccountID != nil:
+		account, err := p.state.DB.GetAccountByID(ctx, *form.AccountID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				text := fmt.Sprintf("no account exists with id %s", *form.AccountID)
+				return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+			}
+
+			err := gtserror.Newf("db error getting account %s: %w", *form.AccountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		policy.AccountID = account.ID
+
+	default:
+		const text = "one of domain or account_id must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	applyModerationPolicyForm(policy, form)
+
+	if err := p.state.DB.PutModerationPolicy(ctx, policy); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			const text = "a moderation policy already exists for this domain or account"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error putting moderation policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Public timeline visibility
+	// may have changed, so clear it.
+	p.state.Caches.Visibility.Clear()
+
+	return p.apiModerationPolicy(ctx, policy)
+}
+
+// ModerationPolicyUpdate updates the moderation policy
+// with the given id, using the set fields of the form.
+// The domain or account targeted cannot be changed.
+func (p *Processor) ModerationPolicyUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.ModerationPolicyRequest,
+) (*apimodel.ModerationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getModerationPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.Domain != nil || form.AccountID != nil {
+		const text = "domain or account_id of an existing moderation policy cannot be changed"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	columns := applyModerationPolicyForm(policy, form)
+	if len(columns) == 0 {
+		// Nothing to update.
+		return p.apiModerationPolicy(ctx, policy)
+	}
+
+	if err := p.state.DB.UpdateModerationPolicy(ctx, policy, columns...); err != nil {
+		err := gtserror.Newf("db error updating moderation policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Public timeline visibility
+	// may have changed, so clear it.
+	p.state.Caches.Visibility.Clear()
+
+	return p.apiModerationPolicy(ctx, policy)
+}
+
+// applyModerationPolicyForm applies the set fields
+// of form to the given moderation policy, returning
+// the names of the columns that changed.
+func applyModerationPolicyForm(
+	policy *gtsmodel.ModerationPolicy,
+	form *apimodel.ModerationPolicyRequest,
+) []string {
+	var columns []string
+
+	if form.ForceSensitive != nil {
+		policy.ForceSensitive = util.Ptr(*form.ForceSensitive)
+		columns = append(columns, "force_sensitive")
+	}
+
+	if form.ContentWarning != nil {
+		policy.ContentWarning = text.SanitizeToPlaintext(*form.ContentWarning)
+		columns = append(columns, "content_warning")
+	}
+
+	if form.RejectMedia != nil {
+		policy.RejectMedia = util.Ptr(*form.RejectMedia)
+		columns = append(columns, "reject_media")
+	}
+
+	if form.StripPublicTimelines != nil {
+		policy.StripPublicTimelines = util.Ptr(*form.StripPublicTimelines)
+		columns = append(columns, "strip_public_timelines")
+	}
+
+	if form.PrivateComment != nil {
+		policy.PrivateComment = text.SanitizeToPlaintext(*form.PrivateComment)
+		columns = append(columns, "private_comment")
+	}
+
+	return columns
+}
+
+// ModerationPolicyRemove removes the moderation
+// policy with the given id, returning the removed
+// policy. Content already converted under the policy
+// (eg., sensitive flags, content warnings) is unchanged.
+func (p *Processor) ModerationPolicyRemove(
+	ctx context.Context,
+	id string,
+) (*apimodel.ModerationPolicy, gtserror.WithCode) {
+	policy, errWithCode := p.getModerationPolicy(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before deletion.
+	apiPolicy, errWithCode := p.apiModerationPolicy(ctx, policy)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteModerationPolicyByID(ctx, policy.ID); err != nil {
+		err := gtserror.Newf("db error deleting moderation policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Public timeline visibility
+	// may have changed, so clear it.
+	p.state.Caches.Visibility.Clear()
+
+	return apiPolicy, nil
+}
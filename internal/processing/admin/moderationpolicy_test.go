@@ -0,0 +1,164 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type ModerationPolicyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ModerationPolicyTestSuite) TestModerationPolicyCreateUpdateRemove() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+	)
+
+	policy, errWithCode := suite.adminProcessor.ModerationPolicyCreate(ctx, adminAcc, &apimodel.ModerationPolicyRequest{
+		Domain:         util.Ptr("FossBros-Anonymous.io"),
+		ForceSensitive: util.Ptr(true),
+		ContentWarning: util.Ptr("fossbro nonsense"),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal("fossbros-anonymous.io", policy.Domain)
+	suite.Empty(policy.AccountID)
+	suite.True(policy.ForceSensitive)
+	suite.Equal("fossbro nonsense", policy.ContentWarning)
+	suite.False(policy.RejectMedia)
+	suite.False(policy.StripPublicTimelines)
+	suite.Equal(adminAcc.ID, policy.CreatedBy)
+
+	// Creating another policy for the same domain should conflict.
+	_, errWithCode = suite.adminProcessor.ModerationPolicyCreate(ctx, adminAcc, &apimodel.ModerationPolicyRequest{
+		Domain: util.Ptr("fossbros-anonymous.io"),
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Only the set fields should be updated.
+	policy, errWithCode = suite.adminProcessor.ModerationPolicyUpdate(ctx, policy.ID, &apimodel.ModerationPolicyRequest{
+		StripPublicTimelines: util.Ptr(true),
+		ContentWarning:       util.Ptr(""),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.True(policy.ForceSensitive)
+	suite.Empty(policy.ContentWarning)
+	suite.True(policy.StripPublicTimelines)
+
+	dbPolicy, err := suite.db.GetModerationPolicyByDomain(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbPolicy.StripPublicTimelines)
+
+	if _, errWithCode := suite.adminProcessor.ModerationPolicyRemove(ctx, policy.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.ModerationPolicyGet(ctx, policy.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ModerationPolicyTestSuite) TestModerationPolicyCreateInvalid() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+	)
+
+	for _, form := range []*apimodel.ModerationPolicyRequest{
+		// Neither domain nor account.
+		{ForceSensitive: util.Ptr(true)},
+		// Both domain and account.
+		{
+			Domain:    util.Ptr("example.org"),
+			AccountID: util.Ptr(suite.testAccounts["remote_account_1"].ID),
+		},
+		// Our own domain.
+		{Domain: util.Ptr("localhost:8080")},
+		// Not a domain.
+		{Domain: util.Ptr("https://example.org/users")},
+		// Account that doesn't exist.
+		{AccountID: util.Ptr("01J5BDXZ4W8RKX6BQ1N7Z3EWZ9")},
+	} {
+		_, errWithCode := suite.adminProcessor.ModerationPolicyCreate(ctx, adminAcc, form)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+}
+
+func (suite *ModerationPolicyTestSuite) TestDomainPermissionWithModerationPolicy() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+	)
+
+	domainBlock, err := suite.db.GetDomainBlock(ctx, "replyguys.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	policy, errWithCode := suite.adminProcessor.ModerationPolicyCreate(ctx, adminAcc, &apimodel.ModerationPolicyRequest{
+		Domain:      util.Ptr("replyguys.com"),
+		RejectMedia: util.Ptr(true),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	domainPerm, errWithCode := suite.adminProcessor.DomainPermissionGet(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		domainBlock.ID,
+		false,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(policy, domainPerm.ModerationPolicy)
+
+	// Policies should never be exported.
+	domainPerm, errWithCode = suite.adminProcessor.DomainPermissionGet(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		domainBlock.ID,
+		true,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Nil(domainPerm.ModerationPolicy)
+}
+
+func TestModerationPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationPolicyTestSuite))
+}
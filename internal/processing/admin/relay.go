@@ -0,0 +1,232 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// apiRelay is a cheeky shortcut for returning the
+// API version of the given relay, or an appropriate
+// error if something goes wrong.
+func (p *Processor) apiRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+) (*apimodel.Relay, gtserror.WithCode) {
+	apiRelay, err := p.converter.RelayToAPIRelay(ctx, relay)
+	if err != nil {
+		err := gtserror.NewfAt(3, "error converting relay to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
+
+// RelaysGet returns all relays this instance
+// is subscribed to, oldest first.
+func (p *Processor) RelaysGet(
+	ctx context.Context,
+) ([]*apimodel.Relay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.Relay, 0, len(relays))
+	for _, relay := range relays {
+		apiRelay, errWithCode := p.apiRelay(ctx, relay)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiRelays = append(apiRelays, apiRelay)
+	}
+
+	return apiRelays, nil
+}
+
+// RelayCreate subscribes this instance to the relay with
+// the given inbox URL, by sending a Follow to the relay
+// from the instance actor. The relay will be pending
+// until the relay Accepts (or Rejects) the Follow.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	inboxURL string,
+) (*apimodel.Relay, gtserror.WithCode) {
+	inboxURL = strings.TrimSpace(inboxURL)
+	inbox, err := url.Parse(inboxURL)
+	if err != nil ||
+		(inbox.Scheme != "http" && inbox.Scheme != "https") ||
+		inbox.Host == "" {
+		text := fmt.Sprintf("inbox_url %s is not a valid http(s) URL", inboxURL)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if inbox.Host == config.GetHost() ||
+		inbox.Host == config.GetAccountDomain() {
+		const text = "inbox_url cannot point to this instance"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:                 relayID,
+		InboxURI:           inbox.String(),
+		FollowURI:          uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+		State:              gtsmodel.RelayStatePending,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			text := fmt.Sprintf("a relay already exists with inbox_url %s", relay.InboxURI)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+
+		err := gtserror.Newf("db error putting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay, instanceAcct)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deliverToRelay(ctx, relay, follow); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiRelay(ctx, relay)
+}
+
+// RelayRemove unsubscribes this instance from the relay
+// with the given id, by sending an Undo of our Follow
+// to the relay, and removing the relay from the database.
+func (p *Processor) RelayRemove(
+	ctx context.Context,
+	id string,
+) (*apimodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no relay exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Convert before deletion.
+	apiRelay, errWithCode := p.apiRelay(ctx, relay)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("db error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay.State == gtsmodel.RelayStateRejected {
+		// Nothing
+		// to undo.
+		return apiRelay, nil
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay, instanceAcct)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Wrap the original
+	// Follow in an Undo.
+	undo := streams.NewActivityStreamsUndo()
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(undoObject)
+	undo.SetActivityStreamsTo(follow.GetActivityStreamsTo())
+
+	if err := p.deliverToRelay(ctx, relay, undo); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
+
+// deliverToRelay delivers the given activity
+// from the instance actor to the given relay.
+func (p *Processor) deliverToRelay(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	activity vocab.Type,
+) error {
+	inbox, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing relay inbox %s: %w", relay.InboxURI, err)
+	}
+
+	data, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing %T: %w", activity, err)
+	}
+
+	// Empty username gets
+	// the instance transport.
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	if err := tsport.Deliver(ctx, data, inbox); err != nil {
+		return gtserror.Newf("error delivering %T to relay %s: %w", activity, relay.InboxURI, err)
+	}
+
+	return nil
+}
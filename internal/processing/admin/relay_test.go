@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RelayTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// popDelivery pops the next queued delivery, returning
+// its target URL and its body unmarshaled from JSON.
+func (suite *RelayTestSuite) popDelivery() (string, map[string]any) {
+	dlv, ok := suite.state.Workers.Delivery.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected queued delivery")
+	}
+
+	b, err := io.ReadAll(dlv.Request.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	body := make(map[string]any)
+	if err := json.Unmarshal(b, &body); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return dlv.Request.URL.String(), body
+}
+
+func (suite *RelayTestSuite) TestRelayCreate() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+		inbox    = "https://relay.example.org/inbox"
+	)
+
+	relay, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcc, inbox)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(inbox, relay.InboxURL)
+	suite.Equal("pending", relay.State)
+	suite.Empty(relay.ActorURI)
+	suite.Equal(adminAcc.ID, relay.CreatedBy)
+
+	// A Follow of Public should have
+	// been sent from the instance actor.
+	to, follow := suite.popDelivery()
+	suite.Equal(inbox, to)
+	suite.Equal("Follow", follow["type"])
+	suite.Equal("http://localhost:8080/users/localhost:8080", follow["actor"])
+	suite.Equal("https://www.w3.org/ns/activitystreams#Public", follow["object"])
+
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(dbRelay.FollowURI, follow["id"])
+
+	// Adding the same
+	// relay again fails.
+	_, errWithCode = suite.adminProcessor.RelayCreate(ctx, adminAcc, inbox)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *RelayTestSuite) TestRelayCreateInvalid() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+	)
+
+	for _, inbox := range []string{
+		"not a url",
+		"ftp://relay.example.org/inbox",
+		"http://localhost:8080/users/localhost:8080/inbox",
+	} {
+		_, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcc, inbox)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code(), inbox)
+	}
+}
+
+func (suite *RelayTestSuite) TestRelayRemove() {
+	var (
+		ctx      = context.Background()
+		adminAcc = suite.testAccounts["admin_account"]
+		inbox    = "https://relay.example.org/inbox"
+	)
+
+	relay, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcc, inbox)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Pop the Follow.
+	suite.popDelivery()
+
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbRelay.State = gtsmodel.RelayStateAccepted
+	if err := suite.state.DB.UpdateRelay(ctx, dbRelay, "state"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	removed, errWithCode := suite.adminProcessor.RelayRemove(ctx, relay.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("accepted", removed.State)
+
+	// An Undo of the Follow
+	// should have been sent.
+	to, undo := suite.popDelivery()
+	suite.Equal(inbox, to)
+	suite.Equal("Undo", undo["type"])
+	object, _ := undo["object"].(map[string]any)
+	suite.Equal("Follow", object["type"])
+	suite.Equal(dbRelay.FollowURI, object["id"])
+
+	// Relay should be gone.
+	relays, errWithCode := suite.adminProcessor.RelaysGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(relays)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
@@ -148,7 +148,7 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 	}
 
 	// Process side effects of closing the report.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ActivityFlag,
 		APActivityType: ap.ActivityUpdate,
 		GTSModel:       report,
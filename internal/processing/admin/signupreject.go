@@ -101,7 +101,7 @@ func (p *Processor) SignupReject(
 	}
 
 	// Process rejection side effects asynschronously.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		// Use ap.ObjectProfile here to
 		// distinguish this message (user model)
 		// from ap.ActorPerson (account model).
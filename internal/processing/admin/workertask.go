@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+// FillWorkerQueues replays all pending worker tasks persisted
+// in the database into their worker queues. These are tasks
+// that were queued but never finished processing on a previous
+// run, e.g. due to an unclean exit. This should be called on
+// startup, after the worker queues have been initialized.
+func (p *Processor) FillWorkerQueues(ctx context.Context) error {
+	tasks, err := p.state.DB.GetPendingWorkerTasks(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting pending worker tasks: %w", err)
+	}
+
+	var filled int
+
+	for _, task := range tasks {
+		var err error
+
+		switch task.WorkerType {
+		case gtsmodel.DeliveryWorker:
+			err = p.fillDeliveryQueue(ctx, task)
+		case gtsmodel.ClientWorker:
+			err = p.fillClientQueue(ctx, task)
+		case gtsmodel.FederatorWorker:
+			err = p.fillFediQueue(ctx, task)
+		default:
+			err = fmt.Errorf("unknown worker type %d", task.WorkerType)
+		}
+
+		if err != nil {
+			// Task can't be replayed, mark
+			// as failed for later inspection.
+			log.Errorf(ctx, "error replaying worker task %d: %v", task.ID, err)
+			p.failWorkerTask(ctx, task, err)
+			continue
+		}
+
+		filled++
+	}
+
+	log.Infof(ctx, "replayed %d worker tasks", filled)
+	return nil
+}
+
+// fillDeliveryQueue deserializes the given delivery task, and
+// re-prepares it for signing by the transport it was created by.
+func (p *Processor) fillDeliveryQueue(ctx context.Context, task *gtsmodel.WorkerTask) error {
+	dlv := new(delivery.Delivery)
+	if err := dlv.Deserialize(task.TaskData); err != nil {
+		return gtserror.Newf("error deserializing delivery: %w", err)
+	}
+
+	// Get the account this delivery is to be signed by.
+	account, err := p.state.DB.GetAccountByPubkeyID(ctx, dlv.PubKeyID)
+	if err != nil {
+		return gtserror.Newf("db error getting account %s: %w", dlv.PubKeyID, err)
+	}
+
+	tsport, err := p.transport.NewTransport(account.PublicKeyURI, account.PrivateKey)
+	if err != nil {
+		return gtserror.Newf("error getting transport for %s: %w", dlv.PubKeyID, err)
+	}
+
+	if err := tsport.PrepareDelivery(dlv); err != nil {
+		return gtserror.Newf("error preparing delivery: %w", err)
+	}
+
+	p.state.Workers.Delivery.Queue.Restore(task.ID, dlv)
+	return nil
+}
+
+// fillClientQueue deserializes the given client API message
+// task, and populates the accounts flattened on serialization.
+func (p *Processor) fillClientQueue(ctx context.Context, task *gtsmodel.WorkerTask) error {
+	msg := new(messages.FromClientAPI)
+	if err := msg.Deserialize(task.TaskData); err != nil {
+		return gtserror.Newf("error deserializing client message: %w", err)
+	}
+
+	var err error
+
+	if msg.Origin != nil {
+		msg.Origin, err = p.state.DB.GetAccountByID(ctx, msg.Origin.ID)
+		if err != nil {
+			return gtserror.Newf("db error getting origin account: %w", err)
+		}
+	}
+
+	if msg.Target != nil {
+		msg.Target, err = p.state.DB.GetAccountByID(ctx, msg.Target.ID)
+		if err != nil {
+			return gtserror.Newf("db error getting target account: %w", err)
+		}
+	}
+
+	p.state.Workers.Client.Queue.Restore(task.ID, msg)
+	return nil
+}
+
+// fillFediQueue deserializes the given fedi API message task,
+// and populates the accounts flattened on serialization.
+func (p *Processor) fillFediQueue(ctx context.Context, task *gtsmodel.WorkerTask) error {
+	msg := new(messages.FromFediAPI)
+	if err := msg.Deserialize(task.TaskData); err != nil {
+		return gtserror.Newf("error deserializing fedi message: %w", err)
+	}
+
+	var err error
+
+	if msg.Requesting != nil {
+		msg.Requesting, err = p.state.DB.GetAccountByID(ctx, msg.Requesting.ID)
+		if err != nil {
+			return gtserror.Newf("db error getting requesting account: %w", err)
+		}
+	}
+
+	if msg.Receiving != nil {
+		msg.Receiving, err = p.state.DB.GetAccountByID(ctx, msg.Receiving.ID)
+		if err != nil {
+			return gtserror.Newf("db error getting receiving account: %w", err)
+		}
+	}
+
+	p.state.Workers.Federator.Queue.Restore(task.ID, msg)
+	return nil
+}
+
+// failWorkerTask marks the given
+// worker task as failed with error.
+func (p *Processor) failWorkerTask(ctx context.Context, task *gtsmodel.WorkerTask, err error) {
+	task.FailedAt = time.Now()
+	task.Error = err.Error()
+	if err := p.state.DB.UpdateWorkerTask(ctx,
+		task,
+		"failed_at",
+		"error",
+	); err != nil {
+		log.Errorf(ctx, "db error marking worker task %d failed: %v", task.ID, err)
+	}
+}
+
+// WorkerQueuesGet returns the current state
+// of the persisted worker queues, including
+// the number of queued and failed tasks.
+func (p *Processor) WorkerQueuesGet(ctx context.Context) ([]*apimodel.WorkerQueue, gtserror.WithCode) {
+	queues := make([]*apimodel.WorkerQueue, 0, 3)
+
+	for _, queue := range []struct {
+		typ    gtsmodel.WorkerType
+		queued int
+	}{
+		{gtsmodel.DeliveryWorker, p.state.Workers.Delivery.Queue.Len()},
+		{gtsmodel.ClientWorker, p.state.Workers.Client.Queue.Len()},
+		{gtsmodel.FederatorWorker, p.state.Workers.Federator.Queue.Len()},
+	} {
+		failed, err := p.state.DB.CountFailedWorkerTasks(ctx, queue.typ)
+		if err != nil {
+			err := gtserror.Newf("db error counting failed %s tasks: %w", queue.typ, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		queues = append(queues, &apimodel.WorkerQueue{
+			Worker: queue.typ.String(),
+			Queued: queue.queued,
+			Failed: failed,
+		})
+	}
+
+	return queues, nil
+}
+
+// WorkerTasksFailedGet returns up to limit of the most
+// recently failed worker tasks, for admin inspection.
+func (p *Processor) WorkerTasksFailedGet(ctx context.Context, limit int) ([]*apimodel.WorkerTask, gtserror.WithCode) {
+	tasks, err := p.state.DB.GetFailedWorkerTasks(ctx, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting failed worker tasks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTasks := make([]*apimodel.WorkerTask, 0, len(tasks))
+	for _, task := range tasks {
+		apiTask, err := p.converter.WorkerTaskToAPIWorkerTask(ctx, task)
+		if err != nil {
+			err := gtserror.Newf("error converting worker task to api: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiTasks = append(apiTasks, apiTask)
+	}
+
+	return apiTasks, nil
+}
+
+// WorkerTaskFailedDelete deletes the failed worker
+// task with the given ID, returning the deleted task.
+func (p *Processor) WorkerTaskFailedDelete(ctx context.Context, id uint) (*apimodel.WorkerTask, gtserror.WithCode) {
+	task, err := p.state.DB.GetWorkerTaskByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting worker task: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if task == nil || !task.Failed() {
+		// Don't allow pending tasks to be
+		// deleted, they're still queued.
+		err := fmt.Errorf("no failed worker task exists with id %d", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	apiTask, err := p.converter.WorkerTaskToAPIWorkerTask(ctx, task)
+	if err != nil {
+		err := gtserror.Newf("error converting worker task to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteWorkerTaskByID(ctx, id); err != nil {
+		err := gtserror.Newf("db error deleting worker task: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiTask, nil
+}
@@ -0,0 +1,206 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+type WorkerTaskTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *WorkerTaskTestSuite) SetupTest() {
+	suite.AdminStandardTestSuite.SetupTest()
+	suite.state.Workers.SetTaskStore(suite.db)
+}
+
+func (suite *WorkerTaskTestSuite) TearDownTest() {
+	// Unset stores so they don't
+	// leak into other test suites.
+	suite.state.Workers.Delivery.Queue.SetStore(nil)
+	suite.state.Workers.Client.Queue.SetStore(nil)
+	suite.state.Workers.Federator.Queue.SetStore(nil)
+	suite.AdminStandardTestSuite.TearDownTest()
+}
+
+// deliver queues a delivery of a
+// test activity from the admin account.
+func (suite *WorkerTaskTestSuite) deliver() {
+	tsport, err := suite.transportController.NewTransportForUsername(context.Background(), "admin")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	to, err := url.Parse("http://example.org/users/Some_User/inbox")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := tsport.Deliver(context.Background(), map[string]interface{}{
+		"id":     "http://localhost:8080/users/admin/activities/01J1ZVP0SXPQ6CH9Q4F2GM2XH0",
+		"type":   "Delete",
+		"actor":  "http://localhost:8080/users/admin",
+		"object": "http://localhost:8080/users/admin/statuses/01J1ZVP0SXPQ6CH9Q4F2GM2XH0",
+	}, to); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// popDelivery pops the next queued delivery.
+func (suite *WorkerTaskTestSuite) popDelivery() *delivery.Delivery {
+	dlv, ok := suite.state.Workers.Delivery.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected queued delivery")
+	}
+	return dlv
+}
+
+func (suite *WorkerTaskTestSuite) TestFillDeliveryQueue() {
+	ctx := context.Background()
+
+	// Queue a delivery, then pop it
+	// without marking it done, as if
+	// the process exited before sending.
+	suite.deliver()
+	suite.popDelivery()
+
+	// The delivery should still be
+	// persisted for replay on startup.
+	tasks, err := suite.db.GetPendingWorkerTasks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(tasks, 1)
+	suite.Equal(gtsmodel.DeliveryWorker, tasks[0].WorkerType)
+
+	// Replay the persisted tasks.
+	if err := suite.adminProcessor.FillWorkerQueues(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delivery should be queued
+	// again, ready for signing.
+	dlv := suite.popDelivery()
+	suite.Equal("http://localhost:8080/users/admin#main-key", dlv.PubKeyID)
+	suite.Equal("http://localhost:8080/users/admin/statuses/01J1ZVP0SXPQ6CH9Q4F2GM2XH0", dlv.ObjectID)
+	suite.Equal("http://example.org/users/Some_User/inbox", dlv.Request.URL.String())
+	suite.Equal("application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"", dlv.Request.Header.Get("Content-Type"))
+	suite.Equal(dlv.PubKeyID, gtscontext.OutgoingPublicKeyID(dlv.Request.Context()))
+	suite.NotNil(gtscontext.HTTPClientSignFunc(dlv.Request.Context()))
+
+	// Marking the replayed delivery as
+	// done should drop it from the store.
+	suite.state.Workers.Delivery.Queue.Done(ctx, dlv, nil)
+	_, err = suite.db.GetPendingWorkerTasks(ctx)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *WorkerTaskTestSuite) TestFailedWorkerTask() {
+	ctx := context.Background()
+
+	// Queue a delivery, and mark it failed.
+	suite.deliver()
+	dlv := suite.popDelivery()
+	suite.state.Workers.Delivery.Queue.Done(ctx, dlv, errors.New("http response: 410 Gone"))
+
+	// Failed tasks should not be replayed.
+	if err := suite.adminProcessor.FillWorkerQueues(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(suite.state.Workers.Delivery.Queue.Len())
+
+	// Failed task should be counted.
+	queues, errWithCode := suite.adminProcessor.WorkerQueuesGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(queues, 3)
+	suite.Equal("delivery", queues[0].Worker)
+	suite.Equal(0, queues[0].Queued)
+	suite.Equal(1, queues[0].Failed)
+
+	// And available for inspection.
+	tasks, errWithCode := suite.adminProcessor.WorkerTasksFailedGet(ctx, 20)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(tasks, 1)
+	suite.Equal("delivery", tasks[0].Worker)
+	suite.Equal("http response: 410 Gone", tasks[0].Error)
+	suite.NotEmpty(tasks[0].FailedAt)
+	suite.Contains(string(tasks[0].Data), `"url":"http://example.org/users/Some_User/inbox"`)
+
+	// Delete the failed task.
+	id, err := strconv.ParseUint(tasks[0].ID, 10, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	task, errWithCode := suite.adminProcessor.WorkerTaskFailedDelete(ctx, uint(id))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(tasks[0].ID, task.ID)
+
+	_, errWithCode = suite.adminProcessor.WorkerTaskFailedDelete(ctx, uint(id))
+	suite.EqualError(errWithCode, "no failed worker task exists with id "+tasks[0].ID)
+}
+
+func (suite *WorkerTaskTestSuite) TestFillUnreplayableTask() {
+	ctx := context.Background()
+
+	// Persist a delivery signed by
+	// an account that doesn't exist.
+	task := &gtsmodel.WorkerTask{
+		WorkerType: gtsmodel.DeliveryWorker,
+		TaskData:   []byte(`{"pub_key_id":"http://localhost:8080/users/nobody/main-key","method":"POST","url":"http://example.org/inbox"}`),
+	}
+	if err := suite.db.PutWorkerTask(ctx, task); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.adminProcessor.FillWorkerQueues(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Task shouldn't be queued,
+	// but marked failed instead.
+	suite.Zero(suite.state.Workers.Delivery.Queue.Len())
+
+	task, err := suite.db.GetWorkerTaskByID(ctx, task.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(task.Failed())
+	suite.Contains(task.Error, "db error getting account http://localhost:8080/users/nobody/main-key")
+}
+
+func TestWorkerTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTaskTestSuite))
+}
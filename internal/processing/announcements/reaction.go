@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+)
+
+// maxUnicodeReactionRunes is the maximum number of runes in a
+// unicode emoji reaction, enough for multi-rune ZWJ sequences.
+const maxUnicodeReactionRunes = 16
+
+// AddReaction adds a reaction with the given name by requester to the
+// announcement with given ID. The name may either be a unicode emoji,
+// or the shortcode of a custom emoji of this instance.
+func (p *Processor) AddReaction(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	emoji, errWithCode := p.getReactionEmoji(ctx, name)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      requester.ID,
+		Name:           name,
+	}
+
+	if emoji != nil {
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutAnnouncementReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Already reacted,
+			// nothing changed.
+			return nil
+		}
+		err := gtserror.Newf("db error putting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.streamReaction(ctx, announcement, name)
+}
+
+// RemoveReaction removes the reaction with the given name by
+// requester from the announcement with given ID, if any.
+func (p *Processor) RemoveReaction(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReaction(ctx,
+		announcement.ID,
+		requester.ID,
+		name,
+	); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.streamReaction(ctx, announcement, name)
+}
+
+// getReactionEmoji validates the given reaction name, returning
+// the matching local custom emoji if it is an emoji shortcode.
+func (p *Processor) getReactionEmoji(ctx context.Context, name string) (*gtsmodel.Emoji, gtserror.WithCode) {
+	if !regexes.EmojiValidator.MatchString(name) {
+		// Not a shortcode, so must be a unicode emoji.
+		if !isUnicodeEmoji(name) {
+			text := fmt.Sprintf("%s is not a valid emoji", name)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+		return nil, nil
+	}
+
+	emoji, err := p.state.DB.GetEmojiByShortcodeDomain(
+		gtscontext.SetBarebones(ctx),
+		name,
+		"", // local only
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if emoji == nil || *emoji.Disabled {
+		text := fmt.Sprintf("custom emoji %s not found", name)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return emoji, nil
+}
+
+// streamReaction streams the current count of reactions
+// with the given name to the announcement to all users.
+func (p *Processor) streamReaction(ctx context.Context, announcement *gtsmodel.Announcement, name string) gtserror.WithCode {
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	var count int
+	for _, reaction := range reactions {
+		if reaction.Name == name {
+			count++
+		}
+	}
+
+	p.stream.AnnouncementReaction(ctx, &apimodel.AnnouncementReactionEvent{
+		Name:           name,
+		Count:          count,
+		AnnouncementID: announcement.ID,
+	})
+
+	return nil
+}
+
+// isUnicodeEmoji does a loose check of whether the given
+// string is a (possibly multi-rune) unicode emoji, by
+// ruling out ascii, letters, digits and whitespace.
+func isUnicodeEmoji(name string) bool {
+	if name == "" || utf8.RuneCountInString(name) > maxUnicodeReactionRunes {
+		return false
+	}
+
+	for _, r := range name {
+		if r <= unicode.MaxASCII ||
+			unicode.IsLetter(r) ||
+			unicode.IsDigit(r) ||
+			unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -32,9 +32,13 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 	// set default 'read' for scopes if it's not set
 	var scopes string
 	if form.Scopes == "" {
-		scopes = "read"
+		scopes = string(oauth.DefaultScope)
 	} else {
-		scopes = form.Scopes
+		parsed := oauth.ParseScopes(form.Scopes)
+		if err := parsed.Validate(); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		scopes = parsed.String()
 	}
 
 	// generate new IDs for this application and its associated client
@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package featuredtags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// Create features the given hashtag on the requesting account's profile,
+// creating the hashtag first if this instance hasn't seen it before.
+func (p *Processor) Create(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	form *apimodel.FeaturedTagCreateRequest,
+) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	name, ok := text.NormalizeHashtag(form.Name)
+	if !ok {
+		const text = "invalid hashtag name"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check current featured tags against the limit.
+	featuredTags, err := p.state.DB.GetFeaturedTagsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requestingAccount.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tags for account %s: %w", requestingAccount.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(featuredTags) >= MaxFeaturedTags {
+		text := fmt.Sprintf("you can feature at most %d hashtags", MaxFeaturedTags)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	tag, err := p.state.DB.GetOrCreateTag(ctx, name)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: requestingAccount.ID,
+		Account:   requestingAccount,
+		TagID:     tag.ID,
+		Tag:       tag,
+	}
+
+	if err := p.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			const text = "you are already featuring this hashtag"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+		err := gtserror.Newf("db error putting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.federateFeaturedTagsUpdate(ctx, requestingAccount)
+
+	apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+	if err != nil {
+		err := gtserror.Newf("error converting featured tag to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFeaturedTag, nil
+}
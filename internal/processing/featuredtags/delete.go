@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package featuredtags
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delete stops featuring the given featured
+// tag on the requesting account's profile.
+func (p *Processor) Delete(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	id string,
+) gtserror.WithCode {
+	// Get the featured tag, checking that
+	// the requesting account owns it.
+	if _, errWithCode := p.getFeaturedTagOwnedBy(
+		gtscontext.SetBarebones(ctx),
+		id,
+		requestingAccount,
+	); errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteFeaturedTagByID(ctx, id); err != nil {
+		err := gtserror.Newf("db error deleting featured tag %s: %w", id, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.federateFeaturedTagsUpdate(ctx, requestingAccount)
+
+	return nil
+}
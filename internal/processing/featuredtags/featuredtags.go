@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package featuredtags
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// MaxFeaturedTags is the maximum number
+// of hashtags an account may feature.
+const MaxFeaturedTags = 10
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+}
+
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+	}
+}
+
+// getFeaturedTagOwnedBy gets a featured tag by ID and checks that it is owned by the given account.
+func (p *Processor) getFeaturedTagOwnedBy(
+	ctx context.Context,
+	id string,
+	requestingAccount *gtsmodel.Account,
+) (*gtsmodel.FeaturedTag, gtserror.WithCode) {
+	featuredTag, err := p.state.DB.GetFeaturedTagByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tag %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if featuredTag == nil || featuredTag.AccountID != requestingAccount.ID {
+		// Don't leak the existence of
+		// other accounts' featured tags.
+		err := gtserror.Newf("featured tag %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return featuredTag, nil
+}
+
+// federateFeaturedTagsUpdate sends out an actor Update for the given
+// account, so that remote instances refetch its featured tags.
+func (p *Processor) federateFeaturedTagsUpdate(ctx context.Context, account *gtsmodel.Account) {
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       account,
+		Origin:         account,
+	})
+}
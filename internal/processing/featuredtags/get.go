@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package featuredtags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// GetAll returns all hashtags featured by the given account.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	featuredTags, err := p.state.DB.GetFeaturedTagsByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tags for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(featuredTags))
+	for _, featuredTag := range featuredTags {
+		apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+		if err != nil {
+			log.Errorf(ctx, "error converting featured tag %s to api model: %v", featuredTag.ID, err)
+			continue
+		}
+		apiFeaturedTags = append(apiFeaturedTags, apiFeaturedTag)
+	}
+
+	return apiFeaturedTags, nil
+}
+
+// Suggestions returns up to 10 hashtags that the requesting
+// account has used recently, but does not yet feature.
+func (p *Processor) Suggestions(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+) ([]apimodel.Tag, gtserror.WithCode) {
+	tagIDs, err := p.state.DB.GetFeaturedTagSuggestionIDs(ctx, requestingAccount.ID, MaxFeaturedTags)
+	if err != nil {
+		err := gtserror.Newf("db error getting featured tag suggestions for account %s: %w", requestingAccount.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	tags, err := p.state.DB.GetTags(ctx, tagIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTags := make([]apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, true)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s to api model: %v", tag.ID, err)
+			continue
+		}
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
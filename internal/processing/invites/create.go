@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+const (
+	// MaxInviteUses is the maximum number
+	// of uses that may be set on an invite.
+	MaxInviteUses = 100
+
+	// Characters and length of generated invite codes.
+	codeChars  = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength = 8
+)
+
+// Create creates a new invite on behalf of the requesting
+// account, if permitted by the instance's invite policy.
+func (p *Processor) Create(
+	ctx context.Context,
+	requestingAccount *gtsmodel.Account,
+	requestingUser *gtsmodel.User,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	if !mayCreateInvites(requestingUser) {
+		const text = "you are not permitted to create invites on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if form.MaxUses < 0 || form.MaxUses > MaxInviteUses {
+		const text = "max_uses must be between 0 and 100"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ExpiresIn < 0 {
+		const text = "expires_in must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:        id.NewULID(),
+		AccountID: requestingAccount.ID,
+		Account:   requestingAccount,
+		MaxUses:   form.MaxUses,
+	}
+
+	if form.ExpiresIn > 0 {
+		expiresIn := time.Duration(form.ExpiresIn) * time.Second
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	// Insert the invite, trying a fresh code in
+	// the (unlikely) event of a code collision.
+	for i := 0; ; i++ {
+		code, err := newInviteCode()
+		if err != nil {
+			err := gtserror.Newf("error generating invite code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		invite.Code = code
+
+		err = p.state.DB.PutInvite(ctx, invite)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, db.ErrAlreadyExists) || i >= 2 {
+			err := gtserror.Newf("db error putting invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
+
+// newInviteCode generates a new random invite code.
+func newInviteCode() (string, error) {
+	max := big.NewInt(int64(len(codeChars)))
+	code := make([]byte, codeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = codeChars[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// GetAll returns all invites created by the given account, newest first.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]*apimodel.Invite, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvitesByAccountID(ctx, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting invites for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvites := make([]*apimodel.Invite, 0, len(invites))
+	for _, invite := range invites {
+		apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+		if err != nil {
+			log.Errorf(ctx, "error converting invite %s to api model: %v", invite.ID, err)
+			continue
+		}
+		apiInvites = append(apiInvites, apiInvite)
+	}
+
+	return apiInvites, nil
+}
@@ -20,6 +20,7 @@ package processing
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -105,6 +106,7 @@ func GetParseMentionFunc(state *state.State, federator *federation.Federator) gt
 		// up to the calling function to do, if they want.
 		return &gtsmodel.Mention{
 			ID:               id.NewULID(),
+			CreatedAt:        time.Now(),
 			StatusID:         statusID,
 			OriginAccountID:  originAcct.ID,
 			OriginAccountURI: originAcct.URI,
@@ -114,6 +116,7 @@ func GetParseMentionFunc(state *state.State, federator *federation.Federator) gt
 			TargetAccountURL: targetAcct.URL,
 			TargetAccount:    targetAcct,
 			NameString:       namestring,
+			IsNew:            true,
 		}, nil
 	}
 }
@@ -96,7 +96,7 @@ func (p *Processor) PollVote(ctx context.Context, requester *gtsmodel.Account, p
 	poll.IncrementVotes(choices)
 
 	// Enqueue worker task to handle side-effects of user poll vote(s).
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APActivityType: ap.ActivityCreate,
 		APObjectType:   ap.ActivityQuestion,
 		GTSModel:       vote, // the vote choices
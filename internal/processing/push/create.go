@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Create creates a web push subscription for the given access token,
+// replacing any existing subscription made with the same token.
+//
+// The request is expected to have already been validated and normalized.
+func (p *Processor) Create(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	accessToken string,
+	form *apimodel.PushSubscriptionCreateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Each token only has one subscription, so
+	// creating a new one replaces the old one.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, tokenID); err != nil {
+		err := gtserror.Newf("db error deleting existing push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		TokenID:            tokenID,
+		Endpoint:           *form.Subscription.Endpoint,
+		Auth:               *form.Subscription.Keys.Auth,
+		P256dh:             *form.Subscription.Keys.P256dh,
+		AlertFollow:        util.Ptr(false),
+		AlertFollowRequest: util.Ptr(false),
+		AlertFavourite:     util.Ptr(false),
+		AlertMention:       util.Ptr(false),
+		AlertReblog:        util.Ptr(false),
+		AlertPoll:          util.Ptr(false),
+		AlertStatus:        util.Ptr(false),
+		AlertSignup:        util.Ptr(false),
+		Policy:             gtsmodel.WebPushNotificationPolicyAll,
+	}
+	applyData(subscription, form.Data)
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		err := gtserror.Newf("db error putting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubscription, err := p.converter.WebPushSubscriptionToAPIWebPushSubscription(ctx, subscription)
+	if err != nil {
+		err := gtserror.Newf("error converting push subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiSubscription, nil
+}
+
+// applyData applies any alerts and policy set in data to
+// the subscription, returning the names of changed columns.
+func applyData(subscription *gtsmodel.WebPushSubscription, data *apimodel.PushSubscriptionRequestData) []string {
+	if data == nil {
+		return nil
+	}
+
+	var columns []string
+
+	if alerts := data.Alerts; alerts != nil {
+		for _, alert := range []struct {
+			column string
+			dst    **bool
+			src    *bool
+		}{
+			{"alert_follow", &subscription.AlertFollow, alerts.Follow},
+			{"alert_follow_request", &subscription.AlertFollowRequest, alerts.FollowRequest},
+			{"alert_favourite", &subscription.AlertFavourite, alerts.Favourite},
+			{"alert_mention", &subscription.AlertMention, alerts.Mention},
+			{"alert_reblog", &subscription.AlertReblog, alerts.Reblog},
+			{"alert_poll", &subscription.AlertPoll, alerts.Poll},
+			{"alert_status", &subscription.AlertStatus, alerts.Status},
+			{"alert_signup", &subscription.AlertSignup, alerts.AdminSignup},
+		} {
+			if alert.src != nil {
+				*alert.dst = util.Ptr(*alert.src)
+				columns = append(columns, alert.column)
+			}
+		}
+	}
+
+	if data.Policy != nil {
+		subscription.Policy = gtsmodel.WebPushNotificationPolicy(*data.Policy)
+		columns = append(columns, "policy")
+	}
+
+	return columns
+}
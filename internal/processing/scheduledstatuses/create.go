@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// Create stores the given status form to be published at scheduledAt,
+// and schedules it for publishing, returning the api model representation
+// of the scheduled status.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.AdvancedStatusCreateForm,
+	scheduledAt time.Time,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	if errWithCode := p.checkScheduledAt(ctx, requester, scheduledAt, nil); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     requester.ID,
+		Account:       requester,
+		ScheduledAt:   scheduledAt,
+		Text:          form.Status,
+		SpoilerText:   form.SpoilerText,
+		Sensitive:     &form.Sensitive,
+		Federated:     form.Federated,
+		Boostable:     form.Boostable,
+		Replyable:     form.Replyable,
+		Likeable:      form.Likeable,
+		InReplyToID:   form.InReplyToID,
+		Language:      form.Language,
+		ContentType:   string(form.ContentType),
+		ApplicationID: application.ID,
+		Application:   application,
+	}
+
+	if form.Visibility != "" {
+		scheduled.Visibility = typeutils.APIVisToVis(form.Visibility)
+	}
+
+	if form.InteractionPolicy != nil {
+		if err := validate.InteractionPolicy(form.InteractionPolicy); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		scheduled.InteractionPolicy = typeutils.APIInteractionPolicyToInteractionPolicy(form.InteractionPolicy)
+	}
+
+	if form.Poll != nil {
+		scheduled.PollOptions = form.Poll.Options
+		scheduled.PollExpiresIn = form.Poll.ExpiresIn
+		scheduled.PollMultiple = &form.Poll.Multiple
+		scheduled.PollHideTotals = &form.Poll.HideTotals
+	}
+
+	// Check the media to attach once published.
+	for _, mediaID := range form.MediaIDs {
+		media, errWithCode := p.getSchedulableMedia(ctx, requester, mediaID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		scheduled.MediaIDs = append(scheduled.MediaIDs, media.ID)
+		scheduled.MediaAttachments = append(scheduled.MediaAttachments, media)
+	}
+
+	// Insert the new scheduled status in the database.
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		err := gtserror.Newf("db error putting scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mark the media as belonging to this scheduled
+	// status, so they can't be used elsewhere meanwhile.
+	for _, media := range scheduled.MediaAttachments {
+		media.ScheduledStatusID = scheduled.ID
+		if err := p.state.DB.UpdateAttachment(ctx,
+			media,
+			"scheduled_status_id",
+		); err != nil {
+			err := gtserror.Newf("db error updating media %s: %w", media.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Schedule the status to be published.
+	if err := p.SchedulePublish(ctx, scheduled); err != nil {
+		err := gtserror.Newf("error scheduling status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
+
+// getSchedulableMedia gets the media with ID, checking that
+// it is owned by requester and not attached to any status.
+func (p *Processor) getSchedulableMedia(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	mediaID string,
+) (*gtsmodel.MediaAttachment, gtserror.WithCode) {
+	media, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media %s: %w", mediaID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if media == nil {
+		text := fmt.Sprintf("media %s not found", mediaID)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if media.AccountID != requester.ID {
+		text := fmt.Sprintf("media %s does not belong to account", mediaID)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if media.StatusID != "" || media.ScheduledStatusID != "" {
+		text := fmt.Sprintf("media %s already attached to status", mediaID)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return media, nil
+}
+
+// apiScheduledStatus converts the given
+// scheduled status to its api model.
+func (p *Processor) apiScheduledStatus(
+	ctx context.Context,
+	scheduled *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduled, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduled)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status %s: %w", scheduled.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiScheduled, nil
+}
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Delete cancels and deletes the scheduled status
+// with the given ID, if owned by requester. Any media
+// of the scheduled status is released for other use.
+func (p *Processor) Delete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) gtserror.WithCode {
+	scheduled, errWithCode := p.getScheduledStatusOwnedBy(ctx, id, requester)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Cancel the scheduled publish task.
+	_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+
+	if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// deleteScheduledStatus deletes the given scheduled status
+// from the database, releasing its media for other use.
+func (p *Processor) deleteScheduledStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduled.ID); err != nil {
+		return gtserror.Newf("db error deleting scheduled status: %w", err)
+	}
+
+	for _, media := range scheduled.MediaAttachments {
+		if media.ScheduledStatusID != scheduled.ID {
+			// Media was moved on.
+			continue
+		}
+
+		media.ScheduledStatusID = ""
+		if err := p.state.DB.UpdateAttachment(ctx,
+			media,
+			"scheduled_status_id",
+		); err != nil {
+			return gtserror.Newf("db error updating media %s: %w", media.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses
+
+import (
+	"context"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ScheduleAll schedules publishing of all pending scheduled statuses in
+// the database. It is to be called on startup, so that statuses scheduled
+// before a restart are still published. Statuses whose scheduled time has
+// already passed while the instance was down are published immediately.
+func (p *Processor) ScheduleAll(ctx context.Context) error {
+	// Fetch all pending scheduled statuses from the database (barebones models are enough).
+	scheduleds, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, scheduled := range scheduleds {
+		// Schedule each of the statuses and catch any errors.
+		if err := p.SchedulePublish(ctx, scheduled); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// SchedulePublish adds the given scheduled status to the
+// scheduler, to be published at its scheduled time.
+func (p *Processor) SchedulePublish(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) error {
+	// Add the given scheduled status to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduled.ID,
+		scheduled.ScheduledAt,
+		p.onPublish(scheduled.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduled.ID)
+	}
+
+	atStr := scheduled.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status publish for %s at '%s'", scheduled.ID, atStr)
+	return nil
+}
+
+// onPublish returns a callback function to be used by the
+// scheduler when the given scheduled status is due to be published.
+func (p *Processor) onPublish(scheduledID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Drop the finished task so the
+		// ID isn't left in the scheduler.
+		defer p.state.Workers.Scheduler.Cancel(scheduledID)
+
+		if err := p.publish(ctx, scheduledID); err != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledID, err)
+		}
+	}
+}
+
+// publish creates a new status from the scheduled status with
+// ID, removing the scheduled status regardless of the outcome,
+// so that a status can never be published more than once.
+func (p *Processor) publish(ctx context.Context, scheduledID string) error {
+	// Get the latest version of scheduled status from database.
+	scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledID)
+	if err != nil {
+		return gtserror.Newf("error getting scheduled status from db: %w", err)
+	}
+
+	// Remove the scheduled status, releasing its media
+	// so that they can be attached to the new status.
+	if err := p.deleteScheduledStatus(ctx, scheduled); err != nil {
+		return err
+	}
+
+	if scheduled.Account == nil {
+		// cannot continue without
+		// scheduling account.
+		return gtserror.New("scheduled status account missing")
+	}
+
+	if scheduled.Account.IsSuspended() || scheduled.Account.IsMoving() {
+		// Account can no longer post.
+		return gtserror.New("scheduled status account can no longer post")
+	}
+
+	application := scheduled.Application
+	if application == nil {
+		// Application may since have
+		// been removed, that's fine.
+		application = new(gtsmodel.Application)
+	}
+
+	// Create the status from scheduled form.
+	form := p.scheduledStatusToForm(ctx, scheduled)
+	if _, errWithCode := p.status.Create(ctx,
+		scheduled.Account,
+		application,
+		form,
+	); errWithCode != nil {
+		return errWithCode
+	}
+
+	return nil
+}
+
+// scheduledStatusToForm rebuilds the status creation
+// form originally submitted for the scheduled status.
+func (p *Processor) scheduledStatusToForm(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) *apimodel.AdvancedStatusCreateForm {
+	form := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      scheduled.Text,
+			MediaIDs:    scheduled.MediaIDs,
+			InReplyToID: scheduled.InReplyToID,
+			Sensitive:   *scheduled.Sensitive,
+			SpoilerText: scheduled.SpoilerText,
+			Language:    scheduled.Language,
+			ContentType: apimodel.StatusContentType(scheduled.ContentType),
+		},
+		AdvancedVisibilityFlagsForm: apimodel.AdvancedVisibilityFlagsForm{
+			Federated: scheduled.Federated,
+			Boostable: scheduled.Boostable,
+			Replyable: scheduled.Replyable,
+			Likeable:  scheduled.Likeable,
+		},
+	}
+
+	switch scheduled.Visibility {
+	case "":
+		// Use account default.
+	case gtsmodel.VisibilityMutualsOnly:
+		// Not a mastodon API visibility,
+		// so converter maps it to private.
+		form.Visibility = apimodel.VisibilityMutualsOnly
+	default:
+		form.Visibility = p.converter.VisToAPIVis(ctx, scheduled.Visibility)
+	}
+
+	if scheduled.InteractionPolicy != nil {
+		policy := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, scheduled.InteractionPolicy)
+		form.InteractionPolicy = &policy
+	}
+
+	if len(scheduled.PollOptions) > 0 {
+		form.Poll = &apimodel.PollRequest{
+			Options:    scheduled.PollOptions,
+			ExpiresIn:  scheduled.PollExpiresIn,
+			Multiple:   util.PtrValueOr(scheduled.PollMultiple, false),
+			HideTotals: util.PtrValueOr(scheduled.PollHideTotals, false),
+		}
+	}
+
+	return form
+}
@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+const (
+	// MinScheduleAhead is the minimum duration from
+	// now that a status may be scheduled to be posted.
+	MinScheduleAhead = 5 * time.Minute
+
+	// MaxScheduledStatuses is the maximum number of
+	// pending scheduled statuses an account may have.
+	MaxScheduledStatuses = 300
+
+	// MaxScheduledStatusesPerDay is the maximum number of
+	// statuses an account may schedule for any one day.
+	MaxScheduledStatusesPerDay = 25
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+
+	// other processors
+	status *status.Processor
+}
+
+// New returns a new scheduled statuses processor.
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	status *status.Processor,
+) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+		status:    status,
+	}
+}
+
+// getScheduledStatusOwnedBy gets a scheduled status by
+// ID and checks that it is owned by the given account.
+func (p *Processor) getScheduledStatusOwnedBy(
+	ctx context.Context,
+	id string,
+	requester *gtsmodel.Account,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, err := p.state.DB.GetScheduledStatusByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled status %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduled == nil || scheduled.AccountID != requester.ID {
+		// Don't leak the existence of
+		// other accounts' scheduled statuses.
+		err := gtserror.Newf("scheduled status %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return scheduled, nil
+}
+
+// checkScheduledAt checks whether the requester may schedule
+// a status at the given time, returning a client error if not.
+// If rescheduling an existing scheduled status, it should be
+// passed in so as not to be counted against the limits.
+func (p *Processor) checkScheduledAt(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledAt time.Time,
+	rescheduling *gtsmodel.ScheduledStatus,
+) gtserror.WithCode {
+	if time.Until(scheduledAt) < MinScheduleAhead {
+		const text = "scheduled_at must be at least 5 minutes in the future"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if rescheduling == nil {
+		// Check total number of pending scheduled statuses.
+		total, err := p.state.DB.CountScheduledStatusesForAccount(ctx,
+			requester.ID,
+			time.Time{},
+			time.Time{},
+		)
+		if err != nil {
+			err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if total >= MaxScheduledStatuses {
+			text := fmt.Sprintf("cannot have more than %d scheduled statuses", MaxScheduledStatuses)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	// Check number of statuses scheduled for the same (UTC) day.
+	dayStart := scheduledAt.UTC().Truncate(24 * time.Hour)
+	daily, err := p.state.DB.CountScheduledStatusesForAccount(ctx,
+		requester.ID,
+		dayStart,
+		dayStart.Add(24*time.Hour),
+	)
+	if err != nil {
+		err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if rescheduling != nil &&
+		rescheduling.ScheduledAt.UTC().Truncate(24*time.Hour).Equal(dayStart) {
+		// Don't count the status
+		// being rescheduled itself.
+		daily--
+	}
+
+	if daily >= MaxScheduledStatusesPerDay {
+		text := fmt.Sprintf("cannot schedule more than %d statuses for the same day", MaxScheduledStatusesPerDay)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/scheduledstatuses"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusesTestSuite struct {
+	suite.Suite
+	state             state.State
+	scheduledStatuses scheduledstatuses.Processor
+
+	testAccounts     map[string]*gtsmodel.Account
+	testApplications map[string]*gtsmodel.Application
+	testAttachments  map[string]*gtsmodel.MediaAttachment
+}
+
+func (suite *ScheduledStatusesTestSuite) SetupSuite() {
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testAttachments = testrig.NewTestAttachments()
+}
+
+func (suite *ScheduledStatusesTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	converter := typeutils.NewConverter(&suite.state)
+	controller := testrig.NewTestTransportController(&suite.state, nil)
+	mediaMgr := media.NewManager(&suite.state)
+	federator := testrig.NewTestFederator(&suite.state, controller, mediaMgr)
+	filter := visibility.NewFilter(&suite.state)
+	testrig.StartTimelines(&suite.state, filter, converter)
+	common := common.New(&suite.state, mediaMgr, converter, federator, filter)
+	polls := polls.New(&common, &suite.state, converter)
+	status := status.New(&suite.state, &common, &polls, federator, converter, filter, processing.GetParseMentionFunc(&suite.state, federator))
+	suite.scheduledStatuses = scheduledstatuses.New(&suite.state, converter, &status)
+	testrig.StandardDBSetup(suite.state.DB, suite.testAccounts)
+}
+
+func (suite *ScheduledStatusesTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *ScheduledStatusesTestSuite) newForm(text string, mediaIDs ...string) *apimodel.AdvancedStatusCreateForm {
+	return &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:      text,
+			MediaIDs:    mediaIDs,
+			Visibility:  apimodel.VisibilityUnlisted,
+			ContentType: apimodel.StatusContentTypePlain,
+		},
+	}
+}
+
+func (suite *ScheduledStatusesTestSuite) TestCreateGetUpdateDelete() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	media := suite.testAttachments["local_account_1_unattached_1"]
+	scheduledAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	// Schedule a new status with media.
+	apiScheduled, errWithCode := suite.scheduledStatuses.Create(ctx,
+		requester,
+		application,
+		suite.newForm("hello future!", media.ID),
+		scheduledAt,
+	)
+	suite.NoError(errWithCode)
+	suite.Equal("hello future!", apiScheduled.Params.Text)
+	suite.Equal("unlisted", apiScheduled.Params.Visibility)
+	suite.Equal(util.FormatISO8601(scheduledAt), apiScheduled.ScheduledAt)
+	suite.Len(apiScheduled.MediaAttachments, 1)
+
+	// Media should now be reserved by the scheduled status.
+	dbMedia, err := suite.state.DB.GetAttachmentByID(ctx, media.ID)
+	suite.NoError(err)
+	suite.Equal(apiScheduled.ID, dbMedia.ScheduledStatusID)
+
+	// The same media can't be scheduled twice.
+	_, errWithCode = suite.scheduledStatuses.Create(ctx,
+		requester,
+		application,
+		suite.newForm("hello again!", media.ID),
+		scheduledAt,
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Scheduled status should be gettable by owner only.
+	_, errWithCode = suite.scheduledStatuses.Get(ctx, requester, apiScheduled.ID)
+	suite.NoError(errWithCode)
+	_, errWithCode = suite.scheduledStatuses.Get(ctx, suite.testAccounts["local_account_2"], apiScheduled.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Reschedule the status.
+	rescheduledAt := scheduledAt.Add(time.Hour)
+	apiScheduled, errWithCode = suite.scheduledStatuses.Update(ctx, requester, apiScheduled.ID, rescheduledAt)
+	suite.NoError(errWithCode)
+	suite.Equal(util.FormatISO8601(rescheduledAt), apiScheduled.ScheduledAt)
+
+	// Delete the scheduled status.
+	errWithCode = suite.scheduledStatuses.Delete(ctx, requester, apiScheduled.ID)
+	suite.NoError(errWithCode)
+
+	// Media should have been released.
+	dbMedia, err = suite.state.DB.GetAttachmentByID(ctx, media.ID)
+	suite.NoError(err)
+	suite.Empty(dbMedia.ScheduledStatusID)
+
+	_, errWithCode = suite.scheduledStatuses.Get(ctx, requester, apiScheduled.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusesTestSuite) TestCreateTooSoon() {
+	_, errWithCode := suite.scheduledStatuses.Create(context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.testApplications["application_1"],
+		suite.newForm("hello now!"),
+		time.Now().Add(time.Minute),
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusesTestSuite) TestCreateDailyLimit() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	scheduledAt := time.Now().Add(48 * time.Hour).UTC().Truncate(24 * time.Hour)
+
+	for i := 0; i < scheduledstatuses.MaxScheduledStatusesPerDay; i++ {
+		_, errWithCode := suite.scheduledStatuses.Create(ctx,
+			requester,
+			application,
+			suite.newForm("hello"),
+			scheduledAt.Add(time.Duration(i)*time.Minute),
+		)
+		suite.NoError(errWithCode)
+	}
+
+	// One more on the same day is too many.
+	_, errWithCode := suite.scheduledStatuses.Create(ctx,
+		requester,
+		application,
+		suite.newForm("hello"),
+		scheduledAt.Add(time.Hour),
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *ScheduledStatusesTestSuite) TestScheduleAllPublishesOverdue() {
+	ctx := context.Background()
+	requester := suite.testAccounts["local_account_1"]
+
+	// Insert a scheduled status which
+	// became due while we were "down".
+	scheduled := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     requester.ID,
+		ScheduledAt:   time.Now().Add(-time.Minute),
+		Text:          "sorry for the delay",
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		ContentType:   string(apimodel.StatusContentTypePlain),
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+	if err := suite.state.DB.PutScheduledStatus(ctx, scheduled); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Reload schedules as on startup.
+	if err := suite.scheduledStatuses.ScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The scheduled status should be published, and then removed.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.state.DB.GetScheduledStatusByID(ctx, scheduled.ID)
+		return err != nil
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to be published")
+	}
+
+	statuses, err := suite.state.DB.GetAccountStatuses(ctx, requester.ID, 1, false, false, "", "", false, false)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+	suite.Equal("<p>sorry for the delay</p>", statuses[0].Content)
+}
+
+func TestScheduledStatusesTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusesTestSuite))
+}
@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses
+
+import (
+	"context"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Update reschedules the scheduled status with the
+// given ID, owned by requester, to be published at
+// the new scheduledAt time.
+func (p *Processor) Update(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	scheduledAt time.Time,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduled, errWithCode := p.getScheduledStatusOwnedBy(ctx, id, requester)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.checkScheduledAt(ctx, requester, scheduledAt, scheduled); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Update the scheduled time in the database.
+	scheduled.ScheduledAt = scheduledAt
+	if err := p.state.DB.UpdateScheduledStatus(ctx,
+		scheduled,
+		"scheduled_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Replace the existing scheduled publish task.
+	_ = p.state.Workers.Scheduler.Cancel(scheduled.ID)
+	if err := p.SchedulePublish(ctx, scheduled); err != nil {
+		err := gtserror.Newf("error scheduling status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduled)
+}
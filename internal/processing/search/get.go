@@ -24,6 +24,8 @@ import (
 	"net/mail"
 	"net/url"
 	"strings"
+	"time"
+	"unicode"
 
 	"codeberg.org/gruf/go-kv"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -119,21 +121,6 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -221,17 +208,21 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
-		if err := p.byURI(
-			ctx,
-			account,
-			uri,
-			queryType,
-			resolve,
-			appendAccount,
-			appendStatus,
-		); err != nil && !errors.Is(err, db.ErrNoEntries) {
-			err = gtserror.Newf("error searching by URI: %w", err)
-			return nil, gtserror.NewErrorInternalError(err)
+		// A URI search has at most one result, so
+		// there's nothing to return past offset 0.
+		if offset == 0 {
+			if err := p.byURI(
+				ctx,
+				account,
+				uri,
+				queryType,
+				resolve,
+				appendAccount,
+				appendStatus,
+			); err != nil && !errors.Is(err, db.ErrNoEntries) {
+				err = gtserror.Newf("error searching by URI: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
 		}
 
 		// This was a URI, so at this point just return
@@ -375,6 +366,13 @@ func (p *Processor) accountsByUsernameDomain(
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
+	//
+	// There's at most one exact match, so there's
+	// nothing to return past offset 0.
+	if offset > 0 {
+		return nil
+	}
+
 	foundAccount, err := p.accountByUsernameDomain(
 		ctx,
 		requestingAccount,
@@ -777,7 +775,7 @@ func (p *Processor) byText(
 	if includeStatuses(queryType) {
 		// Search for statuses using the given text.
 		if err := p.statusesByText(ctx,
-			requestingAccount.ID,
+			requestingAccount,
 			maxID,
 			minID,
 			limit,
@@ -822,10 +820,11 @@ func (p *Processor) accountsByText(
 }
 
 // statusesByText searches in the database for limit
-// number of statuses using the given query text.
+// number of statuses using the given query text, which
+// may contain search operators (see parseQuery).
 func (p *Processor) statusesByText(
 	ctx context.Context,
-	requestingAccountID string,
+	requestingAccount *gtsmodel.Account,
 	maxID string,
 	minID string,
 	limit int,
@@ -838,18 +837,31 @@ func (p *Processor) statusesByText(
 	if err != nil {
 		return err
 	}
-	query = parsed.query
-	// If the owning account for statuses was not provided as the account_id query parameter,
-	// it may still have been provided as a search operator in the query string.
-	if fromAccountID == "" {
-		fromAccountID = parsed.fromAccountID
+
+	statusQuery := &db.StatusSearchQuery{
+		Terms:         parsed.terms,
+		FromAccountID: parsed.fromAccountID,
+		HasMedia:      parsed.hasMedia,
+		Before:        parsed.before,
+		After:         parsed.after,
+	}
+
+	// If the owning account for statuses was provided as the account_id
+	// query parameter, it takes precedence over any from: search operator.
+	if fromAccountID != "" {
+		statusQuery.FromAccountID = fromAccountID
+	}
+
+	// Assume the terms are in the language
+	// the requesting account posts in.
+	if requestingAccount.Settings != nil {
+		statusQuery.Language = requestingAccount.Settings.Language
 	}
 
 	statuses, err := p.state.DB.SearchForStatuses(
 		ctx,
-		requestingAccountID,
-		query,
-		fromAccountID,
+		requestingAccount.ID,
+		statusQuery,
 		maxID,
 		minID,
 		limit,
@@ -866,33 +878,134 @@ func (p *Processor) statusesByText(
 	return nil
 }
 
+// searchDateLayout is the layout of dates
+// given to before: and after: search operators.
+const searchDateLayout = "2006-01-02"
+
 // parsedQuery represents the results of parsing the search operator terms within a query.
 type parsedQuery struct {
-	// query is the original search query text with operator terms removed.
-	query string
+	// terms are the words and "quoted phrases" of the query, with operator terms removed.
+	terms []string
 	// fromAccountID is the account from a successfully resolved `from:` operator, if present.
 	fromAccountID string
+	// hasMedia is true if the `has:media` operator is present.
+	hasMedia bool
+	// before is the start of the day given to the `before:` operator, if present.
+	before time.Time
+	// after is the end of the day given to the `after:` operator, if present.
+	after time.Time
 }
 
 // parseQuery parses query text and handles any search operator terms present.
+//
+// Supported operators are `from:account`, `has:media`, `before:YYYY-MM-DD`
+// and `after:YYYY-MM-DD`. Text in double quotes is kept together as one
+// phrase term, and is never treated as an operator.
 func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsedQuery, err error) {
-	queryPartSeparator := " "
-	queryParts := strings.Split(query, queryPartSeparator)
-	nonOperatorQueryParts := make([]string, 0, len(queryParts))
-	for _, queryPart := range queryParts {
-		if arg, hasPrefix := strings.CutPrefix(queryPart, "from:"); hasPrefix {
+	for _, queryPart := range splitQuery(query) {
+		if queryPart.quoted {
+			parsed.terms = append(parsed.terms, queryPart.text)
+			continue
+		}
+
+		operator, arg, _ := strings.Cut(queryPart.text, ":")
+		switch strings.ToLower(operator) {
+		case "from":
 			parsed.fromAccountID, err = p.parseFromOperatorArg(ctx, arg)
 			if err != nil {
 				return
 			}
-		} else {
-			nonOperatorQueryParts = append(nonOperatorQueryParts, queryPart)
+
+		case "has":
+			if !strings.EqualFold(arg, "media") {
+				err = gtserror.Newf(
+					"the 'has:' search operator only supports 'media', not %q",
+					arg,
+				)
+				return
+			}
+			parsed.hasMedia = true
+
+		case "before":
+			parsed.before, err = parseDateOperatorArg("before", arg)
+			if err != nil {
+				return
+			}
+
+		case "after":
+			parsed.after, err = parseDateOperatorArg("after", arg)
+			if err != nil {
+				return
+			}
+
+			// After the end of the given
+			// day, not after its start.
+			parsed.after = parsed.after.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+		default:
+			parsed.terms = append(parsed.terms, queryPart.text)
 		}
 	}
-	parsed.query = strings.Join(nonOperatorQueryParts, queryPartSeparator)
+
 	return
 }
 
+// queryPart is a whitespace-separated
+// part of a query, or a quoted phrase.
+type queryPart struct {
+	text   string
+	quoted bool
+}
+
+// splitQuery splits query text into parts separated by
+// whitespace, keeping text in double quotes together as
+// one part (without the quotes). An unterminated quote
+// runs to the end of the query.
+func splitQuery(query string) []queryPart {
+	var (
+		parts  []queryPart
+		b      strings.Builder
+		quoted bool
+	)
+
+	flush := func() {
+		// Collapse any whitespace within phrases.
+		if t := strings.Join(strings.Fields(b.String()), " "); t != "" {
+			parts = append(parts, queryPart{text: t, quoted: quoted})
+		}
+		b.Reset()
+	}
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			flush()
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush()
+
+	return parts
+}
+
+// parseDateOperatorArg parses the given date operator's
+// argument as a date, returning the start of that day (UTC).
+func parseDateOperatorArg(operator string, arg string) (time.Time, error) {
+	date, err := time.Parse(searchDateLayout, arg)
+	if err != nil {
+		return time.Time{}, gtserror.Newf(
+			"the '%s:' search operator requires a date in the form YYYY-MM-DD, but got %q",
+			operator, arg,
+		)
+	}
+
+	return date, nil
+}
+
 // parseFromOperatorArg attempts to parse the from: operator's argument as an account name,
 // and returns the account ID if possible. Allows specifying an account name with or without a leading @.
 func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string) (string, error) {
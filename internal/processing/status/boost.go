@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // BoostCreate processes the boost/reblog of target
@@ -90,13 +91,30 @@ func (p *Processor) BoostCreate(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if target.IsLocal() {
+		// Check whether target's interaction
+		// policy requires approval of the boost.
+		permission, err := p.filter.StatusAnnounceable(ctx,
+			requester,
+			target,
+		)
+		if err != nil {
+			err := gtserror.Newf("error seeing if status %s is announceable: %w", target.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if permission == gtsmodel.PolicyPermissionWithApproval {
+			boost.PendingApproval = util.Ptr(true)
+		}
+	}
+
 	// Store the new boost.
 	if err := p.state.DB.PutStatus(ctx, boost); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	// Process side effects asynchronously.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ActivityAnnounce,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       boost,
@@ -148,7 +166,7 @@ func (p *Processor) BoostRemove(
 
 	if boost != nil {
 		// Status was boosted. Process unboost side effects asynchronously.
-		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 			APObjectType:   ap.ActivityAnnounce,
 			APActivityType: ap.ActivityUndo,
 			GTSModel:       boost,
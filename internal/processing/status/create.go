@@ -36,6 +36,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
 // Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
@@ -113,7 +114,7 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
-	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+	if errWithCode := p.processMediaIDs(ctx, form.MediaIDs, requester.ID, status); errWithCode != nil {
 		return nil, errWithCode
 	}
 
@@ -121,11 +122,21 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := processLanguage(form, requester.Settings.Language, status); err != nil {
+	if errWithCode := processInteractionPolicy(form, requester.Settings, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processLanguage(form.Language, requester.Settings.Language, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := p.processContent(ctx, p.parseMention, form, status); err != nil {
+	if err := p.processContent(ctx,
+		p.parseMention,
+		form.ContentType,
+		form.Status,
+		form.SpoilerText,
+		status,
+	); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -143,7 +154,7 @@ func (p *Processor) Create(
 	}
 
 	// send it back to the client API worker for async side-effects.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ObjectNote,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       status,
@@ -185,9 +196,26 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 		return errWithCode
 	}
 
-	if !*inReplyTo.Replyable {
-		const text = "in-reply-to status marked as not replyable"
+	// Check interaction policy permits requester to reply.
+	permission, err := p.filter.StatusReplyable(ctx,
+		requester,
+		inReplyTo,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is replyable: %w", inReplyTo.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	switch permission {
+	case gtsmodel.PolicyPermissionForbidden:
+		const text = "in-reply-to status interaction policy does not permit reply"
 		return gtserror.NewErrorForbidden(errors.New(text), text)
+
+	case gtsmodel.PolicyPermissionWithApproval:
+		// Only mark as pending if we're the
+		// ones who'll handle the approval;
+		// otherwise it's up to the remote.
+		status.PendingApproval = util.Ptr(inReplyTo.IsLocal())
 	}
 
 	// Set status fields from inReplyTo.
@@ -239,8 +267,8 @@ func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status
 	return nil
 }
 
-func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
-	if form.MediaIDs == nil {
+func (p *Processor) processMediaIDs(ctx context.Context, mediaIDs []string, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
+	if mediaIDs == nil {
 		return nil
 	}
 
@@ -250,7 +278,7 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 	attachments := []*gtsmodel.MediaAttachment{}
 	attachmentIDs := []string{}
 
-	for _, mediaID := range form.MediaIDs {
+	for _, mediaID := range mediaIDs {
 		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err := gtserror.Newf("error fetching media from db: %w", err)
@@ -267,7 +295,10 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.Advanced
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+		// Media may already be attached to this status
+		// (e.g. when editing), but not to any other status.
+		if (attachment.StatusID != "" && attachment.StatusID != status.ID) ||
+			attachment.ScheduledStatusID != "" {
 			text := fmt.Sprintf("media %s already attached to status", mediaID)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
@@ -359,9 +390,46 @@ func processVisibility(form *apimodel.AdvancedStatusCreateForm, accountDefaultVi
 	return nil
 }
 
-func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error {
-	if form.Language != "" {
-		status.Language = form.Language
+func processInteractionPolicy(form *apimodel.AdvancedStatusCreateForm, settings *gtsmodel.AccountSettings, status *gtsmodel.Status) gtserror.WithCode {
+	if form.InteractionPolicy != nil {
+		// Use the policy given on the form.
+		if err := validate.InteractionPolicy(form.InteractionPolicy); err != nil {
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		status.InteractionPolicy = typeutils.APIInteractionPolicyToInteractionPolicy(form.InteractionPolicy)
+		return nil
+	}
+
+	// Take a copy of the account
+	// default for this visibility.
+	policy := *settings.InteractionPolicyFor(status.Visibility)
+
+	// Narrow this according to any
+	// advanced visibility flags set.
+	authorOnly := gtsmodel.PolicyRules{
+		Always: gtsmodel.PolicyValues{gtsmodel.PolicyValueAuthor},
+	}
+
+	if !*status.Likeable {
+		policy.CanLike = authorOnly
+	}
+
+	if !*status.Replyable {
+		policy.CanReply = authorOnly
+	}
+
+	if !*status.Boostable {
+		policy.CanAnnounce = authorOnly
+	}
+
+	status.InteractionPolicy = &policy
+	return nil
+}
+
+func processLanguage(language string, accountDefaultLanguage string, status *gtsmodel.Status) error {
+	if language != "" {
+		status.Language = language
 	} else {
 		status.Language = accountDefaultLanguage
 	}
@@ -371,11 +439,17 @@ func processLanguage(form *apimodel.AdvancedStatusCreateForm, accountDefaultLang
 	return nil
 }
 
-func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) error {
-	if form.ContentType == "" {
+func (p *Processor) processContent(
+	ctx context.Context,
+	parseMention gtsmodel.ParseMentionFunc,
+	contentType apimodel.StatusContentType,
+	content string,
+	spoilerText string,
+	status *gtsmodel.Status,
+) error {
+	if contentType == "" {
 		// If content type wasn't specified, use the author's preferred content-type.
-		contentType := apimodel.StatusContentType(status.Account.Settings.StatusContentType)
-		form.ContentType = contentType
+		contentType = apimodel.StatusContentType(status.Account.Settings.StatusContentType)
 	}
 
 	// format is the currently set text formatting
@@ -388,7 +462,7 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 		return formatFunc(ctx, parseMention, status.AccountID, status.ID, input)
 	}
 
-	switch form.ContentType {
+	switch contentType {
 	// None given / set,
 	// use default (plain).
 	case "":
@@ -404,11 +478,11 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 
 	// Unknown.
 	default:
-		return fmt.Errorf("invalid status format: %q", form.ContentType)
+		return fmt.Errorf("invalid status format: %q", contentType)
 	}
 
 	// Sanitize status text and format.
-	contentRes := formatInput(format, form.Status)
+	contentRes := formatInput(format, content)
 
 	// Collect formatted results.
 	status.Content = contentRes.HTML
@@ -421,7 +495,7 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 	format = p.formatter.FromPlainEmojiOnly
 
 	// Sanitize content warning and format.
-	spoiler := text.SanitizeToPlaintext(form.SpoilerText)
+	spoiler := text.SanitizeToPlaintext(spoilerText)
 	warningRes := formatInput(format, spoiler)
 
 	// Collect formatted results.
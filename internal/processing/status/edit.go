@@ -0,0 +1,292 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Edit processes the given form to edit an existing status owned by requester,
+// storing the previous version of the status as a historical edit, and
+// returning the api model representation of the updated status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	form *apimodel.StatusEditRequest,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
+) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Fetch the status to be edited from the database.
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error fetching status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := gtserror.Newf("status %s not found", statusID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if status.AccountID != requester.ID {
+		err := gtserror.Newf(
+			"status %s does not belong to account %s",
+			statusID, requester.ID,
+		)
+		return nil, gtserror.NewErrorForbidden(err)
+	}
+
+	if status.BoostOfID != "" {
+		const text = "boosts cannot be edited"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Use the populated requester
+	// as author; we need settings.
+	status.Account = requester
+
+	// Get current time.
+	now := time.Now()
+
+	// Snapshot the current state of the
+	// status, to be stored as an edit once
+	// the new version has been processed.
+	edit := &gtsmodel.StatusEdit{
+		ID:             id.NewULID(),
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Text:           status.Text,
+		Language:       status.Language,
+		Sensitive:      status.Sensitive,
+		AttachmentIDs:  status.AttachmentIDs,
+		StatusID:       status.ID,
+		CreatedAt:      status.EditedAt,
+	}
+
+	if edit.CreatedAt.IsZero() {
+		// Status was never edited before,
+		// so this revision is the original.
+		edit.CreatedAt = status.CreatedAt
+	}
+
+	// Keep hold of the existing poll (if any),
+	// and the existing mentions to reuse / clean up.
+	oldPoll := status.Poll
+	oldMentions := status.Mentions
+	oldMentionIDs := status.MentionIDs
+
+	if oldPoll != nil {
+		edit.PollOptions = oldPoll.Options
+	}
+
+	// Update status fields from form.
+	status.Text = form.Status
+	status.Sensitive = &form.Sensitive
+	status.ActivityStreamsType = ap.ObjectNote
+	status.Poll = nil
+	status.PollID = ""
+
+	if form.Poll != nil {
+		// Update the status AS type to "Question".
+		status.ActivityStreamsType = ap.ActivityQuestion
+
+		// Create new poll for status from form.
+		secs := time.Duration(form.Poll.ExpiresIn)
+		status.Poll = &gtsmodel.Poll{
+			ID:         id.NewULID(),
+			Multiple:   &form.Poll.Multiple,
+			HideCounts: &form.Poll.HideTotals,
+			Options:    form.Poll.Options,
+			StatusID:   status.ID,
+			Status:     status,
+			ExpiresAt:  now.Add(secs * time.Second),
+		}
+
+		// Set poll ID on the status.
+		status.PollID = status.Poll.ID
+	}
+
+	// Media IDs must always be explicitly
+	// provided on edit, else they're removed.
+	status.Attachments = nil
+	status.AttachmentIDs = nil
+
+	if errWithCode := p.processMediaIDs(ctx, form.MediaIDs, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := processLanguage(form.Language, requester.Settings.Language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Reset any mentions, tags and
+	// emojis, these will be regenerated.
+	status.Mentions = nil
+	status.Tags = nil
+	status.Emojis = nil
+
+	if err := p.processContent(ctx,
+		reuseMentions(p.parseMention, oldMentions),
+		form.ContentType,
+		form.Status,
+		form.SpoilerText,
+		status,
+	); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// pollChanged indicates whether we
+	// need to replace the existing poll.
+	pollChanged := (oldPoll != nil || status.Poll != nil)
+
+	if oldPoll != nil && status.Poll != nil &&
+		slices.Equal(oldPoll.Options, status.Poll.Options) &&
+		*oldPoll.Multiple == *status.Poll.Multiple &&
+		*oldPoll.HideCounts == *status.Poll.HideCounts {
+		// Poll is unchanged, keep existing one
+		// (and its votes) rather than resetting.
+		status.Poll = oldPoll
+		status.PollID = oldPoll.ID
+		pollChanged = false
+	}
+
+	if pollChanged && oldPoll != nil {
+		// Old poll is being replaced, so
+		// store its final vote counts.
+		edit.PollVotes = oldPoll.Votes
+	}
+
+	// Store the previous version as an edit.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		err := gtserror.Newf("error inserting status edit in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	status.Edits = append(status.Edits, edit)
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.EditedAt = now
+
+	if pollChanged && status.Poll != nil {
+		// Try to insert the new status poll in the database.
+		if err := p.state.DB.PutPoll(ctx, status.Poll); err != nil {
+			err := gtserror.Newf("error inserting poll in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Update the status in the database.
+	if err := p.state.DB.UpdateStatus(ctx, status); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if pollChanged && oldPoll != nil {
+		// Delete the replaced poll by ID from the database.
+		if err := p.state.DB.DeletePollByID(ctx, oldPoll.ID); err != nil {
+			log.Errorf(ctx, "error deleting poll: %v", err)
+		}
+
+		// Delete any poll votes pointing to the replaced poll.
+		if err := p.state.DB.DeletePollVotes(ctx, oldPoll.ID); err != nil {
+			log.Errorf(ctx, "error deleting poll votes: %v", err)
+		}
+
+		// Cancel any scheduled expiry task for replaced poll.
+		_ = p.state.Workers.Scheduler.Cancel(oldPoll.ID)
+	}
+
+	// Delete any mentions no longer
+	// referenced by the status.
+	for _, id := range oldMentionIDs {
+		if !slices.Contains(status.MentionIDs, id) {
+			if err := p.state.DB.DeleteMentionByID(ctx, id); err != nil {
+				log.Errorf(ctx, "error deleting mention: %v", err)
+			}
+		}
+	}
+
+	// send it back to the client API worker for async side-effects.
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		Origin:         requester,
+	})
+
+	if pollChanged && status.Poll != nil {
+		// Now that the status is updated, and side effects queued,
+		// attempt to schedule an expiry handler for the status poll.
+		if err := p.polls.ScheduleExpiry(ctx, status.Poll); err != nil {
+			log.Errorf(ctx, "error scheduling poll expiry: %v", err)
+		}
+	}
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// reuseMentions wraps parseMention to return any existing mention
+// of the same target account from the status being edited, so that
+// mentions (and their IDs) are kept across edits. Only mentions of
+// newly mentioned accounts are created, and flagged with IsNew.
+func reuseMentions(
+	parseMention gtsmodel.ParseMentionFunc,
+	existing []*gtsmodel.Mention,
+) gtsmodel.ParseMentionFunc {
+	return func(ctx context.Context, namestring string, originAccountID string, statusID string) (*gtsmodel.Mention, error) {
+		mention, err := parseMention(ctx, namestring, originAccountID, statusID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range existing {
+			if m.TargetAccountID == mention.TargetAccountID {
+				// Account was already
+				// mentioned, reuse mention.
+				m.NameString = mention.NameString
+				m.TargetAccountURI = mention.TargetAccountURI
+				m.TargetAccountURL = mention.TargetAccountURL
+				m.TargetAccount = mention.TargetAccount
+				m.IsNew = false
+				return m, nil
+			}
+		}
+
+		return mention, nil
+	}
+}
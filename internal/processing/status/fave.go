@@ -62,7 +62,19 @@ func (p *Processor) getFaveableStatus(
 		return nil, nil, errWithCode
 	}
 
-	if !*target.Likeable {
+	// Check interaction policy permits requester to
+	// fave. Approval isn't supported for faves, so
+	// anything short of permitted is forbidden.
+	permission, err := p.filter.StatusLikeable(ctx,
+		requester,
+		target,
+	)
+	if err != nil {
+		err = gtserror.Newf("error seeing if status %s is faveable: %w", target.ID, err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if permission != gtsmodel.PolicyPermissionPermitted {
 		err := errors.New("status is not faveable")
 		return nil, nil, gtserror.NewErrorForbidden(err, err.Error())
 	}
@@ -107,7 +119,7 @@ func (p *Processor) FaveCreate(ctx context.Context, requestingAccount *gtsmodel.
 	}
 
 	// Process new status fave side effects.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ActivityLike,
 		APActivityType: ap.ActivityCreate,
 		GTSModel:       gtsFave,
@@ -137,7 +149,7 @@ func (p *Processor) FaveRemove(ctx context.Context, requestingAccount *gtsmodel.
 	}
 
 	// Process remove status fave side effects.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		APObjectType:   ap.ActivityLike,
 		APActivityType: ap.ActivityUndo,
 		GTSModel:       existingFave,
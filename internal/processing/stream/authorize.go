@@ -24,6 +24,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 // Authorize returns an oauth2 token info in response to an access token query from the streaming API
@@ -34,6 +35,13 @@ func (p *Processor) Authorize(ctx context.Context, accessToken string) (*gtsmode
 		return nil, gtserror.NewErrorUnauthorized(err)
 	}
 
+	// Query param tokens don't pass through the
+	// scope middleware, so check the scope here.
+	if !oauth.ParseScopes(ti.GetScope()).Permits(oauth.ScopeReadStreaming) {
+		err := fmt.Errorf("token scope %s does not permit %s", ti.GetScope(), oauth.ScopeReadStreaming)
+		return nil, gtserror.NewErrorForbidden(err)
+	}
+
 	uid := ti.GetUserID()
 	if uid == "" {
 		err := fmt.Errorf("no userid in token")
@@ -19,10 +19,12 @@ package stream_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type AuthorizeTestSuite struct {
@@ -43,6 +45,24 @@ func (suite *AuthorizeTestSuite) TestAuthorize() {
 	suite.Nil(noAccount)
 }
 
+func (suite *AuthorizeTestSuite) TestAuthorizeInsufficientScope() {
+	// Copy an existing token but
+	// only give it write scope.
+	token := new(gtsmodel.Token)
+	*token = *suite.testTokens["local_account_1"]
+	token.ID = "01J7ZQ0ZXG3C8A3YQ5J0B4M6QK"
+	token.Access = "WRITEONLYTOKENWRITEONLYTOKENWRITEONLYTOKEN"
+	token.Scope = "write"
+	if err := suite.db.PutToken(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	account, errWithCode := suite.streamProcessor.Authorize(context.Background(), token.Access)
+	suite.EqualError(errWithCode, "token scope write does not permit read:streaming")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Nil(account)
+}
+
 func TestAuthorizeTestSuite(t *testing.T) {
 	suite.Run(t, &AuthorizeTestSuite{})
 }
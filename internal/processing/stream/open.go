@@ -29,10 +29,28 @@ import (
 
 // Open returns a new Stream for the given account, which will contain a channel for passing messages back to the caller.
 func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamType string) (*stream.Stream, gtserror.WithCode) {
+	return p.OpenWithToken(ctx, account, "", streamType)
+}
+
+// OpenWithToken is like Open, but associates the returned Stream with the given oauth access
+// token, so that it can be closed straight away if that token is later revoked with CloseByToken.
+func (p *Processor) OpenWithToken(ctx context.Context, account *gtsmodel.Account, accessToken string, streamType string) (*stream.Stream, gtserror.WithCode) {
 	l := log.WithContext(ctx).WithFields(kv.Fields{
 		{"account", account.ID},
 		{"streamType", streamType},
 	}...)
 	l.Debug("received open stream request")
-	return p.streams.Open(account.ID, streamType), nil
+	return p.streams.OpenWithToken(account.ID, accessToken, streamType), nil
+}
+
+// CloseByToken closes any open streams of the given account that
+// were opened using the given oauth access token, eg., because
+// that token has just been revoked.
+func (p *Processor) CloseByToken(ctx context.Context, accountID string, accessToken string) {
+	if n := p.streams.CloseByToken(accountID, accessToken); n > 0 {
+		log.WithContext(ctx).
+			WithField("account", accountID).
+			WithField("closed", n).
+			Debug("closed streams for revoked token")
+	}
 }
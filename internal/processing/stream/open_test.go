@@ -20,6 +20,7 @@ package stream_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -35,6 +36,34 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestCloseByToken() {
+	var (
+		ctx     = context.Background()
+		account = suite.testAccounts["local_account_1"]
+		token   = suite.testTokens["local_account_1"]
+	)
+
+	withToken, errWithCode := suite.streamProcessor.OpenWithToken(ctx, account, token.Access, "user")
+	suite.NoError(errWithCode)
+
+	withoutToken, errWithCode := suite.streamProcessor.Open(ctx, account, "user")
+	suite.NoError(errWithCode)
+	defer withoutToken.Close()
+
+	suite.streamProcessor.CloseByToken(ctx, account.ID, token.Access)
+
+	// Stream opened with the token should be closed.
+	_, ok := withToken.Recv(ctx)
+	suite.False(ok)
+
+	// Other stream should still be open.
+	recvCtx, cncl := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cncl()
+	_, ok = withoutToken.Recv(recvCtx)
+	suite.False(ok)
+	suite.ErrorIs(recvCtx.Err(), context.DeadlineExceeded)
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
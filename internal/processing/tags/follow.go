@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// Follow follows the hashtag with the given name on behalf of the
+// requesting account, creating the hashtag first if this instance
+// hasn't seen it before. Following an already-followed hashtag is
+// a no-op.
+func (p *Processor) Follow(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	name, errWithCode := normalizeName(name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tag, err := p.state.DB.GetOrCreateTag(ctx, name)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	followedTag := &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Account:   requester,
+		TagID:     tag.ID,
+		Tag:       tag,
+	}
+
+	if err := p.state.DB.PutFollowedTag(ctx, followedTag); err != nil &&
+		!errors.Is(err, db.ErrAlreadyExists) {
+		err := gtserror.Newf("db error putting followed tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiTag(ctx, tag, true)
+}
+
+// Unfollow unfollows the hashtag with the given name on behalf of the
+// requesting account. Unfollowing a hashtag that isn't followed is a no-op.
+func (p *Processor) Unfollow(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	name, errWithCode := normalizeName(name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", name)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	followedTag, err := p.state.DB.GetFollowedTag(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		tag.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting followed tag %s: %w", tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if followedTag != nil {
+		if err := p.state.DB.DeleteFollowedTagByID(ctx, followedTag.ID); err != nil {
+			err := gtserror.Newf("db error deleting followed tag %s: %w", followedTag.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.apiTag(ctx, tag, false)
+}
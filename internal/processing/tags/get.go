@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tags
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Get returns the hashtag with the given name, including
+// whether or not it's followed by the requesting account.
+func (p *Processor) Get(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.Tag, gtserror.WithCode) {
+	name, errWithCode := normalizeName(name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if tag == nil {
+		err := gtserror.Newf("tag %s not found", name)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	following, err := p.state.DB.IsFollowingAnyTag(ctx,
+		requester.ID,
+		[]string{tag.ID},
+	)
+	if err != nil {
+		err := gtserror.Newf("db error checking followed tag %s: %w", tag.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiTag(ctx, tag, following)
+}
+
+// FollowedTagsGet returns a page of the hashtags followed by the requesting account.
+func (p *Processor) FollowedTagsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	followedTags, err := p.state.DB.GetFollowedTagsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting followed tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(followedTags)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := followedTags[count-1].ID
+	hi := followedTags[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, followedTag := range followedTags {
+		tag, err := p.state.DB.GetTag(ctx, followedTag.TagID)
+		if err != nil {
+			log.Errorf(ctx, "error getting followed tag %s: %v", followedTag.TagID, err)
+			continue
+		}
+
+		apiTag, errWithCode := p.apiTag(ctx, tag, true)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error converting followed tag %s: %v", tag.ID, errWithCode)
+			continue
+		}
+
+		// Append tag to return items.
+		items = append(items, apiTag)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/followed_tags",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
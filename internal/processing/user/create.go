@@ -19,14 +19,18 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
@@ -49,29 +53,49 @@ func (p *Processor) Create(
 		regBacklog  = 20
 	)
 
-	// Ensure no more than usersPerDay
-	// have registered in the last 24h.
-	newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
-	if err != nil {
-		err := fmt.Errorf("db error counting new users: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+	// If an invite code was given, ensure it's
+	// valid before any other checks are made.
+	var invite *gtsmodel.Invite
+	if form.InviteCode != "" {
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.getUsableInvite(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
 	}
 
-	if newUsersCount >= usersPerDay {
-		err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
-	}
+	// Invites with limited uses can only bring in so
+	// many users, but one with unlimited uses could be
+	// shared widely, so it's subject to the daily limit.
+	if invite == nil || invite.MaxUses == 0 {
+		// Ensure no more than usersPerDay
+		// have registered in the last 24h.
+		newUsersCount, err := p.state.DB.CountApprovedSignupsSince(ctx, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			err := fmt.Errorf("db error counting new users: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
 
-	// Ensure the new users backlog isn't full.
-	backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
-	if err != nil {
-		err := fmt.Errorf("db error counting registration backlog length: %w", err)
-		return nil, gtserror.NewErrorInternalError(err)
+		if newUsersCount >= usersPerDay {
+			err := fmt.Errorf("this instance has hit its limit of new sign-ups for today; you can try again tomorrow")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
 	}
 
-	if backlogLen >= regBacklog {
-		err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
-		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	// Invited sign-ups are pre-approved,
+	// so they don't join the backlog.
+	if invite == nil {
+		// Ensure the new users backlog isn't full.
+		backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
+		if err != nil {
+			err := fmt.Errorf("db error counting registration backlog length: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if backlogLen >= regBacklog {
+			err := fmt.Errorf("this instance's sign-up backlog is currently full; you must wait until pending sign-ups are handled by the admin(s)")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
 	}
 
 	emailAvailable, err := p.state.DB.IsEmailAvailable(ctx, form.Email)
@@ -100,6 +124,10 @@ func (p *Processor) Create(
 		reason = form.Reason
 	}
 
+	// Only store sign-up IP for sign-ups
+	// that are pending moderator approval.
+	signUpIP := form.IP
+
 	// Use instance app if no app provided.
 	if app == nil {
 		app, err = p.state.DB.GetInstanceApplication(ctx)
@@ -109,23 +137,50 @@ func (p *Processor) Create(
 		}
 	}
 
+	var inviteID string
+	if invite != nil {
+		// Use up one of the invite's uses. This may still
+		// fail if the invite was used up in the meantime.
+		if err := p.state.DB.IncrementInviteUses(ctx, invite); err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				const text = "invite code is invalid or has expired"
+				return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+			}
+			err := fmt.Errorf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		inviteID = invite.ID
+		signUpIP = nil
+	}
+
 	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
-		Username: form.Username,
-		Email:    form.Email,
-		Password: form.Password,
-		Reason:   text.SanitizeToPlaintext(reason),
-		SignUpIP: form.IP,
-		Locale:   form.Locale,
-		AppID:    app.ID,
+		Username:    form.Username,
+		Email:       form.Email,
+		Password:    form.Password,
+		Reason:      text.SanitizeToPlaintext(reason),
+		PreApproved: invite != nil,
+		SignUpIP:    signUpIP,
+		Locale:      form.Locale,
+		AppID:       app.ID,
+		InviteID:    inviteID,
 	})
 	if err != nil {
+		if invite != nil {
+			// Give back the use of the invite,
+			// as nobody signed up with it.
+			if err := p.state.DB.DecrementInviteUses(ctx, invite); err != nil {
+				log.Errorf(ctx, "db error giving back invite use: %v", err)
+			}
+		}
+
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	// There are side effects for creating a new user+account
 	// (confirmation emails etc), perform these async.
-	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+	p.state.Workers.Client.Queue.Push(ctx, &messages.FromClientAPI{
 		// Use ap.ObjectProfile here to
 		// distinguish this message (user model)
 		// from ap.ActorPerson (account model).
@@ -138,6 +193,30 @@ func (p *Processor) Create(
 	return user, nil
 }
 
+// getUsableInvite gets the invite with the given
+// code, checking that it can be used to sign up.
+func (p *Processor) getUsableInvite(ctx context.Context, code string) (*gtsmodel.Invite, gtserror.WithCode) {
+	const text = "invite code is invalid or has expired"
+
+	if config.GetAccountsInvitePolicy() == config.AccountsInvitePolicyDisabled {
+		// Invites turned off, so
+		// no invite is usable.
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	invite, err := p.state.DB.GetInviteByCode(gtscontext.SetBarebones(ctx), code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || !invite.Usable(time.Now()) {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return invite, nil
+}
+
 // TokenForNewUser generates an OAuth Bearer token
 // for a new user (with account) created by Create().
 func (p *Processor) TokenForNewUser(
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CreateTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *CreateTestSuite) SetupTest() {
+	suite.UserStandardTestSuite.SetupTest()
+	testrig.StartNoopWorkers(&suite.state)
+}
+
+func (suite *CreateTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	suite.UserStandardTestSuite.TearDownTest()
+}
+
+func (suite *CreateTestSuite) newForm(inviteCode string) *apimodel.AccountCreateRequest {
+	return &apimodel.AccountCreateRequest{
+		Username:   "invited_user",
+		Email:      "invited_user@example.org",
+		Password:   "Very-Secure-Password-123",
+		Agreement:  true,
+		Locale:     "en",
+		InviteCode: inviteCode,
+		IP:         net.ParseIP("192.0.2.1"),
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateWithoutInvite() {
+	user, errWithCode := suite.user.Create(context.Background(), nil, suite.newForm(""))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Sign-up should be pending approval.
+	suite.False(*user.Approved)
+	suite.Empty(user.InviteID)
+	suite.NotNil(user.SignUpIP)
+}
+
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	ctx := context.Background()
+	invite := testrig.NewTestInvites()["admin_account_invite"]
+
+	user, errWithCode := suite.user.Create(ctx, nil, suite.newForm(invite.Code))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Sign-up should be pre-approved.
+	suite.True(*user.Approved)
+	suite.Equal(invite.ID, user.InviteID)
+	suite.Nil(user.SignUpIP)
+
+	// Invite should have been used.
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(invite.Uses+1, dbInvite.Uses)
+}
+
+func (suite *CreateTestSuite) TestCreateWithUnusableInvite() {
+	ctx := context.Background()
+	invite := testrig.NewTestInvites()["admin_account_invite"]
+
+	// Use up the remaining uses of the invite.
+	invite.Uses = invite.MaxUses
+	if err := suite.db.UpdateInvite(ctx, invite, "uses"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, code := range []string{
+		invite.Code, // used up
+		testrig.NewTestInvites()["local_account_1_invite_expired"].Code,
+		"nonexistent",
+	} {
+		user, errWithCode := suite.user.Create(ctx, nil, suite.newForm(code))
+		suite.Nil(user)
+		if suite.NotNil(errWithCode) {
+			suite.Equal("Unprocessable Entity: invite code is invalid or has expired", errWithCode.Safe())
+		}
+	}
+}
+
+func (suite *CreateTestSuite) TestCreateWithUnlimitedInvite() {
+	ctx := context.Background()
+	invite := testrig.NewTestInvites()["admin_account_invite"]
+
+	// Give the invite unlimited uses.
+	invite.MaxUses = 0
+	if err := suite.db.UpdateInvite(ctx, invite, "max_uses"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Unlimited invite should still be
+	// subject to the daily sign-up limit.
+	var created int
+	for i := 0; i <= 10; i++ {
+		form := suite.newForm(invite.Code)
+		form.Username = fmt.Sprintf("invited_user_%d", i)
+		form.Email = fmt.Sprintf("invited_user_%d@example.org", i)
+
+		_, errWithCode := suite.user.Create(ctx, nil, form)
+		if errWithCode != nil {
+			suite.Equal("Unprocessable Entity: this instance has hit its limit of new sign-ups for today; you can try again tomorrow", errWithCode.Safe())
+			break
+		}
+		created++
+	}
+	suite.Less(created, 11)
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteDisabled() {
+	config.SetAccountsInvitePolicy(config.AccountsInvitePolicyDisabled)
+	invite := testrig.NewTestInvites()["admin_account_invite"]
+
+	user, errWithCode := suite.user.Create(context.Background(), nil, suite.newForm(invite.Code))
+	suite.Nil(user)
+	if suite.NotNil(errWithCode) {
+		suite.Equal("Unprocessable Entity: invite code is invalid or has expired", errWithCode.Safe())
+	}
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTestSuite))
+}
@@ -27,7 +27,18 @@ import (
 )
 
 // PasswordChange processes a password change request for the given user.
-func (p *Processor) PasswordChange(ctx context.Context, user *gtsmodel.User, oldPassword string, newPassword string) gtserror.WithCode {
+//
+// If revokeOtherTokens is true, then all oauth access tokens of the
+// user other than currentAccessToken will be revoked once the password
+// has been changed, signing the user out of all their other sessions.
+func (p *Processor) PasswordChange(
+	ctx context.Context,
+	user *gtsmodel.User,
+	oldPassword string,
+	newPassword string,
+	revokeOtherTokens bool,
+	currentAccessToken string,
+) gtserror.WithCode {
 	// Ensure provided oldPassword is the correct current password.
 	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(oldPassword)); err != nil {
 		err := gtserror.Newf("%w", err)
@@ -66,5 +77,11 @@ func (p *Processor) PasswordChange(ctx context.Context, user *gtsmodel.User, old
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	if revokeOtherTokens {
+		if err := p.revokeOtherTokens(ctx, user, currentAccessToken); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -34,7 +35,7 @@ type ChangePasswordTestSuite struct {
 func (suite *ChangePasswordTestSuite) TestChangePasswordOK() {
 	user := suite.testUsers["local_account_1"]
 
-	errWithCode := suite.user.PasswordChange(context.Background(), user, "password", "verygoodnewpassword")
+	errWithCode := suite.user.PasswordChange(context.Background(), user, "password", "verygoodnewpassword", false, "")
 	suite.NoError(errWithCode)
 
 	err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte("verygoodnewpassword"))
@@ -53,7 +54,7 @@ func (suite *ChangePasswordTestSuite) TestChangePasswordOK() {
 func (suite *ChangePasswordTestSuite) TestChangePasswordIncorrectOld() {
 	user := suite.testUsers["local_account_1"]
 
-	errWithCode := suite.user.PasswordChange(context.Background(), user, "ooooopsydoooopsy", "verygoodnewpassword")
+	errWithCode := suite.user.PasswordChange(context.Background(), user, "ooooopsydoooopsy", "verygoodnewpassword", false, "")
 	suite.EqualError(errWithCode, "PasswordChange: crypto/bcrypt: hashedPassword is not the hash of the given password")
 	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
 	suite.Equal("Unauthorized: old password was incorrect", errWithCode.Safe())
@@ -71,7 +72,7 @@ func (suite *ChangePasswordTestSuite) TestChangePasswordIncorrectOld() {
 func (suite *ChangePasswordTestSuite) TestChangePasswordWeakNew() {
 	user := suite.testUsers["local_account_1"]
 
-	errWithCode := suite.user.PasswordChange(context.Background(), user, "password", "1234")
+	errWithCode := suite.user.PasswordChange(context.Background(), user, "password", "1234", false, "")
 	suite.EqualError(errWithCode, "password is only 11% strength, try including more special characters, using lowercase letters, using uppercase letters or using a longer password")
 	suite.Equal(http.StatusBadRequest, errWithCode.Code())
 	suite.Equal("Bad Request: password is only 11% strength, try including more special characters, using lowercase letters, using uppercase letters or using a longer password", errWithCode.Safe())
@@ -86,6 +87,36 @@ func (suite *ChangePasswordTestSuite) TestChangePasswordWeakNew() {
 	suite.NoError(err)
 }
 
+func (suite *ChangePasswordTestSuite) TestChangePasswordRevokeOtherTokens() {
+	var (
+		ctx          = context.Background()
+		user         = suite.testUsers["local_account_1"]
+		currentToken = suite.testTokens["local_account_1"]
+		otherToken   = suite.putOtherToken(user)
+	)
+
+	// Open a stream with the other token;
+	// this should be closed on revocation.
+	account := suite.testAccounts["local_account_1"]
+	stream, errWithCode := suite.stream.OpenWithToken(ctx, account, otherToken.Access, "user")
+	suite.NoError(errWithCode)
+
+	errWithCode = suite.user.PasswordChange(ctx, user, "password", "verygoodnewpassword", true, currentToken.Access)
+	suite.NoError(errWithCode)
+
+	// Current token should be kept.
+	_, err := suite.db.GetTokenByID(ctx, currentToken.ID)
+	suite.NoError(err)
+
+	// Other token should be gone.
+	_, err = suite.db.GetTokenByID(ctx, otherToken.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// And the stream closed.
+	_, ok := stream.Recv(ctx)
+	suite.False(ok)
+}
+
 func TestChangePasswordTestSuite(t *testing.T) {
 	suite.Run(t, &ChangePasswordTestSuite{})
 }
@@ -0,0 +1,246 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// TokensGet returns a page of the oauth access tokens
+// that have been issued to the given user, newest first.
+func (p *Processor) TokensGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokens(ctx, user.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(tokens)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := tokens[count-1].ID
+	hi := tokens[0].ID
+
+	items := make([]interface{}, 0, count)
+
+	for _, token := range tokens {
+		apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			log.Errorf(ctx, "error converting token %s to api token info: %v", token.ID, err)
+			continue
+		}
+
+		// Append token to return items.
+		items = append(items, apiToken)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/tokens",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// TokenGet returns info about the oauth access
+// token with the given ID, issued to the given user.
+func (p *Processor) TokenGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	tokenID string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, tokenID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiTokenInfo(ctx, token)
+}
+
+// TokenInvalidate revokes the oauth access token with the given ID, issued
+// to the given user, closing any streams that were opened with it. It
+// returns info about the revoked token.
+func (p *Processor) TokenInvalidate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	tokenID string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, tokenID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert before revoking, as the
+	// token won't be in the db after.
+	apiToken, errWithCode := p.apiTokenInfo(ctx, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.revokeToken(ctx, user, token); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
+
+// TokensInvalidateByApp revokes all oauth access tokens issued to
+// the given user via the application with the given ID, closing
+// any streams that were opened with them. It returns info about
+// the revoked tokens.
+func (p *Processor) TokensInvalidateByApp(
+	ctx context.Context,
+	user *gtsmodel.User,
+	appID string,
+) ([]*apimodel.TokenInfo, gtserror.WithCode) {
+	app, err := p.state.DB.GetApplicationByID(ctx, appID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting application %s: %w", appID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if app == nil {
+		err := gtserror.Newf("application %s not found", appID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	tokens, err := p.state.DB.GetAccessTokens(ctx, user.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTokens := make([]*apimodel.TokenInfo, 0)
+	for _, token := range tokens {
+		if token.ClientID != app.ClientID {
+			// Not one of
+			// this app's.
+			continue
+		}
+
+		apiToken, errWithCode := p.apiTokenInfo(ctx, token)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if err := p.revokeToken(ctx, user, token); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiTokens = append(apiTokens, apiToken)
+	}
+
+	return apiTokens, nil
+}
+
+// revokeOtherTokens revokes all oauth access tokens issued to the
+// given user *except* the given current access token, if set,
+// closing any streams that were opened with the revoked tokens.
+func (p *Processor) revokeOtherTokens(
+	ctx context.Context,
+	user *gtsmodel.User,
+	currentAccess string,
+) error {
+	tokens, err := p.state.DB.GetAccessTokens(ctx, user.ID, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting tokens: %w", err)
+	}
+
+	for _, token := range tokens {
+		if token.Access == currentAccess {
+			// Keep this one.
+			continue
+		}
+
+		if err := p.revokeToken(ctx, user, token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revokeToken deletes the given token of the given user, and
+// closes any streams that were opened using the token, so that
+// it stops working straight away rather than on next request.
+func (p *Processor) revokeToken(
+	ctx context.Context,
+	user *gtsmodel.User,
+	token *gtsmodel.Token,
+) error {
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		return gtserror.Newf("db error deleting token %s: %w", token.ID, err)
+	}
+
+	p.stream.CloseByToken(ctx, user.AccountID, token.Access)
+	return nil
+}
+
+// getOwnToken fetches the token with the given ID, returning
+// 404 if it doesn't exist *or* belongs to another user.
+func (p *Processor) getOwnToken(
+	ctx context.Context,
+	user *gtsmodel.User,
+	tokenID string,
+) (*gtsmodel.Token, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token %s: %w", tokenID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID || token.Access == "" {
+		err := gtserror.Newf("token %s not found", tokenID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return token, nil
+}
+
+// apiTokenInfo is a shortcut to convert
+// the given token to api token info.
+func (p *Processor) apiTokenInfo(
+	ctx context.Context,
+	token *gtsmodel.Token,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	apiToken, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token %s to api token info: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiToken, nil
+}
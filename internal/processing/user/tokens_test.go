@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type TokensTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TokensTestSuite) TestTokensGet() {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	resp, errWithCode := suite.user.TokensGet(ctx, user, nil)
+	suite.NoError(errWithCode)
+
+	// Only the access token should be returned,
+	// not the pending authorization code.
+	suite.Len(resp.Items, 1)
+
+	token := resp.Items[0].(*apimodel.TokenInfo)
+	suite.Equal(suite.testTokens["local_account_1"].ID, token.ID)
+	suite.Equal("read write follow push", token.Scope)
+	suite.Equal("01F8MGY43H3N2C8EWPR2FPYEXG", token.Application.ID)
+	suite.Equal("really cool gts application", token.Application.Name)
+}
+
+func (suite *TokensTestSuite) TestTokenGetOtherUser() {
+	var (
+		ctx   = context.Background()
+		user  = suite.testUsers["local_account_1"]
+		token = suite.testTokens["local_account_2"]
+	)
+
+	_, errWithCode := suite.user.TokenGet(ctx, user, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidate() {
+	var (
+		ctx     = context.Background()
+		user    = suite.testUsers["local_account_1"]
+		account = suite.testAccounts["local_account_1"]
+		token   = suite.testTokens["local_account_1"]
+	)
+
+	stream, errWithCode := suite.stream.OpenWithToken(ctx, account, token.Access, "user")
+	suite.NoError(errWithCode)
+
+	apiToken, errWithCode := suite.user.TokenInvalidate(ctx, user, token.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(token.ID, apiToken.ID)
+
+	// Token should be gone.
+	_, err := suite.db.GetTokenByAccess(ctx, token.Access)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// And the stream closed.
+	_, ok := stream.Recv(ctx)
+	suite.False(ok)
+}
+
+func (suite *TokensTestSuite) TestTokensInvalidateByApp() {
+	var (
+		ctx        = context.Background()
+		user       = suite.testUsers["local_account_1"]
+		otherToken = suite.putOtherToken(user)
+	)
+
+	apiTokens, errWithCode := suite.user.TokensInvalidateByApp(ctx, user, "01F8MGY43H3N2C8EWPR2FPYEXG")
+	suite.NoError(errWithCode)
+	suite.Len(apiTokens, 2)
+
+	// Both tokens should be gone.
+	for _, id := range []string{
+		suite.testTokens["local_account_1"].ID,
+		otherToken.ID,
+	} {
+		_, err := suite.db.GetTokenByID(ctx, id)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+
+	// Other users' tokens for
+	// the same app are untouched.
+	_, err := suite.db.GetTokenByID(ctx, suite.testTokens["local_account_1_client_application_token"].ID)
+	suite.NoError(err)
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, &TokensTestSuite{})
+}
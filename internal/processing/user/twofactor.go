@@ -0,0 +1,386 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"image/png"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// Number of backup codes
+	// generated on enabling 2FA.
+	twoFactorBackupCodes = 10
+
+	// Size in pixels of the
+	// square QR code image.
+	twoFactorQRCodeSize = 256
+
+	// Length in seconds of one TOTP time
+	// step, and the number of steps either
+	// side of now that a code is valid for,
+	// to allow for clock drift. These match
+	// the totp package (and Google Authenticator)
+	// defaults that secrets are generated with.
+	twoFactorPeriod = 30
+	twoFactorSkew   = 1
+)
+
+// twoFactorBackupEncoding is used to encode random bytes as
+// backup codes, lowercased and without padding so they're
+// easy to read and type.
+var twoFactorBackupEncoding = base32.
+	NewEncoding("abcdefghijklmnopqrstuvwxyz234567").
+	WithPadding(base32.NoPadding)
+
+// TwoFactorQRCodeURIGet returns the otpauth:// URI for the given
+// user's TOTP secret, for entering into an authenticator app.
+// If the user has no secret yet, one will be generated and stored.
+func (p *Processor) TwoFactorQRCodeURIGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+) (*url.URL, gtserror.WithCode) {
+	key, errWithCode := p.twoFactorKey(ctx, user)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	uri, err := url.Parse(key.URL())
+	if err != nil {
+		err := gtserror.Newf("error parsing key url: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return uri, nil
+}
+
+// TwoFactorQRCodePNGGet returns a PNG image of a QR code encoding
+// the otpauth:// URI for the given user's TOTP secret, for scanning
+// with an authenticator app. If the user has no secret yet, one
+// will be generated and stored.
+func (p *Processor) TwoFactorQRCodePNGGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+) ([]byte, gtserror.WithCode) {
+	key, errWithCode := p.twoFactorKey(ctx, user)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	img, err := key.Image(twoFactorQRCodeSize, twoFactorQRCodeSize)
+	if err != nil {
+		err := gtserror.Newf("error generating qr code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		err := gtserror.Newf("error encoding qr code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// TwoFactorEnable enables two-factor authentication for the given
+// user, after checking that the given code is valid for the secret
+// previously generated via TwoFactorQRCodeURIGet / TwoFactorQRCodePNGGet.
+//
+// On success, a slice of one-time-use backup codes is returned. These
+// are stored only in hashed form, so this is the only time they're shown.
+func (p *Processor) TwoFactorEnable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) ([]string, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const help = "two-factor authentication is already enabled"
+		return nil, gtserror.NewErrorConflict(errors.New(help), help)
+	}
+
+	if user.TwoFactorSecret == "" {
+		const help = "no two-factor secret generated yet; get a qr code first"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(help), help)
+	}
+
+	step, ok := twoFactorStep(normalizeTwoFactorCode(code), user.TwoFactorSecret, time.Now())
+	if !ok {
+		const help = "invalid two-factor code"
+		return nil, gtserror.NewErrorForbidden(errors.New(help), help)
+	}
+
+	// Generate backup codes,
+	// storing only the hashes.
+	codes := make([]string, twoFactorBackupCodes)
+	hashes := make([]string, twoFactorBackupCodes)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			err := gtserror.Newf("error generating backup code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		codes[i] = twoFactorBackupEncoding.EncodeToString(b)
+
+		hash, err := bcrypt.GenerateFromPassword(
+			[]byte(codes[i]),
+			bcrypt.DefaultCost,
+		)
+		if err != nil {
+			err := gtserror.Newf("error hashing backup code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		hashes[i] = string(hash)
+	}
+
+	user.TwoFactorBackups = hashes
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorLastStep = step
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return codes, nil
+}
+
+// TwoFactorDisable disables two-factor authentication for the
+// given user, after checking their password, and clears their
+// TOTP secret and any remaining backup codes.
+func (p *Processor) TwoFactorDisable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		const help = "two-factor authentication is not enabled"
+		return gtserror.NewErrorConflict(errors.New(help), help)
+	}
+
+	// Ensure provided password is the correct current password.
+	if err := bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte(password)); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	user.TwoFactorLastStep = 0
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// TwoFactorCheck checks the given code against the given user's
+// TOTP secret, or failing that against their remaining backup codes.
+// A matching backup code is used up, and can't be used again. Likewise,
+// a TOTP code is only accepted if it's from a later time step than the
+// last accepted code, so that an intercepted code can't be replayed.
+//
+// Returns a 403 Forbidden error if the code isn't valid.
+func (p *Processor) TwoFactorCheck(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		const help = "two-factor authentication is not enabled"
+		return gtserror.NewErrorConflict(errors.New(help), help)
+	}
+
+	code = normalizeTwoFactorCode(code)
+	if code == "" {
+		const help = "no two-factor code provided"
+		return gtserror.NewErrorBadRequest(errors.New(help), help)
+	}
+
+	// Lock on this user while checking and updating
+	// their two-factor state, and refresh that state
+	// under lock, so that two requests in quick
+	// succession can't both use up the same code.
+	unlock := p.state.ProcessingLocks.Lock(user.ID)
+	defer unlock()
+
+	dbUser, err := p.state.DB.GetUserByID(ctx, user.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+	user.TwoFactorBackups = dbUser.TwoFactorBackups
+	user.TwoFactorLastStep = dbUser.TwoFactorLastStep
+
+	if step, ok := twoFactorStep(code, user.TwoFactorSecret, time.Now()); ok {
+		if step <= user.TwoFactorLastStep {
+			// Code was valid, but it (or a
+			// later one) has already been used.
+			const help = "two-factor code already used; wait for the next code"
+			return gtserror.NewErrorForbidden(errors.New(help), help)
+		}
+
+		user.TwoFactorLastStep = step
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_last_step",
+		); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		return nil
+	}
+
+	// Not a valid TOTP code,
+	// check backup codes instead.
+	code = strings.ToLower(code)
+	for i, hash := range user.TwoFactorBackups {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(code)) != nil {
+			continue
+		}
+
+		// Match! Use up this backup code.
+		user.TwoFactorBackups = slices.Delete(user.TwoFactorBackups, i, i+1)
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_backups",
+		); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		return nil
+	}
+
+	const help = "invalid two-factor code"
+	return gtserror.NewErrorForbidden(errors.New(help), help)
+}
+
+// twoFactorKey returns the TOTP key for the given user, first
+// generating and storing a new secret if the user doesn't have one.
+// Returns an error if the user has already enabled two-factor auth,
+// as the secret shouldn't be shown again after enrolment.
+func (p *Processor) twoFactorKey(
+	ctx context.Context,
+	user *gtsmodel.User,
+) (*otp.Key, gtserror.WithCode) {
+	if user.TwoFactorEnabled() {
+		const help = "two-factor authentication is already enabled; disable it first to re-enrol"
+		return nil, gtserror.NewErrorConflict(errors.New(help), help)
+	}
+
+	if user.Account == nil {
+		var err error
+		user.Account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account for user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	opts := totp.GenerateOpts{
+		Issuer:      config.GetHost(),
+		AccountName: user.Account.Username + "@" + config.GetAccountDomain(),
+	}
+
+	if user.TwoFactorSecret != "" {
+		// Reuse the existing secret, so that a
+		// page reload during enrolment doesn't
+		// invalidate what the user has scanned.
+		secret, err := base32.StdEncoding.
+			WithPadding(base32.NoPadding).
+			DecodeString(user.TwoFactorSecret)
+		if err != nil {
+			err := gtserror.Newf("error decoding secret: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		opts.Secret = secret
+	}
+
+	key, err := totp.Generate(opts)
+	if err != nil {
+		err := gtserror.Newf("error generating key: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user.TwoFactorSecret == "" {
+		user.TwoFactorSecret = key.Secret()
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_secret",
+		); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return key, nil
+}
+
+// twoFactorStep checks the given TOTP code against the given
+// secret, for time steps either side of the given time within
+// twoFactorSkew. If the code is valid, it returns the time step
+// the code belongs to, and true.
+func twoFactorStep(code string, secret string, now time.Time) (int64, bool) {
+	current := now.Unix() / twoFactorPeriod
+	for step := current - twoFactorSkew; step <= current+twoFactorSkew; step++ {
+		if hotp.Validate(code, uint64(step), secret) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeTwoFactorCode strips any whitespace
+// or dashes a user may have typed into a code.
+func normalizeTwoFactorCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-':
+			return -1
+		}
+		return r
+	}, code)
+}
@@ -0,0 +1,131 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableCheckDisable() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	// Get the QR code URI, this
+	// should generate a new secret.
+	uri, errWithCode := suite.user.TwoFactorQRCodeURIGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Equal("otpauth", uri.Scheme)
+	suite.Equal("/localhost:8080:the_mighty_zork@localhost:8080", uri.Path)
+	suite.NotEmpty(user.TwoFactorSecret)
+	suite.Equal(user.TwoFactorSecret, uri.Query().Get("secret"))
+
+	// Getting it again should give the same secret.
+	uri2, errWithCode := suite.user.TwoFactorQRCodeURIGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Equal(uri.String(), uri2.String())
+
+	// As should the QR code.
+	png, errWithCode := suite.user.TwoFactorQRCodePNGGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Equal([]byte("\x89PNG"), png[:4])
+
+	// Enabling with a wrong code should fail.
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "000000")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+
+	// Enable with a correct code.
+	code, err := totp.GenerateCode(user.TwoFactorSecret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	backups, errWithCode := suite.user.TwoFactorEnable(ctx, user, code)
+	suite.NoError(errWithCode)
+	suite.Len(backups, 10)
+	suite.True(user.TwoFactorEnabled())
+
+	// Check the changes were stored.
+	dbUser := &gtsmodel.User{}
+	err = suite.db.GetByID(ctx, user.ID, dbUser)
+	suite.NoError(err)
+	suite.True(dbUser.TwoFactorEnabled())
+	suite.Equal(user.TwoFactorSecret, dbUser.TwoFactorSecret)
+	suite.Len(dbUser.TwoFactorBackups, 10)
+
+	// Secret shouldn't be
+	// shown again now.
+	_, errWithCode = suite.user.TwoFactorQRCodeURIGet(ctx, user)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// The code used to enable two-factor
+	// auth has been used up, so it shouldn't
+	// work again, but the next one should.
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, code)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	code, err = totp.GenerateCode(user.TwoFactorSecret, time.Now().Add(30*time.Second))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NoError(suite.user.TwoFactorCheck(ctx, user, code))
+
+	// Now that one's used up too.
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, code)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Backup code should work.
+	suite.NoError(suite.user.TwoFactorCheck(ctx, user, backups[0]))
+
+	// The backup code should now
+	// be used up, and not work again.
+	suite.Len(user.TwoFactorBackups, 9)
+	errWithCode = suite.user.TwoFactorCheck(ctx, user, backups[0])
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Disabling with a wrong password should fail.
+	errWithCode = suite.user.TwoFactorDisable(ctx, user, "ooooopsydoooopsy")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.True(user.TwoFactorEnabled())
+
+	// Disable with the right password.
+	errWithCode = suite.user.TwoFactorDisable(ctx, user, "password")
+	suite.NoError(errWithCode)
+
+	dbUser = &gtsmodel.User{}
+	err = suite.db.GetByID(ctx, user.ID, dbUser)
+	suite.NoError(err)
+	suite.False(dbUser.TwoFactorEnabled())
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.Empty(dbUser.TwoFactorBackups)
+	suite.Zero(dbUser.TwoFactorLastStep)
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, &TwoFactorTestSuite{})
+}
@@ -20,6 +20,7 @@ package user
 import (
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 )
@@ -29,6 +30,7 @@ type Processor struct {
 	converter   *typeutils.Converter
 	oauthServer oauth.Server
 	emailSender email.Sender
+	stream      *stream.Processor
 }
 
 // New returns a new user processor.
@@ -37,10 +39,12 @@ func New(
 	converter *typeutils.Converter,
 	oauthServer oauth.Server,
 	emailSender email.Sender,
+	stream *stream.Processor,
 ) Processor {
 	return Processor{
 		state:       state,
 		converter:   converter,
 		emailSender: emailSender,
+		stream:      stream,
 	}
 }
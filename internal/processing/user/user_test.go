@@ -18,10 +18,14 @@
 package user_test
 
 import (
+	"context"
+	"time"
+
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
@@ -34,11 +38,14 @@ type UserStandardTestSuite struct {
 	db          db.DB
 	state       state.State
 
-	testUsers map[string]*gtsmodel.User
+	testUsers    map[string]*gtsmodel.User
+	testAccounts map[string]*gtsmodel.Account
+	testTokens   map[string]*gtsmodel.Token
 
 	sentEmails map[string]string
 
-	user user.Processor
+	stream stream.Processor
+	user   user.Processor
 }
 
 func (suite *UserStandardTestSuite) SetupTest() {
@@ -53,8 +60,12 @@ func (suite *UserStandardTestSuite) SetupTest() {
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../web/template/", suite.sentEmails)
 	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTokens = testrig.NewTestTokens()
 
-	suite.user = user.New(&suite.state, typeutils.NewConverter(&suite.state), testrig.NewTestOauthServer(suite.db), suite.emailSender)
+	oauthServer := testrig.NewTestOauthServer(suite.db)
+	suite.stream = stream.New(&suite.state, oauthServer)
+	suite.user = user.New(&suite.state, typeutils.NewConverter(&suite.state), oauthServer, suite.emailSender, &suite.stream)
 
 	testrig.StandardDBSetup(suite.db, nil)
 }
@@ -62,3 +73,24 @@ func (suite *UserStandardTestSuite) SetupTest() {
 func (suite *UserStandardTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
 }
+
+// putOtherToken stores another access token for
+// the given user, as though they'd signed in to
+// a second app, and returns it.
+func (suite *UserStandardTestSuite) putOtherToken(user *gtsmodel.User) *gtsmodel.Token {
+	token := &gtsmodel.Token{
+		ID:             "01J3Z9Y5WQ1PRS0Q7M2D8K4N6B",
+		ClientID:       "01F8MGV8AC3NGSJW0FE8W1BV70",
+		UserID:         user.ID,
+		RedirectURI:    "http://localhost:8080",
+		Scope:          "read",
+		Access:         "OTHERTOKENOTHERTOKENOTHERTOKENOTHERTOKENOTHERTOK",
+		AccessCreateAt: time.Now(),
+	}
+
+	if err := suite.db.PutToken(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
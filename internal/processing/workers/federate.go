@@ -19,11 +19,14 @@ package workers
 
 import (
 	"context"
+	"errors"
 	"net/url"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -170,6 +173,63 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	if _, err := f.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
 		return gtserror.Newf("error sending Create activity via outbox %s: %w", outboxIRI, err)
 	}
+
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		// Public statuses are
+		// also sent to relays.
+		if err := f.deliverToRelays(ctx,
+			status.Account,
+			create,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliverToRelays delivers the given activity from
+// the given local account to the inboxes of all relays
+// that have accepted our instance's subscription.
+func (f *federate) deliverToRelays(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	activity vocab.Type,
+) error {
+	relays, err := f.state.DB.GetAcceptedRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	if len(relays) == 0 {
+		// Nothing
+		// to do.
+		return nil
+	}
+
+	inboxes := make([]*url.URL, 0, len(relays))
+	for _, relay := range relays {
+		inbox, err := parseURI(relay.InboxURI)
+		if err != nil {
+			return err
+		}
+		inboxes = append(inboxes, inbox)
+	}
+
+	data, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing %T: %w", activity, err)
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(ctx, account.Username)
+	if err != nil {
+		return gtserror.Newf("error getting transport for %s: %w", account.Username, err)
+	}
+
+	if err := tsport.BatchDeliver(ctx, data, inboxes); err != nil {
+		return gtserror.Newf("error delivering %T to relays: %w", activity, err)
+	}
+
 	return nil
 }
 
@@ -25,6 +25,8 @@ import (
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -43,6 +45,7 @@ type clientAPI struct {
 	converter *typeutils.Converter
 	surface   *Surface
 	federate  *federate
+	deref     *dereferencing.Dereferencer
 	account   *account.Processor
 	utils     *utils
 }
@@ -241,6 +244,17 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	if status.IsPendingApproval() {
+		// Replies pending approval are neither
+		// timelined nor federated until approved,
+		// just notify the replied-to account.
+		if err := p.surface.notifyPendingApproval(ctx, status); err != nil {
+			log.Errorf(ctx, "error notifying pending reply: %v", err)
+		}
+
+		return nil
+	}
+
 	if err := p.surface.timelineAndNotifyStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
@@ -255,6 +269,9 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error federating status: %v", err)
 	}
 
+	// Generate a preview card for any link in the status.
+	p.deref.RefreshStatusPreviewCardAsync(status.ID)
+
 	return nil
 }
 
@@ -388,6 +405,17 @@ func (p *clientAPI) CreateAnnounce(ctx context.Context, cMsg *messages.FromClien
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	if boost.IsPendingApproval() {
+		// Boosts pending approval are neither
+		// timelined nor federated until approved,
+		// just notify the boosted account.
+		if err := p.surface.notifyPendingApproval(ctx, boost); err != nil {
+			log.Errorf(ctx, "error notifying pending boost: %v", err)
+		}
+
+		return nil
+	}
+
 	// Timeline and notify the boost wrapper status.
 	if err := p.surface.timelineAndNotifyStatus(ctx, boost); err != nil {
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
@@ -458,6 +486,34 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
+	// Links in status may have changed, refresh preview card.
+	p.deref.RefreshStatusPreviewCardAsync(status.ID)
+
+	// Load the status mentions as currently
+	// stored, to check which were newly added
+	// by this edit; existing mentions are kept
+	// across edits, so only those created at
+	// or after the time of this edit are new.
+	stored, err := p.state.DB.GetMentions(
+		gtscontext.SetBarebones(ctx),
+		status.MentionIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error getting status mentions: %v", err)
+	}
+
+	var mentions []*gtsmodel.Mention
+	for _, mention := range stored {
+		if !mention.CreatedAt.Before(status.EditedAt) {
+			mentions = append(mentions, mention)
+		}
+	}
+
+	// Notify only newly mentioned accounts.
+	if err := p.surface.notifyMentions(ctx, status, mentions); err != nil {
+		log.Errorf(ctx, "error notifying status mentions: %v", err)
+	}
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -679,16 +735,16 @@ func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg *messages.FromClientA
 
 	// Drop any outgoing queued AP requests about / targeting
 	// this status, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", status.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", status.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "ObjectID", status.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "TargetID", status.URI)
 
 	// Drop any incoming queued client messages about / targeting
 	// status, (stops processing of local origin data for status).
-	p.state.Workers.Client.Queue.Delete("TargetURI", status.URI)
+	p.state.Workers.Client.Queue.Delete(ctx, "TargetURI", status.URI)
 
 	// Drop any incoming queued federator messages targeting status,
 	// (stops processing of remote origin data targeting this status).
-	p.state.Workers.Federator.Queue.Delete("TargetURI", status.URI)
+	p.state.Workers.Federator.Queue.Delete(ctx, "TargetURI", status.URI)
 
 	// First perform the actual status deletion.
 	if err := p.utils.wipeStatus(ctx, status, deleteAttachments); err != nil {
@@ -735,20 +791,20 @@ func (p *clientAPI) DeleteAccountOrUser(ctx context.Context, cMsg *messages.From
 
 	// Drop any outgoing queued AP requests to / from / targeting
 	// this account, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ActorID", account.URI)
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", account.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "ActorID", account.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "ObjectID", account.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "TargetID", account.URI)
 
 	// Drop any incoming queued client messages to / from this
 	// account, (stops processing of local origin data for acccount).
-	p.state.Workers.Client.Queue.Delete("Origin.ID", account.ID)
-	p.state.Workers.Client.Queue.Delete("Target.ID", account.ID)
-	p.state.Workers.Client.Queue.Delete("TargetURI", account.URI)
+	p.state.Workers.Client.Queue.Delete(ctx, "Origin.ID", account.ID)
+	p.state.Workers.Client.Queue.Delete(ctx, "Target.ID", account.ID)
+	p.state.Workers.Client.Queue.Delete(ctx, "TargetURI", account.URI)
 
 	// Drop any incoming queued federator messages to this account,
 	// (stops processing of remote origin data targeting this account).
-	p.state.Workers.Federator.Queue.Delete("Receiving.ID", account.ID)
-	p.state.Workers.Federator.Queue.Delete("TargetURI", account.URI)
+	p.state.Workers.Federator.Queue.Delete(ctx, "Receiving.ID", account.ID)
+	p.state.Workers.Federator.Queue.Delete(ctx, "TargetURI", account.URI)
 
 	if err := p.federate.DeleteAccount(ctx, cMsg.Target); err != nil {
 		log.Errorf(ctx, "error federating account delete: %v", err)
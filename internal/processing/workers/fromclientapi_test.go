@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -346,6 +347,96 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusReply() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDirectConversation() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["local_account_1"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		streams          = suite.openStreams(ctx,
+			testStructs.Processor,
+			receivingAccount,
+			nil,
+		)
+		directStream = streams[stream.TimelineDirect]
+
+		// Posting account replies to a DM from receiving account.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityDirect,
+			suite.testStatuses["local_account_2_status_6"],
+			nil,
+		)
+	)
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Receiving account should have an unread conversation.
+	conversation, err := testStructs.State.DB.GetConversationByThreadAndAccountIDs(
+		ctx,
+		status.ThreadID,
+		receivingAccount.ID,
+		[]string{postingAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, conversation.LastStatusID)
+	suite.False(*conversation.Read)
+
+	// Posting account should have a read conversation.
+	ownConversation, err := testStructs.State.DB.GetConversationByThreadAndAccountIDs(
+		ctx,
+		status.ThreadID,
+		postingAccount.ID,
+		[]string{receivingAccount.ID},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.ID, ownConversation.LastStatusID)
+	suite.True(*ownConversation.Read)
+
+	apiConversation, err := testStructs.TypeConverter.ConversationToAPIConversation(
+		ctx,
+		conversation,
+		receivingAccount,
+		nil,
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	conversationJSON, err := json.Marshal(apiConversation)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message in direct stream.
+	suite.checkStreamed(
+		directStream,
+		true,
+		string(conversationJSON),
+		stream.EventTypeConversation,
+	)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessCreateStatusReplyMuted() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -810,6 +901,146 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusBoostNoReblogs() {
 	)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFollowedTag() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		tag              = suite.testTags["welcome"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	// Receiving account doesn't follow
+	// admin, but follows the tag instead.
+	if err := testStructs.State.DB.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: receivingAccount.ID,
+		TagID:     tag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tag the new status.
+	status.TagIDs = []string{tag.ID}
+	status.Tags = []*gtsmodel.Tag{tag}
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "tags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusJSON := suite.statusJSON(
+		ctx,
+		testStructs.TypeConverter,
+		status,
+		receivingAccount,
+	)
+
+	// Check message in home stream.
+	suite.checkStreamed(
+		homeStream,
+		true,
+		statusJSON,
+		stream.EventTypeUpdate,
+	)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusFollowedTagBlocked() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		tag              = suite.testTags["welcome"]
+		streams          = suite.openStreams(ctx, testStructs.Processor, receivingAccount, nil)
+		homeStream       = streams[stream.TimelineHome]
+
+		// Admin account posts a new top-level status.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+	)
+
+	// Receiving account follows the tag...
+	if err := testStructs.State.DB.PutFollowedTag(ctx, &gtsmodel.FollowedTag{
+		ID:        id.NewULID(),
+		AccountID: receivingAccount.ID,
+		TagID:     tag.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// ...but has blocked the posting account.
+	if err := testStructs.State.DB.PutBlock(ctx, &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/" + id.NewULID(),
+		AccountID:       receivingAccount.ID,
+		TargetAccountID: postingAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tag the new status.
+	status.TagIDs = []string{tag.ID}
+	status.Tags = []*gtsmodel.Tag{tag}
+	if err := testStructs.State.DB.UpdateStatus(ctx, status, "tags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Check message NOT in home stream.
+	suite.checkStreamed(
+		homeStream,
+		false,
+		"",
+		"",
+	)
+}
+
 func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	testStructs := suite.SetupTestStructs()
 	defer suite.TearDownTestStructs(testStructs)
@@ -870,6 +1101,190 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusDeliveredToRelays() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx            = context.Background()
+		postingAccount = suite.testAccounts["admin_account"]
+		relayInbox     = "http://relay.example.org/inbox"
+	)
+
+	// Subscribe to a (fake) relay that
+	// has already accepted our Follow.
+	if err := testStructs.State.DB.PutRelay(ctx, &gtsmodel.Relay{
+		ID:                 id.NewULID(),
+		InboxURI:           relayInbox,
+		ActorURI:           "http://relay.example.org/actor",
+		FollowURI:          "http://localhost:8080/users/localhost:8080/follow/" + id.NewULID(),
+		State:              gtsmodel.RelayStateAccepted,
+		CreatedByAccountID: postingAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// relayDeliveries drains the delivery queue,
+	// returning the activities sent to the relay.
+	relayDeliveries := func() []map[string]any {
+		var sent []map[string]any
+		for {
+			dlv, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+			if !ok {
+				return sent
+			}
+
+			if dlv.Request.URL.String() != relayInbox {
+				continue
+			}
+
+			b, err := io.ReadAll(dlv.Request.Body)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			activity := make(map[string]any)
+			if err := json.Unmarshal(b, &activity); err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			sent = append(sent, activity)
+		}
+	}
+
+	for _, test := range []struct {
+		visibility gtsmodel.Visibility
+		delivered  bool
+	}{
+		{gtsmodel.VisibilityPublic, true},
+		{gtsmodel.VisibilityUnlocked, false},
+		{gtsmodel.VisibilityFollowersOnly, false},
+	} {
+		status := suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			test.visibility,
+			nil,
+			nil,
+		)
+
+		if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+			ctx,
+			&messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       status,
+				Origin:         postingAccount,
+			},
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		sent := relayDeliveries()
+		if !test.delivered {
+			suite.Empty(sent, test.visibility)
+			continue
+		}
+
+		if suite.Len(sent, 1, test.visibility) {
+			suite.Equal("Create", sent[0]["type"])
+			suite.Equal(postingAccount.URI, sent[0]["actor"])
+			object, _ := sent[0]["object"].(map[string]any)
+			suite.Equal(status.URI, object["id"])
+		}
+	}
+}
+
+func (suite *FromClientAPITestSuite) TestProcessUpdateStatusNotifyNewMentions() {
+	testStructs := suite.SetupTestStructs()
+	defer suite.TearDownTestStructs(testStructs)
+
+	var (
+		ctx            = context.Background()
+		postingAccount = suite.testAccounts["admin_account"]
+		oldTarget      = suite.testAccounts["local_account_1"]
+		newTarget      = suite.testAccounts["local_account_2"]
+
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+		)
+
+		editedAt = time.Now()
+	)
+
+	// Mention of old target was kept from before
+	// the edit, mention of new target was added.
+	oldMention := &gtsmodel.Mention{
+		ID:               id.NewULID(),
+		CreatedAt:        editedAt.Add(-time.Hour),
+		StatusID:         status.ID,
+		OriginAccountID:  postingAccount.ID,
+		OriginAccountURI: postingAccount.URI,
+		TargetAccountID:  oldTarget.ID,
+	}
+	newMention := &gtsmodel.Mention{
+		ID:               id.NewULID(),
+		CreatedAt:        editedAt.Add(time.Millisecond),
+		StatusID:         status.ID,
+		OriginAccountID:  postingAccount.ID,
+		OriginAccountURI: postingAccount.URI,
+		TargetAccountID:  newTarget.ID,
+	}
+
+	for _, mention := range []*gtsmodel.Mention{oldMention, newMention} {
+		if err := testStructs.State.DB.PutMention(ctx, mention); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	status.MentionIDs = []string{oldMention.ID, newMention.ID}
+	status.EditedAt = editedAt
+	if err := testStructs.State.DB.UpdateStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the status edit, with mentions unpopulated
+	// as it would be when replayed from the task queue.
+	status.Mentions = nil
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Newly mentioned account should be notified.
+	_, err := testStructs.State.DB.GetNotification(
+		ctx,
+		gtsmodel.NotificationMention,
+		newTarget.ID,
+		postingAccount.ID,
+		status.ID,
+	)
+	suite.NoError(err)
+
+	// Previously mentioned account should not.
+	_, err = testStructs.State.DB.GetNotification(
+		ctx,
+		gtsmodel.NotificationMention,
+		oldTarget.ID,
+		postingAccount.ID,
+		status.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
@@ -216,8 +216,15 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		return nil
 	}
 
-	// Update stats for the remote account.
-	if err := p.utils.incrementStatusesCount(ctx, fMsg.Requesting, status); err != nil {
+	// Update stats for the remote account. The requester
+	// isn't necessarily the author when this is a forward
+	// (eg., from a relay), so prefer the status author.
+	author := fMsg.Requesting
+	if status.Account != nil {
+		author = status.Account
+	}
+
+	if err := p.utils.incrementStatusesCount(ctx, author, status); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
@@ -228,6 +235,17 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		p.surface.invalidateStatusFromTimelines(ctx, status.InReplyToID)
 	}
 
+	if status.IsPendingApproval() {
+		// Replies pending approval aren't timelined
+		// until approved, just notify the replied-to
+		// account that there's a reply to approve.
+		if err := p.surface.notifyPendingApproval(ctx, status); err != nil {
+			log.Errorf(ctx, "error notifying pending reply: %v", err)
+		}
+
+		return nil
+	}
+
 	if err := p.surface.timelineAndNotifyStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
@@ -391,6 +409,17 @@ func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg *messages.FromFediAPI
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
+	if boost.IsPendingApproval() {
+		// Boosts pending approval aren't timelined
+		// until approved, just notify the boosted
+		// account that there's a boost to approve.
+		if err := p.surface.notifyPendingApproval(ctx, boost); err != nil {
+			log.Errorf(ctx, "error notifying pending announce: %v", err)
+		}
+
+		return nil
+	}
+
 	// Timeline and notify the announce.
 	if err := p.surface.timelineAndNotifyStatus(ctx, boost); err != nil {
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
@@ -613,16 +642,16 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 
 	// Drop any outgoing queued AP requests about / targeting
 	// this status, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", status.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", status.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "ObjectID", status.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "TargetID", status.URI)
 
 	// Drop any incoming queued client messages about / targeting
 	// status, (stops processing of local origin data for status).
-	p.state.Workers.Client.Queue.Delete("TargetURI", status.URI)
+	p.state.Workers.Client.Queue.Delete(ctx, "TargetURI", status.URI)
 
 	// Drop any incoming queued federator messages targeting status,
 	// (stops processing of remote origin data targeting this status).
-	p.state.Workers.Federator.Queue.Delete("TargetURI", status.URI)
+	p.state.Workers.Federator.Queue.Delete(ctx, "TargetURI", status.URI)
 
 	// First perform the actual status deletion.
 	if err := p.utils.wipeStatus(ctx, status, deleteAttachments); err != nil {
@@ -651,18 +680,18 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg *messages.FromFediAPI)
 
 	// Drop any outgoing queued AP requests to / from / targeting
 	// this account, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", account.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "ObjectID", account.URI)
+	p.state.Workers.Delivery.Queue.Delete(ctx, "TargetID", account.URI)
 
 	// Drop any incoming queued client messages to / from this
 	// account, (stops processing of local origin data for acccount).
-	p.state.Workers.Client.Queue.Delete("Target.ID", account.ID)
-	p.state.Workers.Client.Queue.Delete("TargetURI", account.URI)
+	p.state.Workers.Client.Queue.Delete(ctx, "Target.ID", account.ID)
+	p.state.Workers.Client.Queue.Delete(ctx, "TargetURI", account.URI)
 
 	// Drop any incoming queued federator messages to this account,
 	// (stops processing of remote origin data targeting this account).
-	p.state.Workers.Federator.Queue.Delete("Requesting.ID", account.ID)
-	p.state.Workers.Federator.Queue.Delete("TargetURI", account.URI)
+	p.state.Workers.Federator.Queue.Delete(ctx, "Requesting.ID", account.ID)
+	p.state.Workers.Federator.Queue.Delete(ctx, "TargetURI", account.URI)
 
 	// First perform the actual account deletion.
 	if err := p.account.Delete(ctx, account, account.ID); err != nil {
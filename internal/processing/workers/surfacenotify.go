@@ -32,16 +32,17 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
-// notifyMentions iterates through mentions on the
-// given status, and notifies each mentioned account
+// notifyMentions iterates through given mentions on
+// the status, and notifies each mentioned account
 // that they have a new mention.
 func (s *Surface) notifyMentions(
 	ctx context.Context,
 	status *gtsmodel.Status,
+	mentions []*gtsmodel.Mention,
 ) error {
 	var errs gtserror.MultiError
 
-	for _, mention := range status.Mentions {
+	for _, mention := range mentions {
 		// Set status on the mention (stops
 		// the below function populating it).
 		mention.Status = status
@@ -287,6 +288,58 @@ func (s *Surface) notifyAnnounce(
 	return nil
 }
 
+// notifyPendingApproval notifies the author of the status
+// that the given reply or boost interacts with, that the
+// reply or boost is awaiting their approval.
+func (s *Surface) notifyPendingApproval(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
+		return gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	var (
+		notifType gtsmodel.NotificationType
+		target    *gtsmodel.Account
+	)
+
+	switch {
+	case status.BoostOfID != "":
+		notifType = gtsmodel.NotificationPendingReblog
+		target = status.BoostOfAccount
+
+	case status.InReplyToID != "":
+		notifType = gtsmodel.NotificationPendingReply
+		target = status.InReplyToAccount
+
+	default:
+		// Not an interaction,
+		// nothing to approve.
+		return nil
+	}
+
+	if target == nil || target.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// notify interacted-with status
+	// author of pending interaction.
+	if err := s.Notify(ctx,
+		notifType,
+		target,
+		status.Account,
+		status.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying status author %s: %w", target.ID, err)
+	}
+
+	return nil
+}
+
 func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status) error {
 	// Beforehand, ensure the passed status is fully populated.
 	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
@@ -489,5 +542,10 @@ func (s *Surface) Notify(
 	}
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Push notification to the user's web push subscriptions.
+	if err := s.WebPushSender.Send(ctx, notif, apiNotif); err != nil {
+		return gtserror.Newf("error sending web push notifications: %w", err)
+	}
+
 	return nil
 }
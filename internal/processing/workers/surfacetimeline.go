@@ -27,9 +27,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // timelineAndNotifyStatus inserts the given status into the HOME
@@ -68,11 +70,23 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for followers: %w", status.ID, err)
 	}
 
+	// Timeline the status for each local account following
+	// one of its hashtags, which wasn't already covered above.
+	if err := s.timelineStatusForTagFollowers(ctx, status, follows); err != nil {
+		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
-	if err := s.notifyMentions(ctx, status); err != nil {
+	if err := s.notifyMentions(ctx, status, status.Mentions); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Update any direct message conversations
+	// of local accounts involved in this status.
+	if err := s.updateConversationsForStatus(ctx, status); err != nil {
+		return gtserror.Newf("error updating conversations for status %s: %w", status.ID, err)
+	}
+
 	return nil
 }
 
@@ -190,6 +204,113 @@ func (s *Surface) timelineAndNotifyStatusForFollowers(
 	return errs.Combine()
 }
 
+// timelineStatusForTagFollowers adds the given status to the home
+// timelines of local accounts that follow any of the hashtags it
+// uses, skipping accounts in the given follows slice, which will
+// already have been handled by timelineAndNotifyStatusForFollowers.
+func (s *Surface) timelineStatusForTagFollowers(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	follows []*gtsmodel.Follow,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" ||
+		len(status.TagIDs) == 0 {
+		// Only public, original statuses with
+		// hashtags are shown through followed tags.
+		return nil
+	}
+
+	// Gather the IDs of accounts
+	// already handled as followers.
+	handled := make(map[string]struct{}, len(follows))
+	for _, follow := range follows {
+		handled[follow.AccountID] = struct{}{}
+	}
+
+	var errs gtserror.MultiError
+
+	for _, tagID := range status.TagIDs {
+		accountIDs, err := s.State.DB.GetFollowedTagAccountIDs(ctx, tagID)
+		if err != nil {
+			errs.Appendf("error getting followers of tag %s: %w", tagID, err)
+			continue
+		}
+
+		for _, accountID := range accountIDs {
+			if _, ok := handled[accountID]; ok {
+				// Already timelined
+				// (or not) for this one.
+				continue
+			}
+
+			// Mark as handled, as the same
+			// account may follow multiple tags.
+			handled[accountID] = struct{}{}
+
+			if err := s.timelineStatusForTagFollower(ctx, status, accountID); err != nil {
+				errs.Appendf("error timelining status for tag follower %s: %w", accountID, err)
+			}
+		}
+	}
+
+	return errs.Combine()
+}
+
+// timelineStatusForTagFollower adds the given status to the home timeline
+// of the account with given ID, which follows one of the status hashtags.
+func (s *Surface) timelineStatusForTagFollower(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	accountID string,
+) error {
+	account, err := s.State.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("error getting account: %w", err)
+	}
+
+	// Check to see if the status is timelineable for this
+	// tag follower, which takes account of blocks, and of
+	// whether the account follows any of the status hashtags.
+	timelineable, err := s.Filter.StatusHomeTimelineable(ctx, account, status)
+	if err != nil {
+		return gtserror.Newf("error checking status %s hometimelineability: %w", status.ID, err)
+	}
+
+	if !timelineable {
+		// Nothing to do.
+		return nil
+	}
+
+	filters, err := s.State.DB.GetFiltersForAccountID(ctx, accountID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", accountID, err)
+	}
+
+	mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), accountID, nil)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve mutes for account %s: %w", accountID, err)
+	}
+	compiledMutes := usermute.NewCompiledUserMuteList(mutes)
+
+	// Add status to home timeline for
+	// tag follower, if not filtered / muted.
+	if _, err := s.timelineStatus(
+		ctx,
+		s.State.Timelines.Home.IngestOne,
+		accountID, // home timelines are keyed by account ID
+		account,
+		status,
+		stream.TimelineHome,
+		filters,
+		compiledMutes,
+	); err != nil {
+		return gtserror.Newf("error home timelining status: %w", err)
+	}
+
+	return nil
+}
+
 // listTimelineStatusForFollow puts the given status
 // in any eligible lists owned by the given follower.
 func (s *Surface) listTimelineStatusForFollow(
@@ -607,3 +728,190 @@ func (s *Surface) timelineStreamStatusUpdate(
 	s.Stream.StatusUpdate(ctx, account, apiStatus, streamType)
 	return nil
 }
+
+// updateConversationsForStatus updates the direct message
+// conversation of each local account involved in the given
+// status, creating conversations where necessary, and streams
+// the updated conversation to each of those accounts.
+//
+// The status must be fully populated, and is expected to
+// be newly created; non-direct statuses are ignored.
+func (s *Surface) updateConversationsForStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.Visibility != gtsmodel.VisibilityDirect {
+		// Only DMs are
+		// conversations.
+		return nil
+	}
+
+	if status.BoostOfID != "" || status.ThreadID == "" {
+		// Boosts and unthreaded statuses
+		// can't be part of a conversation.
+		return nil
+	}
+
+	// Gather all the accounts participating
+	// in this status: the author + mentions.
+	participants := make([]*gtsmodel.Account, 0, 1+len(status.Mentions))
+	participants = append(participants, status.Account)
+	for _, mention := range status.Mentions {
+		if mention.TargetAccount == nil {
+			// Mention couldn't
+			// be dereferenced.
+			continue
+		}
+		participants = append(participants, mention.TargetAccount)
+	}
+	participants = util.DeduplicateFunc(participants,
+		func(a *gtsmodel.Account) string { return a.ID },
+	)
+
+	var errs gtserror.MultiError
+
+	for _, participant := range participants {
+		if !participant.IsLocal() {
+			// Only local accounts
+			// own conversations.
+			continue
+		}
+
+		if err := s.updateConversationForParticipant(
+			ctx,
+			status,
+			participant,
+			participants,
+		); err != nil {
+			errs.Appendf("error updating conversation for account %s: %w", participant.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// updateConversationForParticipant updates the conversation owned by the
+// given local participant to include status, and streams it to them.
+func (s *Surface) updateConversationForParticipant(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	participant *gtsmodel.Account,
+	participants []*gtsmodel.Account,
+) error {
+	// Check the participant
+	// can actually see the status.
+	visible, err := s.Filter.StatusVisible(ctx, participant, status)
+	if err != nil {
+		return gtserror.Newf("error checking status %s visibility: %w", status.ID, err)
+	}
+
+	if !visible {
+		// Nothing to do.
+		return nil
+	}
+
+	// Everyone except the conversation
+	// owner counts as an "other" account.
+	otherAccounts := make([]*gtsmodel.Account, 0, len(participants)-1)
+	otherAccountIDs := make([]string, 0, len(participants)-1)
+	for _, account := range participants {
+		if account.ID == participant.ID {
+			continue
+		}
+		otherAccounts = append(otherAccounts, account)
+		otherAccountIDs = append(otherAccountIDs, account.ID)
+	}
+
+	// We're doing state-y stuff so get a lock
+	// on this thread for the owning account.
+	unlock := s.State.ProcessingLocks.Lock(
+		"conversation:" + status.ThreadID + ":" + participant.ID,
+	)
+	defer unlock()
+
+	conversation, err := s.State.DB.GetConversationByThreadAndAccountIDs(
+		ctx,
+		status.ThreadID,
+		participant.ID,
+		otherAccountIDs,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting conversation: %w", err)
+	}
+
+	var columns []string
+	if conversation == nil {
+		// Create a new conversation.
+		conversation = &gtsmodel.Conversation{
+			ID:               id.NewULID(),
+			AccountID:        participant.ID,
+			Account:          participant,
+			OtherAccountIDs:  otherAccountIDs,
+			OtherAccounts:    otherAccounts,
+			OtherAccountsKey: gtsmodel.ConversationOtherAccountsKey(otherAccountIDs),
+			ThreadID:         status.ThreadID,
+		}
+	} else {
+		// Only update the fields
+		// that may have changed.
+		columns = []string{
+			"last_status_id",
+			"read",
+		}
+	}
+
+	// Bump the last status if this one is newer,
+	// which it should be barring federation lag.
+	if status.ID > conversation.LastStatusID {
+		conversation.LastStatusID = status.ID
+		conversation.LastStatus = status
+	}
+
+	// Statuses authored by the owner
+	// don't make the conversation unread.
+	conversation.Read = util.Ptr(status.AccountID == participant.ID)
+
+	if err := s.State.DB.UpsertConversation(ctx, conversation, columns...); err != nil {
+		return gtserror.Newf("error upserting conversation: %w", err)
+	}
+
+	if err := s.State.DB.LinkConversationToStatus(ctx, conversation.ID, status.ID); err != nil {
+		return gtserror.Newf("error linking conversation to status: %w", err)
+	}
+
+	// Stream the updated conversation to the owner.
+	filters, err := s.State.DB.GetFiltersForAccountID(ctx, participant.ID)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve filters for account %s: %w", participant.ID, err)
+	}
+
+	mutes, err := s.State.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), participant.ID, nil)
+	if err != nil {
+		return gtserror.Newf("couldn't retrieve mutes for account %s: %w", participant.ID, err)
+	}
+	compiledMutes := usermute.NewCompiledUserMuteList(mutes)
+
+	// Ensure other fields are populated
+	// for a pre-existing conversation.
+	if err := s.State.DB.PopulateConversation(ctx, conversation); err != nil {
+		return gtserror.Newf("error populating conversation: %w", err)
+	}
+
+	apiConversation, err := s.Converter.ConversationToAPIConversation(
+		ctx,
+		conversation,
+		participant,
+		filters,
+		compiledMutes,
+	)
+	if err != nil {
+		return gtserror.Newf("error converting conversation to api representation: %w", err)
+	}
+
+	if apiConversation.LastStatus == nil {
+		// Status was filtered
+		// out, don't stream.
+		return nil
+	}
+
+	s.Stream.Conversation(ctx, participant, apiConversation)
+
+	return nil
+}
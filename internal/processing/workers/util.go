@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -30,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // util provides util functions used by both
@@ -52,6 +54,26 @@ func (u *utils) wipeStatus(
 ) error {
 	var errs gtserror.MultiError
 
+	// Gather all attachment IDs for this status,
+	// including any that were only ever attached
+	// to historical edits of this status.
+	attachmentIDs := statusToDelete.AttachmentIDs
+	if len(statusToDelete.EditIDs) > 0 {
+		edits, err := u.state.DB.GetStatusEditsByIDs(
+			gtscontext.SetBarebones(ctx),
+			statusToDelete.EditIDs,
+		)
+		if err != nil {
+			errs.Appendf("error fetching status edits: %w", err)
+		}
+
+		attachmentIDs = slices.Clone(attachmentIDs)
+		for _, edit := range edits {
+			attachmentIDs = append(attachmentIDs, edit.AttachmentIDs...)
+		}
+		attachmentIDs = util.Deduplicate(attachmentIDs)
+	}
+
 	// Either delete all attachments for this status,
 	// or simply unattach + clean them separately later.
 	//
@@ -60,20 +82,25 @@ func (u *utils) wipeStatus(
 	// status immediately (in case of delete + redraft)
 	if deleteAttachments {
 		// todo:u.state.DB.DeleteAttachmentsForStatus
-		for _, id := range statusToDelete.AttachmentIDs {
+		for _, id := range attachmentIDs {
 			if err := u.media.Delete(ctx, id); err != nil {
 				errs.Appendf("error deleting media: %w", err)
 			}
 		}
 	} else {
 		// todo:u.state.DB.UnattachAttachmentsForStatus
-		for _, id := range statusToDelete.AttachmentIDs {
+		for _, id := range attachmentIDs {
 			if _, err := u.media.Unattach(ctx, statusToDelete.Account, id); err != nil {
 				errs.Appendf("error unattaching media: %w", err)
 			}
 		}
 	}
 
+	// Delete all historical edits of this status.
+	if err := u.state.DB.DeleteStatusEdits(ctx, statusToDelete.EditIDs); err != nil {
+		errs.Appendf("error deleting status edits: %w", err)
+	}
+
 	// delete all mention entries generated by this status
 	// todo:u.state.DB.DeleteMentionsForStatus
 	for _, id := range statusToDelete.MentionIDs {
@@ -137,6 +164,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status from timelines: %w", err)
 	}
 
+	// delete this status from any conversations it's part of
+	if err := u.state.DB.DeleteStatusFromConversations(ctx, statusToDelete.ID); err != nil {
+		errs.Appendf("error deleting status from conversations: %w", err)
+	}
+
 	// finally, delete the status itself
 	if err := u.state.DB.DeleteStatusByID(ctx, statusToDelete.ID); err != nil {
 		errs.Appendf("error deleting status: %w", err)
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -70,6 +71,7 @@ type TestStructs struct {
 	HTTPClient    *testrig.MockHTTPClient
 	TypeConverter *typeutils.Converter
 	EmailSender   email.Sender
+	WebPushSender webpush.Sender
 }
 
 func (suite *WorkersTestSuite) SetupSuite() {
@@ -108,6 +110,7 @@ func (suite *WorkersTestSuite) openStreams(ctx context.Context, processor *proce
 		stream.TimelineHome,
 		stream.TimelinePublic,
 		stream.TimelineNotifications,
+		stream.TimelineDirect,
 	} {
 		stream, err := processor.Stream().Open(ctx, account, streamType)
 		if err != nil {
@@ -158,8 +161,9 @@ func (suite *WorkersTestSuite) SetupTestStructs() *TestStructs {
 	federator := testrig.NewTestFederator(&state, transportController, mediaManager)
 	oauthServer := testrig.NewTestOauthServer(db)
 	emailSender := testrig.NewEmailSender("../../../web/template/", nil)
+	webPushSender := webpush.NewNoopSender(nil)
 
-	processor := processing.NewProcessor(cleaner.New(&state), typeconverter, federator, oauthServer, mediaManager, &state, emailSender)
+	processor := processing.NewProcessor(cleaner.New(&state), typeconverter, federator, oauthServer, mediaManager, &state, emailSender, webPushSender)
 	testrig.StartWorkers(&state, processor.Workers())
 
 	testrig.StandardDBSetup(db, suite.testAccounts)
@@ -171,6 +175,7 @@ func (suite *WorkersTestSuite) SetupTestStructs() *TestStructs {
 		HTTPClient:    httpClient,
 		TypeConverter: typeconverter,
 		EmailSender:   emailSender,
+		WebPushSender: webPushSender,
 	}
 }
 
@@ -19,15 +19,40 @@ package queue
 
 import (
 	"context"
+	"sync"
 
 	"codeberg.org/gruf/go-structr"
 )
 
+// Store provides write-ahead persistence for
+// values pushed to a StructQueue{}, such that
+// queued values may be recovered after exit.
+type Store[T any] interface {
+	// Put persists the given value,
+	// returning its ID in the store.
+	Put(ctx context.Context, value T) (id uint, err error)
+
+	// Delete drops the value with ID from the store,
+	// on either successful completion or being dropped.
+	Delete(ctx context.Context, id uint)
+
+	// Fail marks the value with ID as
+	// failed in the store, with error.
+	Fail(ctx context.Context, id uint, err error)
+}
+
 // StructQueue wraps a structr.Queue{} to
 // provide simple index caching by name.
 type StructQueue[StructType any] struct {
 	queue structr.QueueCtx[StructType]
 	index map[string]*structr.Index
+
+	// optional write-ahead store,
+	// with map of queued values
+	// (pointers) to store IDs.
+	store Store[StructType]
+	ids   map[any]uint
+	mutex sync.Mutex
 }
 
 // Init initializes queue with structr.QueueConfig{}.
@@ -55,15 +80,69 @@ func (q *StructQueue[T]) PopCtx(ctx context.Context) (value T, ok bool) {
 	return q.queue.PopFront(ctx)
 }
 
-// Push: see structr.Queue.PushBack().
-func (q *StructQueue[T]) Push(values ...T) {
+// SetStore sets a write-ahead Store{} for the queue, to
+// which values will be persisted on push, and dropped on
+// Done(). Queued values MUST be of a comparable (pointer)
+// type. Should be set before any values are pushed.
+func (q *StructQueue[T]) SetStore(store Store[T]) {
+	q.mutex.Lock()
+	q.store = store
+	q.ids = make(map[any]uint)
+	q.mutex.Unlock()
+}
+
+// Push: see structr.Queue.PushBack(). If a Store{} is
+// set, values are first persisted to it before pushing.
+func (q *StructQueue[T]) Push(ctx context.Context, values ...T) {
+	if q.store != nil {
+		for _, value := range values {
+			id, err := q.store.Put(ctx, value)
+			if err != nil {
+				// Still queue value
+				// even if not persisted.
+				continue
+			}
+			q.setID(value, id)
+		}
+	}
 	q.queue.PushBack(values...)
 }
 
+// Restore pushes value previously persisted in the
+// Store{} under ID, without persisting it once again.
+func (q *StructQueue[T]) Restore(id uint, value T) {
+	if q.store != nil {
+		q.setID(value, id)
+	}
+	q.queue.PushBack(value)
+}
+
+// Done marks a popped value as finished with, dropping
+// it from the Store{} if set, or marking as failed on
+// error. Values that are neither finished with nor
+// dropped, remain in the store to be later restored.
+func (q *StructQueue[T]) Done(ctx context.Context, value T, err error) {
+	if q.store == nil {
+		return
+	}
+	id, ok := q.popID(value)
+	if !ok {
+		return
+	}
+	if err != nil {
+		q.store.Fail(ctx, id, err)
+		return
+	}
+	q.store.Delete(ctx, id)
+}
+
 // Delete pops (and drops!) all queued entries under index with key.
-func (q *StructQueue[T]) Delete(index string, key ...any) {
+func (q *StructQueue[T]) Delete(ctx context.Context, index string, key ...any) {
 	i := q.index[index]
-	_ = q.queue.Pop(i, i.Key(key...))
+	values := q.queue.Pop(i, i.Key(key...))
+	for _, value := range values {
+		q.Done(ctx, value, nil)
+	}
 }
 
 // Len: see structr.Queue{}.Len().
@@ -76,3 +155,19 @@ func (q *StructQueue[T]) Len() int {
 func (q *StructQueue[T]) Wait() <-chan struct{} {
 	return q.queue.Wait()
 }
+
+// setID sets the store ID for value.
+func (q *StructQueue[T]) setID(value T, id uint) {
+	q.mutex.Lock()
+	q.ids[value] = id
+	q.mutex.Unlock()
+}
+
+// popID pops the store ID for value.
+func (q *StructQueue[T]) popID(value T) (uint, bool) {
+	q.mutex.Lock()
+	id, ok := q.ids[value]
+	delete(q.ids, value)
+	q.mutex.Unlock()
+	return id, ok
+}
@@ -46,6 +46,22 @@ const (
 	// EventTypeFiltersChanged -- the user's filters
 	// (including keywords and statuses) have changed.
 	EventTypeFiltersChanged = "filters_changed"
+
+	// EventTypeConversation -- a user
+	// should be shown an updated conversation.
+	EventTypeConversation = "conversation"
+
+	// EventTypeAnnouncement -- an instance
+	// announcement has been published or updated.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementReaction -- the reactions
+	// to an instance announcement have changed.
+	EventTypeAnnouncementReaction = "announcement.reaction"
+
+	// EventTypeAnnouncementDelete -- an instance
+	// announcement has been deleted or has ended.
+	EventTypeAnnouncementDelete = "announcement.delete"
 )
 
 const (
@@ -95,12 +111,19 @@ type Streams struct {
 
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
 func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+	return s.OpenWithToken(accountID, "", streamTypes...)
+}
+
+// OpenWithToken is like Open, but associates the new Stream with the given
+// oauth access token, so that it can later be closed by CloseByToken.
+func (s *Streams) OpenWithToken(accountID string, accessToken string, streamTypes ...string) *Stream {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
 
 	// Prep new Stream.
 	str := new(Stream)
+	str.token = accessToken
 	str.done = make(chan struct{})
 	str.msgCh = make(chan Message, 50) // TODO: make configurable
 	for _, streamType := range streamTypes {
@@ -142,6 +165,37 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	return str
 }
 
+// CloseByToken will close all streams of given account ID that were
+// opened using the given oauth access token, returning the number closed.
+func (s *Streams) CloseByToken(accountID string, accessToken string) int {
+	if accessToken == "" {
+		return 0
+	}
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	// Gather streams opened with token. Note we
+	// can't close them here, as the close hook
+	// itself needs to acquire the main mutex.
+	var toClose []*Stream
+	for _, str := range s.streams[accountID] {
+		if str.token == accessToken {
+			toClose = append(toClose, str)
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	// Close outside lock.
+	for _, str := range toClose {
+		str.Close()
+	}
+
+	return len(toClose)
+}
+
 // Post will post the given message to all streams of given account ID matching type.
 func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool {
 	var deferred []func() bool
@@ -259,6 +313,11 @@ type Stream struct {
 	// inbound msg ch.
 	msgCh chan Message
 
+	// oauth access token
+	// this stream was
+	// opened with, if any.
+	token string
+
 	// close hook to remove
 	// stream from Streams{}.
 	close func()
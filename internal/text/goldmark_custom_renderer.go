@@ -24,9 +24,7 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
-	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
-	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/yuin/goldmark"
@@ -143,7 +141,7 @@ func (cr *customRenderer) renderMention(
 // or '@localusername', and does the following:
 //
 //   - Parse the mention string into a *gtsmodel.Mention.
-//   - Insert mention into database if necessary.
+//   - Insert mention into database if new.
 //   - Add mention to cr.results.Mentions slice.
 //   - Return mention rendered as nice HTML.
 //
@@ -156,7 +154,7 @@ func (cr *customRenderer) handleMention(text string) string {
 		return text
 	}
 
-	if cr.statusID != "" {
+	if cr.statusID != "" && mention.IsNew {
 		if err := cr.db.PutMention(cr.ctx, mention); err != nil {
 			log.Errorf(cr.ctx, "error putting mention in db: %s", err)
 			return text
@@ -261,38 +259,7 @@ func (cr *customRenderer) handleHashtag(text string) string {
 		return text
 	}
 
-	getOrCreateHashtag := func(name string) (*gtsmodel.Tag, error) {
-		var (
-			tag *gtsmodel.Tag
-			err error
-		)
-
-		// Check if we have a tag with this name already.
-		tag, err = cr.db.GetTagByName(cr.ctx, name)
-		if err != nil && !errors.Is(err, db.ErrNoEntries) {
-			return nil, gtserror.Newf("db error getting tag %s: %w", name, err)
-		}
-
-		if tag != nil {
-			// We had it!
-			return tag, nil
-		}
-
-		// We didn't have a tag with
-		// this name, create one.
-		tag = &gtsmodel.Tag{
-			ID:   id.NewULID(),
-			Name: name,
-		}
-
-		if err = cr.db.PutTag(cr.ctx, tag); err != nil {
-			return nil, gtserror.Newf("db error putting new tag %s: %w", name, err)
-		}
-
-		return tag, nil
-	}
-
-	tag, err := getOrCreateHashtag(normalized)
+	tag, err := cr.db.GetOrCreateTag(cr.ctx, normalized)
 	if err != nil {
 		log.Errorf(cr.ctx, "error generating hashtags from status: %s", err)
 		return text
@@ -23,6 +23,8 @@ import (
 	"strings"
 
 	"github.com/microcosm-cc/bluemonday"
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // Regular HTML policy is an adapted version of the default
@@ -153,6 +155,37 @@ var regular *bluemonday.Policy = func() *bluemonday.Policy {
 	return p
 }()
 
+// Embed HTML policy permits only the iframes used by oEmbed
+// providers to embed video / rich content in preview cards.
+// See: https://oembed.com/#section2.3
+var embed *bluemonday.Policy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+
+	// "iframe" is permitted with its sizing attributes,
+	// and a src which must be a standard https URL.
+	p.AllowElements("iframe")
+	p.AllowAttrs("width", "height").Matching(bluemonday.Integer).OnElements("iframe")
+	p.AllowAttrs("allowfullscreen", "frameborder", "title").OnElements("iframe")
+	p.AllowAttrs("src", "sandbox").OnElements("iframe")
+
+	// Iframes are always sandboxed, with only the
+	// permissions needed for embedded media players.
+	p.RequireSandboxOnIFrame(
+		bluemonday.SandboxAllowScripts,
+		bluemonday.SandboxAllowSameOrigin,
+		bluemonday.SandboxAllowPopups,
+		bluemonday.SandboxAllowPresentation,
+	)
+
+	// URLs must be parseable by net/url.Parse().
+	p.RequireParseableURLs(true)
+
+	// Only allow secure embeds.
+	p.AllowURLSchemes("https")
+
+	return p
+}()
+
 // '[C]an be thought of as equivalent to stripping all HTML
 // elements and their attributes as it has nothing on its allowlist.
 // An example usage scenario would be blog post titles where HTML
@@ -175,6 +208,23 @@ func SanitizeToHTML(in string) string {
 	return regular.Sanitize(in)
 }
 
+// embedSandbox is the sandbox set on embed
+// iframes that don't specify their own.
+const embedSandbox = `sandbox="allow-scripts allow-same-origin allow-popups allow-presentation"`
+
+// SanitizeToEmbedHTML sanitizes the given oEmbed
+// html string, only allowing through iframes.
+func SanitizeToEmbedHTML(in string) string {
+	out := embed.Sanitize(in)
+
+	// The embed policy gives iframes without a sandbox
+	// an empty (most restrictive) one, which breaks most
+	// players. Swap these for the default embed sandbox.
+	out = strings.ReplaceAll(out, `sandbox=""`, embedSandbox)
+
+	return strings.TrimSpace(out)
+}
+
 // SanitizeToPlaintext runs text through basic sanitization.
 // This removes any html elements that were in the string,
 // and returns clean plaintext.
@@ -189,3 +239,38 @@ func SanitizeToPlaintext(in string) string {
 	content = html.UnescapeString(content)
 	return strings.TrimSpace(content)
 }
+
+// SanitizeToSearchText converts the given html string
+// to plaintext suitable for full-text search indexing.
+//
+// Unlike SanitizeToPlaintext, text either side of line
+// breaks and block elements is kept apart by a space,
+// so that words in eg., separate paragraphs don't get
+// glued together. All whitespace is collapsed.
+func SanitizeToSearchText(in string) string {
+	var (
+		b = strings.Builder{}
+		z = xhtml.NewTokenizer(strings.NewReader(in))
+	)
+
+	for {
+		switch z.Next() {
+		case xhtml.ErrorToken:
+			// Done (or malformed html, in
+			// which case take what we have).
+			return strings.Join(strings.Fields(b.String()), " ")
+
+		case xhtml.TextToken:
+			b.Write(z.Text())
+
+		case xhtml.StartTagToken, xhtml.EndTagToken, xhtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Br, atom.P, atom.Div, atom.Li,
+				atom.Blockquote, atom.Pre, atom.H1, atom.H2,
+				atom.H3, atom.H4, atom.H5, atom.H6:
+				b.WriteByte(' ')
+			}
+		}
+	}
+}
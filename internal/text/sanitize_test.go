@@ -128,6 +128,12 @@ func (suite *SanitizeTestSuite) TestSanitizeInlineImg() {
 	suite.Equal(`<p>Here&#39;s an inline image: </p>`, sanitized)
 }
 
+func (suite *SanitizeTestSuite) TestSanitizeToSearchText() {
+	in := `<p>Another test <span class="h-card"><a href="http://fossbros-anonymous.io/@foss_satan" class="u-url mention">@<span>foss_satan</span></a></span><br/><br/><a href="http://localhost:8080/tags/Hashtag" class="mention hashtag">#<span>Hashtag</span></a></p><p>it&#39;s   some&amp;text</p>`
+	sanitized := text.SanitizeToSearchText(in)
+	suite.Equal("Another test @foss_satan #Hashtag it's some&text", sanitized)
+}
+
 func TestSanitizeTestSuite(t *testing.T) {
 	suite.Run(t, new(SanitizeTestSuite))
 }
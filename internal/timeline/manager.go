@@ -59,6 +59,10 @@ type Manager interface {
 	// GetIndexedLength returns the amount of items that have been indexed for the given account ID.
 	GetIndexedLength(ctx context.Context, timelineID string) int
 
+	// Size returns the amount of timelines currently held by the manager,
+	// and the total amount of items that have been indexed across them.
+	Size() (timelines int, items int)
+
 	// GetOldestIndexedID returns the id ID for the oldest item that we have indexed for the given timeline.
 	// Will be an empty string if nothing is (yet) indexed.
 	GetOldestIndexedID(ctx context.Context, timelineID string) string
@@ -186,6 +190,15 @@ func (m *manager) GetIndexedLength(ctx context.Context, timelineID string) int {
 	return m.getOrCreateTimeline(ctx, timelineID).Len()
 }
 
+func (m *manager) Size() (timelines int, items int) {
+	m.timelines.Range(func(_ any, v any) bool {
+		timelines++
+		items += v.(Timeline).Len()
+		return true // always continue range
+	})
+	return
+}
+
 func (m *manager) GetOldestIndexedID(ctx context.Context, timelineID string) string {
 	return m.getOrCreateTimeline(ctx, timelineID).OldestIndexedItemID()
 }
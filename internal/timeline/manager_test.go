@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ManagerTestSuite struct {
+	TimelineStandardTestSuite
+}
+
+func (suite *ManagerTestSuite) TestSize() {
+	var (
+		ctx        = context.Background()
+		account1ID = suite.testAccounts["local_account_1"].ID
+		account2ID = suite.testAccounts["local_account_2"].ID
+	)
+
+	// Nothing held yet.
+	timelines, items := suite.state.Timelines.Home.Size()
+	suite.Equal(0, timelines)
+	suite.Equal(0, items)
+
+	suite.fillTimeline(account1ID)
+	indexed := suite.state.Timelines.Home.GetIndexedLength(ctx, account1ID)
+	suite.NotZero(indexed)
+
+	timelines, items = suite.state.Timelines.Home.Size()
+	suite.Equal(1, timelines)
+	suite.Equal(indexed, items)
+
+	// Empty timeline counts,
+	// but adds no items.
+	suite.Zero(suite.state.Timelines.Home.GetIndexedLength(ctx, account2ID))
+
+	timelines, items = suite.state.Timelines.Home.Size()
+	suite.Equal(2, timelines)
+	suite.Equal(indexed, items)
+
+	// Pruned items shouldn't count.
+	if _, err := suite.state.Timelines.Home.Prune(ctx, account1ID, 5, 5); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	timelines, items = suite.state.Timelines.Home.Size()
+	suite.Equal(2, timelines)
+	suite.Equal(5, items)
+
+	// Other managers are separate.
+	timelines, items = suite.state.Timelines.List.Size()
+	suite.Equal(0, timelines)
+	suite.Equal(0, items)
+}
+
+func TestManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(ManagerTestSuite))
+}
@@ -21,11 +21,22 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
+	// ExportMinimal exports local accounts, their relationships with
+	// other accounts, users, domain blocks and instances to path.
 	ExportMinimal(ctx context.Context, path string) error
+
+	// ExportFull exports every entry of every
+	// database model to path, as JSON lines.
+	ExportFull(ctx context.Context, path string) error
+
+	// ExportStorage writes every file in the given
+	// storage into a tar archive at path.
+	ExportStorage(ctx context.Context, path string, storage *storage.Driver) error
 }
 
 type exporter struct {
@@ -0,0 +1,155 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+func (e *exporter) ExportFull(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("ExportFull: path empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportFull: couldn't export to %s: %s", path, err)
+	}
+
+	w := bufio.NewWriter(file)
+	encoder := json.NewEncoder(w)
+
+	for _, fm := range fullModels {
+		if err := e.exportFull(ctx, encoder, fm); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("ExportFull: error exporting %s entries: %s", fm.t, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportFull: error flushing file: %s", err)
+	}
+
+	return neatClose(file)
+}
+
+// exportFull streams all entries of the given
+// model type from the database to encoder.
+func (e *exporter) exportFull(ctx context.Context, encoder *json.Encoder, fm *fullModel) error {
+	var (
+		model = fm.new()
+		count int
+	)
+
+	if err := e.db.GetAllStream(ctx, model, func() error {
+		b, err := json.Marshal(model)
+		if err != nil {
+			return err
+		}
+
+		count++
+		return encoder.Encode(transmodel.FullEntry{
+			Type:  fm.t,
+			Model: b,
+		})
+	}); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "exported %d %s entries", count, fm.t)
+	return nil
+}
+
+func (e *exporter) ExportStorage(ctx context.Context, path string, driver *storage.Driver) error {
+	if path == "" {
+		return errors.New("ExportStorage: path empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportStorage: couldn't export to %s: %s", path, err)
+	}
+
+	// Gather keys before reading any files, as
+	// storage may be locked for the whole walk.
+	var keys []string
+	if err := driver.WalkKeys(ctx, func(key string) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportStorage: error walking storage: %s", err)
+	}
+
+	var (
+		tw  = tar.NewWriter(file)
+		now = time.Now()
+	)
+
+	for _, key := range keys {
+		if err := exportStored(ctx, tw, driver, key, now); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("ExportStorage: error exporting storage: %s", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportStorage: error closing archive: %s", err)
+	}
+
+	log.Infof(ctx, "exported %d stored files", len(keys))
+	return neatClose(file)
+}
+
+// exportStored writes the file stored at
+// key in driver to the tar writer tw.
+func exportStored(ctx context.Context, tw *tar.Writer, driver *storage.Driver, key string, modTime time.Time) error {
+	b, err := driver.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error getting %s: %w", key, err)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     key,
+		Mode:     0o644,
+		Size:     int64(len(b)),
+		ModTime:  modTime,
+	}); err != nil {
+		return fmt.Errorf("error writing header for %s: %w", key, err)
+	}
+
+	if _, err := tw.Write(b); err != nil {
+		return fmt.Errorf("error writing %s: %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"reflect"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// fullModel describes one type of
+// database model included in a full
+// export, and how to check its entries.
+type fullModel struct {
+	t    transmodel.Type
+	new  func() interface{}
+	refs []refField
+}
+
+// refField describes a model field holding
+// the ID of another entry in the export.
+type refField struct {
+	name  string
+	index []int
+	to    transmodel.Type
+}
+
+// fullModels lists every type of database model included in
+// a full export, in the order they're written out to file. As
+// far as possible, referenced entries are written before the
+// entries that reference them.
+//
+// Queued worker tasks are left out, since their IDs are
+// auto-incremented, and wouldn't play nicely with the
+// sequences of the database being imported into.
+var fullModels = []*fullModel{
+	newFullModel[gtsmodel.Instance](transmodel.TransInstance),
+	newFullModel[gtsmodel.DomainBlock](transmodel.TransDomainBlock),
+	newFullModel[gtsmodel.DomainAllow](transmodel.TransDomainAllow),
+	newFullModel[gtsmodel.DomainPermissionSubscription](transmodel.TransDomainPermissionSubscription),
+	newFullModel[gtsmodel.EmailDomainBlock](transmodel.TransEmailDomainBlock),
+	newFullModel[gtsmodel.HeaderFilterAllow](transmodel.TransHeaderFilterAllow),
+	newFullModel[gtsmodel.HeaderFilterBlock](transmodel.TransHeaderFilterBlock),
+	newFullModel[gtsmodel.Rule](transmodel.TransRule),
+	newFullModel[gtsmodel.Relay](transmodel.TransRelay),
+	newFullModel[gtsmodel.EmojiCategory](transmodel.TransEmojiCategory),
+	newFullModel[gtsmodel.Emoji](transmodel.TransEmoji),
+	newFullModel[gtsmodel.Account](transmodel.TransAccount),
+	newFullModel[gtsmodel.AccountToEmoji](transmodel.TransAccountToEmoji),
+	newFullModel[gtsmodel.AccountSettings](transmodel.TransAccountSettings),
+	newFullModel[gtsmodel.AccountStats](transmodel.TransAccountStats),
+	newFullModel[gtsmodel.AccountNote](transmodel.TransAccountNote),
+	newFullModel[gtsmodel.AccountArchive](transmodel.TransAccountArchive),
+	newFullModel[gtsmodel.ModerationPolicy](transmodel.TransModerationPolicy),
+	newFullModel[gtsmodel.Move](transmodel.TransMove),
+	newFullModel[gtsmodel.User](transmodel.TransUser),
+	newFullModel[gtsmodel.DeniedUser](transmodel.TransDeniedUser),
+	newFullModel[gtsmodel.Invite](transmodel.TransInvite),
+	newFullModel[gtsmodel.Application](transmodel.TransApplication),
+	newFullModel[gtsmodel.Client](transmodel.TransClient),
+	newFullModel[gtsmodel.Token](transmodel.TransToken),
+	newFullModel[gtsmodel.RouterSession](transmodel.TransRouterSession),
+	newFullModel[gtsmodel.VAPIDKeyPair](transmodel.TransVAPIDKeyPair),
+	newFullModel[gtsmodel.WebPushSubscription](transmodel.TransWebPushSubscription),
+	newFullModel[gtsmodel.AdminAction](transmodel.TransAdminAction),
+	newFullModel[gtsmodel.Block](transmodel.TransBlock),
+	newFullModel[gtsmodel.Follow](transmodel.TransFollow),
+	newFullModel[gtsmodel.FollowRequest](transmodel.TransFollowRequest),
+	newFullModel[gtsmodel.UserMute](transmodel.TransUserMute),
+	newFullModel[gtsmodel.List](transmodel.TransList),
+	newFullModel[gtsmodel.ListEntry](transmodel.TransListEntry),
+	newFullModel[gtsmodel.Tag](transmodel.TransTag),
+	newFullModel[gtsmodel.FeaturedTag](transmodel.TransFeaturedTag),
+	newFullModel[gtsmodel.FollowedTag](transmodel.TransFollowedTag),
+	newFullModel[gtsmodel.Thread](transmodel.TransThread),
+	newFullModel[gtsmodel.Status](transmodel.TransStatus),
+	newFullModel[gtsmodel.StatusToEmoji](transmodel.TransStatusToEmoji),
+	newFullModel[gtsmodel.StatusToTag](transmodel.TransStatusToTag),
+	newFullModel[gtsmodel.StatusEdit](transmodel.TransStatusEdit),
+	newFullModel[gtsmodel.ThreadToStatus](transmodel.TransThreadToStatus),
+	newFullModel[gtsmodel.ThreadMute](transmodel.TransThreadMute),
+	newFullModel[gtsmodel.MediaAttachment](transmodel.TransMediaAttachment),
+	newFullModel[gtsmodel.PreviewCard](transmodel.TransPreviewCard),
+	newFullModel[gtsmodel.Poll](transmodel.TransPoll),
+	newFullModel[gtsmodel.PollVote](transmodel.TransPollVote),
+	newFullModel[gtsmodel.Mention](transmodel.TransMention),
+	newFullModel[gtsmodel.StatusFave](transmodel.TransStatusFave),
+	newFullModel[gtsmodel.StatusBookmark](transmodel.TransStatusBookmark),
+	newFullModel[gtsmodel.ScheduledStatus](transmodel.TransScheduledStatus),
+	newFullModel[gtsmodel.Conversation](transmodel.TransConversation),
+	newFullModel[gtsmodel.ConversationToStatus](transmodel.TransConversationToStatus),
+	newFullModel[gtsmodel.Notification](transmodel.TransNotification),
+	newFullModel[gtsmodel.Report](transmodel.TransReport),
+	newFullModel[gtsmodel.Filter](transmodel.TransFilter),
+	newFullModel[gtsmodel.FilterKeyword](transmodel.TransFilterKeyword),
+	newFullModel[gtsmodel.FilterStatus](transmodel.TransFilterStatus),
+	newFullModel[gtsmodel.Marker](transmodel.TransMarker),
+	newFullModel[gtsmodel.Announcement](transmodel.TransAnnouncement),
+	newFullModel[gtsmodel.AnnouncementDismissal](transmodel.TransAnnouncementDismissal),
+	newFullModel[gtsmodel.AnnouncementReaction](transmodel.TransAnnouncementReaction),
+	newFullModel[gtsmodel.Tombstone](transmodel.TransTombstone),
+}
+
+// fullModelsByType allows lookup
+// of fullModels by their trans type.
+var fullModelsByType = func() map[transmodel.Type]*fullModel {
+	m := make(map[transmodel.Type]*fullModel, len(fullModels))
+	for _, fm := range fullModels {
+		m[fm.t] = fm
+	}
+	return m
+}()
+
+// refFieldNames maps the names of model fields which hold
+// the ID of another entry, to the type of that other entry.
+// Only fields that are expected to always be resolvable
+// are included here; looser references such as in-reply-to
+// IDs may legitimately point to entries long since deleted.
+var refFieldNames = map[string]transmodel.Type{
+	"AccountID":       transmodel.TransAccount,
+	"TargetAccountID": transmodel.TransAccount,
+	"OriginAccountID": transmodel.TransAccount,
+	"AnnouncementID":  transmodel.TransAnnouncement,
+	"CategoryID":      transmodel.TransEmojiCategory,
+	"ConversationID":  transmodel.TransConversation,
+	"EmojiID":         transmodel.TransEmoji,
+	"FilterID":        transmodel.TransFilter,
+	"FollowID":        transmodel.TransFollow,
+	"ListID":          transmodel.TransList,
+	"PollID":          transmodel.TransPoll,
+	"StatusID":        transmodel.TransStatus,
+	"TagID":           transmodel.TransTag,
+	"ThreadID":        transmodel.TransThread,
+}
+
+// newFullModel prepares a fullModel of type t for model
+// type T, looking up which of its fields reference others.
+func newFullModel[T any](t transmodel.Type) *fullModel {
+	fm := &fullModel{
+		t:   t,
+		new: func() interface{} { return new(T) },
+	}
+
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(fm.new()).Elem()) {
+		to, ok := refFieldNames[field.Name]
+		if !ok || field.Type.Kind() != reflect.String {
+			continue
+		}
+
+		fm.refs = append(fm.refs, refField{
+			name:  field.Name,
+			index: field.Index,
+			to:    to,
+		})
+	}
+
+	return fm
+}
+
+// modelID returns the ID of the given model,
+// if it has one, else an empty string.
+func modelID(model interface{}) string {
+	v := reflect.ValueOf(model).Elem()
+	if id := v.FieldByName("ID"); id.Kind() == reflect.String {
+		return id.String()
+	}
+	return ""
+}
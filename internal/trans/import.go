@@ -18,6 +18,7 @@
 package trans
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -40,25 +41,63 @@ func (i *importer) Import(ctx context.Context, path string) error {
 		return fmt.Errorf("Import: couldn't export to %s: %s", path, err)
 	}
 
+	// Before inserting anything, make sure entries
+	// from a full export only reference entries that
+	// are also included in the file being imported.
+	if err := i.validateFull(ctx, file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Import: error validating file: %s", err)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("Import: error rewinding file: %s", err)
+	}
+
 	decoder := json.NewDecoder(file)
-	decoder.UseNumber()
 
 	for {
-		entry := transmodel.Entry{}
-		err := decoder.Decode(&entry)
+		var line json.RawMessage
+		err := decoder.Decode(&line)
 		if err != nil {
 			if err == io.EOF {
 				log.Infof(ctx, "reached end of file")
 				return neatClose(file)
 			}
+			_ = file.Close()
 			return fmt.Errorf("Import: error decoding in readLoop: %s", err)
 		}
-		if err := i.inputEntry(ctx, entry); err != nil {
+		if err := i.inputLine(ctx, line); err != nil {
+			_ = file.Close()
 			return fmt.Errorf("Import: error inputting entry: %s", err)
 		}
 	}
 }
 
+// inputLine inputs one line of an export file, passing
+// it on to inputFull if it was written by a full export,
+// or to inputEntry if it was written by a minimal export.
+func (i *importer) inputLine(ctx context.Context, line json.RawMessage) error {
+	var full transmodel.FullEntry
+	if err := json.Unmarshal(line, &full); err != nil {
+		return fmt.Errorf("inputLine: error decoding line: %s", err)
+	}
+
+	if full.Model != nil {
+		return i.inputFull(ctx, full)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	entry := transmodel.Entry{}
+	if err := decoder.Decode(&entry); err != nil {
+		return fmt.Errorf("inputLine: error decoding entry: %s", err)
+	}
+
+	return i.inputEntry(ctx, entry)
+}
+
 func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error {
 	t, ok := entry[transmodel.TypeKey].(string)
 	if !ok {
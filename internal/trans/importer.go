@@ -21,11 +21,20 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Importer wraps functionality for importing entries from a file into the database.
 type Importer interface {
+	// Import imports entries from the file at path,
+	// written by either a minimal or a full export.
+	// Entries from a full export are first checked
+	// for references to entries missing from the file.
 	Import(ctx context.Context, path string) error
+
+	// ImportStorage writes every file in the tar
+	// archive at path into the given storage.
+	ImportStorage(ctx context.Context, path string, storage *storage.Driver) error
 }
 
 type importer struct {
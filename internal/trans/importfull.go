@@ -0,0 +1,212 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+)
+
+// ref is a reference from one entry
+// in a full export file to another.
+type ref struct {
+	from   transmodel.Type
+	fromID string
+	field  string
+	to     transmodel.Type
+	id     string
+}
+
+// validateFull reads through the whole of file, checking
+// that every reference held by entries from a full export
+// points to an entry that is also present in the file.
+// Entries from a minimal export aren't checked.
+func (i *importer) validateFull(ctx context.Context, file *os.File) error {
+	var (
+		decoder = json.NewDecoder(file)
+		ids     = make(map[transmodel.Type]map[string]struct{})
+		refs    []ref
+	)
+
+	for {
+		var full transmodel.FullEntry
+		err := decoder.Decode(&full)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("validateFull: error decoding line: %s", err)
+		}
+
+		if full.Model == nil {
+			// Minimal export entry.
+			continue
+		}
+
+		fm, ok := fullModelsByType[full.Type]
+		if !ok {
+			// Unknown types are
+			// skipped over on input.
+			continue
+		}
+
+		model := fm.new()
+		if err := json.Unmarshal(full.Model, model); err != nil {
+			return fmt.Errorf("validateFull: error decoding %s entry: %s", full.Type, err)
+		}
+
+		id := modelID(model)
+		if id != "" {
+			if ids[fm.t] == nil {
+				ids[fm.t] = make(map[string]struct{})
+			}
+			ids[fm.t][id] = struct{}{}
+		}
+
+		v := reflect.ValueOf(model).Elem()
+		for _, field := range fm.refs {
+			refID := v.FieldByIndex(field.index).String()
+			if refID == "" {
+				// Nothing
+				// referenced.
+				continue
+			}
+
+			refs = append(refs, ref{
+				from:   fm.t,
+				fromID: id,
+				field:  field.name,
+				to:     field.to,
+				id:     refID,
+			})
+		}
+	}
+
+	var missing int
+	for _, r := range refs {
+		if _, ok := ids[r.to][r.id]; ok {
+			continue
+		}
+
+		log.Errorf(ctx, "%s %s: %s %s referenced by %s not found",
+			r.from, r.fromID, r.to, r.id, r.field,
+		)
+		missing++
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("validateFull: %d references to entries not present in file", missing)
+	}
+
+	return nil
+}
+
+// inputFull inserts the database model
+// held by one entry from a full export.
+func (i *importer) inputFull(ctx context.Context, full transmodel.FullEntry) error {
+	fm, ok := fullModelsByType[full.Type]
+	if !ok {
+		log.Errorf(ctx, "didn't recognize transtype '%s', skipping it", full.Type)
+		return nil
+	}
+
+	model := fm.new()
+	if err := json.Unmarshal(full.Model, model); err != nil {
+		return fmt.Errorf("inputFull: error decoding %s entry: %s", full.Type, err)
+	}
+
+	if err := i.putInDB(ctx, model); err != nil {
+		return fmt.Errorf("inputFull: error adding %s to database: %s", full.Type, err)
+	}
+
+	log.Debugf(ctx, "added %s with id %s", full.Type, modelID(model))
+	return nil
+}
+
+func (i *importer) ImportStorage(ctx context.Context, path string, driver *storage.Driver) error {
+	if path == "" {
+		return errors.New("ImportStorage: path empty")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("ImportStorage: couldn't import from %s: %s", path, err)
+	}
+
+	var (
+		tr    = tar.NewReader(file)
+		count int
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("ImportStorage: error reading archive: %s", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			// Only regular
+			// files stored.
+			continue
+		}
+
+		if !validStorageKey(hdr.Name) {
+			_ = file.Close()
+			return fmt.Errorf("ImportStorage: invalid file name in archive: %s", hdr.Name)
+		}
+
+		if _, err := driver.PutStream(ctx, hdr.Name, tr); err != nil {
+			if storage.IsAlreadyExist(err) {
+				log.Warnf(ctx, "%s already in storage, skipping it", hdr.Name)
+				continue
+			}
+			_ = file.Close()
+			return fmt.Errorf("ImportStorage: error storing %s: %s", hdr.Name, err)
+		}
+
+		count++
+	}
+
+	log.Infof(ctx, "imported %d stored files", count)
+	return neatClose(file)
+}
+
+// validStorageKey returns whether name from a storage
+// archive is usable as a key, ie., it's relative and
+// doesn't try to climb out of the storage base path.
+func validStorageKey(name string) bool {
+	return name != "" &&
+		name == path.Clean(name) &&
+		!path.IsAbs(name) &&
+		name != ".." &&
+		!strings.HasPrefix(name, "../")
+}
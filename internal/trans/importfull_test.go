@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ImportFullTestSuite struct {
+	TransTestSuite
+}
+
+func (suite *ImportFullTestSuite) TestImportFullOK() {
+	ctx := context.Background()
+
+	storage := testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(storage, "../../testrig/media")
+	defer testrig.StandardStorageTeardown(storage)
+
+	// The standard test filters of local_account_2 are
+	// keyed by its user ID rather than its account ID,
+	// which import would rightly refuse, so fix them up.
+	accountID := suite.testAccounts["local_account_2"].ID
+	for id, model := range map[string]interface{}{
+		"01HNGFYJBED9FS0VWRVMY4TKXH": &gtsmodel.Filter{AccountID: accountID},
+		"01HNGG51HV2JT67XQ5MQ7RA1WE": &gtsmodel.FilterKeyword{AccountID: accountID},
+		"01HX9WXVEH05E78ABR81FZFFFY": &gtsmodel.FilterStatus{AccountID: accountID},
+	} {
+		err := suite.db.UpdateByID(ctx, model, id, "account_id")
+		suite.NoError(err)
+	}
+
+	var (
+		tempDir     = suite.T().TempDir()
+		filePath    = fmt.Sprintf("%s/%s", tempDir, uuid.NewString())
+		archivePath = fmt.Sprintf("%s/%s", tempDir, uuid.NewString())
+	)
+
+	// export db and storage
+	exporter := trans.NewExporter(suite.db)
+	err := exporter.ExportFull(ctx, filePath)
+	suite.NoError(err)
+	err = exporter.ExportStorage(ctx, archivePath, storage)
+	suite.NoError(err)
+
+	var state state.State
+	state.Caches.Init()
+
+	// create a new database with just the tables
+	// created, no entries, and new empty storage
+	newDB := testrig.NewTestDB(&state)
+	newStorage := testrig.NewInMemoryStorage()
+
+	importer := trans.NewImporter(newDB)
+	err = importer.Import(ctx, filePath)
+	suite.NoError(err)
+	err = importer.ImportStorage(ctx, archivePath, newStorage)
+	suite.NoError(err)
+
+	// every model should have been carried over
+	for _, models := range []struct {
+		before interface{}
+		after  interface{}
+	}{
+		{&[]*gtsmodel.Account{}, &[]*gtsmodel.Account{}},
+		{&[]*gtsmodel.Status{}, &[]*gtsmodel.Status{}},
+		{&[]*gtsmodel.MediaAttachment{}, &[]*gtsmodel.MediaAttachment{}},
+		{&[]*gtsmodel.Emoji{}, &[]*gtsmodel.Emoji{}},
+		{&[]*gtsmodel.List{}, &[]*gtsmodel.List{}},
+		{&[]*gtsmodel.Filter{}, &[]*gtsmodel.Filter{}},
+		{&[]*gtsmodel.Poll{}, &[]*gtsmodel.Poll{}},
+		{&[]*gtsmodel.Report{}, &[]*gtsmodel.Report{}},
+		{&[]*gtsmodel.Rule{}, &[]*gtsmodel.Rule{}},
+		{&[]*gtsmodel.Token{}, &[]*gtsmodel.Token{}},
+	} {
+		suite.NoError(suite.db.GetAll(ctx, models.before))
+		suite.NoError(newDB.GetAll(ctx, models.after))
+		suite.NotEmpty(models.after)
+
+		// compare as json, as the location
+		// of times may differ after import
+		before, err := json.Marshal(models.before)
+		suite.NoError(err)
+		after, err := json.Marshal(models.after)
+		suite.NoError(err)
+		suite.Equal(string(before), string(after))
+	}
+
+	// compare a status before + after
+	statusID := suite.testStatuses["admin_account_status_1"].ID
+	statusBefore, err := suite.db.GetStatusByID(ctx, statusID)
+	suite.NoError(err)
+	statusAfter, err := newDB.GetStatusByID(ctx, statusID)
+	suite.NoError(err)
+	suite.Equal(statusBefore.Content, statusAfter.Content)
+	suite.Equal(statusBefore.AttachmentIDs, statusAfter.AttachmentIDs)
+	suite.Equal(statusBefore.Account.PrivateKey, statusAfter.Account.PrivateKey)
+
+	// every stored file should have been carried over
+	var keys []string
+	suite.NoError(storage.WalkKeys(ctx, func(key string) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	suite.NotEmpty(keys)
+
+	for _, key := range keys {
+		b, err := newStorage.Get(ctx, key)
+		suite.NoError(err)
+
+		expect, err := storage.Get(ctx, key)
+		suite.NoError(err)
+		suite.Equal(expect, b)
+	}
+}
+
+func (suite *ImportFullTestSuite) TestImportFullMissingReference() {
+	ctx := context.Background()
+
+	// status whose author isn't in the file
+	const line = `{"type":"status","model":{"ID":"01J5QVB9VC76NPPRQ207GG4DRZ","AccountID":"01F8MH1H7YV1Z7D2C8K2730QBF","URI":"http://localhost:8080/users/the_mighty_zork/statuses/01J5QVB9VC76NPPRQ207GG4DRZ"}}`
+
+	filePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+	err := os.WriteFile(filePath, []byte(line+"\n"), 0o600)
+	suite.NoError(err)
+
+	var state state.State
+	state.Caches.Init()
+
+	newDB := testrig.NewTestDB(&state)
+
+	importer := trans.NewImporter(newDB)
+	err = importer.Import(ctx, filePath)
+	suite.ErrorContains(err, "1 references to entries not present in file")
+
+	// nothing should have been inserted
+	statuses := []*gtsmodel.Status{}
+	err = newDB.GetAll(ctx, &statuses)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func TestImportFullTestSuite(t *testing.T) {
+	suite.Run(t, &ImportFullTestSuite{})
+}
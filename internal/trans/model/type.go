@@ -17,24 +17,96 @@
 
 package trans
 
+import "encoding/json"
+
 // TypeKey should be set on a TransEntry to indicate the type of entry it is.
 const TypeKey = "type"
 
+// ModelKey is set on entries written by a full export, and
+// holds the database model of the entry as-is, see FullEntry.
+const ModelKey = "model"
+
 // Type describes the type of a trans entry, and how it should be read/serialized.
 type Type string
 
 // Type of the trans entry. Describes how it should be read from file.
 const (
-	TransAccount          Type = "account"
-	TransBlock            Type = "block"
-	TransDomainBlock      Type = "domainBlock"
-	TransEmailDomainBlock Type = "emailDomainBlock"
-	TransFollow           Type = "follow"
-	TransFollowRequest    Type = "followRequest"
-	TransInstance         Type = "instance"
-	TransUser             Type = "user"
+	TransAccount                      Type = "account"
+	TransAccountArchive               Type = "accountArchive"
+	TransAccountNote                  Type = "accountNote"
+	TransAccountSettings              Type = "accountSettings"
+	TransAccountStats                 Type = "accountStats"
+	TransAccountToEmoji               Type = "accountToEmoji"
+	TransAdminAction                  Type = "adminAction"
+	TransAnnouncement                 Type = "announcement"
+	TransAnnouncementDismissal        Type = "announcementDismissal"
+	TransAnnouncementReaction         Type = "announcementReaction"
+	TransApplication                  Type = "application"
+	TransBlock                        Type = "block"
+	TransClient                       Type = "client"
+	TransConversation                 Type = "conversation"
+	TransConversationToStatus         Type = "conversationToStatus"
+	TransDeniedUser                   Type = "deniedUser"
+	TransDomainAllow                  Type = "domainAllow"
+	TransDomainBlock                  Type = "domainBlock"
+	TransDomainPermissionSubscription Type = "domainPermissionSubscription"
+	TransEmailDomainBlock             Type = "emailDomainBlock"
+	TransEmoji                        Type = "emoji"
+	TransEmojiCategory                Type = "emojiCategory"
+	TransFeaturedTag                  Type = "featuredTag"
+	TransFilter                       Type = "filter"
+	TransFilterKeyword                Type = "filterKeyword"
+	TransFilterStatus                 Type = "filterStatus"
+	TransFollow                       Type = "follow"
+	TransFollowedTag                  Type = "followedTag"
+	TransFollowRequest                Type = "followRequest"
+	TransHeaderFilterAllow            Type = "headerFilterAllow"
+	TransHeaderFilterBlock            Type = "headerFilterBlock"
+	TransInstance                     Type = "instance"
+	TransInvite                       Type = "invite"
+	TransList                         Type = "list"
+	TransListEntry                    Type = "listEntry"
+	TransMarker                       Type = "marker"
+	TransMediaAttachment              Type = "mediaAttachment"
+	TransMention                      Type = "mention"
+	TransModerationPolicy             Type = "moderationPolicy"
+	TransMove                         Type = "move"
+	TransNotification                 Type = "notification"
+	TransPoll                         Type = "poll"
+	TransPollVote                     Type = "pollVote"
+	TransPreviewCard                  Type = "previewCard"
+	TransRelay                        Type = "relay"
+	TransReport                       Type = "report"
+	TransRouterSession                Type = "routerSession"
+	TransRule                         Type = "rule"
+	TransScheduledStatus              Type = "scheduledStatus"
+	TransStatus                       Type = "status"
+	TransStatusBookmark               Type = "statusBookmark"
+	TransStatusEdit                   Type = "statusEdit"
+	TransStatusFave                   Type = "statusFave"
+	TransStatusToEmoji                Type = "statusToEmoji"
+	TransStatusToTag                  Type = "statusToTag"
+	TransTag                          Type = "tag"
+	TransThread                       Type = "thread"
+	TransThreadMute                   Type = "threadMute"
+	TransThreadToStatus               Type = "threadToStatus"
+	TransToken                        Type = "token"
+	TransTombstone                    Type = "tombstone"
+	TransUser                         Type = "user"
+	TransUserMute                     Type = "userMute"
+	TransVAPIDKeyPair                 Type = "vapidKeyPair"
+	TransWebPushSubscription          Type = "webPushSubscription"
 )
 
 // Entry is used for deserializing trans entries into a rough interface so that
 // the TypeKey can be fetched, before continuing with full parsing.
 type Entry map[string]interface{}
+
+// FullEntry is one line of a full export. Rather than
+// using a trans model, the database model of the entry
+// is stored as-is under ModelKey, so that no information
+// is lost when it's imported again.
+type FullEntry struct {
+	Type  Type            `json:"type"`
+	Model json.RawMessage `json:"model"`
+}
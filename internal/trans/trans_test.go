@@ -29,6 +29,7 @@ type TransTestSuite struct {
 	suite.Suite
 	db           db.DB
 	testAccounts map[string]*gtsmodel.Account
+	testStatuses map[string]*gtsmodel.Status
 }
 
 func (suite *TransTestSuite) SetupTest() {
@@ -39,6 +40,7 @@ func (suite *TransTestSuite) SetupTest() {
 	testrig.InitTestLog()
 
 	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
 
 	suite.db = testrig.NewTestDB(&state)
 	testrig.StandardDBSetup(suite.db, nil)
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -80,7 +81,7 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 	}
 
 	// Push prepared request list to the delivery queue.
-	t.controller.state.Workers.Delivery.Queue.Push(reqs...)
+	t.controller.state.Workers.Delivery.Queue.Push(ctx, reqs...)
 
 	// Return combined err.
 	return errs.Combine()
@@ -111,7 +112,43 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 	}
 
 	// Push prepared request to the delivery queue.
-	t.controller.state.Workers.Delivery.Queue.Push(req)
+	t.controller.state.Workers.Delivery.Queue.Push(ctx, req)
+
+	return nil
+}
+
+func (t *transport) PrepareDelivery(dlv *delivery.Delivery) error {
+	if dlv.PubKeyID != t.pubKeyID {
+		return gtserror.Newf("delivery public key id %s does not match transport", dlv.PubKeyID)
+	}
+
+	var data []byte
+
+	if dlv.Request.GetBody != nil {
+		// Read request body
+		// for POST signing.
+		body, err := dlv.Request.GetBody()
+		if err != nil {
+			return gtserror.Newf("error getting request body: %w", err)
+		}
+		data, err = io.ReadAll(body)
+		_ = body.Close()
+		if err != nil {
+			return gtserror.Newf("error reading request body: %w", err)
+		}
+	}
+
+	// Prepare POST signer.
+	sign := t.signPOST(data)
+
+	// Update request context with signing details.
+	ctx := dlv.Request.Context()
+	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
+	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+
+	// Rewrap request with updated context.
+	r := dlv.Request.Request.WithContext(ctx)
+	dlv.Request = httpclient.WrapRequest(r)
 
 	return nil
 }
@@ -162,6 +199,7 @@ func (t *transport) prepare(
 	}
 
 	return &delivery.Delivery{
+		PubKeyID: t.pubKeyID,
 		ActorID:  actorID,
 		ObjectID: objectID,
 		TargetID: targetID,
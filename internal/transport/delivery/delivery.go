@@ -143,6 +143,11 @@ func (dlv *Delivery) Deserialize(data []byte) error {
 		return err
 	}
 
+	if idlv.Header != nil {
+		// Copy over request headers.
+		r.Header = idlv.Header
+	}
+
 	// Wrap request in httpclient type.
 	dlv.Request = httpclient.WrapRequest(r)
 
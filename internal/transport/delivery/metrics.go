@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// deliveryTotal counts delivery attempts
+	// by target domain and attempt result.
+	deliveryTotal metric.Int64Counter
+
+	// deliveryDuration records the time taken by
+	// each delivery attempt. This isn't split by
+	// domain, to keep the number of series bounded.
+	deliveryDuration metric.Float64Histogram
+)
+
+func init() {
+	meter := otel.Meter("github.com/superseriousbusiness/gotosocial/internal/transport/delivery")
+
+	var err error
+	deliveryTotal, err = meter.Int64Counter(
+		"gotosocial.delivery.total",
+		metric.WithDescription("Number of outgoing delivery attempts, by domain and result (success, failure, retry)"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	deliveryDuration, err = meter.Float64Histogram(
+		"gotosocial.delivery.duration",
+		metric.WithDescription("Time taken per outgoing delivery attempt"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// recordDelivery records the result of a delivery attempt to domain
+// that began at start, where result is one of success, failure or retry.
+func recordDelivery(ctx context.Context, domain string, start time.Time, result string) {
+	deliveryTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("domain", domain),
+		attribute.String("result", result),
+	))
+	deliveryDuration.Record(ctx, time.Since(start).Seconds())
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRecordDelivery(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	ctx := context.Background()
+	start := time.Now()
+	recordDelivery(ctx, "example.org", start, "success")
+	recordDelivery(ctx, "example.org", start, "success")
+	recordDelivery(ctx, "example.org", start, "retry")
+	recordDelivery(ctx, "example.com", start, "failure")
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	// Attempts should be counted
+	// by both domain and result.
+	totals := make(map[attribute.Set]int64)
+	var durations []metricdata.HistogramDataPoint[float64]
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch m.Name {
+			case "gotosocial.delivery.total":
+				for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					totals[dp.Attributes] = dp.Value
+				}
+			case "gotosocial.delivery.duration":
+				durations = m.Data.(metricdata.Histogram[float64]).DataPoints
+			}
+		}
+	}
+
+	for _, expect := range []struct {
+		domain string
+		result string
+		count  int64
+	}{
+		{"example.org", "success", 2},
+		{"example.org", "retry", 1},
+		{"example.org", "failure", 0},
+		{"example.com", "failure", 1},
+	} {
+		attrs := attribute.NewSet(
+			attribute.String("domain", expect.domain),
+			attribute.String("result", expect.result),
+		)
+		if count := totals[attrs]; count != expect.count {
+			t.Errorf("expected %d %s deliveries to %s, got %d", expect.count, expect.result, expect.domain, count)
+		}
+	}
+
+	// Durations should be recorded
+	// for all attempts, as one series.
+	if len(durations) != 1 {
+		t.Fatalf("expected 1 duration series, got %d", len(durations))
+	}
+	if durations[0].Attributes.Len() != 0 {
+		t.Errorf("expected no duration attributes, got %v", durations[0].Attributes.ToSlice())
+	}
+	if durations[0].Count != 4 {
+		t.Errorf("expected 4 durations recorded, got %d", durations[0].Count)
+	}
+}
@@ -194,6 +194,7 @@ loop:
 		}
 
 		// Attempt delivery of AP request.
+		start := time.Now()
 		rsp, retry, err := w.Client.DoOnce(
 			&dlv.Request,
 		)
@@ -201,16 +202,36 @@ loop:
 		if err == nil {
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+
+			// Record successful delivery.
+			recordDelivery(ctx, dlv.Request.URL.Host,
+				start, "success")
+
+			// Mark delivery done.
+			w.Queue.Done(ctx, dlv, nil)
 			continue loop
 		}
 
+		if ctx.Err() != nil {
+			// Worker was stopped mid-delivery,
+			// leave in any store for replay.
+			return true
+		}
+
 		if !retry {
 			// Drop deliveries when no
 			// retry requested, or they
 			// reached max (either).
+			recordDelivery(ctx, dlv.Request.URL.Host,
+				start, "failure")
+			w.Queue.Done(ctx, dlv, err)
 			continue loop
 		}
 
+		// Record delivery to be retried.
+		recordDelivery(ctx, dlv.Request.URL.Host,
+			start, "retry")
+
 		// Determine next delivery attempt.
 		backoff := dlv.Request.BackOff()
 		dlv.next = time.Now().Add(backoff)
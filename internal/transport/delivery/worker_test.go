@@ -18,6 +18,7 @@
 package delivery_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math/rand"
@@ -97,7 +98,7 @@ func test(
 		dlv.Request = r
 
 		// Enqueue delivery!
-		queue.Push(dlv)
+		queue.Push(context.Background(), dlv)
 		expect <- test
 
 		// Wait for errors from handler.
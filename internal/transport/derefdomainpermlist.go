@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DereferenceDomainPermissionsResp wraps the
+// response to a domain permission list request.
+type DereferenceDomainPermissionsResp struct {
+	// Body of the response, if the list has changed.
+	// Caller is responsible for closing it.
+	Body io.ReadCloser
+
+	// ETag header value returned
+	// from the remote, if any.
+	ETag string
+
+	// Last-Modified header value
+	// returned from the remote,
+	// if set and parseable.
+	LastModified time.Time
+
+	// Unmodified is true if the remote
+	// indicated that the list hasn't
+	// changed since the last fetch
+	// (ie., 304 Not Modified).
+	Unmodified bool
+}
+
+func (t *transport) DereferenceDomainPermissions(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	skipCache bool,
+) (*DereferenceDomainPermissionsResp, error) {
+	// Prepare HTTP request to the list URI.
+	req, err := http.NewRequestWithContext(ctx, "GET", permSub.URI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// We only want the content type we're expecting.
+	req.Header.Add("Accept", string(permSub.ContentType))
+
+	// Make this a conditional request if we have
+	// cache details from the last successful fetch.
+	if !skipCache {
+		if permSub.ETag != "" {
+			req.Header.Add("If-None-Match", permSub.ETag)
+		}
+
+		if !permSub.LastModified.IsZero() {
+			req.Header.Add("If-Modified-Since", permSub.LastModified.UTC().Format(http.TimeFormat))
+		}
+	}
+
+	// Perform the HTTP request
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// List hasn't changed since last fetch.
+	if rsp.StatusCode == http.StatusNotModified {
+		_ = rsp.Body.Close()
+		return &DereferenceDomainPermissionsResp{
+			ETag:         permSub.ETag,
+			LastModified: permSub.LastModified,
+			Unmodified:   true,
+		}, nil
+	}
+
+	// Check for an expected status code
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	derefResp := &DereferenceDomainPermissionsResp{
+		Body: rsp.Body,
+		ETag: rsp.Header.Get("ETag"),
+	}
+
+	// Parse Last-Modified header, if set.
+	if lastModified := rsp.Header.Get("Last-Modified"); lastModified != "" {
+		derefResp.LastModified, _ = http.ParseTime(lastModified)
+	}
+
+	return derefResp, nil
+}
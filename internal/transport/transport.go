@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 	"github.com/superseriousbusiness/httpsig"
 )
 
@@ -56,6 +57,11 @@ type Transport interface {
 	// BatchDeliver sends an ActivityStreams object to multiple recipients.
 	BatchDeliver(ctx context.Context, obj map[string]interface{}, recipients []*url.URL) error
 
+	// PrepareDelivery prepares a delivery restored from its serialized form for
+	// queueing, setting request signing details (dropped on serialization) to
+	// that of this transport. It should have been created by this transport.
+	PrepareDelivery(dlv *delivery.Delivery) error
+
 	/*
 		GET functions
 	*/
@@ -70,6 +76,15 @@ type Transport interface {
 	// DereferenceMedia fetches the given media attachment IRI, returning the reader and filesize.
 	DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error)
 
+	// DereferenceDomainPermissions dereferences the list of domain permissions
+	// at the URI of the given subscription. Unless skipCache is true, the request
+	// will be made conditional on the subscription's stored ETag and Last-Modified.
+	DereferenceDomainPermissions(ctx context.Context, permSub *gtsmodel.DomainPermissionSubscription, skipCache bool) (*DereferenceDomainPermissionsResp, error)
+
+	// DereferenceProfileLink fetches the HTML page linked to in an account's
+	// profile field, so that it can be checked for rel="me" links back to it.
+	DereferenceProfileLink(ctx context.Context, iri *url.URL) (io.ReadCloser, error)
+
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
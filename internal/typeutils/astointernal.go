@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"strings"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -331,6 +332,14 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "unusable published property on %s", uri)
 	}
 
+	// status.EditedAt
+	//
+	// Extract updated time for the status,
+	// zero-time indicates it was never edited.
+	if upd := ap.GetUpdated(statusable); !upd.IsZero() {
+		status.EditedAt = upd
+	}
+
 	// status.AccountURI
 	// status.AccountID
 	// status.Account
@@ -395,14 +404,29 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 
 	// Advanced visibility toggles for this status.
 	//
-	// TODO: a lot of work to be done here -- a new type
-	// needs to be created for this in go-fed/activity.
-	// Until this is implemented, assume all true.
+	// These are superseded by the interaction
+	// policy below, so just assume all true.
 	status.Federated = util.Ptr(true)
 	status.Boostable = util.Ptr(true)
 	status.Replyable = util.Ptr(true)
 	status.Likeable = util.Ptr(true)
 
+	// status.InteractionPolicy
+	//
+	// Resolve policy IRIs relative to the status
+	// author and the accounts it mentions. If no
+	// policy is set, the default for the status
+	// visibility applies, so leave this nil.
+	mentionURIs := make([]string, 0, len(status.Mentions))
+	for _, mention := range status.Mentions {
+		mentionURIs = append(mentionURIs, mention.TargetAccountURI)
+	}
+	status.InteractionPolicy = ap.ExtractInteractionPolicy(
+		statusable,
+		status.Account,
+		mentionURIs,
+	)
+
 	// status.Sensitive
 	sensitive := ap.ExtractSensitive(statusable)
 	status.Sensitive = &sensitive
@@ -410,9 +434,52 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
 
+	// Apply any moderation policies
+	// covering the status author.
+	if err := c.applyModerationPolicies(ctx, &status); err != nil {
+		return nil, err
+	}
+
 	return &status, nil
 }
 
+// applyModerationPolicies applies any domain or account
+// moderation policies covering the author of the given
+// status, as well as enforcing the author's SensitizedAt.
+func (c *Converter) applyModerationPolicies(ctx context.Context, status *gtsmodel.Status) error {
+	policies, err := c.state.DB.GetModerationPoliciesForAccount(ctx, status.Account)
+	if err != nil {
+		return gtserror.Newf("error getting moderation policies for %s: %w", status.AccountURI, err)
+	}
+
+	if policies.ForceSensitive() || !status.Account.SensitizedAt.IsZero() {
+		status.Sensitive = util.Ptr(true)
+	}
+
+	for _, cw := range policies.ContentWarnings() {
+		// Append the policy content warning
+		// to any existing one, unless the
+		// status already contains it.
+		switch {
+		case status.ContentWarning == "":
+			status.ContentWarning = cw
+		case !strings.Contains(status.ContentWarning, cw):
+			status.ContentWarning += "; " + cw
+		}
+
+		// Hide status content behind the CW.
+		status.Sensitive = util.Ptr(true)
+	}
+
+	if policies.RejectMedia() {
+		// Drop attachments so that
+		// they're never dereferenced.
+		status.Attachments = nil
+	}
+
+	return nil
+}
+
 // ASFollowToFollowRequest converts a remote activitystreams `follow` representation into gts model follow request.
 func (c *Converter) ASFollowToFollowRequest(ctx context.Context, followable ap.Followable) (*gtsmodel.FollowRequest, error) {
 	uriObj := ap.GetJSONLDId(followable)
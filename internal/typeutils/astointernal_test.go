@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
@@ -31,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 type ASToInternalTestSuite struct {
@@ -138,6 +140,64 @@ func (suite *ASToInternalTestSuite) TestParsePublicStatusNoURL() {
 	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/statuses/108138763199405167", status.URL)
 }
 
+func (suite *ASToInternalTestSuite) TestParsePublicStatusModerationPolicy() {
+	ctx := context.Background()
+
+	// Put a policy on the status author's domain.
+	if err := suite.db.PutModerationPolicy(ctx, &gtsmodel.ModerationPolicy{
+		ID:                   "01J5B9XJ5SNWFN1E5FG1E1QKRF",
+		Domain:               "fossbros-anonymous.io",
+		ForceSensitive:       util.Ptr(false),
+		ContentWarning:       "fossbro nonsense",
+		RejectMedia:          util.Ptr(true),
+		StripPublicTimelines: util.Ptr(false),
+		CreatedByAccountID:   suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t := suite.jsonToType(publicStatusActivityJson)
+	rep, ok := t.(ap.Statusable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(ctx, rep)
+	suite.NoError(err)
+
+	// Policy CW should be appended to the existing one.
+	suite.Equal("reading: Punishment and Reward in the Corporate University; fossbro nonsense", status.ContentWarning)
+	suite.True(*status.Sensitive)
+	suite.Empty(status.Attachments)
+}
+
+func (suite *ASToInternalTestSuite) TestParseReplySensitizedAccount() {
+	ctx := context.Background()
+
+	// Mark the status author as sensitized.
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["remote_account_1"]
+	account.SensitizedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, account, "sensitized_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t := suite.jsonToType(statusWithMentionsActivityJson)
+	create, ok := t.(vocab.ActivityStreamsCreate)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	statusable := create.GetActivityStreamsObject().Begin().GetActivityStreamsNote()
+	status, err := suite.typeconverter.ASStatusToStatus(ctx, statusable)
+	suite.NoError(err)
+
+	// Status isn't marked sensitive by
+	// the author, but should be forced.
+	suite.Empty(status.ContentWarning)
+	suite.True(*status.Sensitive)
+}
+
 func (suite *ASToInternalTestSuite) TestParseGargron() {
 	t := suite.jsonToType(gargronAsActivityJson)
 	rep, ok := t.(ap.Accountable)
@@ -57,3 +57,28 @@ func APIFilterActionToFilterAction(m apimodel.FilterAction) gtsmodel.FilterActio
 	}
 	return gtsmodel.FilterActionNone
 }
+
+// APIInteractionPolicyToInteractionPolicy converts an api interaction
+// policy into its gts equivalent. The api policy should already have
+// been checked by the caller using validate.InteractionPolicy().
+func APIInteractionPolicyToInteractionPolicy(p *apimodel.InteractionPolicy) *gtsmodel.InteractionPolicy {
+	return &gtsmodel.InteractionPolicy{
+		CanLike:     apiPolicyRulesToPolicyRules(p.CanFavourite),
+		CanReply:    apiPolicyRulesToPolicyRules(p.CanReply),
+		CanAnnounce: apiPolicyRulesToPolicyRules(p.CanReblog),
+	}
+}
+
+func apiPolicyRulesToPolicyRules(r apimodel.PolicyRules) gtsmodel.PolicyRules {
+	var rules gtsmodel.PolicyRules
+
+	for _, value := range r.Always {
+		rules.Always = append(rules.Always, gtsmodel.PolicyValue(value))
+	}
+
+	for _, value := range r.WithApproval {
+		rules.WithApproval = append(rules.WithApproval, gtsmodel.PolicyValue(value))
+	}
+
+	return rules
+}
@@ -118,7 +118,15 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	person.SetTootFeatured(featuredProp)
 
 	// featuredTags
-	// NOT IMPLEMENTED
+	// Featured hashtags, not part of the
+	// vocab so set as an unknown property.
+	if a.IsLocal() {
+		featuredTagsURI, err := url.Parse(uris.GenerateURIsForAccount(a.Username).FeaturedTagsCollectionURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetFeaturedTags(person, featuredTagsURI)
+	}
 
 	// preferredUsername
 	// Used for Webfinger lookup. Must be unique on the domain, and must correspond to a Webfinger acct: URI.
@@ -488,6 +496,11 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		ap.SetUpdated(status, s.EditedAt)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -672,9 +685,133 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
+	// interactionPolicy
+	policy, err := c.InteractionPolicyToASInteractionPolicy(ctx,
+		s.GetInteractionPolicy(),
+		s,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting interaction policy: %w", err)
+	}
+
+	policyProp := streams.NewGoToSocialInteractionPolicyProperty()
+	policyProp.AppendGoToSocialInteractionPolicy(policy)
+	status.SetGoToSocialInteractionPolicy(policyProp)
+
 	return status, nil
 }
 
+// InteractionPolicyToASInteractionPolicy converts the given interaction
+// policy of the given status into an AS InteractionPolicy, expressing each
+// policy value as the relevant IRI(s) relative to the status author and
+// the accounts mentioned in the status.
+func (c *Converter) InteractionPolicyToASInteractionPolicy(
+	ctx context.Context,
+	policy *gtsmodel.InteractionPolicy,
+	s *gtsmodel.Status,
+) (vocab.GoToSocialInteractionPolicy, error) {
+	asPolicy := streams.NewGoToSocialInteractionPolicy()
+
+	// canLike
+	canLike := streams.NewGoToSocialCanLike()
+	if err := c.policyRulesToAS(ctx, policy.CanLike, s, canLike); err != nil {
+		return nil, gtserror.Newf("error converting canLike: %w", err)
+	}
+	canLikeProp := streams.NewGoToSocialCanLikeProperty()
+	canLikeProp.AppendGoToSocialCanLike(canLike)
+	asPolicy.SetGoToSocialCanLike(canLikeProp)
+
+	// canReply
+	canReply := streams.NewGoToSocialCanReply()
+	if err := c.policyRulesToAS(ctx, policy.CanReply, s, canReply); err != nil {
+		return nil, gtserror.Newf("error converting canReply: %w", err)
+	}
+	canReplyProp := streams.NewGoToSocialCanReplyProperty()
+	canReplyProp.AppendGoToSocialCanReply(canReply)
+	asPolicy.SetGoToSocialCanReply(canReplyProp)
+
+	// canAnnounce
+	canAnnounce := streams.NewGoToSocialCanAnnounce()
+	if err := c.policyRulesToAS(ctx, policy.CanAnnounce, s, canAnnounce); err != nil {
+		return nil, gtserror.Newf("error converting canAnnounce: %w", err)
+	}
+	canAnnounceProp := streams.NewGoToSocialCanAnnounceProperty()
+	canAnnounceProp.AppendGoToSocialCanAnnounce(canAnnounce)
+	asPolicy.SetGoToSocialCanAnnounce(canAnnounceProp)
+
+	return asPolicy, nil
+}
+
+func (c *Converter) policyRulesToAS(
+	ctx context.Context,
+	rules gtsmodel.PolicyRules,
+	s *gtsmodel.Status,
+	dst ap.WithPolicyRules,
+) error {
+	alwaysProp := streams.NewGoToSocialAlwaysProperty()
+	iris, err := c.policyValuesToIRIs(ctx, rules.Always, s)
+	if err != nil {
+		return err
+	}
+	for _, iri := range iris {
+		alwaysProp.AppendIRI(iri)
+	}
+	dst.SetGoToSocialAlways(alwaysProp)
+
+	approvalProp := streams.NewGoToSocialApprovalRequiredProperty()
+	iris, err = c.policyValuesToIRIs(ctx, rules.WithApproval, s)
+	if err != nil {
+		return err
+	}
+	for _, iri := range iris {
+		approvalProp.AppendIRI(iri)
+	}
+	dst.SetGoToSocialApprovalRequired(approvalProp)
+
+	return nil
+}
+
+// policyValuesToIRIs resolves the given policy values
+// to IRIs relative to the given (populated) status.
+func (c *Converter) policyValuesToIRIs(
+	ctx context.Context,
+	values gtsmodel.PolicyValues,
+	s *gtsmodel.Status,
+) ([]*url.URL, error) {
+	iris := make([]*url.URL, 0, len(values))
+
+	for _, value := range values {
+		var uris []string
+
+		switch value {
+		case gtsmodel.PolicyValuePublic:
+			uris = []string{pub.PublicActivityPubIRI}
+		case gtsmodel.PolicyValueFollowers:
+			uris = []string{s.Account.FollowersURI}
+		case gtsmodel.PolicyValueAuthor:
+			uris = []string{s.Account.URI}
+		case gtsmodel.PolicyValueMentioned:
+			for _, m := range s.Mentions {
+				if m.TargetAccount != nil {
+					uris = append(uris, m.TargetAccount.URI)
+				}
+			}
+		default:
+			log.Warnf(ctx, "unrecognized policy value %s on status %s", value, s.URI)
+		}
+
+		for _, uri := range uris {
+			iri, err := url.Parse(uri)
+			if err != nil {
+				return nil, gtserror.Newf("error parsing uri %s: %w", uri, err)
+			}
+			iris = append(iris, iri)
+		}
+	}
+
+	return iris, nil
+}
+
 func (c *Converter) addPollToAS(ctx context.Context, poll *gtsmodel.Poll, dst ap.Pollable) error {
 	var optionsProp interface {
 		// the minimum interface for appending AS Notes
@@ -932,6 +1069,51 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts a gts model relay into the activity streams
+// Follow sent by the given instance account to subscribe to the relay.
+//
+// As with Mastodon, the object of the Follow is the Public collection.
+func (c *Converter) RelayToASFollow(
+	ctx context.Context,
+	r *gtsmodel.Relay,
+	instanceAcct *gtsmodel.Account,
+) (vocab.ActivityStreamsFollow, error) {
+	actorIRI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followIRI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing follow uri: %w", err)
+	}
+
+	publicURI, err := url.Parse(pub.PublicActivityPubIRI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing url %s: %w", pub.PublicActivityPubIRI, err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+
+	// Set the id.
+	ap.SetJSONLDId(follow, followIRI)
+
+	// Set the actor.
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorIRI)
+	follow.SetActivityStreamsActor(actorProp)
+
+	// Set the object.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(publicURI)
+	follow.SetActivityStreamsObject(objectProp)
+
+	// Address to the public.
+	ap.AppendTo(follow, publicURI)
+
+	return follow, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1590,6 +1772,36 @@ func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 	return collection, nil
 }
 
+// FeaturedTagsToASCollection converts a slice of featured tags into a collection
+// of hashtags, suitable for serializing and serving via the activitypub API.
+func (c *Converter) FeaturedTagsToASCollection(ctx context.Context, collectionID string, featuredTags []*gtsmodel.FeaturedTag) (vocab.ActivityStreamsCollection, error) {
+	collection := streams.NewActivityStreamsCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	collectionIDURI, err := url.Parse(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", collectionID)
+	}
+	collectionIDProp.SetIRI(collectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsItemsProperty()
+	for _, featuredTag := range featuredTags {
+		tag, err := c.TagToAS(ctx, featuredTag.Tag)
+		if err != nil {
+			return nil, gtserror.Newf("error converting tag %s: %w", featuredTag.TagID, err)
+		}
+		itemsProp.AppendTootHashtag(tag)
+	}
+	collection.SetActivityStreamsItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(featuredTags))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 // ReportToASFlag converts a gts model report into an activitystreams FLAG, suitable for federation.
 func (c *Converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
 	flag := streams.NewActivityStreamsFlag()
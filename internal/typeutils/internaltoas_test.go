@@ -55,6 +55,7 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 
 	suite.Equal(`: true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -118,6 +119,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
   ],
   "discoverable": false,
   "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
   "followers": "http://localhost:8080/users/1happyturtle/followers",
   "following": "http://localhost:8080/users/1happyturtle/following",
   "id": "http://localhost:8080/users/1happyturtle",
@@ -173,6 +175,7 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
   ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -232,6 +235,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithOneField() {
   ],
   "discoverable": false,
   "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
   "followers": "http://localhost:8080/users/1happyturtle/followers",
   "following": "http://localhost:8080/users/1happyturtle/following",
   "id": "http://localhost:8080/users/1happyturtle",
@@ -272,6 +276,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
 
 	suite.Equal(`: true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -336,6 +341,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
     "sharedInbox": "http://localhost:8080/sharedInbox"
   },
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "icon": {
@@ -379,9 +385,11 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
 	bytes, err := json.MarshalIndent(ser, "", "  ")
 	suite.NoError(err)
 
-	suite.Equal(`{
-  "@context": "https://www.w3.org/ns/activitystreams",
-  "attachment": [],
+	// we can't be sure in what order the two context entries --
+	// https://gotosocial.org/ns, https://www.w3.org/ns/activitystreams --
+	// will appear, so trim them out of the string for consistency
+	trimmed := strings.SplitAfter(string(bytes), `"attachment":`)[1]
+	suite.Equal(` [],
   "attributedTo": "http://localhost:8080/users/the_mighty_zork",
   "cc": "http://localhost:8080/users/the_mighty_zork/followers",
   "content": "hello everyone!",
@@ -389,6 +397,20 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
     "en": "hello everyone!"
   },
   "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "interactionPolicy": {
+    "canAnnounce": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canLike": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canReply": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    }
+  },
   "published": "2021-10-20T12:40:37+02:00",
   "replies": {
     "first": {
@@ -406,7 +428,7 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
   "to": "https://www.w3.org/ns/activitystreams#Public",
   "type": "Note",
   "url": "http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
-}`, string(bytes))
+}`, trimmed)
 }
 
 func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
@@ -443,6 +465,20 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
     "en": "hello world! #welcome ! first post on the instance :rainbow: !"
   },
   "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "interactionPolicy": {
+    "canAnnounce": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canLike": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canReply": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    }
+  },
   "published": "2021-10-20T11:36:45Z",
   "replies": {
     "first": {
@@ -515,6 +551,20 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
     "en": "hello world! #welcome ! first post on the instance :rainbow: !"
   },
   "id": "http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R",
+  "interactionPolicy": {
+    "canAnnounce": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canLike": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canReply": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    }
+  },
   "published": "2021-10-20T11:36:45Z",
   "replies": {
     "first": {
@@ -568,9 +618,11 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
 	bytes, err := json.MarshalIndent(ser, "", "  ")
 	suite.NoError(err)
 
-	suite.Equal(`{
-  "@context": "https://www.w3.org/ns/activitystreams",
-  "attachment": [],
+	// we can't be sure in what order the two context entries --
+	// https://gotosocial.org/ns, https://www.w3.org/ns/activitystreams --
+	// will appear, so trim them out of the string for consistency
+	trimmed := strings.SplitAfter(string(bytes), `"attachment":`)[1]
+	suite.Equal(` [],
   "attributedTo": "http://localhost:8080/users/admin",
   "cc": [
     "http://localhost:8080/users/admin/followers",
@@ -582,6 +634,20 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
   },
   "id": "http://localhost:8080/users/admin/statuses/01FF25D5Q0DH7CHD57CTRS6WK0",
   "inReplyTo": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+  "interactionPolicy": {
+    "canAnnounce": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canLike": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    },
+    "canReply": {
+      "always": "https://www.w3.org/ns/activitystreams#Public",
+      "approvalRequired": []
+    }
+  },
   "published": "2021-11-20T13:32:16Z",
   "replies": {
     "first": {
@@ -603,7 +669,7 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
   "to": "https://www.w3.org/ns/activitystreams#Public",
   "type": "Note",
   "url": "http://localhost:8080/@admin/statuses/01FF25D5Q0DH7CHD57CTRS6WK0"
-}`, string(bytes))
+}`, trimmed)
 }
 
 func (suite *InternalToASTestSuite) TestStatusToASDeletePublicReply() {
@@ -19,6 +19,7 @@ package typeutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -32,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/language"
@@ -99,6 +101,10 @@ func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimod
 		user.ResetPasswordSentAt = util.FormatISO8601(u.ResetPasswordSentAt)
 	}
 
+	if !u.TwoFactorEnabledAt.IsZero() {
+		user.TwoFactorEnabledAt = util.FormatISO8601(u.TwoFactorEnabledAt)
+	}
+
 	return user
 }
 
@@ -466,6 +472,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = apimodel.AccountRole{Name: apimodel.AccountRoleUser} // assume user by default
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -514,6 +521,19 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// User signed up with an invite,
+			// look up who created the invite.
+			invite, err := c.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s for account id %s: %w", user.InviteID, a.ID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -539,19 +559,31 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
 func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Application) (*apimodel.Application, error) {
-	return &apimodel.Application{
+	apiApp := &apimodel.Application{
 		ID:           a.ID,
 		Name:         a.Name,
 		Website:      a.Website,
 		RedirectURI:  a.RedirectURI,
 		ClientID:     a.ClientID,
 		ClientSecret: a.ClientSecret,
-	}, nil
+	}
+
+	// Apps use the instance's VAPID
+	// key to create push subscriptions.
+	vapidKeyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting vapid key pair: %w", err)
+	}
+	if vapidKeyPair != nil {
+		apiApp.VapidKey = vapidKeyPair.Public
+	}
+
+	return apiApp, nil
 }
 
 // AppToAPIAppPublic takes a db model application as a param, and returns a populated apitype application, or an error
@@ -564,6 +596,38 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gts model token into an api token info
+// for serving to the user the token belongs to. The token's secret values
+// (access, refresh, code etc) are never included in the returned model.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, t *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	app, err := c.state.DB.GetApplicationByClientID(ctx, t.ClientID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting application with client id %s: %w", t.ClientID, err)
+	}
+
+	apiApp, err := c.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, gtserror.Newf("error converting application to api: %w", err)
+	}
+
+	// Include app ID so the app's tokens
+	// can be invalidated all in one go.
+	apiApp.ID = app.ID
+
+	var lastUsed string
+	if !t.LastUsed.IsZero() {
+		lastUsed = util.FormatISO8601(t.LastUsed)
+	}
+
+	return &apimodel.TokenInfo{
+		ID:          t.ID,
+		CreatedAt:   util.FormatISO8601(t.CreatedAt),
+		LastUsed:    lastUsed,
+		Scope:       t.Scope,
+		Application: apiApp,
+	}, nil
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	apiAttachment := apimodel.Attachment{
@@ -637,6 +701,15 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.M
 			apiAttachment.Meta.Original.FrameRate = fr + "/1"
 		}
 
+		if i := a.FileMeta.Original.Bitrate; i != nil {
+			apiAttachment.Meta.Original.Bitrate = int(*i)
+		}
+
+	case gtsmodel.FileTypeAudio:
+		if i := a.FileMeta.Original.Duration; i != nil {
+			apiAttachment.Meta.Original.Duration = *i
+		}
+
 		if i := a.FileMeta.Original.Bitrate; i != nil {
 			apiAttachment.Meta.Original.Bitrate = int(*i)
 		}
@@ -1067,17 +1140,109 @@ func (c *Converter) StatusToWebStatus(
 // Callers should check beforehand whether a requester has permission to view the
 // source of the status, and ensure they're passing only a local status into this function.
 func (c *Converter) StatusToAPIStatusSource(ctx context.Context, s *gtsmodel.Status) (*apimodel.StatusSource, error) {
-	// TODO: remove this when edit support is added.
-	text := "**STATUS EDITS ARE NOT CURRENTLY SUPPORTED IN GOTOSOCIAL (coming in 2024)**\n" +
-		"You can review the original text of your status below, but you will not be able to submit this edit.\n\n---\n\n" + s.Text
-
 	return &apimodel.StatusSource{
 		ID:          s.ID,
-		Text:        text,
+		Text:        s.Text,
 		SpoilerText: s.ContentWarning,
 	}, nil
 }
 
+// StatusToAPIEdits converts a status and its historical edits into a
+// slice of API model status edits, suitable for serving at /history.
+// Edits are ordered oldest first, with the current version of the
+// status always included as the final entry.
+func (c *Converter) StatusToAPIEdits(ctx context.Context, s *gtsmodel.Status) ([]*apimodel.StatusEdit, error) {
+	// Ensure the status is populated,
+	// including its historical edits.
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		if s.Account == nil {
+			return nil, gtserror.Newf("error(s) populating status, required account not set: %w", err)
+		}
+		log.Errorf(ctx, "error(s) populating status, will continue: %v", err)
+	}
+
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status author: %w", err)
+	}
+
+	// Edits don't track their own emojis,
+	// so just use those of the current status.
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status emojis: %v", err)
+	}
+
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(s.Edits)+1)
+
+	for _, edit := range s.Edits {
+		apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, edit.Attachments, edit.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting edit attachments: %v", err)
+		}
+
+		var apiPoll *apimodel.Poll
+		if len(edit.PollOptions) > 0 {
+			apiPoll = &apimodel.Poll{
+				Options: make([]apimodel.PollOption, len(edit.PollOptions)),
+				Emojis:  apiEmojis,
+			}
+
+			for i, title := range edit.PollOptions {
+				apiPoll.Options[i].Title = title
+			}
+		}
+
+		apiEdits = append(apiEdits, &apimodel.StatusEdit{
+			Content:          edit.Content,
+			SpoilerText:      edit.ContentWarning,
+			Sensitive:        util.PtrValueOr(edit.Sensitive, false),
+			CreatedAt:        util.FormatISO8601(edit.CreatedAt),
+			Account:          apiAccount,
+			Poll:             apiPoll,
+			MediaAttachments: apiAttachments,
+			Emojis:           apiEmojis,
+		})
+	}
+
+	// Finally, append the current
+	// version of the status itself.
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status attachments: %v", err)
+	}
+
+	var apiPoll *apimodel.Poll
+	if s.Poll != nil {
+		apiPoll = &apimodel.Poll{
+			Options: make([]apimodel.PollOption, len(s.Poll.Options)),
+			Emojis:  apiEmojis,
+		}
+
+		for i, title := range s.Poll.Options {
+			apiPoll.Options[i].Title = title
+		}
+	}
+
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+
+	apiEdits = append(apiEdits, &apimodel.StatusEdit{
+		Content:          s.Content,
+		SpoilerText:      s.ContentWarning,
+		Sensitive:        util.PtrValueOr(s.Sensitive, false),
+		CreatedAt:        util.FormatISO8601(createdAt),
+		Account:          apiAccount,
+		Poll:             apiPoll,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	})
+
+	return apiEdits, nil
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
 //
@@ -1223,8 +1388,10 @@ func (c *Converter) baseStatusToFrontend(
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               nil, // Set below.
 		Text:               s.Text,
+		InteractionPolicy:  c.InteractionPolicyToAPIInteractionPolicy(ctx, s.GetInteractionPolicy()),
+		PendingApproval:    s.IsPendingApproval(),
 	}
 
 	// Nullable fields.
@@ -1240,6 +1407,10 @@ func (c *Converter) baseStatusToFrontend(
 		apiStatus.Language = util.Ptr(s.Language)
 	}
 
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
 	if app := s.CreatedWithApplication; app != nil {
 		apiStatus.Application, err = c.AppToAPIAppPublic(ctx, app)
 		if err != nil {
@@ -1262,6 +1433,13 @@ func (c *Converter) baseStatusToFrontend(
 		}
 	}
 
+	if s.PreviewCard != nil {
+		apiStatus.Card, err = c.PreviewCardToAPICard(ctx, s.PreviewCard)
+		if err != nil {
+			log.Errorf(ctx, "error converting status preview card: %v", err)
+		}
+	}
+
 	// Status interactions.
 	//
 	if s.BoostOf != nil { //nolint
@@ -1305,6 +1483,32 @@ func (c *Converter) baseStatusToFrontend(
 	return apiStatus, nil
 }
 
+// InteractionPolicyToAPIInteractionPolicy converts a gts interaction policy into its api equivalent.
+func (c *Converter) InteractionPolicyToAPIInteractionPolicy(ctx context.Context, p *gtsmodel.InteractionPolicy) apimodel.InteractionPolicy {
+	return apimodel.InteractionPolicy{
+		CanFavourite: policyRulesToAPIPolicyRules(p.CanLike),
+		CanReply:     policyRulesToAPIPolicyRules(p.CanReply),
+		CanReblog:    policyRulesToAPIPolicyRules(p.CanAnnounce),
+	}
+}
+
+func policyRulesToAPIPolicyRules(r gtsmodel.PolicyRules) apimodel.PolicyRules {
+	rules := apimodel.PolicyRules{
+		Always:       make([]apimodel.PolicyValue, 0, len(r.Always)),
+		WithApproval: make([]apimodel.PolicyValue, 0, len(r.WithApproval)),
+	}
+
+	for _, value := range r.Always {
+		rules.Always = append(rules.Always, apimodel.PolicyValue(value))
+	}
+
+	for _, value := range r.WithApproval {
+		rules.WithApproval = append(rules.WithApproval, apimodel.PolicyValue(value))
+	}
+
+	return rules
+}
+
 // VisToAPIVis converts a gts visibility into its api equivalent
 func (c *Converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility {
 	switch m {
@@ -1363,8 +1567,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,  // approval always required
-		InvitesEnabled:       false, // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsInvitePolicy() == config.AccountsInvitePolicyUsers,
 		MaxTootChars:         uint(config.GetStatusesMaxChars()),
 		Rules:                c.InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1540,6 +1744,14 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize())
 	instance.Configuration.OIDCEnabled = config.GetOIDCEnabled()
 
+	vapidKeyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error getting vapid key pair: %w", err)
+	}
+	if vapidKeyPair != nil {
+		instance.Configuration.VAPID.PublicKey = vapidKeyPair.Public
+	}
+
 	// registrations
 	instance.Registrations.Enabled = config.GetAccountsRegistrationOpen()
 	instance.Registrations.ApprovalRequired = true // always required
@@ -1656,6 +1868,63 @@ func (c *Converter) NotificationToAPINotification(
 	}, nil
 }
 
+// ConversationToAPIConversation converts a conversation into its API representation.
+// The conversation status will be filtered using the thread filter context,
+// and may be nil if the status was filtered out.
+func (c *Converter) ConversationToAPIConversation(
+	ctx context.Context,
+	conversation *gtsmodel.Conversation,
+	requestingAccount *gtsmodel.Account,
+	filters []*gtsmodel.Filter,
+	mutes *usermute.CompiledUserMuteList,
+) (*apimodel.Conversation, error) {
+	apiConversation := &apimodel.Conversation{
+		ID:       conversation.ID,
+		Unread:   !*conversation.Read,
+		Accounts: make([]apimodel.Account, 0, len(conversation.OtherAccounts)),
+	}
+
+	for _, account := range conversation.OtherAccounts {
+		var apiAccount *apimodel.Account
+		blocked, err := c.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, account.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error checking blocks between accounts %s and %s: %w", requestingAccount.ID, account.ID, err)
+		}
+
+		if blocked || account.IsSuspended() {
+			apiAccount, err = c.AccountToAPIAccountBlocked(ctx, account)
+		} else {
+			apiAccount, err = c.AccountToAPIAccountPublic(ctx, account)
+		}
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s to API representation: %w", account.ID, err)
+		}
+
+		apiConversation.Accounts = append(apiConversation.Accounts, *apiAccount)
+	}
+
+	if conversation.LastStatus != nil {
+		var err error
+		apiConversation.LastStatus, err = c.StatusToAPIStatus(
+			ctx,
+			conversation.LastStatus,
+			requestingAccount,
+			statusfilter.FilterContextThread,
+			filters,
+			mutes,
+		)
+		if err != nil && !errors.Is(err, statusfilter.ErrHideStatus) {
+			return nil, gtserror.Newf(
+				"error converting status %s to API representation: %w",
+				conversation.LastStatus.ID,
+				err,
+			)
+		}
+	}
+
+	return apiConversation, nil
+}
+
 // DomainPermToAPIDomainPerm converts a gts model domin block or allow into an api domain permission.
 func (c *Converter) DomainPermToAPIDomainPerm(
 	ctx context.Context,
@@ -1689,9 +1958,118 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	domainPerm.CreatedBy = d.GetCreatedByAccountID()
 	domainPerm.CreatedAt = util.FormatISO8601(d.GetCreatedAt())
 
+	// Include any moderation
+	// policy set on the domain.
+	policy, err := c.state.DB.GetModerationPolicyByDomain(ctx, d.GetDomain())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting moderation policy for %s: %w", d.GetDomain(), err)
+	}
+
+	if policy != nil {
+		domainPerm.ModerationPolicy, err = c.ModerationPolicyToAPIModerationPolicy(ctx, policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return domainPerm, nil
 }
 
+// DomainPermSubToAPIDomainPermSub converts a gts model domain permission subscription into an api domain permission subscription.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(
+	ctx context.Context,
+	d *gtsmodel.DomainPermissionSubscription,
+) (*apimodel.DomainPermissionSubscription, error) {
+	var (
+		fetchedAt             string
+		successfullyFetchedAt string
+	)
+
+	if !d.FetchedAt.IsZero() {
+		fetchedAt = util.FormatISO8601(d.FetchedAt)
+	}
+
+	if !d.SuccessfullyFetchedAt.IsZero() {
+		successfullyFetchedAt = util.FormatISO8601(d.SuccessfullyFetchedAt)
+	}
+
+	return &apimodel.DomainPermissionSubscription{
+		ID:                    d.ID,
+		Priority:              d.Priority,
+		Title:                 d.Title,
+		PermissionType:        d.PermissionType.String(),
+		AdoptOrphans:          util.PtrValueOr(d.AdoptOrphans, false),
+		RemoveOrphans:         util.PtrValueOr(d.RemoveOrphans, false),
+		CreatedAt:             util.FormatISO8601(d.CreatedAt),
+		CreatedBy:             d.CreatedByAccountID,
+		URI:                   d.URI,
+		ContentType:           string(d.ContentType),
+		FetchedAt:             fetchedAt,
+		SuccessfullyFetchedAt: successfullyFetchedAt,
+		Error:                 d.Error,
+	}, nil
+}
+
+// RelayToAPIRelay converts a gts model relay into an api relay.
+func (c *Converter) RelayToAPIRelay(
+	ctx context.Context,
+	r *gtsmodel.Relay,
+) (*apimodel.Relay, error) {
+	return &apimodel.Relay{
+		ID:        r.ID,
+		InboxURL:  r.InboxURI,
+		ActorURI:  r.ActorURI,
+		State:     string(r.State),
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		CreatedBy: r.CreatedByAccountID,
+	}, nil
+}
+
+// ModerationPolicyToAPIModerationPolicy converts a gts model moderation policy into an api moderation policy.
+func (c *Converter) ModerationPolicyToAPIModerationPolicy(
+	ctx context.Context,
+	p *gtsmodel.ModerationPolicy,
+) (*apimodel.ModerationPolicy, error) {
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	var domain string
+	if p.Domain != "" {
+		var err error
+		domain, err = util.DePunify(p.Domain)
+		if err != nil {
+			return nil, gtserror.Newf("error de-punifying domain %s: %w", p.Domain, err)
+		}
+	}
+
+	return &apimodel.ModerationPolicy{
+		ID:                   p.ID,
+		Domain:               domain,
+		AccountID:            p.AccountID,
+		ForceSensitive:       util.PtrValueOr(p.ForceSensitive, false),
+		ContentWarning:       p.ContentWarning,
+		RejectMedia:          util.PtrValueOr(p.RejectMedia, false),
+		StripPublicTimelines: util.PtrValueOr(p.StripPublicTimelines, false),
+		PrivateComment:       p.PrivateComment,
+		CreatedAt:            util.FormatISO8601(p.CreatedAt),
+		CreatedBy:            p.CreatedByAccountID,
+	}, nil
+}
+
+// AccountArchiveToAPIAccountArchive converts a gts model
+// account archive into an api account archive. The time
+// of the next permitted archive request is left unset.
+func (c *Converter) AccountArchiveToAPIAccountArchive(
+	ctx context.Context,
+	a *gtsmodel.AccountArchive,
+) (*apimodel.AccountArchive, error) {
+	return &apimodel.AccountArchive{
+		ID:        a.ID,
+		CreatedAt: util.FormatISO8601(a.CreatedAt),
+		State:     string(a.State),
+		Size:      a.Size,
+	}, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1833,6 +2211,50 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 	}, nil
 }
 
+// FeaturedTagToAPIFeaturedTag converts one gts model featured tag into an api model
+// featured tag, for serving at /api/v1/featured_tags. The featured tag's Tag must be populated.
+func (c *Converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, f *gtsmodel.FeaturedTag) (*apimodel.FeaturedTag, error) {
+	statusesCount, lastStatusAt, err := c.state.DB.GetFeaturedTagStatusStats(ctx, f)
+	if err != nil {
+		return nil, gtserror.Newf("error getting stats for featured tag %s: %w", f.ID, err)
+	}
+
+	apiFeaturedTag := &apimodel.FeaturedTag{
+		ID:            f.ID,
+		Name:          strings.ToLower(f.Tag.Name),
+		URL:           uris.URIForTag(f.Tag.Name),
+		StatusesCount: statusesCount,
+	}
+
+	if !lastStatusAt.IsZero() {
+		apiFeaturedTag.LastStatusAt = util.Ptr(util.FormatISO8601(lastStatusAt))
+	}
+
+	return apiFeaturedTag, nil
+}
+
+// InviteToAPIInvite converts one gts model invite into an api model invite, for serving at /api/v1/invites.
+func (c *Converter) InviteToAPIInvite(ctx context.Context, i *gtsmodel.Invite) (*apimodel.Invite, error) {
+	apiInvite := &apimodel.Invite{
+		ID:        i.ID,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		Code:      i.Code,
+		URL:       uris.URLForInvite(i.Code),
+		Uses:      i.Uses,
+		Expired:   !i.Usable(time.Now()),
+	}
+
+	if i.MaxUses > 0 {
+		apiInvite.MaxUses = util.Ptr(i.MaxUses)
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		apiInvite.ExpiresAt = util.Ptr(util.FormatISO8601(i.ExpiresAt))
+	}
+
+	return apiInvite, nil
+}
+
 // ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 func (c *Converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error) {
 	return &apimodel.List{
@@ -1978,6 +2400,44 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 	}, nil
 }
 
+// PreviewCardToAPICard converts a database (gtsmodel) PreviewCard into an API model Card.
+func (c *Converter) PreviewCardToAPICard(ctx context.Context, card *gtsmodel.PreviewCard) (*apimodel.Card, error) {
+	// Ensure the card model is fully populated.
+	if err := c.state.DB.PopulatePreviewCard(ctx, card); err != nil {
+		return nil, gtserror.Newf("error populating preview card: %w", err)
+	}
+
+	apiCard := &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         string(card.Type),
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		HTML:         card.HTML,
+		Width:        card.Width,
+		Height:       card.Height,
+		EmbedURL:     card.EmbedURL,
+	}
+
+	if image := card.Image; image != nil && *image.Cached {
+		// Only include thumbnail
+		// if we have it stored.
+		apiCard.Image = image.URL
+		apiCard.Blurhash = image.Blurhash
+
+		if apiCard.Width == 0 && apiCard.Height == 0 {
+			// Fall back to image dimensions.
+			apiCard.Width = image.FileMeta.Original.Width
+			apiCard.Height = image.FileMeta.Original.Height
+		}
+	}
+
+	return apiCard, nil
+}
+
 // convertAttachmentsToAPIAttachments will convert a slice of GTS model attachments to frontend API model attachments, falling back to IDs if no GTS models supplied.
 func (c *Converter) convertAttachmentsToAPIAttachments(ctx context.Context, attachments []*gtsmodel.MediaAttachment, attachmentIDs []string) ([]*apimodel.Attachment, error) {
 	var errs gtserror.MultiError
@@ -2223,3 +2683,201 @@ func (c *Converter) ThemesToAPIThemes(themes []*gtsmodel.Theme) []apimodel.Theme
 	}
 	return apiThemes
 }
+
+// ScheduledStatusToAPIScheduledStatus converts a GTS model
+// scheduled status into its API (frontend) representation.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(ctx context.Context, scheduled *gtsmodel.ScheduledStatus) (*apimodel.ScheduledStatus, error) {
+	// Ensure the scheduled status model is fully populated.
+	if err := c.state.DB.PopulateScheduledStatus(ctx, scheduled); err != nil {
+		return nil, gtserror.Newf("error populating scheduled status: %w", err)
+	}
+
+	scheduledAt := util.FormatISO8601(scheduled.ScheduledAt)
+
+	params := &apimodel.StatusParams{
+		Text:          scheduled.Text,
+		InReplyToID:   scheduled.InReplyToID,
+		MediaIDs:      scheduled.MediaIDs,
+		Sensitive:     util.PtrValueOr(scheduled.Sensitive, false),
+		SpoilerText:   scheduled.SpoilerText,
+		ScheduledAt:   scheduledAt,
+		ApplicationID: scheduled.ApplicationID,
+		Language:      scheduled.Language,
+		ContentType:   scheduled.ContentType,
+	}
+
+	if scheduled.Visibility != "" {
+		params.Visibility = string(c.VisToAPIVis(ctx, scheduled.Visibility))
+	}
+
+	if len(scheduled.PollOptions) > 0 {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    scheduled.PollOptions,
+			ExpiresIn:  scheduled.PollExpiresIn,
+			Multiple:   util.PtrValueOr(scheduled.PollMultiple, false),
+			HideTotals: util.PtrValueOr(scheduled.PollHideTotals, false),
+		}
+	}
+
+	attachments := make([]apimodel.Attachment, 0, len(scheduled.MediaAttachments))
+	for _, media := range scheduled.MediaAttachments {
+		attachment, err := c.AttachmentToAPIAttachment(ctx, media)
+		if err != nil {
+			return nil, gtserror.Newf("error converting attachment %s: %w", media.ID, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:               scheduled.ID,
+		ScheduledAt:      scheduledAt,
+		Params:           params,
+		MediaAttachments: attachments,
+	}, nil
+}
+
+// AnnouncementToAPIAnnouncement converts a GTS model announcement into its API
+// (frontend) representation. If requester is set, whether the requester has
+// dismissed the announcement, and which reactions are its own, are included.
+func (c *Converter) AnnouncementToAPIAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, error) {
+	// Ensure the announcement model is fully populated.
+	if err := c.state.DB.PopulateAnnouncement(ctx, announcement); err != nil {
+		return nil, gtserror.Newf("error populating announcement: %w", err)
+	}
+
+	apiAnnouncement := &apimodel.Announcement{
+		ID:        announcement.ID,
+		Content:   announcement.Content,
+		AllDay:    *announcement.AllDay,
+		UpdatedAt: util.FormatISO8601(announcement.UpdatedAt),
+		Published: *announcement.Published,
+		Mentions:  []apimodel.Mention{},
+		Statuses:  []apimodel.Status{},
+		Tags:      []apimodel.Tag{},
+		Reactions: []apimodel.AnnouncementReaction{},
+	}
+
+	if !announcement.StartsAt.IsZero() {
+		apiAnnouncement.StartsAt = util.FormatISO8601(announcement.StartsAt)
+	}
+
+	if !announcement.EndsAt.IsZero() {
+		apiAnnouncement.EndsAt = util.FormatISO8601(announcement.EndsAt)
+	}
+
+	if !announcement.PublishedAt.IsZero() {
+		apiAnnouncement.PublishedAt = util.FormatISO8601(announcement.PublishedAt)
+	}
+
+	// Convert announcement emojis to frontend API model.
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx,
+		announcement.Emojis,
+		announcement.EmojiIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement emojis: %v", err)
+	}
+	apiAnnouncement.Emojis = apiEmojis
+
+	if requester != nil {
+		// Check whether requester has dismissed this announcement.
+		apiAnnouncement.Read, err = c.state.DB.IsAnnouncementDismissed(ctx,
+			announcement.ID,
+			requester.ID,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error checking announcement dismissal: %w", err)
+		}
+	}
+
+	// Fetch all reactions to this announcement.
+	reactions, err := c.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error getting announcement reactions: %w", err)
+	}
+
+	// Group reactions by name, keeping
+	// the order in which each was first used.
+	indices := make(map[string]int, len(reactions))
+	for _, reaction := range reactions {
+		i, ok := indices[reaction.Name]
+		if !ok {
+			apiReaction := apimodel.AnnouncementReaction{
+				Name: reaction.Name,
+			}
+
+			if emoji := reaction.Emoji; emoji != nil {
+				apiReaction.URL = emoji.ImageURL
+				apiReaction.StaticURL = emoji.ImageStaticURL
+			}
+
+			i = len(apiAnnouncement.Reactions)
+			indices[reaction.Name] = i
+			apiAnnouncement.Reactions = append(apiAnnouncement.Reactions, apiReaction)
+		}
+
+		apiAnnouncement.Reactions[i].Count++
+		if requester != nil && reaction.AccountID == requester.ID {
+			apiAnnouncement.Reactions[i].Me = true
+		}
+	}
+
+	return apiAnnouncement, nil
+}
+
+// WebPushSubscriptionToAPIWebPushSubscription converts a gts model web push subscription into its api representation.
+func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+) (*apimodel.PushSubscription, error) {
+	vapidKeyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting vapid key pair: %w", err)
+	}
+
+	return &apimodel.PushSubscription{
+		ID:        subscription.ID,
+		Endpoint:  subscription.Endpoint,
+		ServerKey: vapidKeyPair.Public,
+		Alerts: &apimodel.PushSubscriptionAlerts{
+			Follow:        *subscription.AlertFollow,
+			FollowRequest: *subscription.AlertFollowRequest,
+			Favourite:     *subscription.AlertFavourite,
+			Mention:       *subscription.AlertMention,
+			Reblog:        *subscription.AlertReblog,
+			Poll:          *subscription.AlertPoll,
+			Status:        *subscription.AlertStatus,
+			AdminSignup:   *subscription.AlertSignup,
+		},
+		Policy: string(subscription.Policy),
+	}, nil
+}
+
+// WorkerTaskToAPIWorkerTask converts a gts model worker task into its api representation.
+func (c *Converter) WorkerTaskToAPIWorkerTask(
+	ctx context.Context,
+	task *gtsmodel.WorkerTask,
+) (*apimodel.WorkerTask, error) {
+	apiTask := &apimodel.WorkerTask{
+		ID:        strconv.FormatUint(uint64(task.ID), 10),
+		Worker:    task.WorkerType.String(),
+		CreatedAt: util.FormatISO8601(task.CreatedAt),
+		Error:     task.Error,
+	}
+
+	if !task.FailedAt.IsZero() {
+		apiTask.FailedAt = util.FormatISO8601(task.FailedAt)
+	}
+
+	if json.Valid(task.TaskData) {
+		// All task types are serialized
+		// as JSON, so include data as-is.
+		apiTask.Data = task.TaskData
+	}
+
+	return apiTask, nil
+}
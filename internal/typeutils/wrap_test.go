@@ -20,6 +20,7 @@ package typeutils_test
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -75,9 +76,11 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
 	bytes, err := json.MarshalIndent(createI, "", "  ")
 	suite.NoError(err)
 
-	suite.Equal(`{
-  "@context": "https://www.w3.org/ns/activitystreams",
-  "actor": "http://localhost:8080/users/the_mighty_zork",
+	// we can't be sure in what order the two context entries --
+	// https://gotosocial.org/ns, https://www.w3.org/ns/activitystreams --
+	// will appear, so trim them out of the string for consistency
+	trimmed := strings.SplitAfter(string(bytes), `"actor":`)[1]
+	suite.Equal(` "http://localhost:8080/users/the_mighty_zork",
   "cc": "http://localhost:8080/users/the_mighty_zork/followers",
   "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY/activity#Create",
   "object": {
@@ -89,6 +92,20 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
       "en": "hello everyone!"
     },
     "id": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+    "interactionPolicy": {
+      "canAnnounce": {
+        "always": "https://www.w3.org/ns/activitystreams#Public",
+        "approvalRequired": []
+      },
+      "canLike": {
+        "always": "https://www.w3.org/ns/activitystreams#Public",
+        "approvalRequired": []
+      },
+      "canReply": {
+        "always": "https://www.w3.org/ns/activitystreams#Public",
+        "approvalRequired": []
+      }
+    },
     "published": "2021-10-20T12:40:37+02:00",
     "replies": {
       "first": {
@@ -110,7 +127,7 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
   "published": "2021-10-20T12:40:37+02:00",
   "to": "https://www.w3.org/ns/activitystreams#Public",
   "type": "Create"
-}`, string(bytes))
+}`, trimmed)
 }
 
 func TestWrapTestSuite(t *testing.T) {
@@ -46,6 +46,7 @@ const (
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
 	TagsPath         = "tags"          // TagsPath represents the activitypub tags location
+	SignupPath       = "signup"        // SignupPath is used to generate the URL for an invite link
 )
 
 // UserURIs contains a bunch of UserURIs and URLs for a user, host, account, etc.
@@ -73,6 +74,8 @@ type UserURIs struct {
 	LikedURI string
 	// The activitypub URI for this user's featured collections, eg., https://example.org/users/example_user/collections/featured
 	FeaturedCollectionURI string
+	// The activitypub URI for this user's featured hashtags, eg., https://example.org/users/example_user/collections/tags
+	FeaturedTagsCollectionURI string
 	// The URI for this user's public key, eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
 }
@@ -155,6 +158,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 	followingURI := fmt.Sprintf("%s/%s", userURI, FollowingPath)
 	likedURI := fmt.Sprintf("%s/%s", userURI, LikedPath)
 	collectionURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, FeaturedPath)
+	featuredTagsURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, TagsPath)
 	publicKeyURI := fmt.Sprintf("%s/%s", userURI, PublicKeyPath)
 
 	return &UserURIs{
@@ -162,15 +166,16 @@ func GenerateURIsForAccount(username string) *UserURIs {
 		UserURL:     userURL,
 		StatusesURL: statusesURL,
 
-		UserURI:               userURI,
-		StatusesURI:           statusesURI,
-		InboxURI:              inboxURI,
-		OutboxURI:             outboxURI,
-		FollowersURI:          followersURI,
-		FollowingURI:          followingURI,
-		LikedURI:              likedURI,
-		FeaturedCollectionURI: collectionURI,
-		PublicKeyURI:          publicKeyURI,
+		UserURI:                   userURI,
+		StatusesURI:               statusesURI,
+		InboxURI:                  inboxURI,
+		OutboxURI:                 outboxURI,
+		FollowersURI:              followersURI,
+		FollowingURI:              followingURI,
+		LikedURI:                  likedURI,
+		FeaturedCollectionURI:     collectionURI,
+		FeaturedTagsCollectionURI: featuredTagsURI,
+		PublicKeyURI:              publicKeyURI,
 	}
 }
 
@@ -252,6 +257,13 @@ func URIForTag(name string) string {
 	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, TagsPath, strings.ToLower(name))
 }
 
+// URLForInvite generates a web URL for signing up with the given invite code.
+func URLForInvite(code string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s?invite=%s", protocol, host, SignupPath, url.QueryEscape(code))
+}
+
 // IsUserPath returns true if the given URL path corresponds to eg /users/example_username
 func IsUserPath(id *url.URL) bool {
 	return regexes.UserPath.MatchString(id.Path)
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -384,6 +385,43 @@ func FilterAction(action apimodel.FilterAction) error {
 	)
 }
 
+// InteractionPolicy validates an interaction policy
+// submitted for a new status or as an account default.
+func InteractionPolicy(policy *apimodel.InteractionPolicy) error {
+	if len(policy.CanFavourite.WithApproval) != 0 {
+		return errors.New("interaction policy can_favourite: with_approval is not supported for favourites")
+	}
+
+	for _, rules := range []struct {
+		name string
+		apimodel.PolicyRules
+	}{
+		{"can_favourite", policy.CanFavourite},
+		{"can_reply", policy.CanReply},
+		{"can_reblog", policy.CanReblog},
+	} {
+		for _, value := range slices.Concat(rules.Always, rules.WithApproval) {
+			switch value {
+			case apimodel.PolicyValuePublic,
+				apimodel.PolicyValueFollowers,
+				apimodel.PolicyValueMentioned,
+				apimodel.PolicyValueAuthor:
+				continue
+			}
+			return fmt.Errorf(
+				"interaction policy %s: value '%s' was not recognized, valid options are '%s', '%s', '%s', '%s'",
+				rules.name, value,
+				apimodel.PolicyValuePublic,
+				apimodel.PolicyValueFollowers,
+				apimodel.PolicyValueMentioned,
+				apimodel.PolicyValueAuthor,
+			)
+		}
+	}
+
+	return nil
+}
+
 // CreateAccount checks through all the prerequisites for
 // creating a new account, according to the provided form.
 // If the account isn't eligible, an error will be returned.
@@ -394,7 +432,10 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// Registration may be closed, but
+	// sign-ups can still use an invite.
+	invited := form.InviteCode != ""
+	if !config.GetAccountsRegistrationOpen() && !invited {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -420,5 +461,8 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 	}
 	form.Locale = locale
 
-	return SignUpReason(form.Reason, config.GetAccountsReasonRequired())
+	// Invited sign-ups are pre-approved,
+	// so they don't need to give a reason.
+	reasonRequired := config.GetAccountsReasonRequired() && !invited
+	return SignUpReason(form.Reason, reasonRequired)
 }
@@ -53,13 +53,19 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If an invite link was followed, carry the
+	// invite code through into the sign-up form.
+	// It will be checked when the form is submitted.
+	inviteCode := c.Query(inviteKey)
+
 	page := apiutil.WebPage{
 		Template: "sign-up.tmpl",
 		Instance: instance,
 		OGMeta:   apiutil.OGBase(instance),
 		Extra: map[string]any{
-			"reasonRequired":   config.GetAccountsReasonRequired(),
-			"registrationOpen": config.GetAccountsRegistrationOpen(),
+			"inviteCode":       inviteCode,
+			"reasonRequired":   config.GetAccountsReasonRequired() && inviteCode == "",
+			"registrationOpen": config.GetAccountsRegistrationOpen() || inviteCode != "",
 		},
 	}
 
@@ -132,6 +138,7 @@ func (m *Module) signupPOSTHandler(c *gin.Context) {
 		Extra: map[string]any{
 			"email":    user.UnconfirmedEmail,
 			"username": user.Account.Username,
+			"approved": *user.Approved,
 		},
 	}
 
@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// recordSize is the aes128gcm record size we
+	// advertise. Payloads are always sent in a
+	// single record, so must be smaller than this.
+	recordSize = 4096
+
+	// saltSize is the size of the random salt
+	// used to derive content encryption keys.
+	saltSize = 16
+
+	// headerSize is the size of the aes128gcm header:
+	// salt, record size (4), key id length (1), and the
+	// key id, which is an uncompressed P-256 key (65).
+	headerSize = saltSize + 4 + 1 + 65
+
+	// maxPayloadSize is the maximum size of a plaintext
+	// payload, such that the whole encrypted body stays
+	// within 4096 bytes (RFC 8291 section 4): the record
+	// size, minus the header, AEAD tag (16) and padding
+	// delimiter (1) that the payload is wrapped with.
+	maxPayloadSize = recordSize - headerSize - 16 - 1
+)
+
+// encrypt encrypts the given plaintext payload for the user agent with
+// the given base64-encoded public key and auth secret, as described by
+// RFC 8291 (Message Encryption for Web Push), returning a body with an
+// aes128gcm header (RFC 8188), ready to be POSTed to a push service.
+func encrypt(payload []byte, p256dh string, auth string) ([]byte, error) {
+	if len(payload) > maxPayloadSize {
+		return nil, fmt.Errorf("payload size %d exceeds max %d", len(payload), maxPayloadSize)
+	}
+
+	uaPublicBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding p256dh: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing p256dh: %w", err)
+	}
+
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding auth: %w", err)
+	}
+
+	if len(authSecret) != 16 {
+		return nil, errors.New("auth secret must be 16 bytes")
+	}
+
+	// Generate a fresh application server
+	// key pair for this message, to perform
+	// ECDH with the user agent's public key.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating key: %w", err)
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("error computing shared secret: %w", err)
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+
+	// Combine the shared secret with the
+	// user agent's auth secret (RFC 8291 3.3).
+	keyInfo := make([]byte, 0, 14+len(uaPublicBytes)+len(asPublicBytes))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+
+	// Derive content encryption key and
+	// nonce from the combined secret (RFC 8188 2.2-2.3).
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header is salt, record size,
+	// then key id, which is our public key.
+	body := make([]byte, 0, saltSize+4+1+len(asPublicBytes)+len(payload)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicBytes)))
+	body = append(body, asPublicBytes...)
+
+	// Single (and so last) record is
+	// delimited with a 0x02 padding byte.
+	plaintext := make([]byte, 0, len(payload)+1)
+	plaintext = append(plaintext, payload...)
+	plaintext = append(plaintext, 0x02)
+
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+// hkdf performs HKDF (RFC 5869) with SHA-256, returning
+// a key of given length, which must be at most 32 bytes.
+func hkdf(salt, ikm, info []byte, length int) []byte {
+	// Extract.
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	prk := mac.Sum(nil)
+
+	// Expand, for which one
+	// iteration is enough.
+	mac = hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+// decodeBase64 decodes the given string as base64, accepting
+// both the url-safe and standard alphabets, with or without
+// padding, as different user agents send keys differently.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
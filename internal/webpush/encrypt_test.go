@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncryptMaxPayload(t *testing.T) {
+	uaKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256dh := base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes())
+
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		t.Fatal(err)
+	}
+	auth := base64.RawURLEncoding.EncodeToString(authSecret)
+
+	// Max size payload must fit in 4096 bytes total.
+	body, err := encrypt(make([]byte, maxPayloadSize), p256dh, auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(body) > 4096 {
+		t.Fatalf("encrypted body size %d exceeds 4096", len(body))
+	}
+
+	// Anything larger must be rejected.
+	if _, err := encrypt(make([]byte, maxPayloadSize+1), p256dh, auth); err == nil {
+		t.Fatal("expected error for oversized payload")
+	}
+}
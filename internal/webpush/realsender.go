@@ -0,0 +1,369 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// pushTTL is how long push services should
+	// hold on to notifications for offline clients.
+	pushTTL = 48 * time.Hour
+
+	// maxBodyRunes is the max length of
+	// the notification preview body text.
+	maxBodyRunes = 140
+)
+
+type realSender struct {
+	httpClient *httpclient.Client
+	state      *state.State
+
+	// the instance's VAPID key,
+	// lazily loaded on first use.
+	key   *vapidKey
+	keyMu sync.Mutex
+}
+
+func (r *realSender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	subscriptions, err := r.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notification.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting web push subscriptions: %w", err)
+	}
+
+	// Drop subscriptions that haven't opted in
+	// to this notification, either by type, or
+	// by their policy towards its origin account.
+	var policyErr error
+	policies := r.policyChecker(ctx, notification)
+	subscriptions = slices.DeleteFunc(subscriptions, func(subscription *gtsmodel.WebPushSubscription) bool {
+		if !subscription.AlertEnabled(notification.NotificationType) {
+			return true
+		}
+
+		allowed, err := policies(subscription.Policy)
+		if err != nil {
+			policyErr = err
+			return true
+		}
+
+		return !allowed
+	})
+
+	if policyErr != nil {
+		return policyErr
+	}
+
+	if len(subscriptions) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	key, err := r.getVAPIDKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := r.state.DB.GetUserByAccountID(gtscontext.SetBarebones(ctx), notification.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user of account %s: %w", notification.TargetAccountID, err)
+	}
+
+	title, body := formatNotification(apiNotification)
+	payload := &apimodel.WebPushNotification{
+		NotificationID:   apiNotification.ID,
+		NotificationType: apiNotification.Type,
+		Title:            title,
+		Body:             body,
+		PreferredLocale:  user.Locale,
+	}
+
+	if apiNotification.Account != nil {
+		payload.Icon = apiNotification.Account.Avatar
+	}
+
+	var errs gtserror.MultiError
+	for _, subscription := range subscriptions {
+		if err := r.sendToSubscription(ctx, key, subscription, payload); err != nil {
+			errs.Appendf("error sending to web push subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// policyChecker returns a function for checking whether the
+// given notification passes a subscription policy, which only
+// performs the db lookups needed by each policy at most once.
+func (r *realSender) policyChecker(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+) func(gtsmodel.WebPushNotificationPolicy) (bool, error) {
+	var followed, follower *bool
+
+	isFollowing := func(cached **bool, sourceAccountID, targetAccountID string) (bool, error) {
+		if *cached == nil {
+			following, err := r.state.DB.IsFollowing(ctx, sourceAccountID, targetAccountID)
+			if err != nil {
+				return false, gtserror.Newf("db error checking follow: %w", err)
+			}
+			*cached = &following
+		}
+		return **cached, nil
+	}
+
+	return func(policy gtsmodel.WebPushNotificationPolicy) (bool, error) {
+		switch policy {
+		case gtsmodel.WebPushNotificationPolicyFollowed:
+			// Notification's origin must be
+			// followed by the subscribed account.
+			return isFollowing(&followed,
+				notification.TargetAccountID,
+				notification.OriginAccountID,
+			)
+
+		case gtsmodel.WebPushNotificationPolicyFollower:
+			// Notification's origin must
+			// follow the subscribed account.
+			return isFollowing(&follower,
+				notification.OriginAccountID,
+				notification.TargetAccountID,
+			)
+
+		case gtsmodel.WebPushNotificationPolicyNone:
+			return false, nil
+
+		default:
+			return true, nil
+		}
+	}
+}
+
+// getVAPIDKey returns the instance's VAPID key,
+// loading it from the database if necessary.
+func (r *realSender) getVAPIDKey(ctx context.Context) (*vapidKey, error) {
+	r.keyMu.Lock()
+	defer r.keyMu.Unlock()
+
+	if r.key != nil {
+		return r.key, nil
+	}
+
+	keyPair, err := r.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting vapid key pair: %w", err)
+	}
+
+	r.key, err = parseVAPIDKeyPair(keyPair)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing vapid key pair: %w", err)
+	}
+
+	return r.key, nil
+}
+
+// sendToSubscription encrypts and sends the given
+// payload to the push service of the subscription.
+func (r *realSender) sendToSubscription(
+	ctx context.Context,
+	key *vapidKey,
+	subscription *gtsmodel.WebPushSubscription,
+	payload *apimodel.WebPushNotification,
+) error {
+	token, err := r.state.DB.GetTokenByID(ctx, subscription.TokenID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Token has been revoked since subscribing,
+			// so its client can no longer use the API.
+			return r.deleteSubscription(ctx, subscription)
+		}
+		return gtserror.Newf("db error getting token: %w", err)
+	}
+
+	// Clients use the included access token to check
+	// which of their accounts the notification is for.
+	p := *payload
+	p.AccessToken = token.Access
+
+	b, err := json.Marshal(&p)
+	if err != nil {
+		return gtserror.Newf("error marshaling payload: %w", err)
+	}
+
+	body, err := encrypt(b, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return gtserror.Newf("error encrypting payload: %w", err)
+	}
+
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil {
+		return gtserror.Newf("error parsing endpoint: %w", err)
+	}
+
+	authorization, err := key.authorization(endpoint, vapidSubject(), time.Now())
+	if err != nil {
+		return gtserror.Newf("error creating vapid authorization: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return gtserror.Newf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(pushTTL.Seconds())))
+	req.Header.Set("Urgency", "normal")
+	req.Header.Set("User-Agent", userAgent())
+
+	rsp, err := r.httpClient.Do(req)
+	if err != nil {
+		return gtserror.Newf("error doing request: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	switch code := rsp.StatusCode; {
+	case code >= 200 && code < 300:
+		return nil
+
+	case code == http.StatusNotFound || code == http.StatusGone:
+		// Push service says subscription has
+		// expired or been unsubscribed (RFC 8030 7.3).
+		return r.deleteSubscription(ctx, subscription)
+
+	default:
+		b, _ := io.ReadAll(io.LimitReader(rsp.Body, 256))
+		return gtserror.Newf("push service responded %s: %s", rsp.Status, b)
+	}
+}
+
+// deleteSubscription deletes the
+// given stale subscription.
+func (r *realSender) deleteSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	log.Debugf(ctx, "deleting stale web push subscription %s", subscription.ID)
+	if err := r.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, subscription.TokenID); err != nil {
+		return gtserror.Newf("db error deleting subscription: %w", err)
+	}
+	return nil
+}
+
+// formatNotification returns a human-readable title
+// and body previewing the given notification.
+func formatNotification(apiNotification *apimodel.Notification) (string, string) {
+	var name, acct string
+	if account := apiNotification.Account; account != nil {
+		acct = "@" + account.Acct
+		name = account.DisplayName
+		if name == "" {
+			name = acct
+		}
+	}
+
+	var title string
+	switch gtsmodel.NotificationType(apiNotification.Type) {
+	case gtsmodel.NotificationFollow:
+		title = fmt.Sprintf("%s followed you", name)
+	case gtsmodel.NotificationFollowRequest:
+		title = fmt.Sprintf("%s requested to follow you", name)
+	case gtsmodel.NotificationMention:
+		title = fmt.Sprintf("%s mentioned you", name)
+	case gtsmodel.NotificationReblog:
+		title = fmt.Sprintf("%s boosted your post", name)
+	case gtsmodel.NotificationFave:
+		title = fmt.Sprintf("%s favourited your post", name)
+	case gtsmodel.NotificationPoll:
+		title = "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		title = fmt.Sprintf("%s just posted", name)
+	case gtsmodel.NotificationSignup:
+		title = fmt.Sprintf("%s signed up", name)
+	case gtsmodel.NotificationPendingReply:
+		title = fmt.Sprintf("%s replied to your post (pending approval)", name)
+	case gtsmodel.NotificationPendingReblog:
+		title = fmt.Sprintf("%s boosted your post (pending approval)", name)
+	default:
+		title = fmt.Sprintf("New notification from %s", name)
+	}
+
+	body := acct
+	if status := apiNotification.Status; status != nil {
+		if status.SpoilerText != "" {
+			// Don't leak content
+			// behind a content warning.
+			body = status.SpoilerText
+		} else {
+			body = text.SanitizeToPlaintext(status.Content)
+		}
+	}
+
+	return title, truncate(body, maxBodyRunes)
+}
+
+// truncate truncates the given string to at most
+// max runes, marking any truncation with an ellipsis.
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// vapidSubject returns the contact URL of
+// this instance, included in VAPID tokens.
+func vapidSubject() string {
+	return config.GetProtocol() + "://" + config.GetHost()
+}
+
+// userAgent returns the user agent with
+// which to send requests to push services.
+func userAgent() string {
+	return fmt.Sprintf("gotosocial/%s (+%s://%s)",
+		config.GetSoftwareVersion(),
+		config.GetProtocol(),
+		config.GetHost(),
+	)
+}
@@ -0,0 +1,360 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RealSenderTestSuite struct {
+	suite.Suite
+	db    db.DB
+	state state.State
+
+	testTokens   map[string]*gtsmodel.Token
+	testAccounts map[string]*gtsmodel.Account
+
+	// user agent keys
+	uaKey  *ecdh.PrivateKey
+	uaAuth []byte
+
+	// push service
+	server     *httptest.Server
+	statusCode int
+	requests   []*http.Request
+	bodies     [][]byte
+	requestsMu sync.Mutex
+
+	sender webpush.Sender
+}
+
+func (suite *RealSenderTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *RealSenderTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.db, nil)
+
+	var err error
+	suite.uaKey, err = ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.uaAuth = make([]byte, 16)
+	if _, err := rand.Read(suite.uaAuth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.statusCode = http.StatusCreated
+	suite.requests = nil
+	suite.bodies = nil
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		suite.requestsMu.Lock()
+		suite.requests = append(suite.requests, r)
+		suite.bodies = append(suite.bodies, body)
+		suite.requestsMu.Unlock()
+
+		w.WriteHeader(suite.statusCode)
+	}))
+
+	suite.sender = webpush.NewSender(
+		httpclient.New(httpclient.Config{
+			AllowRanges: []netip.Prefix{
+				netip.MustParsePrefix("127.0.0.0/8"),
+			},
+		}),
+		&suite.state,
+	)
+}
+
+func (suite *RealSenderTestSuite) TearDownTest() {
+	suite.server.Close()
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// putSubscription puts a web push subscription for
+// local_account_1's token, pointing to the test server.
+func (suite *RealSenderTestSuite) putSubscription(policy gtsmodel.WebPushNotificationPolicy, mention bool) *gtsmodel.WebPushSubscription {
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:                 id.NewULID(),
+		AccountID:          suite.testAccounts["local_account_1"].ID,
+		TokenID:            suite.testTokens["local_account_1"].ID,
+		Endpoint:           suite.server.URL + "/push/some_subscription",
+		Auth:               base64.RawURLEncoding.EncodeToString(suite.uaAuth),
+		P256dh:             base64.RawURLEncoding.EncodeToString(suite.uaKey.PublicKey().Bytes()),
+		AlertFollow:        util.Ptr(true),
+		AlertFollowRequest: util.Ptr(false),
+		AlertFavourite:     util.Ptr(false),
+		AlertMention:       util.Ptr(mention),
+		AlertReblog:        util.Ptr(false),
+		AlertPoll:          util.Ptr(false),
+		AlertStatus:        util.Ptr(false),
+		AlertSignup:        util.Ptr(false),
+		Policy:             policy,
+	}
+
+	if err := suite.db.PutWebPushSubscription(context.Background(), subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return subscription
+}
+
+// mentionFrom returns a mention notification
+// to local_account_1 from the given account.
+func (suite *RealSenderTestSuite) mentionFrom(origin string) (*gtsmodel.Notification, *apimodel.Notification) {
+	notification := &gtsmodel.Notification{
+		ID:               id.NewULID(),
+		NotificationType: gtsmodel.NotificationMention,
+		TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+		OriginAccountID:  suite.testAccounts[origin].ID,
+	}
+
+	apiNotification := &apimodel.Notification{
+		ID:   notification.ID,
+		Type: string(notification.NotificationType),
+		Account: &apimodel.Account{
+			Acct:        suite.testAccounts[origin].Username,
+			DisplayName: "Some Account",
+			Avatar:      "https://example.org/avatar.png",
+		},
+		Status: &apimodel.Status{
+			Content: "<p>hello <b>world</b></p>",
+		},
+	}
+
+	return notification, apiNotification
+}
+
+// decrypt decrypts the given aes128gcm web push message
+// body with the test user agent keys, per RFC 8291.
+func (suite *RealSenderTestSuite) decrypt(body []byte) []byte {
+	hkdf := func(salt, ikm, info []byte, length int) []byte {
+		mac := hmac.New(sha256.New, salt)
+		mac.Write(ikm)
+		mac = hmac.New(sha256.New, mac.Sum(nil))
+		mac.Write(info)
+		mac.Write([]byte{0x01})
+		return mac.Sum(nil)[:length]
+	}
+
+	salt := body[:16]
+	recordSize := binary.BigEndian.Uint32(body[16:20])
+	keyIDLen := int(body[20])
+	asPublicBytes := body[21 : 21+keyIDLen]
+	ciphertext := body[21+keyIDLen:]
+	suite.LessOrEqual(len(ciphertext), int(recordSize))
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ecdhSecret, err := suite.uaKey.ECDH(asPublic)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), suite.uaKey.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(suite.uaAuth, ecdhSecret, keyInfo, 32)
+	cek := hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Strip last record padding delimiter.
+	suite.Equal(byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+// verifyVAPID checks the given VAPID authorization
+// header is signed with the instance's VAPID key.
+func (suite *RealSenderTestSuite) verifyVAPID(authorization string) {
+	keyPair, err := suite.db.GetVAPIDKeyPair(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	token, k, ok := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	if !ok {
+		suite.FailNow("malformed authorization: " + authorization)
+	}
+	suite.Equal(keyPair.Public, k)
+
+	publicBytes, err := base64.RawURLEncoding.DecodeString(k)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), publicBytes) //nolint:staticcheck
+	public := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	parts := strings.Split(token, ".")
+	suite.Len(parts, 3)
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	suite.True(ecdsa.Verify(public, digest[:], r, s))
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(string(claims), `"aud":"`+suite.server.URL+`"`)
+	suite.Contains(string(claims), `"sub":"http://localhost:8080"`)
+}
+
+func (suite *RealSenderTestSuite) TestSend() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll, true)
+
+	notification, apiNotification := suite.mentionFrom("local_account_2")
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(suite.requests, 1) {
+		suite.FailNow("")
+	}
+
+	req := suite.requests[0]
+	suite.Equal(http.MethodPost, req.Method)
+	suite.Equal("/push/some_subscription", req.URL.Path)
+	suite.Equal("aes128gcm", req.Header.Get("Content-Encoding"))
+	suite.Equal("172800", req.Header.Get("TTL"))
+	suite.verifyVAPID(req.Header.Get("Authorization"))
+
+	payload := &apimodel.WebPushNotification{}
+	if err := json.Unmarshal(suite.decrypt(suite.bodies[0]), payload); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(&apimodel.WebPushNotification{
+		AccessToken:      suite.testTokens["local_account_1"].Access,
+		NotificationID:   notification.ID,
+		NotificationType: "mention",
+		Title:            "Some Account mentioned you",
+		Body:             "hello world",
+		Icon:             "https://example.org/avatar.png",
+		PreferredLocale:  "en",
+	}, payload)
+}
+
+func (suite *RealSenderTestSuite) TestSendAlertDisabled() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll, false)
+
+	notification, apiNotification := suite.mentionFrom("local_account_2")
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.requests)
+}
+
+func (suite *RealSenderTestSuite) TestSendPolicy() {
+	suite.putSubscription(gtsmodel.WebPushNotificationPolicyFollowed, true)
+
+	// local_account_1 doesn't follow remote_account_1.
+	notification, apiNotification := suite.mentionFrom("remote_account_1")
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(suite.requests)
+
+	// local_account_1 does follow local_account_2.
+	notification, apiNotification = suite.mentionFrom("local_account_2")
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.requests, 1)
+}
+
+func (suite *RealSenderTestSuite) TestSendGone() {
+	subscription := suite.putSubscription(gtsmodel.WebPushNotificationPolicyAll, true)
+	suite.statusCode = http.StatusGone
+
+	notification, apiNotification := suite.mentionFrom("local_account_2")
+	if err := suite.sender.Send(context.Background(), notification, apiNotification); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.requests, 1)
+
+	// Expired subscription should be deleted.
+	_, err := suite.db.GetWebPushSubscriptionByTokenID(context.Background(), subscription.TokenID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestRealSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(RealSenderTestSuite))
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// Sender contains functions for sending Web Push
+// notifications (RFC 8030) to instance users' clients.
+type Sender interface {
+	// Send sends the given notification to all of the target account's
+	// Web Push subscriptions which have opted in to notifications of its
+	// type, from its origin account.
+	//
+	// The apiNotification is used to build a human-readable preview of
+	// the notification; clients fetch the full notification themselves.
+	Send(ctx context.Context, notification *gtsmodel.Notification, apiNotification *apimodel.Notification) error
+}
+
+// NewSender returns a new Web Push Sender which POSTs
+// encrypted notification payloads to push services
+// using the given http client.
+func NewSender(httpClient *httpclient.Client, state *state.State) Sender {
+	return &realSender{
+		httpClient: httpClient,
+		state:      state,
+	}
+}
@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// vapidTokenTTL is how long VAPID tokens are
+// valid for. RFC 8292 limits this to 24 hours.
+const vapidTokenTTL = 12 * time.Hour
+
+// vapidHeader is the base64url-encoded JOSE header
+// of all VAPID tokens, which are always ES256 JWTs.
+var vapidHeader = base64.RawURLEncoding.EncodeToString(
+	[]byte(`{"typ":"JWT","alg":"ES256"}`),
+)
+
+// vapidKey wraps the instance's VAPID
+// key pair, parsed ready for signing.
+type vapidKey struct {
+	public  string
+	private *ecdsa.PrivateKey
+}
+
+// parseVAPIDKeyPair parses the given stored VAPID key pair.
+func parseVAPIDKeyPair(keyPair *gtsmodel.VAPIDKeyPair) (*vapidKey, error) {
+	privateBytes, err := base64.RawURLEncoding.DecodeString(keyPair.Private)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding private key: %w", err)
+	}
+
+	ecdhKey, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	// There's no direct way to convert an ecdh
+	// private key to ecdsa, so go via PKCS #8.
+	der, err := x509.MarshalPKCS8PrivateKey(ecdhKey)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling private key: %w", err)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling private key: %w", err)
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an ecdsa key")
+	}
+
+	return &vapidKey{
+		public:  base64.RawURLEncoding.EncodeToString(ecdhKey.PublicKey().Bytes()),
+		private: ecdsaKey,
+	}, nil
+}
+
+// authorization returns the value of an Authorization header
+// identifying this instance to the push service at endpoint,
+// as described by RFC 8292 (VAPID for Web Push).
+func (k *vapidKey) authorization(endpoint *url.URL, subject string, now time.Time) (string, error) {
+	claims, err := json.Marshal(struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}{
+		Aud: endpoint.Scheme + "://" + endpoint.Host,
+		Exp: now.Add(vapidTokenTTL).Unix(),
+		Sub: subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := vapidHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing token: %w", err)
+	}
+
+	// ES256 signatures are the fixed-size
+	// big-endian r and s values concatenated.
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+	return "vapid t=" + token + ", k=" + k.public, nil
+}
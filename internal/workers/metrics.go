@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// processingDuration records how long each job
+// popped from a worker pool queue takes to process.
+// This is a no-op unless metrics are initialized.
+var processingDuration metric.Float64Histogram
+
+func init() {
+	var err error
+	processingDuration, err = otel.
+		Meter("github.com/superseriousbusiness/gotosocial/internal/workers").
+		Float64Histogram(
+			"gotosocial.workers.processing_duration",
+			metric.WithDescription("Time taken to process a job from a worker pool queue"),
+			metric.WithUnit("s"),
+		)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// poolAttrs returns the metric attributes for a worker pool with name.
+func poolAttrs(name string) metric.MeasurementOption {
+	return metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("pool", name),
+	))
+}
+
+// recordProcessing records processing duration since start with attrs.
+func recordProcessing(ctx context.Context, start time.Time, attrs metric.MeasurementOption) {
+	processingDuration.Record(ctx, time.Since(start).Seconds(), attrs)
+}
@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// serializer is the common interface of
+// worker message and delivery types.
+type serializer interface {
+	Serialize() ([]byte, error)
+}
+
+// taskStore implements queue.Store{} for worker
+// pool queues, persisting the queued values to
+// the database as gtsmodel.WorkerTask{}s.
+//
+// Store writes ignore cancellation of the passed
+// context (e.g. the request, or a stopping worker),
+// which is only used for its values when logging.
+type taskStore[T serializer] struct {
+	db  db.WorkerTask
+	typ gtsmodel.WorkerType
+}
+
+func (s *taskStore[T]) Put(ctx context.Context, value T) (uint, error) {
+	ctx = context.WithoutCancel(ctx)
+
+	data, err := value.Serialize()
+	if err != nil {
+		log.Errorf(ctx, "error serializing %s task: %v", s.typ, err)
+		return 0, err
+	}
+
+	task := &gtsmodel.WorkerTask{
+		WorkerType: s.typ,
+		TaskData:   data,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.db.PutWorkerTask(ctx, task); err != nil {
+		log.Errorf(ctx, "error persisting %s task: %v", s.typ, err)
+		return 0, err
+	}
+
+	return task.ID, nil
+}
+
+func (s *taskStore[T]) Delete(ctx context.Context, id uint) {
+	ctx = context.WithoutCancel(ctx)
+
+	if err := s.db.DeleteWorkerTaskByID(ctx, id); err != nil {
+		log.Errorf(ctx, "error deleting %s task %d: %v", s.typ, id, err)
+	}
+}
+
+func (s *taskStore[T]) Fail(ctx context.Context, id uint, err error) {
+	ctx = context.WithoutCancel(ctx)
+
+	task := &gtsmodel.WorkerTask{
+		ID:       id,
+		FailedAt: time.Now(),
+		Error:    err.Error(),
+	}
+
+	if err := s.db.UpdateWorkerTask(ctx,
+		task,
+		"failed_at",
+		"error",
+	); err != nil {
+		log.Errorf(ctx, "error marking %s task %d failed: %v", s.typ, id, err)
+	}
+}
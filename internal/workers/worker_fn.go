@@ -19,11 +19,13 @@ package workers
 
 import (
 	"context"
+	"time"
 
 	"codeberg.org/gruf/go-runners"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/queue"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // FnWorkerPool wraps multiple FnWorker{}s in
@@ -35,6 +37,7 @@ type FnWorkerPool struct {
 	Queue queue.SimpleQueue[func(context.Context)]
 
 	// internal fields.
+	name    string
 	workers []*FnWorker
 }
 
@@ -54,6 +57,7 @@ func (p *FnWorkerPool) Start(n int) {
 		// Allocate new FnWorker{}.
 		p.workers[i] = new(FnWorker)
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].attrs = poolAttrs(p.name)
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -97,6 +101,7 @@ type FnWorker struct {
 
 	// internal fields.
 	service runners.Service
+	attrs   metric.MeasurementOption
 }
 
 // Start will attempt to start the Worker{}.
@@ -137,6 +142,8 @@ func (w *FnWorker) process(ctx context.Context) {
 		}
 
 		// run!
+		start := time.Now()
 		fn(ctx)
+		recordProcessing(ctx, start, w.attrs)
 	}
 }
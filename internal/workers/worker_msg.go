@@ -19,12 +19,14 @@ package workers
 
 import (
 	"context"
+	"time"
 
 	"codeberg.org/gruf/go-runners"
 	"codeberg.org/gruf/go-structr"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/queue"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // MsgWorkerPool wraps multiple MsgWorker{}s in
@@ -39,6 +41,7 @@ type MsgWorkerPool[Msg any] struct {
 	Queue queue.StructQueue[Msg]
 
 	// internal fields.
+	name    string
 	workers []*MsgWorker[Msg]
 }
 
@@ -64,6 +67,7 @@ func (p *MsgWorkerPool[T]) Start(n int) {
 		p.workers[i] = new(MsgWorker[T])
 		p.workers[i].Process = p.Process
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].attrs = poolAttrs(p.name)
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -110,6 +114,7 @@ type MsgWorker[Msg any] struct {
 
 	// internal fields.
 	service runners.Service
+	attrs   metric.MeasurementOption
 }
 
 // Start will attempt to start the Worker{}.
@@ -148,8 +153,20 @@ func (w *MsgWorker[T]) process(ctx context.Context) {
 		}
 
 		// Attempt to process popped message type.
-		if err := w.Process(ctx, msg); err != nil {
+		start := time.Now()
+		err := w.Process(ctx, msg)
+		recordProcessing(ctx, start, w.attrs)
+		if err != nil {
 			log.Errorf(ctx, "%p: error processing: %v", w, err)
 		}
+
+		if err != nil && ctx.Err() != nil {
+			// Worker was stopped mid-processing,
+			// leave in any store for replay.
+			return
+		}
+
+		// Mark message done.
+		w.Queue.Done(ctx, msg, err)
 	}
 }
@@ -21,6 +21,8 @@ import (
 	"runtime"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
@@ -49,6 +51,18 @@ type Workers struct {
 	// for asynchronous dereferencer jobs.
 	Dereference FnWorkerPool
 
+	// Processing provides a worker pool for
+	// long-running jobs requested by users,
+	// like account archives and archive imports.
+	Processing FnWorkerPool
+
+	// Import provides a worker pool for CSV
+	// imports of follows, blocks etc., which
+	// are throttled and so may run for a very
+	// long time, kept apart from Processing so
+	// they can't hold up other users' jobs.
+	Import FnWorkerPool
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -59,6 +73,25 @@ func (w *Workers) StartScheduler() {
 	log.Info(nil, "started scheduler")
 }
 
+// SetTaskStore sets the database to use for write-ahead
+// persistence of tasks queued in the delivery, client and
+// federator worker pools, such that they may be replayed
+// on startup. This should be called before starting pools.
+func (w *Workers) SetTaskStore(db db.WorkerTask) {
+	w.Delivery.Queue.SetStore(&taskStore[*delivery.Delivery]{
+		db:  db,
+		typ: gtsmodel.DeliveryWorker,
+	})
+	w.Client.Queue.SetStore(&taskStore[*messages.FromClientAPI]{
+		db:  db,
+		typ: gtsmodel.ClientWorker,
+	})
+	w.Federator.Queue.SetStore(&taskStore[*messages.FromFediAPI]{
+		db:  db,
+		typ: gtsmodel.FederatorWorker,
+	})
+}
+
 // Start will start contained worker pools.
 func (w *Workers) Start() {
 	var n int
@@ -70,16 +103,29 @@ func (w *Workers) Start() {
 	log.Infof(nil, "started %d delivery workers", n)
 
 	n = 4 * maxprocs
+	w.Client.name = "client"
 	w.Client.Start(n)
 	log.Infof(nil, "started %d client workers", n)
 
 	n = 4 * maxprocs
+	w.Federator.name = "federator"
 	w.Federator.Start(n)
 	log.Infof(nil, "started %d federator workers", n)
 
 	n = 4 * maxprocs
+	w.Dereference.name = "dereference"
 	w.Dereference.Start(n)
 	log.Infof(nil, "started %d dereference workers", n)
+
+	n = maxprocs
+	w.Processing.name = "processing"
+	w.Processing.Start(n)
+	log.Infof(nil, "started %d processing workers", n)
+
+	n = maxprocs
+	w.Import.name = "import"
+	w.Import.Start(n)
+	log.Infof(nil, "started %d import workers", n)
 }
 
 // Stop will stop all of the contained worker pools (and global scheduler).
@@ -97,6 +143,12 @@ func (w *Workers) Stop() {
 
 	w.Dereference.Stop()
 	log.Info(nil, "stopped dereference workers")
+
+	w.Processing.Stop()
+	log.Info(nil, "stopped processing workers")
+
+	w.Import.Stop()
+	log.Info(nil, "stopped import workers")
 }
 
 // nocopy when embedded will signal linter to
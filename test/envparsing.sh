@@ -7,10 +7,12 @@ EXPECT=$(cat << "EOF"
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
     "accounts-custom-css-length": 5000,
+    "accounts-invite-policy": "users",
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
+    "advanced-failed-worker-task-days": 7,
     "advanced-header-filter-mode": "block",
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
@@ -32,8 +34,12 @@ EXPECT=$(cat << "EOF"
         "block-mem-ratio": 2,
         "boost-of-ids-mem-ratio": 3,
         "client-mem-ratio": 0.1,
+        "conversation-last-status-ids-mem-ratio": 2,
+        "conversation-mem-ratio": 1,
         "emoji-category-mem-ratio": 0.1,
         "emoji-mem-ratio": 3,
+        "featured-tag-ids-mem-ratio": 0.5,
+        "featured-tag-mem-ratio": 0.5,
         "filter-keyword-mem-ratio": 0.5,
         "filter-mem-ratio": 0.5,
         "filter-status-mem-ratio": 0.5,
@@ -41,6 +47,8 @@ EXPECT=$(cat << "EOF"
         "follow-mem-ratio": 2,
         "follow-request-ids-mem-ratio": 2,
         "follow-request-mem-ratio": 2,
+        "followed-tag-ids-mem-ratio": 1,
+        "followed-tag-mem-ratio": 0.5,
         "in-reply-to-ids-mem-ratio": 3,
         "instance-mem-ratio": 1,
         "list-entry-mem-ratio": 2,
@@ -54,9 +62,12 @@ EXPECT=$(cat << "EOF"
         "poll-mem-ratio": 1,
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
+        "preview-card-mem-ratio": 1,
         "report-mem-ratio": 1,
+        "scheduled-status-mem-ratio": 0.5,
         "status-bookmark-ids-mem-ratio": 2,
         "status-bookmark-mem-ratio": 0.5,
+        "status-edit-mem-ratio": 2,
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
@@ -68,6 +79,7 @@ EXPECT=$(cat << "EOF"
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
+        "web-push-subscription-mem-ratio": 1,
         "webfinger-mem-ratio": 0.1
     },
     "config-path": "internal/config/testdata/test.yaml",
@@ -105,6 +117,8 @@ EXPECT=$(cat << "EOF"
         "nl",
         "en-GB"
     ],
+    "instance-subscriptions-process-every": 86400000000000,
+    "instance-subscriptions-process-from": "23:00",
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -128,6 +142,7 @@ EXPECT=$(cat << "EOF"
     "metrics-auth-password": "",
     "metrics-auth-username": "",
     "metrics-enabled": false,
+    "minimal": false,
     "oidc-admin-groups": [
         "steamy"
     ],
@@ -162,6 +177,8 @@ EXPECT=$(cat << "EOF"
     "statuses-media-max-files": 1,
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
+    "statuses-remote-cache-days": 30,
+    "storage-archive": "",
     "storage-backend": "local",
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
@@ -254,6 +271,7 @@ GTS_STATUSES_CW_MAX_CHARS=420 \
 GTS_STATUSES_POLL_MAX_OPTIONS=1 \
 GTS_STATUSES_POLL_OPTIONS_MAX_CHARS=69 \
 GTS_STATUSES_MEDIA_MAX_FILES=1 \
+GTS_STATUSES_REMOTE_CACHE_DAYS=30 \
 GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
@@ -283,6 +301,7 @@ GTS_ADVANCED_COOKIES_SAMESITE='strict' \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
+GTS_ADVANCED_FAILED_WORKER_TASK_DAYS=7 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
 GTS_ADVANCED_HEADER_FILTER_MODE='block' \
@@ -80,9 +80,12 @@ func testDefaults() config.Configuration {
 				TagStr: "en-gb",
 			},
 		},
+		InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm.
+		InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 
 		AccountsRegistrationOpen: true,
 		AccountsReasonRequired:   true,
+		AccountsInvitePolicy:     "moderators",
 		AccountsAllowCustomCSS:   true,
 		AccountsCustomCSSLength:  10000,
 
@@ -106,6 +109,7 @@ func testDefaults() config.Configuration {
 		StatusesPollMaxOptions:     6,
 		StatusesPollOptionMaxChars: 50,
 		StatusesMediaMaxFiles:      6,
+		StatusesRemoteCacheDays:    0,
 
 		LetsEncryptEnabled:      false,
 		LetsEncryptPort:         0,
@@ -146,6 +150,7 @@ func testDefaults() config.Configuration {
 		AdvancedRateLimitRequests:    0, // disabled
 		AdvancedThrottlingMultiplier: 0, // disabled
 		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+		AdvancedFailedWorkerTaskDays: 30,
 
 		SoftwareVersion: "0.0.0-testrig",
 
@@ -29,10 +29,19 @@ import (
 
 var testModels = []interface{}{
 	&gtsmodel.Account{},
+	&gtsmodel.AccountArchive{},
 	&gtsmodel.AccountToEmoji{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementDismissal{},
+	&gtsmodel.AnnouncementReaction{},
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPermissionSubscription{},
+	&gtsmodel.FeaturedTag{},
+	&gtsmodel.FollowedTag{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
@@ -46,7 +55,12 @@ var testModels = []interface{}{
 	&gtsmodel.Mention{},
 	&gtsmodel.Poll{},
 	&gtsmodel.PollVote{},
+	&gtsmodel.PreviewCard{},
+	&gtsmodel.Relay{},
+	&gtsmodel.ModerationPolicy{},
+	&gtsmodel.ScheduledStatus{},
 	&gtsmodel.Status{},
+	&gtsmodel.StatusEdit{},
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
@@ -57,8 +71,12 @@ var testModels = []interface{}{
 	&gtsmodel.ThreadToStatus{},
 	&gtsmodel.User{},
 	&gtsmodel.UserMute{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.WorkerTask{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
+	&gtsmodel.Invite{},
 	&gtsmodel.Notification{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
@@ -163,6 +181,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestDomainPermissionSubscriptions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	for _, v := range NewTestInstances() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
@@ -345,6 +369,16 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestInvites() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
+	if err := db.Put(ctx, NewTestVAPIDKeyPair()); err != nil {
+		log.Panic(nil, err)
+	}
+
 	if err := db.CreateInstanceAccount(ctx); err != nil {
 		log.Panic(nil, err)
 	}
@@ -353,6 +387,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		log.Panic(nil, err)
 	}
 
+	// Fixtures are put without going through
+	// PutStatus / PutAccount, so index them now.
+	if err := db.RebuildSearchIndex(ctx); err != nil {
+		log.Panic(nil, err)
+	}
+
 	log.Debug(nil, "testing db setup complete")
 }
 
@@ -1314,6 +1314,24 @@ func NewTestDomainBlocks() map[string]*gtsmodel.DomainBlock {
 	}
 }
 
+func NewTestDomainPermissionSubscriptions() map[string]*gtsmodel.DomainPermissionSubscription {
+	return map[string]*gtsmodel.DomainPermissionSubscription{
+		"subscription_1": {
+			ID:                 "01JGE681TQSBPAV59GZXPKE62H",
+			CreatedAt:          TimeMustParse("2024-07-05T12:00:00+02:00"),
+			UpdatedAt:          TimeMustParse("2024-07-05T12:00:00+02:00"),
+			Priority:           255,
+			Title:              "baddies",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			AdoptOrphans:       util.Ptr(false),
+			RemoveOrphans:      util.Ptr(false),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			URI:                "https://lists.example.org/baddies.csv",
+			ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+		},
+	}
+}
+
 type filenames struct {
 	Original string
 	Small    string
@@ -2985,6 +3003,46 @@ func NewTestFediAttachments(relativePath string) map[string]RemoteAttachmentFile
 	}
 }
 
+// RemoteDomainPermissionList mimics a remotely-hosted list of domain permissions.
+type RemoteDomainPermissionList struct {
+	Data        []byte
+	ContentType string
+}
+
+func NewTestFediDomainPermissionLists() map[string]RemoteDomainPermissionList {
+	return map[string]RemoteDomainPermissionList{
+		"https://lists.example.org/baddies.csv": {
+			Data: []byte(`#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,suspend,false,false,harassment,false
+nothanks.com,suspend,false,false,,false
+quiet.example,silence,false,false,too noisy,false`),
+			ContentType: "text/csv",
+		},
+		"https://lists.example.org/baddies.json": {
+			Data: []byte(`[
+  {
+    "domain": "bumfaces.net",
+    "public_comment": "big jerks"
+  },
+  {
+    "domain": "peepee.poopoo",
+    "public_comment": "harassment"
+  }
+]`),
+			ContentType: "application/json",
+		},
+		"https://lists.example.org/baddies.txt": {
+			Data: []byte(`# baddies
+bumfaces.net
+peepee.poopoo
+nothanks.com
+`),
+			ContentType: "text/plain",
+		},
+	}
+}
+
 func NewTestFediStatuses() map[string]vocab.ActivityStreamsNote {
 	return map[string]vocab.ActivityStreamsNote{
 		"http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1": NewAPNote(
@@ -3373,6 +3431,39 @@ func NewTestUserMutes() map[string]*gtsmodel.UserMute {
 	return map[string]*gtsmodel.UserMute{}
 }
 
+func NewTestInvites() map[string]*gtsmodel.Invite {
+	return map[string]*gtsmodel.Invite{
+		"admin_account_invite": {
+			ID:        "01J2RQ7Y3ZB1M5Q6C9F0N4X8KD",
+			CreatedAt: TimeMustParse("2024-07-14T10:00:00Z"),
+			UpdatedAt: TimeMustParse("2024-07-14T10:00:00Z"),
+			Code:      "k7w3NqZp",
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			MaxUses:   5,
+			Uses:      1,
+		},
+		"local_account_1_invite_expired": {
+			ID:        "01J2RQ9HE7TTYV2S8W5D1G3MPA",
+			CreatedAt: TimeMustParse("2024-07-14T11:00:00Z"),
+			UpdatedAt: TimeMustParse("2024-07-14T11:00:00Z"),
+			Code:      "Xr2b8LmT",
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			ExpiresAt: TimeMustParse("2024-07-15T11:00:00Z"),
+		},
+	}
+}
+
+// NewTestVAPIDKeyPair returns a fixed VAPID key pair,
+// so that API responses including it are predictable.
+func NewTestVAPIDKeyPair() *gtsmodel.VAPIDKeyPair {
+	return &gtsmodel.VAPIDKeyPair{
+		ID:        "01J1QZ5T3NW7ZNWQ1RXS6DJ7TM",
+		CreatedAt: TimeMustParse("2024-06-30T10:00:00Z"),
+		Public:    "BP7pFKyn5MsXib9R6zZUzDy-9ivK7W7Lbdki3ZknFj_BxkssAmK1CV6zE5FV5B0Jx_Tt-grJFSsWpZhfRbBOuXQ",
+		Private:   "BT1wAdOpXTvFDSof7bpyAFCX7MFZiZ3mG9NCNFC4GnM",
+	}
+}
+
 // GetSignatureForActivity prepares a mock HTTP request as if it were going to deliver activity to destination signed for privkey and pubKeyID, signs the request and returns the header values.
 func GetSignatureForActivity(activity pub.Activity, pubKeyID string, privkey *rsa.PrivateKey, destination *url.URL) (signatureHeader string, digestHeader string, dateHeader string) {
 	// convert the activity into json bytes
@@ -64,6 +64,7 @@ type MockHTTPClient struct {
 	TestRemoteGroups      map[string]vocab.ActivityStreamsGroup
 	TestRemoteServices    map[string]vocab.ActivityStreamsService
 	TestRemoteAttachments map[string]RemoteAttachmentFile
+	TestRemoteDomainPerms map[string]RemoteDomainPermissionList
 	TestRemoteEmojis      map[string]vocab.TootEmoji
 	TestTombstones        map[string]*gtsmodel.Tombstone
 
@@ -92,6 +93,7 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 	mockHTTPClient.TestRemoteGroups = NewTestFediGroups()
 	mockHTTPClient.TestRemoteServices = NewTestFediServices()
 	mockHTTPClient.TestRemoteAttachments = NewTestFediAttachments(relativeMediaPath)
+	mockHTTPClient.TestRemoteDomainPerms = NewTestFediDomainPermissionLists()
 	mockHTTPClient.TestRemoteEmojis = NewTestFediEmojis()
 	mockHTTPClient.TestTombstones = NewTestTombstones()
 
@@ -202,6 +204,11 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseBytes = attachment.Data
 			responseContentType = attachment.ContentType
 			responseContentLength = len(attachment.Data)
+		} else if list, ok := mockHTTPClient.TestRemoteDomainPerms[reqURLString]; ok {
+			responseCode = http.StatusOK
+			responseBytes = list.Data
+			responseContentType = list.ContentType
+			responseContentLength = len(list.Data)
 		} else if _, ok := mockHTTPClient.TestTombstones[reqURLString]; ok {
 			responseCode = http.StatusGone
 			responseBytes = []byte{}
@@ -55,6 +55,8 @@ func StartNoopWorkers(state *state.State) {
 	// _ = state.Workers.Client.Start(1)
 	// _ = state.Workers.Federator.Start(1)
 	// _ = state.Workers.Dereference.Start(1)
+	// _ = state.Workers.Processing.Start(1)
+	// _ = state.Workers.Import.Start(1)
 	// _ = state.Workers.Media.Start(1)
 	//
 	// (except for the scheduler, that's fine)
@@ -82,6 +84,8 @@ func StartWorkers(state *state.State, processor *workers.Processor) {
 	state.Workers.Client.Start(1)
 	state.Workers.Federator.Start(1)
 	state.Workers.Dereference.Start(1)
+	state.Workers.Processing.Start(1)
+	state.Workers.Import.Start(1)
 }
 
 func StopWorkers(state *state.State) {
@@ -89,6 +93,8 @@ func StopWorkers(state *state.State) {
 	state.Workers.Client.Stop()
 	state.Workers.Federator.Stop()
 	state.Workers.Dereference.Stop()
+	state.Workers.Processing.Stop()
+	state.Workers.Import.Stop()
 }
 
 func StartTimelines(state *state.State, filter *visibility.Filter, converter *typeutils.Converter) {
@@ -124,7 +124,9 @@ const gtsBaseQuery: BaseQueryFn<
 			if (token != undefined) {
 				headers.set('Authorization', token);
 			}
-			headers.set("Accept", "application/json");
+			if (!headers.has("Accept")) {
+				headers.set("Accept", "application/json");
+			}
 			return headers;
 		},
 	})(args, api, extraOptions);
@@ -141,6 +143,10 @@ export const gtsApi = createApi({
 		"InstanceRules",
 		"HTTPHeaderAllows",
 		"HTTPHeaderBlocks",
+		"Invite",
+		"Token",
+		"Archive",
+		"User",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
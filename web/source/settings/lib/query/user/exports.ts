@@ -0,0 +1,154 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+import fileDownload from "js-file-download";
+
+import { gtsApi } from "../gts-api";
+import type { FetchBaseQueryError } from "@reduxjs/toolkit/query";
+import type { AccountArchive, ExportType, ImportFormData } from "../../types/export";
+
+// Filenames used by Mastodon for each CSV export,
+// so that files can be moved between the two easily.
+const exportFilenames: Record<ExportType, string> = {
+	following: "following_accounts.csv",
+	blocks: "blocked_accounts.csv",
+	mutes: "muted_accounts.csv",
+	bookmarks: "bookmarks.csv",
+	lists: "lists.csv",
+};
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		exportCSV: build.mutation<null, ExportType>({
+			async queryFn(exportType, _api, _extraOpts, fetchWithBQ) {
+				const res = await fetchWithBQ({
+					url: `/api/v1/exports/${exportType}.csv`,
+					headers: { "Accept": "text/csv" },
+					responseHandler: (response) => response.blob()
+				});
+				if (res.error) {
+					return { error: res.error as FetchBaseQueryError };
+				}
+
+				fileDownload(res.data as Blob, exportFilenames[exportType], "text/csv");
+				return { data: null };
+			}
+		}),
+
+		importCSV: build.mutation<any, ImportFormData>({
+			query: (formData) => ({
+				method: "POST",
+				url: `/api/v1/import`,
+				asForm: true,
+				body: formData,
+				discardEmpty: true
+			})
+		}),
+
+		importArchive: build.mutation<any, { data: File }>({
+			query: (formData) => ({
+				method: "POST",
+				url: `/api/v1/import/archive`,
+				asForm: true,
+				body: formData,
+			})
+		}),
+
+		getArchive: build.query<AccountArchive | null, void>({
+			async queryFn(_arg, _api, _extraOpts, fetchWithBQ) {
+				const res = await fetchWithBQ({ url: `/api/v1/exports/archive` });
+				if (res.error) {
+					// No archive requested yet.
+					if (res.error.status === 404) {
+						return { data: null };
+					}
+					return { error: res.error as FetchBaseQueryError };
+				}
+				return { data: res.data as AccountArchive };
+			},
+			providesTags: ["Archive"]
+		}),
+
+		requestArchive: build.mutation<AccountArchive, void>({
+			query: () => ({
+				method: "POST",
+				url: `/api/v1/exports/archive`
+			}),
+			invalidatesTags: ["Archive"]
+		}),
+
+		downloadArchive: build.mutation<null, void>({
+			async queryFn(_arg, _api, _extraOpts, fetchWithBQ) {
+				const res = await fetchWithBQ({
+					url: `/api/v1/exports/archive/download`,
+					headers: { "Accept": "application/zip" },
+					responseHandler: (response) => response.blob()
+				});
+				if (res.error) {
+					return { error: res.error as FetchBaseQueryError };
+				}
+
+				fileDownload(res.data as Blob, "archive.zip", "application/zip");
+				return { data: null };
+			}
+		}),
+	}),
+});
+
+/**
+ * Download one of the logged-in user's
+ * CSV exports, in Mastodon's format.
+ */
+const useExportCSVMutation = extended.useExportCSVMutation;
+
+/**
+ * Upload a Mastodon-format CSV file
+ * to be imported in the background.
+ */
+const useImportCSVMutation = extended.useImportCSVMutation;
+
+/**
+ * Upload a Mastodon-style account archive
+ * to have its posts imported in the background.
+ */
+const useImportArchiveMutation = extended.useImportArchiveMutation;
+
+/**
+ * Get the logged-in user's most recent
+ * account archive, or null if there is none.
+ */
+const useGetArchiveQuery = extended.useGetArchiveQuery;
+
+/**
+ * Request generation of a new account archive.
+ */
+const useRequestArchiveMutation = extended.useRequestArchiveMutation;
+
+/**
+ * Download the most recently completed account archive.
+ */
+const useDownloadArchiveMutation = extended.useDownloadArchiveMutation;
+
+export {
+	useExportCSVMutation,
+	useImportCSVMutation,
+	useImportArchiveMutation,
+	useGetArchiveQuery,
+	useRequestArchiveMutation,
+	useDownloadArchiveMutation,
+};
@@ -24,7 +24,8 @@ import type {
 	UpdateAliasesFormData
 } from "../../types/migration";
 import type { Theme } from "../../types/theme";
-import { User } from "../../types/user";
+import type { FetchBaseQueryError } from "@reduxjs/toolkit/query";
+import type { TwoFactorQRCodeURI, User } from "../../types/user";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -39,14 +40,16 @@ const extended = gtsApi.injectEndpoints({
 			...replaceCacheOnMutation("verifyCredentials")
 		}),
 		user: build.query<User, void>({
-			query: () => ({url: `/api/v1/user`})
+			query: () => ({url: `/api/v1/user`}),
+			providesTags: ["User"]
 		}),
 		passwordChange: build.mutation({
 			query: (data) => ({
 				method: "POST",
 				url: `/api/v1/user/password_change`,
 				body: data
-			})
+			}),
+			invalidatesTags: [{ type: "Token", id: "LIST" }],
 		}),
 		emailChange: build.mutation<User, { password: string, new_email: string }>({
 			query: (data) => ({
@@ -56,6 +59,52 @@ const extended = gtsApi.injectEndpoints({
 			}),
 			...replaceCacheOnMutation("user")
 		}),
+		twoFactorQRCodeURI: build.query<TwoFactorQRCodeURI, void>({
+			query: () => ({
+				url: `/api/v1/user/2fa/qruri`
+			}),
+			keepUnusedDataFor: 0
+		}),
+		twoFactorQRCodePNG: build.query<string, void>({
+			async queryFn(_arg, _api, _extraOpts, fetchWithBQ) {
+				const res = await fetchWithBQ({
+					url: `/api/v1/user/2fa/qr.png`,
+					headers: { "Accept": "image/png" },
+					responseHandler: (response) => response.blob()
+				});
+				if (res.error) {
+					return { error: res.error as FetchBaseQueryError };
+				}
+
+				// Store the image as a data URL, as
+				// blobs can't go in the redux store.
+				const dataURL = await new Promise<string>((resolve, reject) => {
+					const reader = new FileReader();
+					reader.onload = () => resolve(reader.result as string);
+					reader.onerror = () => reject(reader.error);
+					reader.readAsDataURL(res.data as Blob);
+				});
+
+				return { data: dataURL };
+			},
+			keepUnusedDataFor: 0
+		}),
+		twoFactorEnable: build.mutation<string[], { code: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/enable`,
+				body: data
+			}),
+			invalidatesTags: ["User"]
+		}),
+		twoFactorDisable: build.mutation<any, { password: string }>({
+			query: (data) => ({
+				method: "POST",
+				url: `/api/v1/user/2fa/disable`,
+				body: data
+			}),
+			invalidatesTags: ["User"]
+		}),
 		aliasAccount: build.mutation<any, UpdateAliasesFormData>({
 			async queryFn(formData, _api, _extraOpts, fetchWithBQ) {
 				// Pull entries out from the hooked form.
@@ -93,6 +142,10 @@ export const {
 	useUserQuery,
 	usePasswordChangeMutation,
 	useEmailChangeMutation,
+	useTwoFactorQRCodeURIQuery,
+	useTwoFactorQRCodePNGQuery,
+	useTwoFactorEnableMutation,
+	useTwoFactorDisableMutation,
 	useAliasAccountMutation,
 	useMoveAccountMutation,
 	useAccountThemesQuery,
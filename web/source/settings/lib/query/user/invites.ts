@@ -0,0 +1,77 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../gts-api";
+import type { Invite, InviteCreateFormData } from "../../types/invite";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getInvites: build.query<Invite[], void>({
+			query: () => ({
+				url: `/api/v1/invites`
+			}),
+			providesTags: (res) =>
+				res
+					? [
+						...res.map(({ id }) => ({ type: "Invite" as const, id })),
+						{ type: "Invite", id: "LIST" },
+					]
+					: [{ type: "Invite", id: "LIST" }],
+		}),
+
+		createInvite: build.mutation<Invite, InviteCreateFormData>({
+			query: (formData) => ({
+				method: "POST",
+				url: `/api/v1/invites`,
+				asForm: true,
+				body: formData,
+			}),
+			invalidatesTags: [{ type: "Invite", id: "LIST" }],
+		}),
+
+		revokeInvite: build.mutation<Invite, string>({
+			query: (id) => ({
+				method: "DELETE",
+				url: `/api/v1/invites/${id}`
+			}),
+			invalidatesTags: (_res, _error, id) => [{ type: "Invite", id }],
+		}),
+	}),
+});
+
+/**
+ * Get all invites created by the logged-in account.
+ */
+const useGetInvitesQuery = extended.useGetInvitesQuery;
+
+/**
+ * Create a new invite.
+ */
+const useCreateInviteMutation = extended.useCreateInviteMutation;
+
+/**
+ * Revoke one invite by its ID.
+ */
+const useRevokeInviteMutation = extended.useRevokeInviteMutation;
+
+export {
+	useGetInvitesQuery,
+	useCreateInviteMutation,
+	useRevokeInviteMutation,
+};
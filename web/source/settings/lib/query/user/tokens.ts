@@ -0,0 +1,77 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../gts-api";
+import type { TokenInfo } from "../../types/token";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		getTokens: build.query<TokenInfo[], void>({
+			query: () => ({
+				url: `/api/v1/tokens?limit=80`
+			}),
+			providesTags: (res) =>
+				res
+					? [
+						...res.map(({ id }) => ({ type: "Token" as const, id })),
+						{ type: "Token", id: "LIST" },
+					]
+					: [{ type: "Token", id: "LIST" }],
+		}),
+
+		invalidateToken: build.mutation<TokenInfo, string>({
+			query: (id) => ({
+				method: "POST",
+				url: `/api/v1/tokens/${id}/invalidate`
+			}),
+			invalidatesTags: (_res, _error, id) => [{ type: "Token", id }],
+		}),
+
+		invalidateAppTokens: build.mutation<TokenInfo[], string>({
+			query: (applicationID) => ({
+				method: "POST",
+				url: `/api/v1/tokens/invalidate`,
+				asForm: true,
+				body: { application_id: applicationID },
+			}),
+			invalidatesTags: [{ type: "Token", id: "LIST" }],
+		}),
+	}),
+});
+
+/**
+ * Get access tokens issued to the logged-in user.
+ */
+const useGetTokensQuery = extended.useGetTokensQuery;
+
+/**
+ * Invalidate one token by its ID.
+ */
+const useInvalidateTokenMutation = extended.useInvalidateTokenMutation;
+
+/**
+ * Invalidate all tokens of one application, by application ID.
+ */
+const useInvalidateAppTokensMutation = extended.useInvalidateAppTokensMutation;
+
+export {
+	useGetTokensQuery,
+	useInvalidateTokenMutation,
+	useInvalidateAppTokensMutation,
+};
@@ -31,4 +31,9 @@ export interface User {
 	disabled: boolean;
 	approved: boolean;
 	reset_password_sent_at?: string;
+	two_factor_enabled_at?: string;
+}
+
+export interface TwoFactorQRCodeURI {
+	uri: string;
 }
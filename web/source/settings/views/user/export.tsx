@@ -0,0 +1,283 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+import React from "react";
+import prettierBytes from "prettier-bytes";
+import MutationButton from "../../components/form/mutation-button";
+import Loading from "../../components/loading";
+import { Error } from "../../components/error";
+import { FileInput, Select } from "../../components/form/inputs";
+import { useFileInput, useTextInput } from "../../lib/form";
+import useFormSubmit from "../../lib/form/submit";
+import { NoArg } from "../../lib/types/query";
+import type { ExportType } from "../../lib/types/export";
+import {
+	useDownloadArchiveMutation,
+	useExportCSVMutation,
+	useGetArchiveQuery,
+	useImportArchiveMutation,
+	useImportCSVMutation,
+	useRequestArchiveMutation,
+} from "../../lib/query/user/exports";
+
+export default function UserExport() {
+	return (
+		<div className="user-export">
+			<div className="form-section-docs">
+				<h1>Import and Export</h1>
+				<p>
+					Here you can download your follows, blocks, mutes, bookmarks and lists as
+					CSV files, in the same format used by Mastodon, and import such files from
+					another account. You can also request an archive of your posts, media and
+					profile, or import the posts from an archive of another account.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/importing_exporting/"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+			<ExportCSV />
+			<ImportCSV />
+			<Archive />
+			<ImportArchive />
+		</div>
+	);
+}
+
+const exportTypes: { type: ExportType, label: string }[] = [
+	{ type: "following", label: "Follows" },
+	{ type: "blocks", label: "Blocks" },
+	{ type: "mutes", label: "Mutes" },
+	{ type: "bookmarks", label: "Bookmarks" },
+	{ type: "lists", label: "Lists" },
+];
+
+function ExportCSV() {
+	return (
+		<div className="export-csv">
+			<h2>Export CSV</h2>
+			<div className="action-buttons">
+				{exportTypes.map(({ type, label }) =>
+					<ExportButton key={type} type={type} label={label} />
+				)}
+			</div>
+		</div>
+	);
+}
+
+function ExportButton({ type, label }: { type: ExportType, label: string }) {
+	const [ exportTrigger, exportResult ] = useExportCSVMutation();
+
+	return (
+		<MutationButton
+			type="button"
+			onClick={() => exportTrigger(type)}
+			label={label}
+			result={exportResult}
+			disabled={false}
+		/>
+	);
+}
+
+function ImportCSV() {
+	const form = {
+		data: useFileInput("data"),
+		type: useTextInput("type", { defaultValue: "following" }),
+		mode: useTextInput("mode", { defaultValue: "merge" }),
+	};
+
+	const [formSubmit, result] = useFormSubmit(
+		form,
+		useImportCSVMutation(),
+		{
+			changedOnly: false,
+			onFinish: () => form.data.reset(),
+		},
+	);
+
+	return (
+		<form className="import-csv" onSubmit={formSubmit}>
+			<h2>Import CSV</h2>
+			<p>
+				Imports are processed in the background, so it may take a while before
+				everything shows up, especially when the file refers to many remote accounts.
+			</p>
+			<FileInput
+				field={form.data}
+				label="CSV file"
+				accept=".csv,text/csv"
+			/>
+			<Select
+				field={form.type}
+				label="Type of data"
+				options={<>
+					{exportTypes.map(({ type, label }) =>
+						<option key={type} value={type}>{label}</option>
+					)}
+				</>}
+			/>
+			<Select
+				field={form.mode}
+				label="Import mode"
+				options={<>
+					<option value="merge">Merge: keep existing entries and add the ones from the file</option>
+					<option value="overwrite">Overwrite: also remove existing entries that aren&apos;t in the file</option>
+				</>}
+			/>
+			{ result.isSuccess &&
+				<div className="info">
+					<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+					<b>Import started.</b>
+				</div>
+			}
+			<MutationButton
+				label="Import"
+				result={result}
+				disabled={form.data.value === undefined}
+			/>
+		</form>
+	);
+}
+
+function Archive() {
+	const {
+		data: archive,
+		isLoading,
+		isFetching,
+		isError,
+		error,
+		refetch,
+	} = useGetArchiveQuery(NoArg);
+	const [ requestTrigger, requestResult ] = useRequestArchiveMutation();
+	const [ downloadTrigger, downloadResult ] = useDownloadArchiveMutation();
+
+	let content: React.JSX.Element;
+	if (isLoading || isFetching) {
+		content = <Loading />;
+	} else if (isError) {
+		content = <Error error={error} />;
+	} else if (!archive) {
+		content = <p>You haven&apos;t requested an archive yet.</p>;
+	} else {
+		content = (
+			<dl className="entry">
+				<dt>Requested</dt>
+				<dd>{new Date(archive.created_at).toLocaleString()}</dd>
+				<dt>Status</dt>
+				<dd>
+					{archive.state === "pending" && "Being generated, check back later."}
+					{archive.state === "complete" && "Ready"}
+					{archive.state === "failed" && "Failed, please try again."}
+				</dd>
+				{ archive.size !== undefined &&
+					<>
+						<dt>Size</dt>
+						<dd>{prettierBytes(archive.size)}</dd>
+					</>
+				}
+			</dl>
+		);
+	}
+
+	const canRequest =
+		!archive || (
+			archive.state !== "pending" &&
+			new Date(archive.next_request_at) <= new Date()
+		);
+
+	return (
+		<div className="archive">
+			<h2>Account archive</h2>
+			<p>
+				An archive contains your posts and their media, your profile, and your
+				likes and bookmarks, in ActivityPub format. You can request a new
+				archive once every 7 days.
+			</p>
+			{content}
+			<div className="action-buttons">
+				{ archive?.state === "complete" &&
+					<MutationButton
+						type="button"
+						onClick={() => downloadTrigger()}
+						label="Download archive"
+						result={downloadResult}
+						disabled={false}
+					/>
+				}
+				{ archive?.state === "pending" &&
+					<button type="button" onClick={() => refetch()}>
+						Refresh
+					</button>
+				}
+				<MutationButton
+					type="button"
+					onClick={() => requestTrigger()}
+					label="Request new archive"
+					result={requestResult}
+					disabled={!canRequest}
+				/>
+			</div>
+		</div>
+	);
+}
+
+function ImportArchive() {
+	const form = {
+		data: useFileInput("data"),
+	};
+
+	const [formSubmit, result] = useFormSubmit(
+		form,
+		useImportArchiveMutation(),
+		{
+			changedOnly: false,
+			onFinish: () => form.data.reset(),
+		},
+	);
+
+	return (
+		<form className="import-archive" onSubmit={formSubmit}>
+			<h2>Import archive</h2>
+			<p>
+				Import the posts from an archive exported by Mastodon or GoToSocial. Posts
+				keep their original dates and media, and are not sent to your followers&apos;
+				timelines. Importing the same archive twice will duplicate its posts.
+			</p>
+			<FileInput
+				field={form.data}
+				label="Archive zip file"
+				accept=".zip,application/zip"
+			/>
+			{ result.isSuccess &&
+				<div className="info">
+					<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+					<b>Import started.</b>
+				</div>
+			}
+			<MutationButton
+				label="Import"
+				result={result}
+				disabled={form.data.value === undefined}
+			/>
+		</form>
+	);
+}
@@ -0,0 +1,163 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import { useTextInput } from "../../lib/form";
+import useFormSubmit from "../../lib/form/submit";
+import { Select } from "../../components/form/inputs";
+import MutationButton from "../../components/form/mutation-button";
+import { PageableList } from "../../components/pageable-list";
+import { NoArg } from "../../lib/types/query";
+import { Invite } from "../../lib/types/invite";
+import {
+	useCreateInviteMutation,
+	useGetInvitesQuery,
+	useRevokeInviteMutation,
+} from "../../lib/query/user/invites";
+
+export default function UserInvites() {
+	const {
+		data: invites,
+		isLoading,
+		isFetching,
+		isSuccess,
+		isError,
+		error,
+	} = useGetInvitesQuery(NoArg);
+
+	const emptyMessage = (
+		<div className="info">
+			<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+			<b>
+				You haven't created any invites yet.
+				You can create one using the form below.
+			</b>
+		</div>
+	);
+
+	return (
+		<div className="user-invites">
+			<div className="form-section-docs">
+				<h1>Invites</h1>
+				<p>
+					Invite links let people sign up to this instance, even if registration
+					is closed. Sign-ups made with a valid invite link don't need to be
+					approved by an admin. Admins can see which accounts signed up using
+					your invites.
+					<br/>
+					Whether you can create invites depends on the instance's invite policy.
+				</p>
+			</div>
+			<PageableList
+				isLoading={isLoading}
+				isFetching={isFetching}
+				isSuccess={isSuccess}
+				isError={isError}
+				error={error}
+				items={invites}
+				itemToEntry={(invite) => <InviteEntry key={invite.id} invite={invite} />}
+				emptyMessage={emptyMessage}
+			/>
+			<InviteCreateForm />
+		</div>
+	);
+}
+
+function InviteEntry({ invite }: { invite: Invite }) {
+	const [ revokeTrigger, revokeResult ] = useRevokeInviteMutation();
+
+	const uses = invite.max_uses
+		? `${invite.uses} / ${invite.max_uses}`
+		: `${invite.uses}`;
+
+	const expires = invite.expires_at
+		? new Date(invite.expires_at).toLocaleString()
+		: "Never";
+
+	return (
+		<dl className="entry">
+			<dt>Link</dt>
+			<dd className="monospace">{invite.url}</dd>
+			<dt>Uses</dt>
+			<dd>{uses}</dd>
+			<dt>Expires</dt>
+			<dd>{invite.expired ? <b>Expired</b> : expires}</dd>
+			{ !invite.expired &&
+				<MutationButton
+					type="button"
+					onClick={() => revokeTrigger(invite.id)}
+					label="Revoke"
+					result={revokeResult}
+					className="button danger"
+					disabled={false}
+				/>
+			}
+		</dl>
+	);
+}
+
+function InviteCreateForm() {
+	const form = {
+		maxUses: useTextInput("max_uses", { defaultValue: "0" }),
+		expiresIn: useTextInput("expires_in", { defaultValue: "604800" }),
+	};
+
+	const [formSubmit, result] = useFormSubmit(
+		form,
+		useCreateInviteMutation(),
+		{ changedOnly: false },
+	);
+
+	return (
+		<form onSubmit={formSubmit}>
+			<h2>Create new invite</h2>
+			<Select
+				field={form.maxUses}
+				label="Maximum number of uses"
+				options={<>
+					<option value="0">No limit</option>
+					<option value="1">1 use</option>
+					<option value="5">5 uses</option>
+					<option value="10">10 uses</option>
+					<option value="25">25 uses</option>
+					<option value="50">50 uses</option>
+					<option value="100">100 uses</option>
+				</>}
+			/>
+			<Select
+				field={form.expiresIn}
+				label="Expire after"
+				options={<>
+					<option value="0">Never</option>
+					<option value="1800">30 minutes</option>
+					<option value="3600">1 hour</option>
+					<option value="21600">6 hours</option>
+					<option value="43200">12 hours</option>
+					<option value="86400">1 day</option>
+					<option value="604800">1 week</option>
+				</>}
+			/>
+			<MutationButton
+				label="Create invite"
+				result={result}
+				disabled={false}
+			/>
+		</form>
+	);
+}
@@ -24,6 +24,8 @@ import React from "react";
  * - /settings/user/profile
  * - /settings/user/settings
  * - /settings/user/migration
+ * - /settings/user/export
+ * - /settings/user/invites
  */
 export default function UserMenu() {	
 	return (
@@ -47,6 +49,21 @@ export default function UserMenu() {
 				itemUrl="migration"
 				icon="fa-exchange"
 			/>
+			<MenuItem
+				name="Import/Export"
+				itemUrl="export"
+				icon="fa-download"
+			/>
+			<MenuItem
+				name="Invites"
+				itemUrl="invites"
+				icon="fa-envelope-open"
+			/>
+			<MenuItem
+				name="Apps"
+				itemUrl="tokens"
+				icon="fa-key"
+			/>
 		</MenuItem>
 	);
 }
@@ -23,12 +23,18 @@ import { Redirect, Route, Router, Switch } from "wouter";
 import { ErrorBoundary } from "../../lib/navigation/error";
 import UserProfile from "./profile";
 import UserMigration from "./migration";
+import UserExport from "./export";
 import UserSettings from "./settings";
+import UserInvites from "./invites";
+import UserTokens from "./tokens";
 
 /**
  * - /settings/user/profile
  * - /settings/user/settings
  * - /settings/user/migration
+ * - /settings/user/export
+ * - /settings/user/invites
+ * - /settings/user/tokens
  */
 export default function UserRouter() {
 	const baseUrl = useBaseUrl();
@@ -43,6 +49,9 @@ export default function UserRouter() {
 						<Route path="/profile" component={UserProfile} />
 						<Route path="/settings" component={UserSettings} />
 						<Route path="/migration" component={UserMigration} />
+						<Route path="/export" component={UserExport} />
+						<Route path="/invites" component={UserInvites} />
+						<Route path="/tokens" component={UserTokens} />
 						<Route><Redirect to="/profile" /></Route>
 					</Switch>
 				</ErrorBoundary>
@@ -17,7 +17,7 @@
 	along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 
-import React from "react";
+import React, { useState } from "react";
 import { useTextInput, useBoolInput } from "../../lib/form";
 import useFormSubmit from "../../lib/form/submit";
 import { Select, TextInput, Checkbox } from "../../components/form/inputs";
@@ -25,7 +25,16 @@ import FormWithData from "../../lib/form/form-with-data";
 import Languages from "../../components/languages";
 import MutationButton from "../../components/form/mutation-button";
 import { useVerifyCredentialsQuery } from "../../lib/query/oauth";
-import { useEmailChangeMutation, usePasswordChangeMutation, useUpdateCredentialsMutation, useUserQuery } from "../../lib/query/user";
+import {
+	useEmailChangeMutation,
+	usePasswordChangeMutation,
+	useTwoFactorDisableMutation,
+	useTwoFactorEnableMutation,
+	useTwoFactorQRCodePNGQuery,
+	useTwoFactorQRCodeURIQuery,
+	useUpdateCredentialsMutation,
+	useUserQuery,
+} from "../../lib/query/user";
 import Loading from "../../components/loading";
 import { User } from "../../lib/types/user";
 import { useInstanceV1Query } from "../../lib/query/gts-api";
@@ -101,6 +110,7 @@ function UserSettingsForm({ data }) {
 				/>
 			</form>
 			<PasswordChange />
+			<TwoFactor />
 			<EmailChange />
 		</>
 	);
@@ -134,7 +144,8 @@ function PasswordChangeForm({ oidcEnabled }: { oidcEnabled?: boolean }) {
 				}
 				return "";
 			}
-		})
+		}),
+		revokeOtherTokens: useBoolInput("revoke_other_tokens"),
 	};
 
 	const verifyNewPassword = useTextInput("verifyNewPassword", {
@@ -193,6 +204,11 @@ function PasswordChangeForm({ oidcEnabled }: { oidcEnabled?: boolean }) {
 				autoComplete="new-password"
 				disabled={oidcEnabled}
 			/>
+			<Checkbox
+				field={form.revokeOtherTokens}
+				label="Sign out of all other apps and sessions"
+				disabled={oidcEnabled}
+			/>
 			<MutationButton
 				label="Change password"
 				result={result}
@@ -202,6 +218,199 @@ function PasswordChangeForm({ oidcEnabled }: { oidcEnabled?: boolean }) {
 	);
 }
 
+function TwoFactor() {
+	// Load instance data.
+	const {
+		data: instance,
+		isFetching: isFetchingInstance,
+		isLoading: isLoadingInstance
+	} = useInstanceV1Query();
+
+	// Load user data.
+	const {
+		data: user,
+		isFetching: isFetchingUser,
+		isLoading: isLoadingUser
+	} = useUserQuery();
+
+	if (
+		(isFetchingInstance || isLoadingInstance) ||
+		(isFetchingUser || isLoadingUser)
+	) {
+		return <Loading />;
+	}
+
+	if (user === undefined) {
+		throw "could not fetch user";
+	}
+
+	if (instance === undefined) {
+		throw "could not fetch instance";
+	}
+
+	const oidcEnabled = instance.configuration.oidc_enabled;
+
+	return (
+		<div className="two-factor">
+			<div className="form-section-docs">
+				<h3>Two-Factor Authentication</h3>
+				{ oidcEnabled && <p>
+					This instance is running with OIDC as its authorization + identity provider.
+					<br/>
+					This means <strong>you cannot set up two-factor authentication using this settings panel</strong>.
+					<br/>
+					To set up two-factor authentication, you should instead contact your OIDC provider.
+				</p> }
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#two-factor-authentication"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+			{ !oidcEnabled && (
+				user.two_factor_enabled_at
+					? <TwoFactorDisableForm enabledAt={user.two_factor_enabled_at} />
+					: <TwoFactorSetup />
+			)}
+		</div>
+	);
+}
+
+function TwoFactorSetup() {
+	const [ settingUp, setSettingUp ] = useState(false);
+	const [ backupCodes, setBackupCodes ] = useState<string[]>();
+
+	if (backupCodes !== undefined) {
+		return (
+			<div className="backup-codes">
+				<div className="info">
+					<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+					<b>
+						Two-factor authentication is now enabled. Below are your backup codes.
+						Each can be used once to sign in, in place of a code from your
+						authenticator app. Store them somewhere safe, as they will not be shown again!
+					</b>
+				</div>
+				<ul className="monospace">
+					{ backupCodes.map((code) => <li key={code}>{code}</li>) }
+				</ul>
+			</div>
+		);
+	}
+
+	if (!settingUp) {
+		return (
+			<button type="button" onClick={() => setSettingUp(true)}>
+				Set up two-factor authentication
+			</button>
+		);
+	}
+
+	return <TwoFactorEnableForm onEnabled={setBackupCodes} />;
+}
+
+function TwoFactorEnableForm({ onEnabled }: { onEnabled: (_codes: string[]) => void }) {
+	// Get the URI first, as this generates the
+	// secret; only then get the QR code for it.
+	const {
+		data: qrURI,
+		isFetching: isFetchingURI,
+		isLoading: isLoadingURI
+	} = useTwoFactorQRCodeURIQuery();
+
+	const {
+		data: qrPNG,
+		isFetching: isFetchingPNG,
+		isLoading: isLoadingPNG
+	} = useTwoFactorQRCodePNGQuery(undefined, { skip: qrURI === undefined });
+
+	const form = {
+		code: useTextInput("code"),
+	};
+
+	const [submitForm, result] = useFormSubmit(
+		form,
+		useTwoFactorEnableMutation(),
+		{
+			changedOnly: false,
+			onFinish: (res) => {
+				if (res.data) {
+					onEnabled(res.data);
+				}
+			}
+		},
+	);
+
+	if (
+		(isFetchingURI || isLoadingURI) ||
+		(isFetchingPNG || isLoadingPNG)
+	) {
+		return <Loading />;
+	}
+
+	return (
+		<form className="two-factor-enable" onSubmit={submitForm}>
+			<p>
+				Scan the QR code below with your authenticator app,
+				or enter the secret into it manually. Then enter the
+				code it shows to confirm setup.
+			</p>
+			{ qrPNG && <img src={qrPNG} alt="QR code of two-factor authentication secret" /> }
+			{ qrURI && <p className="monospace">{qrURI.uri}</p> }
+			<TextInput
+				field={form.code}
+				label="Code from authenticator app"
+				autoComplete="one-time-code"
+			/>
+			<MutationButton
+				label="Enable two-factor authentication"
+				result={result}
+				disabled={!form.code.value}
+			/>
+		</form>
+	);
+}
+
+function TwoFactorDisableForm({ enabledAt }: { enabledAt: string }) {
+	const form = {
+		password: useTextInput("password"),
+	};
+
+	const [submitForm, result] = useFormSubmit(
+		form,
+		useTwoFactorDisableMutation(),
+		{ changedOnly: false },
+	);
+
+	return (
+		<form className="two-factor-disable" onSubmit={submitForm}>
+			<div className="info">
+				<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+				<b>
+					Two-factor authentication has been enabled
+					since {new Date(enabledAt).toLocaleString()}.
+				</b>
+			</div>
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+			/>
+			<MutationButton
+				label="Disable two-factor authentication"
+				result={result}
+				className="button danger"
+				disabled={!form.password.value}
+			/>
+		</form>
+	);
+}
+
 function EmailChange() {
 	// Load instance data.
 	const {
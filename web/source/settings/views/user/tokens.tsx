@@ -0,0 +1,151 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useMemo } from "react";
+import MutationButton from "../../components/form/mutation-button";
+import Loading from "../../components/loading";
+import { Error } from "../../components/error";
+import { NoArg } from "../../lib/types/query";
+import { TokenApplication, TokenInfo } from "../../lib/types/token";
+import {
+	useGetTokensQuery,
+	useInvalidateAppTokensMutation,
+	useInvalidateTokenMutation,
+} from "../../lib/query/user/tokens";
+
+export default function UserTokens() {
+	const {
+		data: tokens,
+		isLoading,
+		isFetching,
+		isError,
+		error,
+	} = useGetTokensQuery(NoArg);
+
+	// Group tokens by the app they were issued to,
+	// so that apps can be signed out in one go.
+	const apps = useMemo(() => {
+		const byApp = new Map<string, { app: TokenApplication, tokens: TokenInfo[] }>();
+		tokens?.forEach((token) => {
+			const entry = byApp.get(token.application.id);
+			if (entry) {
+				entry.tokens.push(token);
+			} else {
+				byApp.set(token.application.id, { app: token.application, tokens: [token] });
+			}
+		});
+		return Array.from(byApp.values());
+	}, [tokens]);
+
+	let content: React.JSX.Element;
+	if (isLoading || isFetching) {
+		content = <Loading />;
+	} else if (isError) {
+		content = <Error error={error} />;
+	} else if (apps.length === 0) {
+		content = (
+			<div className="info">
+				<i className="fa fa-fw fa-info-circle" aria-hidden="true"></i>
+				<b>No apps are signed in to your account.</b>
+			</div>
+		);
+	} else {
+		content = (
+			<>
+				{apps.map(({ app, tokens }) =>
+					<AppEntry key={app.id} app={app} tokens={tokens} />
+				)}
+			</>
+		);
+	}
+
+	return (
+		<div className="user-tokens">
+			<div className="form-section-docs">
+				<h1>Apps and Sessions</h1>
+				<p>
+					These are the apps that you've signed in to your account with, and the
+					access tokens they were given. You can revoke a single token to sign out
+					one session, or sign out an app entirely. Revoking a token also closes any
+					live streaming connections that were opened with it.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#apps-and-sessions"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about this (opens in a new tab)
+				</a>
+			</div>
+			{content}
+		</div>
+	);
+}
+
+function AppEntry({ app, tokens }: { app: TokenApplication, tokens: TokenInfo[] }) {
+	const [ invalidateTrigger, invalidateResult ] = useInvalidateAppTokensMutation();
+
+	return (
+		<div className="app-entry">
+			<h2>
+				{ app.website
+					? <a href={app.website} target="_blank" rel="noreferrer">{app.name}</a>
+					: app.name
+				}
+			</h2>
+			{tokens.map((token) => <TokenEntry key={token.id} token={token} />)}
+			<MutationButton
+				type="button"
+				onClick={() => invalidateTrigger(app.id)}
+				label="Sign out app"
+				result={invalidateResult}
+				className="button danger"
+				disabled={false}
+			/>
+		</div>
+	);
+}
+
+function TokenEntry({ token }: { token: TokenInfo }) {
+	const [ invalidateTrigger, invalidateResult ] = useInvalidateTokenMutation();
+
+	const lastUsed = token.last_used
+		? new Date(token.last_used).toLocaleString()
+		: "Unknown";
+
+	return (
+		<dl className="entry">
+			<dt>Scopes</dt>
+			<dd className="monospace">{token.scope}</dd>
+			<dt>Created</dt>
+			<dd>{new Date(token.created_at).toLocaleString()}</dd>
+			<dt>Last used</dt>
+			<dd>{lastUsed}</dd>
+			<MutationButton
+				type="button"
+				onClick={() => invalidateTrigger(token.id)}
+				label="Revoke"
+				result={invalidateResult}
+				className="button danger"
+				disabled={false}
+			/>
+		</dl>
+	);
+}